@@ -49,6 +49,7 @@ type ImageHandler interface {
 	Push(registry, username, token, remoteImage string) error
 	Pull(registry, username, token, remoteImage string) error
 	GetLabel(altImageName, labelName string) (string, error)
+	GetRemoteDigest(remoteImage string) (string, error)
 	DoesImageExist(image string) error
 	ListLabels() (map[string]string, error)
 	TagLocalImage(localImage string) error
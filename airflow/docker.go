@@ -17,6 +17,7 @@ import (
 
 	semver "github.com/Masterminds/semver/v3"
 	airflowTypes "github.com/astronomer/astro-cli/airflow/types"
+	airflowversions "github.com/astronomer/astro-cli/airflow_versions"
 	"github.com/astronomer/astro-cli/astro-client"
 	astrocore "github.com/astronomer/astro-cli/astro-client-core"
 	"github.com/astronomer/astro-cli/cloud/deployment"
@@ -24,6 +25,7 @@ import (
 	"github.com/astronomer/astro-cli/docker"
 	"github.com/astronomer/astro-cli/pkg/ansi"
 	"github.com/astronomer/astro-cli/pkg/fileutil"
+	"github.com/astronomer/astro-cli/pkg/httputil"
 	"github.com/astronomer/astro-cli/pkg/util"
 	"github.com/astronomer/astro-cli/settings"
 	composeInterp "github.com/compose-spec/compose-go/interpolation"
@@ -95,6 +97,11 @@ var (
 	startupTimeout time.Duration
 	isM1           = util.IsM1
 
+	printRuntimeVersionWarning = func(version string) {
+		httpClient := airflowversions.NewClient(httputil.NewHTTPClient(), false)
+		airflowversions.PrintRuntimeVersionWarning(httpClient, version, os.Stderr)
+	}
+
 	majorUpdatesAirflowProviders    = []string{}
 	minorUpdatesAirflowProviders    = []string{}
 	patchUpdatesAirflowProviders    = []string{}
@@ -262,6 +269,10 @@ func (d *DockerCompose) Start(imageName, settingsFile, composeFile string, noCac
 		return err
 	}
 
+	if runtimeVersion, ok := imageLabels[runtimeVersionLabelName]; ok && config.CFG.ShowWarnings.GetBool() {
+		printRuntimeVersionWarning(runtimeVersion)
+	}
+
 	// Create a compose project
 	project, err := createDockerProject(d.projectName, d.airflowHome, d.envFile, "", settingsFile, composeFile, imageLabels)
 	if err != nil {
@@ -34,7 +34,27 @@ const (
 	prefix             = "Bearer "
 )
 
-var errGetImageLabel = errors.New("error getting image label")
+var (
+	errGetImageLabel = errors.New("error getting image label")
+	errNoImageDigest = errors.New("no digest found for the image")
+
+	// ErrImageNotFound is returned by DoesImageExist when the registry has no such image
+	ErrImageNotFound = errors.New("image not found in the registry")
+
+	// messages of docker manifest inspect for an image missing from the registry
+	imageNotFoundMessages = []string{"no such manifest", "manifest unknown"}
+)
+
+// remoteManifest is the part of the output of docker manifest inspect --verbose used to get the digest of an image
+type remoteManifest struct {
+	Descriptor struct {
+		Digest   string `json:"digest"`
+		Platform struct {
+			Architecture string `json:"architecture"`
+			OS           string `json:"os"`
+		} `json:"platform"`
+	} `json:"Descriptor"`
+}
 
 type DockerImage struct {
 	imageName string
@@ -407,6 +427,38 @@ func (d *DockerImage) GetLabel(altImageName, labelName string) (string, error) {
 	return label, nil
 }
 
+// GetRemoteDigest returns the digest of remoteImage in its registry. The digest of the linux/amd64 manifest is returned
+// for multi-platform images.
+func (d *DockerImage) GetRemoteDigest(remoteImage string) (string, error) {
+	dockerCommand := config.CFG.DockerCommand.GetString()
+	stdout := new(bytes.Buffer)
+	stderr := new(bytes.Buffer)
+
+	err := cmdExec(dockerCommand, stdout, stderr, "manifest", "inspect", "--verbose", remoteImage)
+	if err != nil {
+		return "", fmt.Errorf("command '%s manifest inspect --verbose %s' failed: %w", dockerCommand, remoteImage, err)
+	}
+
+	var manifests []remoteManifest
+	if bytes.HasPrefix(bytes.TrimSpace(stdout.Bytes()), []byte("[")) {
+		err = json.Unmarshal(stdout.Bytes(), &manifests)
+	} else {
+		var manifest remoteManifest
+		err = json.Unmarshal(stdout.Bytes(), &manifest)
+		manifests = append(manifests, manifest)
+	}
+	if err != nil {
+		return "", err
+	}
+	for i := range manifests {
+		platform := manifests[i].Descriptor.Platform
+		if (platform.OS == "" || platform.OS == "linux") && (platform.Architecture == "" || platform.Architecture == "amd64") && manifests[i].Descriptor.Digest != "" {
+			return manifests[i].Descriptor.Digest, nil
+		}
+	}
+	return "", fmt.Errorf("%w: %s", errNoImageDigest, remoteImage)
+}
+
 func (d *DockerImage) DoesImageExist(image string) error {
 	dockerCommand := config.CFG.DockerCommand.GetString()
 	stdout := new(bytes.Buffer)
@@ -414,6 +466,15 @@ func (d *DockerImage) DoesImageExist(image string) error {
 
 	err := cmdExec(dockerCommand, stdout, stderr, "manifest", "inspect", image)
 	if err != nil {
+		execErr := strings.TrimSpace(stderr.String())
+		for _, message := range imageNotFoundMessages {
+			if strings.Contains(strings.ToLower(execErr), message) {
+				return fmt.Errorf("%w: %s", ErrImageNotFound, image)
+			}
+		}
+		if execErr != "" {
+			return fmt.Errorf("%s: %w", execErr, err)
+		}
 		return err
 	}
 	return nil
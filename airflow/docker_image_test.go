@@ -529,6 +529,84 @@ func TestDoesImageExist(t *testing.T) {
 		err := handler.DoesImageExist(testImage)
 		assert.ErrorIs(t, err, errMockDocker)
 	})
+
+	t.Run("image not found", func(t *testing.T) {
+		cmdExec = func(cmd string, stdout, stderr io.Writer, args ...string) error {
+			stderr.Write([]byte("no such manifest: registry.example.com/airflow:v1\n"))
+			return errMockDocker
+		}
+
+		err := handler.DoesImageExist(testImage)
+		assert.ErrorIs(t, err, ErrImageNotFound)
+	})
+
+	t.Run("registry error", func(t *testing.T) {
+		cmdExec = func(cmd string, stdout, stderr io.Writer, args ...string) error {
+			stderr.Write([]byte("unauthorized: authentication required\n"))
+			return errMockDocker
+		}
+
+		err := handler.DoesImageExist(testImage)
+		assert.ErrorIs(t, err, errMockDocker)
+		assert.NotErrorIs(t, err, ErrImageNotFound)
+		assert.ErrorContains(t, err, "unauthorized")
+	})
+}
+
+func TestGetRemoteDigest(t *testing.T) {
+	testUtil.InitTestConfig(testUtil.LocalPlatform)
+	handler := DockerImage{
+		imageName: "testing",
+	}
+	testImage := "registry.example.com/airflow:v1"
+
+	previousCmdExec := cmdExec
+	defer func() { cmdExec = previousCmdExec }()
+
+	t.Run("single platform image", func(t *testing.T) {
+		cmdExec = func(cmd string, stdout, stderr io.Writer, args ...string) error {
+			assert.Equal(t, []string{"manifest", "inspect", "--verbose", testImage}, args)
+			_, err := stdout.Write([]byte(`{"Ref": "registry.example.com/airflow:v1", "Descriptor": {"digest": "sha256:1234"}}`))
+			return err
+		}
+
+		digest, err := handler.GetRemoteDigest(testImage)
+		assert.NoError(t, err)
+		assert.Equal(t, "sha256:1234", digest)
+	})
+
+	t.Run("multi platform image", func(t *testing.T) {
+		cmdExec = func(cmd string, stdout, stderr io.Writer, args ...string) error {
+			_, err := stdout.Write([]byte(`[
+				{"Descriptor": {"digest": "sha256:arm", "platform": {"architecture": "arm64", "os": "linux"}}},
+				{"Descriptor": {"digest": "sha256:amd", "platform": {"architecture": "amd64", "os": "linux"}}}
+			]`))
+			return err
+		}
+
+		digest, err := handler.GetRemoteDigest(testImage)
+		assert.NoError(t, err)
+		assert.Equal(t, "sha256:amd", digest)
+	})
+
+	t.Run("no digest", func(t *testing.T) {
+		cmdExec = func(cmd string, stdout, stderr io.Writer, args ...string) error {
+			_, err := stdout.Write([]byte(`{}`))
+			return err
+		}
+
+		_, err := handler.GetRemoteDigest(testImage)
+		assert.ErrorIs(t, err, errNoImageDigest)
+	})
+
+	t.Run("cmdExec error", func(t *testing.T) {
+		cmdExec = func(cmd string, stdout, stderr io.Writer, args ...string) error {
+			return errMockDocker
+		}
+
+		_, err := handler.GetRemoteDigest(testImage)
+		assert.ErrorIs(t, err, errMockDocker)
+	})
 }
 
 func TestDockerTagLocalImage(t *testing.T) {
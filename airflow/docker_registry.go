@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"os"
 
+	"github.com/astronomer/astro-cli/pkg/httputil"
 	cliConfig "github.com/docker/cli/cli/config"
 	cliTypes "github.com/docker/cli/cli/config/types"
 	"github.com/docker/docker/api/types"
@@ -13,6 +14,19 @@ import (
 	log "github.com/sirupsen/logrus"
 )
 
+// Registry logins, pulls and pushes are performed by the Docker daemon, which does not use the CA bundle, client
+// certificate and proxy of the astro config. These hints explain how to configure the daemon when a login fails.
+const (
+	registryCertHintMsg = "The Docker daemon performs registry logins and pushes and does not use the astro CLI CA bundle. " +
+		"Trust your CA in the Docker daemon, then try again:\n" +
+		"  sudo mkdir -p /etc/docker/certs.d/%[1]s\n" +
+		"  sudo cp %[2]s /etc/docker/certs.d/%[1]s/ca.crt\n" +
+		"For mutual TLS, copy your client certificate and key to /etc/docker/certs.d/%[1]s/client.cert and client.key. " +
+		"See https://docs.docker.com/engine/security/certificates/"
+	registryProxyHintMsg = "The Docker daemon performs registry logins and pushes and does not use the proxy %s of the astro context. " +
+		"Configure the proxy of the Docker daemon, then try again. See https://docs.docker.com/config/daemon/systemd/#httphttps-proxy"
+)
+
 type DockerRegistry struct {
 	registry string
 	cli      DockerRegistryAPI
@@ -56,7 +70,7 @@ func (d *DockerRegistry) Login(username, token string) error {
 	log.Debugf("docker creds %v \n", authConfig)
 	_, err := d.cli.RegistryLogin(ctx, authConfig)
 	if err != nil {
-		return fmt.Errorf("registry login error: %w", err)
+		return fmt.Errorf("registry login error: %w%s", err, registryLoginHint(err, serverAddress))
 	}
 
 	cliAuthConfig := cliTypes.AuthConfig(authConfig)
@@ -73,3 +87,20 @@ func (d *DockerRegistry) Login(username, token string) error {
 	}
 	return nil
 }
+
+// registryLoginHint returns how to configure the Docker daemon with the network settings of the astro config when a
+// login failed because of them, or an empty string
+func registryLoginHint(err error, serverAddress string) string {
+	opts := httputil.CurrentTransportOptions()
+	switch {
+	case httputil.IsCertificateError(err):
+		caBundle := opts.CABundle
+		if caBundle == "" {
+			caBundle = "<your-ca-certificate.pem>"
+		}
+		return "\n\n" + fmt.Sprintf(registryCertHintMsg, serverAddress, caBundle)
+	case opts.ProxyURL != "":
+		return "\n\n" + fmt.Sprintf(registryProxyHintMsg, opts.ProxyURL)
+	}
+	return ""
+}
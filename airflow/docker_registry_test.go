@@ -2,9 +2,11 @@ package airflow
 
 import (
 	"context"
+	"errors"
 	"testing"
 
 	"github.com/astronomer/astro-cli/airflow/mocks"
+	"github.com/astronomer/astro-cli/pkg/httputil"
 	"github.com/docker/docker/api/types/registry"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
@@ -46,4 +48,42 @@ func TestRegistryLogin(t *testing.T) {
 		assert.ErrorIs(t, err, errMockDocker)
 		mockClient.AssertExpectations(t)
 	})
+	t.Run("certificate error", func(t *testing.T) {
+		certErr := errors.New("Error response from daemon: Get \"https://test/v2/\": x509: certificate signed by unknown authority") //nolint:goerr113
+		mockClient := new(mocks.DockerRegistryAPI)
+		mockClient.On("NegotiateAPIVersion", context.Background()).Return(nil).Once()
+		mockClient.On("RegistryLogin", context.Background(), mock.AnythingOfType("types.AuthConfig")).Return(registry.AuthenticateOKBody{}, certErr).Once()
+
+		handler := DockerRegistry{
+			registry: "test",
+			cli:      mockClient,
+		}
+
+		// the hint only needs the path of the bundle, which doesn't have to exist
+		httputil.SetTransportOptions(httputil.TransportOptions{CABundle: "testfiles/ca.pem"}) //nolint:errcheck
+		defer httputil.SetTransportOptions(httputil.TransportOptions{})                       //nolint:errcheck
+
+		err := handler.Login("user", "token")
+		assert.ErrorIs(t, err, certErr)
+		assert.Contains(t, err.Error(), "sudo cp testfiles/ca.pem /etc/docker/certs.d/test/ca.crt")
+		mockClient.AssertExpectations(t)
+	})
+
+	t.Run("proxy hint", func(t *testing.T) {
+		mockClient := new(mocks.DockerRegistryAPI)
+		mockClient.On("NegotiateAPIVersion", context.Background()).Return(nil).Once()
+		mockClient.On("RegistryLogin", context.Background(), mock.AnythingOfType("types.AuthConfig")).Return(registry.AuthenticateOKBody{}, errMockDocker).Once()
+
+		handler := DockerRegistry{
+			registry: "test",
+			cli:      mockClient,
+		}
+		assert.NoError(t, httputil.SetTransportOptions(httputil.TransportOptions{ProxyURL: "http://proxy.example.com:3128"}))
+		defer httputil.SetTransportOptions(httputil.TransportOptions{}) //nolint:errcheck
+
+		err := handler.Login("user", "token")
+		assert.ErrorIs(t, err, errMockDocker)
+		assert.Contains(t, err.Error(), "does not use the proxy http://proxy.example.com:3128 of the astro context")
+		mockClient.AssertExpectations(t)
+	})
 }
@@ -613,6 +613,32 @@ func TestDockerComposeStart(t *testing.T) {
 		composeMock.AssertExpectations(t)
 	})
 
+	t.Run("runtime version warning", func(t *testing.T) {
+		noCache := false
+		imageHandler := new(mocks.ImageHandler)
+		imageHandler.On("Build", "", airflowTypes.ImageBuildConfig{Path: mockDockerCompose.airflowHome, Output: true, NoCache: noCache}).Return(nil).Once()
+		imageHandler.On("ListLabels").Return(map[string]string{runtimeVersionLabelName: "4.2.5"}, nil).Once()
+
+		composeMock := new(mocks.DockerComposeAPI)
+		composeMock.On("Ps", mock.Anything, mockDockerCompose.projectName, api.PsOptions{All: true}).Return([]api.ContainerSummary{}, nil).Once()
+		composeMock.On("Up", mock.Anything, mock.Anything, api.UpOptions{Create: api.CreateOptions{}}).Return(errMockDocker).Once()
+
+		checkedVersion := ""
+		orgPrintRuntimeVersionWarning := printRuntimeVersionWarning
+		printRuntimeVersionWarning = func(version string) { checkedVersion = version }
+		defer func() { printRuntimeVersionWarning = orgPrintRuntimeVersionWarning }()
+
+		mockDockerCompose.composeService = composeMock
+		mockDockerCompose.imageHandler = imageHandler
+
+		err := mockDockerCompose.Start("", "", "", noCache, false, waitTime, nil)
+		assert.ErrorIs(t, err, errMockDocker)
+		assert.Equal(t, "4.2.5", checkedVersion)
+
+		imageHandler.AssertExpectations(t)
+		composeMock.AssertExpectations(t)
+	})
+
 	t.Run("webserver health check failure", func(t *testing.T) {
 		noCache := false
 		imageHandler := new(mocks.ImageHandler)
@@ -102,6 +102,30 @@ func (_m *ImageHandler) GetLabel(altImageName string, labelName string) (string,
 	return r0, r1
 }
 
+// GetRemoteDigest provides a mock function with given fields: remoteImage
+func (_m *ImageHandler) GetRemoteDigest(remoteImage string) (string, error) {
+	ret := _m.Called(remoteImage)
+
+	var r0 string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string) (string, error)); ok {
+		return rf(remoteImage)
+	}
+	if rf, ok := ret.Get(0).(func(string) string); ok {
+		r0 = rf(remoteImage)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(remoteImage)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // ListLabels provides a mock function with given fields:
 func (_m *ImageHandler) ListLabels() (map[string]string, error) {
 	ret := _m.Called()
@@ -167,6 +167,7 @@ func TestGetAstroRuntimeTag(t *testing.T) {
 
 func TestGetDefaultImageTag(t *testing.T) {
 	testUtil.InitTestConfig(testUtil.LocalPlatform)
+	useTempCacheDir(t)
 
 	t.Run("certified", func(t *testing.T) {
 		mockResp := &Response{
@@ -307,6 +308,7 @@ func TestGetDefaultImageTag(t *testing.T) {
 
 func TestGetDefaultImageTagError(t *testing.T) {
 	testUtil.InitTestConfig(testUtil.LocalPlatform)
+	useTempCacheDir(t)
 	okResponse := `Page not found`
 	client := testUtil.NewTestClient(func(req *http.Request) *http.Response {
 		return &http.Response{
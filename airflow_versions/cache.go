@@ -0,0 +1,60 @@
+package airflowversions
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/astronomer/astro-cli/config"
+)
+
+const (
+	runtimeCacheFileName   = "astro-runtime-versions.json"
+	certifiedCacheFileName = "astronomer-certified-versions.json"
+
+	cacheDirPerm  os.FileMode = 0o700
+	cacheFilePerm os.FileMode = 0o600
+)
+
+// cacheDir is the directory the last version catalogs are kept in, set as a variable so tests can change it
+var cacheDir = func() string {
+	return filepath.Join(config.HomeConfigPath, "cache")
+}
+
+type catalogCache struct {
+	FetchedAt time.Time `json:"fetched_at"`
+	Response  Response  `json:"response"`
+}
+
+func cacheFile(useAstronomerCertified bool) string {
+	if useAstronomerCertified {
+		return filepath.Join(cacheDir(), certifiedCacheFileName)
+	}
+	return filepath.Join(cacheDir(), runtimeCacheFileName)
+}
+
+// writeCatalogCache keeps the last successful response, so that it can be used when the updates endpoint can't be reached
+func writeCatalogCache(useAstronomerCertified bool, resp *Response) error {
+	data, err := json.Marshal(catalogCache{FetchedAt: time.Now().UTC(), Response: *resp})
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(cacheDir(), cacheDirPerm); err != nil {
+		return err
+	}
+	return os.WriteFile(cacheFile(useAstronomerCertified), data, cacheFilePerm)
+}
+
+// readCatalogCache returns the last successful response along with the time it was fetched at
+func readCatalogCache(useAstronomerCertified bool) (*Response, time.Time, error) {
+	data, err := os.ReadFile(cacheFile(useAstronomerCertified))
+	if err != nil {
+		return nil, time.Time{}, err
+	}
+	var cache catalogCache
+	if err := json.Unmarshal(data, &cache); err != nil {
+		return nil, time.Time{}, err
+	}
+	return &cache.Response, cache.FetchedAt, nil
+}
@@ -2,17 +2,23 @@ package airflowversions
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
 	"strings"
+	"time"
 
 	"github.com/astronomer/astro-cli/pkg/httputil"
+
+	"github.com/sirupsen/logrus"
 )
 
 const (
 	RuntimeReleaseURL = "https://updates.astronomer.io/astronomer-runtime"
 	AirflowReleaseURL = "https://updates.astronomer.io/astronomer-certified"
+
+	offlineCatalogMsg = "Unable to reach %s, using the versions fetched on %s"
 )
 
 // Client containers the logger and HTTPClient used to communicate with the HoustonAPI
@@ -48,7 +54,8 @@ func (r *Request) Do() (*Response, error) {
 	return r.DoWithClient(NewClient(httputil.NewHTTPClient(), false))
 }
 
-// Do executes a query against the updates astronomer API, logging out any errors contained in the response object
+// Do executes a query against the updates astronomer API, logging out any errors contained in the response object.
+// The last successful response is cached under the astro home directory and returned when the API can't be reached.
 func (c *Client) Do(doOpts *httputil.DoOptions) (*Response, error) {
 	var response httputil.HTTPResponse
 	doOpts.Path = RuntimeReleaseURL
@@ -58,7 +65,18 @@ func (c *Client) Do(doOpts *httputil.DoOptions) (*Response, error) {
 	doOpts.Method = http.MethodGet
 	httpResponse, err := c.HTTPClient.Do(doOpts)
 	if err != nil {
-		return nil, err
+		// only fall back to the cache when offline, errors returned by the API are reported as they are
+		var apiErr *httputil.Error
+		if errors.As(err, &apiErr) {
+			return nil, err
+		}
+		cached, fetchedAt, cacheErr := readCatalogCache(c.useAstronomerCertified)
+		if cacheErr != nil {
+			return nil, err
+		}
+		logrus.Debugf("Error fetching %s: %s", doOpts.Path, err.Error())
+		logrus.Warnf(offlineCatalogMsg, doOpts.Path, fetchedAt.Format(time.RFC1123))
+		return cached, nil
 	}
 	defer httpResponse.Body.Close()
 
@@ -76,6 +94,9 @@ func (c *Client) Do(doOpts *httputil.DoOptions) (*Response, error) {
 	if err != nil {
 		return nil, fmt.Errorf("failed to JSON decode %s response: %w", doOpts.Path, err)
 	}
+	if err := writeCatalogCache(c.useAstronomerCertified, &decode); err != nil {
+		logrus.Debugf("Unable to cache %s response: %s", doOpts.Path, err.Error())
+	}
 
 	return &decode, nil
 }
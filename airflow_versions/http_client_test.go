@@ -3,6 +3,7 @@ package airflowversions
 import (
 	"bytes"
 	"encoding/json"
+	"errors"
 	"io"
 	"net/http"
 	"testing"
@@ -13,6 +14,7 @@ import (
 )
 
 func TestClientDo(t *testing.T) {
+	useTempCacheDir(t)
 	t.Run("success", func(t *testing.T) {
 		mockResp := Response{RuntimeVersions: map[string]RuntimeVersion{"4.2.5": {RuntimeVersionMetadata{AirflowVersion: "2.2.5", Channel: "stable"}, RuntimeVersionMigrations{}}}}
 		jsonResponse, err := json.Marshal(mockResp)
@@ -32,3 +34,72 @@ func TestClientDo(t *testing.T) {
 		assert.Equal(t, mockResp, *resp)
 	})
 }
+
+func TestClientDoOffline(t *testing.T) {
+	useTempCacheDir(t)
+	mockResp := Response{RuntimeVersions: map[string]RuntimeVersion{"7.0.0": {RuntimeVersionMetadata{AirflowVersion: "2.5.0", Channel: "stable"}, RuntimeVersionMigrations{}}}}
+	jsonResponse, err := json.Marshal(mockResp)
+	assert.NoError(t, err)
+
+	online := testUtil.NewTestClient(func(req *http.Request) *http.Response {
+		return &http.Response{
+			StatusCode: 200,
+			Body:       io.NopCloser(bytes.NewBuffer(jsonResponse)),
+			Header:     make(http.Header),
+		}
+	})
+	offline := httputil.NewHTTPClient()
+	offline.HTTPClient.Transport = roundTripError{err: errOffline}
+
+	t.Run("no cached versions", func(t *testing.T) {
+		client := &Client{HTTPClient: offline}
+		_, err := client.Do(&httputil.DoOptions{})
+		assert.ErrorIs(t, err, errOffline)
+	})
+
+	t.Run("cached versions are used offline", func(t *testing.T) {
+		client := &Client{HTTPClient: online}
+		_, err := client.Do(&httputil.DoOptions{})
+		assert.NoError(t, err)
+
+		client = &Client{HTTPClient: offline}
+		resp, err := client.Do(&httputil.DoOptions{})
+		assert.NoError(t, err)
+		assert.Equal(t, mockResp, *resp)
+	})
+
+	t.Run("API errors are not hidden by the cache", func(t *testing.T) {
+		client := &Client{HTTPClient: testUtil.NewTestClient(func(req *http.Request) *http.Response {
+			return &http.Response{
+				StatusCode: 500,
+				Body:       io.NopCloser(bytes.NewBufferString("Internal Server Error")),
+				Header:     make(http.Header),
+			}
+		})}
+		_, err := client.Do(&httputil.DoOptions{})
+		assert.Error(t, err)
+	})
+
+	t.Run("certified versions are cached separately", func(t *testing.T) {
+		client := &Client{HTTPClient: offline, useAstronomerCertified: true}
+		_, err := client.Do(&httputil.DoOptions{})
+		assert.ErrorIs(t, err, errOffline)
+	})
+}
+
+var errOffline = errors.New("dial tcp: lookup updates.astronomer.io: no such host")
+
+type roundTripError struct {
+	err error
+}
+
+func (r roundTripError) RoundTrip(*http.Request) (*http.Response, error) {
+	return nil, r.err
+}
+
+func useTempCacheDir(t *testing.T) {
+	dir := t.TempDir()
+	origCacheDir := cacheDir
+	cacheDir = func() string { return dir }
+	t.Cleanup(func() { cacheDir = origCacheDir })
+}
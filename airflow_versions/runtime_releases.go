@@ -0,0 +1,118 @@
+package airflowversions
+
+import (
+	"io"
+	"sort"
+	"time"
+
+	"github.com/astronomer/astro-cli/pkg/printutil"
+)
+
+const (
+	VersionChannelDeprecated = "deprecated"
+
+	RuntimeStatusStable     = "Stable"
+	RuntimeStatusDeprecated = "Deprecated"
+	RuntimeStatusEndOfLife  = "End of life"
+
+	dateLayout = "2006-01-02"
+)
+
+// RuntimeRelease describes a single Astro Runtime version of the catalog
+type RuntimeRelease struct {
+	Version        string
+	AirflowVersion string
+	Channel        string
+	ReleaseDate    string
+	EndOfSupport   string
+	Status         string
+}
+
+// GetRuntimeReleases returns every Astro Runtime version of the catalog, the most recent version first.
+// Pre-release channels are only returned when includePreReleases is set.
+func GetRuntimeReleases(httpClient *Client, includePreReleases bool) ([]RuntimeRelease, error) {
+	r := Request{}
+	resp, err := r.DoWithClient(httpClient)
+	if err != nil {
+		return nil, err
+	}
+
+	releases := []RuntimeRelease{}
+	for version, runtimeVersion := range resp.RuntimeVersions {
+		status := runtimeStatus(runtimeVersion.Metadata, time.Now())
+		if status == "" && !includePreReleases {
+			continue
+		}
+		if status == "" {
+			status = runtimeVersion.Metadata.Channel
+		}
+		releases = append(releases, RuntimeRelease{
+			Version:        version,
+			AirflowVersion: runtimeVersion.Metadata.AirflowVersion,
+			Channel:        runtimeVersion.Metadata.Channel,
+			ReleaseDate:    runtimeVersion.Metadata.ReleaseDate,
+			EndOfSupport:   runtimeVersion.Metadata.EndOfSupport,
+			Status:         status,
+		})
+	}
+	sort.SliceStable(releases, func(i, j int) bool {
+		return compareRuntimeVersions(releases[i].Version, releases[j].Version) > 0
+	})
+	return releases, nil
+}
+
+// runtimeStatus returns the support status of a runtime version, or an empty string for pre-release channels
+func runtimeStatus(metadata RuntimeVersionMetadata, now time.Time) string {
+	if metadata.EndOfSupport != "" {
+		if endOfSupport, err := time.Parse(dateLayout, metadata.EndOfSupport); err == nil && !now.Before(endOfSupport) {
+			return RuntimeStatusEndOfLife
+		}
+	}
+	switch metadata.Channel {
+	case VersionChannelStable:
+		return RuntimeStatusStable
+	case VersionChannelDeprecated:
+		return RuntimeStatusDeprecated
+	}
+	return ""
+}
+
+func compareRuntimeVersions(a, b string) int {
+	aVersion, aErr := NewAirflowVersion(a, nil)
+	bVersion, bErr := NewAirflowVersion(b, nil)
+	switch {
+	case aErr != nil && bErr != nil:
+		if a < b {
+			return -1
+		}
+		return 1
+	case aErr != nil:
+		return -1
+	case bErr != nil:
+		return 1
+	}
+	return aVersion.Compare(bVersion)
+}
+
+// ListRuntimeReleases prints the Astro Runtime versions of the catalog with their Airflow version and support status
+func ListRuntimeReleases(httpClient *Client, includePreReleases bool, out io.Writer) error {
+	releases, err := GetRuntimeReleases(httpClient, includePreReleases)
+	if err != nil {
+		return err
+	}
+
+	tab := printutil.Table{
+		Padding:        []int{20, 20, 15, 18, 15},
+		DynamicPadding: true,
+		Header:         []string{"RUNTIME VERSION", "AIRFLOW VERSION", "RELEASE DATE", "END OF SUPPORT", "STATUS"},
+		NoResultsMsg:   "No Astro Runtime versions found",
+	}
+	for i := range releases {
+		endOfSupport := releases[i].EndOfSupport
+		if endOfSupport == "" {
+			endOfSupport = "N/A"
+		}
+		tab.AddRow([]string{releases[i].Version, releases[i].AirflowVersion, releases[i].ReleaseDate, endOfSupport, releases[i].Status}, false)
+	}
+	return tab.Print(out)
+}
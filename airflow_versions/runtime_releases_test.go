@@ -0,0 +1,73 @@
+package airflowversions
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"testing"
+	"time"
+
+	testUtil "github.com/astronomer/astro-cli/pkg/testing"
+	"github.com/stretchr/testify/assert"
+)
+
+func newRuntimeReleasesClient(t *testing.T) *Client {
+	mockResp := Response{RuntimeVersions: map[string]RuntimeVersion{
+		"4.2.5":        {Metadata: RuntimeVersionMetadata{AirflowVersion: "2.2.5", Channel: VersionChannelDeprecated, ReleaseDate: "2022-04-07", EndOfSupport: "2022-10-07"}},
+		"6.0.4":        {Metadata: RuntimeVersionMetadata{AirflowVersion: "2.4.3", Channel: VersionChannelDeprecated, ReleaseDate: "2022-11-23"}},
+		"7.0.0":        {Metadata: RuntimeVersionMetadata{AirflowVersion: "2.5.0", Channel: VersionChannelStable, ReleaseDate: "2022-12-05", EndOfSupport: "2999-12-05"}},
+		"7.1.0-alpha1": {Metadata: RuntimeVersionMetadata{AirflowVersion: "2.5.1", Channel: "alpha", ReleaseDate: "2023-01-01"}},
+	}}
+	jsonResponse, err := json.Marshal(mockResp)
+	assert.NoError(t, err)
+	client := testUtil.NewTestClient(func(req *http.Request) *http.Response {
+		return &http.Response{
+			StatusCode: 200,
+			Body:       io.NopCloser(bytes.NewBuffer(jsonResponse)),
+			Header:     make(http.Header),
+		}
+	})
+	return NewClient(client, false)
+}
+
+func TestGetRuntimeReleases(t *testing.T) {
+	useTempCacheDir(t)
+
+	t.Run("stable, deprecated and end of life versions", func(t *testing.T) {
+		releases, err := GetRuntimeReleases(newRuntimeReleasesClient(t), false)
+		assert.NoError(t, err)
+		assert.Equal(t, []RuntimeRelease{
+			{Version: "7.0.0", AirflowVersion: "2.5.0", Channel: VersionChannelStable, ReleaseDate: "2022-12-05", EndOfSupport: "2999-12-05", Status: RuntimeStatusStable},
+			{Version: "6.0.4", AirflowVersion: "2.4.3", Channel: VersionChannelDeprecated, ReleaseDate: "2022-11-23", Status: RuntimeStatusDeprecated},
+			{Version: "4.2.5", AirflowVersion: "2.2.5", Channel: VersionChannelDeprecated, ReleaseDate: "2022-04-07", EndOfSupport: "2022-10-07", Status: RuntimeStatusEndOfLife},
+		}, releases)
+	})
+
+	t.Run("including pre-releases", func(t *testing.T) {
+		releases, err := GetRuntimeReleases(newRuntimeReleasesClient(t), true)
+		assert.NoError(t, err)
+		assert.Len(t, releases, 4)
+		assert.Equal(t, "7.1.0-alpha1", releases[0].Version)
+		assert.Equal(t, "alpha", releases[0].Status)
+	})
+}
+
+func TestRuntimeStatus(t *testing.T) {
+	now := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+	assert.Equal(t, RuntimeStatusStable, runtimeStatus(RuntimeVersionMetadata{Channel: VersionChannelStable, EndOfSupport: "2023-01-02"}, now))
+	assert.Equal(t, RuntimeStatusEndOfLife, runtimeStatus(RuntimeVersionMetadata{Channel: VersionChannelStable, EndOfSupport: "2023-01-01"}, now))
+	assert.Equal(t, RuntimeStatusDeprecated, runtimeStatus(RuntimeVersionMetadata{Channel: VersionChannelDeprecated}, now))
+	assert.Equal(t, "", runtimeStatus(RuntimeVersionMetadata{Channel: "beta"}, now))
+}
+
+func TestListRuntimeReleases(t *testing.T) {
+	useTempCacheDir(t)
+	out := new(bytes.Buffer)
+	err := ListRuntimeReleases(newRuntimeReleasesClient(t), false, out)
+	assert.NoError(t, err)
+	assert.Contains(t, out.String(), "RUNTIME VERSION")
+	assert.Contains(t, out.String(), "7.0.0")
+	assert.Contains(t, out.String(), "End of life")
+	assert.NotContains(t, out.String(), "alpha")
+}
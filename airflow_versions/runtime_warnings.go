@@ -0,0 +1,130 @@
+package airflowversions
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"strings"
+	"time"
+)
+
+// NearEndOfSupportDays is the number of days before the end of support of a runtime version from which users are warned
+const NearEndOfSupportDays = 90
+
+// RuntimeVersionWarning describes why a runtime version should be upgraded. It is based on the support status and end
+// of support of the runtime catalog, which has no security advisories, so versions with known CVEs are not reported.
+type RuntimeVersionWarning struct {
+	Version string
+	// Status is the support status of the version, see runtimeStatus
+	Status       string
+	EndOfSupport string
+	// DaysUntilEndOfSupport is only set when the end of support is within NearEndOfSupportDays
+	DaysUntilEndOfSupport int
+	// RecommendedUpgrade is the closest newer stable version, empty if there is none
+	RecommendedUpgrade string
+}
+
+// Print writes the warning in a human readable form
+func (w *RuntimeVersionWarning) Print(out io.Writer) {
+	switch {
+	case w.Status == RuntimeStatusEndOfLife:
+		fmt.Fprintf(out, "WARNING! Astro Runtime %s reached its end of support on %s and no longer receives fixes\n", w.Version, w.EndOfSupport)
+	case w.Status == RuntimeStatusDeprecated:
+		fmt.Fprintf(out, "WARNING! Astro Runtime %s is deprecated\n", w.Version)
+	case w.DaysUntilEndOfSupport > 0:
+		fmt.Fprintf(out, "WARNING! Astro Runtime %s reaches its end of support on %s (in %d days)\n", w.Version, w.EndOfSupport, w.DaysUntilEndOfSupport)
+	}
+	if w.RecommendedUpgrade != "" {
+		fmt.Fprintf(out, "Consider upgrading to Astro Runtime %s\n", w.RecommendedUpgrade)
+	}
+}
+
+// GetRuntimeVersionWarnings checks the given runtime versions against the catalog and returns a warning, keyed
+// by version, for each version which is deprecated, or close to or past its end of support
+func GetRuntimeVersionWarnings(httpClient *Client, versions ...string) (map[string]*RuntimeVersionWarning, error) {
+	r := Request{}
+	resp, err := r.DoWithClient(httpClient)
+	if err != nil {
+		return nil, err
+	}
+
+	warnings := map[string]*RuntimeVersionWarning{}
+	now := time.Now()
+	for _, version := range versions {
+		if _, ok := warnings[version]; ok {
+			continue
+		}
+		if warning := runtimeVersionWarning(resp.RuntimeVersions, version, now); warning != nil {
+			warnings[version] = warning
+		}
+	}
+	return warnings, nil
+}
+
+// PrintRuntimeVersionWarning prints the warning of a runtime version, if it has one. Errors fetching the
+// catalog are ignored, as the warning is only advisory.
+func PrintRuntimeVersionWarning(httpClient *Client, version string, out io.Writer) {
+	warnings, err := GetRuntimeVersionWarnings(httpClient, version)
+	if err != nil {
+		return
+	}
+	if warning, ok := warnings[version]; ok {
+		warning.Print(out)
+	}
+}
+
+func runtimeVersionWarning(runtimeVersions map[string]RuntimeVersion, version string, now time.Time) *RuntimeVersionWarning {
+	runtimeVersion, ok := runtimeVersions[version]
+	if !ok {
+		// images can be tagged with a suffix, like 7.0.0-base
+		runtimeVersion, ok = runtimeVersions[strings.SplitN(version, "-", 2)[0]] //nolint:gomnd
+		if !ok {
+			return nil
+		}
+	}
+
+	metadata := runtimeVersion.Metadata
+	warning := &RuntimeVersionWarning{
+		Version:      version,
+		Status:       runtimeStatus(metadata, now),
+		EndOfSupport: metadata.EndOfSupport,
+	}
+	if warning.Status == RuntimeStatusStable && metadata.EndOfSupport != "" {
+		if endOfSupport, err := time.Parse(dateLayout, metadata.EndOfSupport); err == nil {
+			days := int(math.Ceil(endOfSupport.Sub(now).Hours() / 24)) //nolint:gomnd
+			if days <= NearEndOfSupportDays {
+				warning.DaysUntilEndOfSupport = days
+			}
+		}
+	}
+	if warning.Status != RuntimeStatusEndOfLife && warning.Status != RuntimeStatusDeprecated && warning.DaysUntilEndOfSupport == 0 {
+		return nil
+	}
+	warning.RecommendedUpgrade = recommendedUpgrade(runtimeVersions, version, now)
+	return warning
+}
+
+// recommendedUpgrade returns the closest version newer than version which is stable and not close to its end
+// of support
+func recommendedUpgrade(runtimeVersions map[string]RuntimeVersion, version string, now time.Time) string {
+	recommended := ""
+	for candidate, runtimeVersion := range runtimeVersions {
+		metadata := runtimeVersion.Metadata
+		if compareRuntimeVersions(candidate, version) <= 0 {
+			continue
+		}
+		if runtimeStatus(metadata, now) != RuntimeStatusStable {
+			continue
+		}
+		if metadata.EndOfSupport != "" {
+			endOfSupport, err := time.Parse(dateLayout, metadata.EndOfSupport)
+			if err == nil && endOfSupport.Sub(now) <= NearEndOfSupportDays*24*time.Hour {
+				continue
+			}
+		}
+		if recommended == "" || compareRuntimeVersions(candidate, recommended) < 0 {
+			recommended = candidate
+		}
+	}
+	return recommended
+}
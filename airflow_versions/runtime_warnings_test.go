@@ -0,0 +1,83 @@
+package airflowversions
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRuntimeVersionWarning(t *testing.T) {
+	now := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+	runtimeVersions := map[string]RuntimeVersion{
+		"4.2.5":        {Metadata: RuntimeVersionMetadata{Channel: VersionChannelDeprecated, EndOfSupport: "2022-10-07"}},
+		"6.0.4":        {Metadata: RuntimeVersionMetadata{Channel: VersionChannelDeprecated}},
+		"7.0.0":        {Metadata: RuntimeVersionMetadata{Channel: VersionChannelStable, EndOfSupport: "2023-02-01"}},
+		"7.2.0":        {Metadata: RuntimeVersionMetadata{Channel: VersionChannelStable, EndOfSupport: "2024-06-01"}},
+		"7.3.0":        {Metadata: RuntimeVersionMetadata{Channel: VersionChannelStable}},
+		"7.4.0-alpha1": {Metadata: RuntimeVersionMetadata{Channel: "alpha"}},
+	}
+
+	t.Run("end of life", func(t *testing.T) {
+		warning := runtimeVersionWarning(runtimeVersions, "4.2.5", now)
+		assert.Equal(t, &RuntimeVersionWarning{Version: "4.2.5", Status: RuntimeStatusEndOfLife, EndOfSupport: "2022-10-07", RecommendedUpgrade: "7.2.0"}, warning)
+	})
+
+	t.Run("deprecated", func(t *testing.T) {
+		warning := runtimeVersionWarning(runtimeVersions, "6.0.4", now)
+		assert.Equal(t, RuntimeStatusDeprecated, warning.Status)
+		assert.Equal(t, "7.2.0", warning.RecommendedUpgrade)
+	})
+
+	t.Run("near end of support", func(t *testing.T) {
+		warning := runtimeVersionWarning(runtimeVersions, "7.0.0", now)
+		assert.Equal(t, 31, warning.DaysUntilEndOfSupport)
+		assert.Equal(t, "7.2.0", warning.RecommendedUpgrade)
+	})
+
+	t.Run("image tag suffix", func(t *testing.T) {
+		warning := runtimeVersionWarning(runtimeVersions, "6.0.4-base", now)
+		assert.Equal(t, RuntimeStatusDeprecated, warning.Status)
+	})
+
+	t.Run("supported versions", func(t *testing.T) {
+		assert.Nil(t, runtimeVersionWarning(runtimeVersions, "7.2.0", now))
+		assert.Nil(t, runtimeVersionWarning(runtimeVersions, "7.3.0", now))
+		assert.Nil(t, runtimeVersionWarning(runtimeVersions, "1.0.0", now))
+	})
+}
+
+func TestRuntimeVersionWarningPrint(t *testing.T) {
+	t.Run("end of life", func(t *testing.T) {
+		buf := new(bytes.Buffer)
+		(&RuntimeVersionWarning{Version: "4.2.5", Status: RuntimeStatusEndOfLife, EndOfSupport: "2022-10-07", RecommendedUpgrade: "7.2.0"}).Print(buf)
+		assert.Equal(t, "WARNING! Astro Runtime 4.2.5 reached its end of support on 2022-10-07 and no longer receives fixes\nConsider upgrading to Astro Runtime 7.2.0\n", buf.String())
+	})
+
+	t.Run("near end of support", func(t *testing.T) {
+		buf := new(bytes.Buffer)
+		(&RuntimeVersionWarning{
+			Version:               "7.0.0",
+			Status:                RuntimeStatusStable,
+			EndOfSupport:          "2023-02-01",
+			DaysUntilEndOfSupport: 31,
+		}).Print(buf)
+		assert.Contains(t, buf.String(), "reaches its end of support on 2023-02-01 (in 31 days)")
+		assert.NotContains(t, buf.String(), "Consider upgrading")
+	})
+}
+
+func TestGetRuntimeVersionWarnings(t *testing.T) {
+	useTempCacheDir(t)
+
+	warnings, err := GetRuntimeVersionWarnings(newRuntimeReleasesClient(t), "4.2.5", "7.0.0", "4.2.5")
+	assert.NoError(t, err)
+	assert.Len(t, warnings, 1)
+	assert.Equal(t, RuntimeStatusEndOfLife, warnings["4.2.5"].Status)
+	assert.Equal(t, "7.0.0", warnings["4.2.5"].RecommendedUpgrade)
+
+	buf := new(bytes.Buffer)
+	PrintRuntimeVersionWarning(newRuntimeReleasesClient(t), "6.0.4", buf)
+	assert.Contains(t, buf.String(), "Astro Runtime 6.0.4 is deprecated")
+}
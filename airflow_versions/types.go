@@ -29,6 +29,7 @@ type RuntimeVersionMetadata struct {
 	AirflowVersion string `json:"airflowVersion"`
 	Channel        string `json:"channel"`
 	ReleaseDate    string `json:"releaseDate"`
+	EndOfSupport   string `json:"endOfSupport,omitempty"`
 }
 
 type RuntimeVersionMigrations struct {
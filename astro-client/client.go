@@ -1,6 +1,7 @@
 package astro
 
 import (
+	httpContext "context"
 	"encoding/json"
 	"fmt"
 	"io"
@@ -52,6 +53,9 @@ func (r *Request) DoWithPublicClient(api *HTTPClient) (*Response, error) {
 			"Accept": "application/json",
 		},
 	}
+	if isQuery(r.Query) {
+		doOpts.Context = httputil.GraphQLQueryContext(httpContext.Background())
+	}
 
 	return api.doPublicGraphQLQuery(doOpts)
 }
@@ -130,6 +134,12 @@ func (c *HTTPClient) doPublicGraphQLQuery(doOpts *httputil.DoOptions) (*Response
 	return &decode, nil
 }
 
+// isQuery reports whether the given GraphQL document is a query, as opposed to a mutation
+func isQuery(document string) bool {
+	document = strings.TrimSpace(document)
+	return strings.HasPrefix(document, "query") || strings.HasPrefix(document, "{")
+}
+
 func (c *HTTPClient) DoPublic(doOpts *httputil.DoOptions) (*httputil.HTTPResponse, error) {
 	httpResponse, err := c.HTTPClient.Do(doOpts)
 	if err != nil {
@@ -760,4 +760,7 @@ func CheckVersion(version string, out io.Writer) {
 	default:
 		fmt.Fprintf(out, "Runtime Version: %s\n", version)
 	}
+	if config.CFG.ShowWarnings.GetBool() {
+		airflowversions.PrintRuntimeVersionWarning(httpClient, version, os.Stderr)
+	}
 }
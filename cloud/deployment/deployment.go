@@ -39,6 +39,10 @@ var (
 	canCiCdDeploy    = CanCiCdDeploy
 	parseToken       = util.ParseAPIToken
 	CleanOutput      = false
+
+	getRuntimeVersionWarnings = airflowversions.GetRuntimeVersionWarnings
+	// warnings are written to stderr to keep the output of the commands parsable
+	warningsOut io.Writer = os.Stderr
 )
 
 const (
@@ -49,6 +53,10 @@ const (
 	gcpCloud       = "gcp"
 	awsCloud       = "aws"
 	standard       = "standard"
+
+	hostedShared     = "HOSTED_SHARED"
+	hostedDedicated  = "HOSTED_DEDICATED"
+	defaultQueueName = "default"
 )
 
 var (
@@ -106,10 +114,7 @@ func List(ws string, all bool, client astro.Client, out io.Writer) error {
 		return errors.Wrap(err, astro.AstronomerConnectionErrMsg)
 	}
 
-	if len(deployments) == 0 {
-		fmt.Printf("No Deployments found in workspace %s\n", ansi.Bold(ws))
-		return nil
-	}
+	tab.NoResultsMsg = fmt.Sprintf("No Deployments found in workspace %s", ansi.Bold(ws))
 
 	sort.Slice(deployments, func(i, j int) bool { return deployments[i].Label > deployments[j].Label })
 
@@ -133,7 +138,48 @@ func List(ws string, all bool, client astro.Client, out io.Writer) error {
 		}
 	}
 
-	return tab.Print(out)
+	if err := tab.Print(out); err != nil {
+		return err
+	}
+	if config.CFG.ShowWarnings.GetBool() && !printutil.IsStructuredOutput() {
+		printRuntimeVersionWarnings(deployments, warningsOut)
+	}
+	return nil
+}
+
+// printRuntimeVersionWarnings warns about the deployments running a runtime version which is deprecated, or close to
+// or past its end of support. Errors fetching the runtime catalog are ignored.
+func printRuntimeVersionWarnings(deployments []astro.Deployment, out io.Writer) {
+	deploymentsByVersion := map[string][]string{}
+	runtimeVersions := []string{}
+	for i := range deployments {
+		version := deployments[i].RuntimeRelease.Version
+		if version == "" {
+			continue
+		}
+		if _, ok := deploymentsByVersion[version]; !ok {
+			runtimeVersions = append(runtimeVersions, version)
+		}
+		deploymentsByVersion[version] = append(deploymentsByVersion[version], deployments[i].Label)
+	}
+	if len(runtimeVersions) == 0 {
+		return
+	}
+
+	airflowVersionClient := airflowversions.NewClient(httputil.NewHTTPClient(), false)
+	warnings, err := getRuntimeVersionWarnings(airflowVersionClient, runtimeVersions...)
+	if err != nil {
+		return
+	}
+	for _, version := range runtimeVersions {
+		warning, ok := warnings[version]
+		if !ok {
+			continue
+		}
+		fmt.Fprintln(out, "")
+		warning.Print(out)
+		fmt.Fprintf(out, "Deployments using Astro Runtime %s: %s\n", version, strings.Join(deploymentsByVersion[version], ", "))
+	}
 }
 
 func Logs(deploymentID, ws, deploymentName string, warnLogs, errorLogs, infoLogs bool, logCount int, client astro.Client) error {
@@ -314,6 +360,32 @@ func Create(label, workspaceID, description, clusterID, runtimeVersion, dagDeplo
 		}
 	}
 
+	// show the footprint of the new deployment and its default worker queue
+	estimated := astro.Deployment{
+		Label:              label,
+		SchedulerSize:      createInput.SchedulerSize,
+		IsHighAvailability: createInput.IsHighAvailability,
+		DeploymentSpec:     astro.DeploymentSpec{Executor: executor, Scheduler: scheduler},
+	}
+	if organization.IsOrgHosted() {
+		estimated.Type = hostedShared
+	}
+	defaultQueue := astro.WorkerQueue{Name: defaultQueueName, AstroMachine: configOption.DefaultAstroMachine.Type}
+	showEstimate := true
+	if executor == CeleryExecutor {
+		// the estimate is only informational, it is left out rather than failing the create when the worker counts
+		// of the default queue are not available
+		defaultOptions, err := client.GetWorkerQueueOptions()
+		if err == nil {
+			defaultQueue.MinWorkerCount = defaultOptions.MinWorkerCount.Default
+			defaultQueue.MaxWorkerCount = defaultOptions.MaxWorkerCount.Default
+		}
+		showEstimate = err == nil
+	}
+	if showEstimate {
+		PrintEstimate(&estimated, []astro.WorkerQueue{defaultQueue}, &configOption, os.Stdout)
+	}
+
 	// Create request
 	d, err := client.CreateDeployment(createInput)
 	if err != nil {
@@ -727,6 +799,21 @@ func Update(deploymentID, label, ws, description, deploymentName, dagDeploy, exe
 		}
 	}
 
+	// show the footprint of the deployment after the update, worker queue commands show their own
+	if !queueCreateUpdate {
+		estimated := currentDeployment
+		estimated.Label = deploymentUpdate.Label
+		estimated.DeploymentSpec.Scheduler = scheduler
+		estimated.DeploymentSpec.Executor = spec.Executor
+		if organization.IsOrgHosted() {
+			estimated.SchedulerSize = deploymentUpdate.SchedulerSize
+			if highAvailability != "" {
+				estimated.IsHighAvailability = deploymentUpdate.IsHighAvailability
+			}
+		}
+		PrintEstimate(&estimated, currentDeployment.WorkerQueues, &configOption, os.Stdout)
+	}
+
 	// confirm changes with user only if force=false
 	if !forceDeploy {
 		if confirmWithUser {
@@ -811,11 +898,11 @@ func Delete(deploymentID, ws, deploymentName string, forceDelete bool, client as
 }
 
 func IsDeploymentHosted(deploymentType string) bool {
-	return deploymentType == "HOSTED_SHARED"
+	return deploymentType == hostedShared
 }
 
 func IsDeploymentDedicated(deploymentType string) bool {
-	return deploymentType == "HOSTED_DEDICATED"
+	return deploymentType == hostedDedicated
 }
 
 var GetDeployments = func(ws, org string, client astro.Client) ([]astro.Deployment, error) {
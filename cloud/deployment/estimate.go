@@ -0,0 +1,305 @@
+package deployment
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+
+	astro "github.com/astronomer/astro-cli/astro-client"
+	"github.com/astronomer/astro-cli/config"
+	"github.com/astronomer/astro-cli/pkg/ansi"
+	"github.com/astronomer/astro-cli/pkg/printutil"
+)
+
+const (
+	highAvailabilitySchedulers = 2
+	unknownResource            = "-"
+	// the astro unit of the deployment config options is in millicores and MiB
+	milliCPUPerCPU = 1000
+	mibPerGiB      = 1024
+)
+
+// ResourceEstimate is the footprint of a deployment: its scheduler and the workers of its worker queues
+type ResourceEstimate struct {
+	Label            string
+	Scheduler        string
+	SchedulerCPU     float64
+	SchedulerMemory  float64
+	Schedulers       int
+	HighAvailability bool
+	WorkerQueues     []QueueEstimate
+}
+
+// QueueEstimate is the footprint of a worker queue. CPU and Memory are per worker, from the Astro machine or from the
+// worker pod size on node pools, and 0 when they are not known.
+type QueueEstimate struct {
+	Name       string
+	WorkerType string
+	CPU        float64
+	Memory     float64
+	MinWorkers int
+	MaxWorkers int
+}
+
+// EstimateResources returns the footprint of deployment d running the worker queues queues. Hosted deployments are
+// estimated from their scheduler size and Astro machines, other deployments from their scheduler AU and the worker pod
+// size of their queues.
+func EstimateResources(d *astro.Deployment, queues []astro.WorkerQueue, configOption *astro.DeploymentConfig) ResourceEstimate {
+	estimate := ResourceEstimate{Label: d.Label, Schedulers: 1, HighAvailability: d.IsHighAvailability}
+	hosted := IsDeploymentHosted(d.Type) || IsDeploymentDedicated(d.Type)
+	if hosted {
+		estimate.Scheduler = d.SchedulerSize
+		for _, size := range configOption.SchedulerSizes {
+			if strings.EqualFold(size.Size, d.SchedulerSize) {
+				estimate.SchedulerCPU = parseCPU(size.CPU)
+				estimate.SchedulerMemory = parseMemory(size.Memory)
+			}
+		}
+		if d.IsHighAvailability {
+			estimate.Schedulers = highAvailabilitySchedulers
+		}
+	} else {
+		au := d.DeploymentSpec.Scheduler.AU
+		estimate.Scheduler = fmt.Sprintf("%d AU", au)
+		estimate.SchedulerCPU = float64(au*configOption.AstronomerUnit.CPU) / milliCPUPerCPU
+		estimate.SchedulerMemory = float64(au*configOption.AstronomerUnit.Memory) / mibPerGiB
+		if d.DeploymentSpec.Scheduler.Replicas > 0 {
+			estimate.Schedulers = d.DeploymentSpec.Scheduler.Replicas
+		}
+	}
+
+	for i := range queues {
+		queue := QueueEstimate{Name: queues[i].Name, MinWorkers: queues[i].MinWorkerCount, MaxWorkers: queues[i].MaxWorkerCount}
+		if hosted {
+			queue.WorkerType = queues[i].AstroMachine
+			for _, machine := range configOption.AstroMachines {
+				if strings.EqualFold(machine.Type, queues[i].AstroMachine) {
+					queue.CPU = parseCPU(machine.CPU)
+					queue.Memory = parseMemory(machine.Memory)
+				}
+			}
+		} else {
+			for _, pool := range d.Cluster.NodePools {
+				if pool.ID == queues[i].NodePoolID {
+					queue.WorkerType = pool.NodeInstanceType
+				}
+			}
+			queue.CPU = parseCPU(queues[i].PodCPU)
+			queue.Memory = parseMemory(queues[i].PodRAM)
+		}
+		if d.DeploymentSpec.Executor == KubeExecutor {
+			// KubernetesExecutor runs a pod per task, so there is no worker count to estimate
+			queue.MinWorkers, queue.MaxWorkers = 0, 0
+		}
+		estimate.WorkerQueues = append(estimate.WorkerQueues, queue)
+	}
+	return estimate
+}
+
+// MinWorkers is the number of workers the deployment runs when it is idle
+func (e *ResourceEstimate) MinWorkers() int {
+	workers := 0
+	for i := range e.WorkerQueues {
+		workers += e.WorkerQueues[i].MinWorkers
+	}
+	return workers
+}
+
+// MaxWorkers is the number of workers the deployment can scale up to
+func (e *ResourceEstimate) MaxWorkers() int {
+	workers := 0
+	for i := range e.WorkerQueues {
+		workers += e.WorkerQueues[i].MaxWorkers
+	}
+	return workers
+}
+
+// CPU returns the CPU used by the deployment at its minimum and maximum worker counts. ok is false when the size of a
+// scheduler or a worker is not known.
+func (e *ResourceEstimate) CPU() (minimum, maximum float64, ok bool) {
+	return e.total(func(cpu, _ float64) float64 { return cpu }, e.SchedulerCPU)
+}
+
+// Memory returns the memory in GiB used by the deployment at its minimum and maximum worker counts. ok is false when the
+// size of a scheduler or a worker is not known.
+func (e *ResourceEstimate) Memory() (minimum, maximum float64, ok bool) {
+	return e.total(func(_, memory float64) float64 { return memory }, e.SchedulerMemory)
+}
+
+func (e *ResourceEstimate) total(resource func(cpu, memory float64) float64, scheduler float64) (minimum, maximum float64, ok bool) {
+	if scheduler == 0 {
+		return 0, 0, false
+	}
+	minimum = scheduler * float64(e.Schedulers)
+	maximum = minimum
+	for i := range e.WorkerQueues {
+		queue := &e.WorkerQueues[i]
+		perWorker := resource(queue.CPU, queue.Memory)
+		if perWorker == 0 && queue.MaxWorkers > 0 {
+			return 0, 0, false
+		}
+		minimum += perWorker * float64(queue.MinWorkers)
+		maximum += perWorker * float64(queue.MaxWorkers)
+	}
+	return minimum, maximum, true
+}
+
+// Print writes the estimate as a summary of the scheduler, a table of worker queues and the total footprint
+func (e *ResourceEstimate) Print(out io.Writer) {
+	fmt.Fprintf(out, "\nEstimated resources for Deployment %s:\n", ansi.Bold(e.Label))
+	scheduler := e.Scheduler
+	if e.SchedulerCPU != 0 {
+		scheduler += fmt.Sprintf(" (%s CPU, %s GiB)", formatQuantity(e.SchedulerCPU), formatQuantity(e.SchedulerMemory))
+	}
+	switch {
+	case e.HighAvailability:
+		scheduler += fmt.Sprintf(" x %d, high availability enabled", e.Schedulers)
+	case e.Schedulers > 1:
+		scheduler += fmt.Sprintf(" x %d replicas", e.Schedulers)
+	}
+	fmt.Fprintf(out, " Scheduler: %s\n", scheduler)
+
+	if len(e.WorkerQueues) > 0 {
+		tab := printutil.Table{
+			Padding:        []int{30, 20, 10, 10, 12, 12},
+			DynamicPadding: true,
+			Header:         []string{"WORKER QUEUE", "WORKER TYPE", "CPU", "MEMORY", "MIN WORKERS", "MAX WORKERS"},
+		}
+		for i := range e.WorkerQueues {
+			queue := &e.WorkerQueues[i]
+			cpu, memory := unknownResource, unknownResource
+			if queue.CPU != 0 {
+				cpu = formatQuantity(queue.CPU)
+				memory = formatQuantity(queue.Memory) + " GiB"
+			}
+			workerType := queue.WorkerType
+			if workerType == "" {
+				workerType = unknownResource
+			}
+			tab.AddRow([]string{queue.Name, workerType, cpu, memory, strconv.Itoa(queue.MinWorkers), strconv.Itoa(queue.MaxWorkers)}, false)
+		}
+		// the estimate is part of a create or update, so it is printed as text whatever the output format
+		tab.PrintHeader(out)
+		tab.PrintRows(out, 0)
+	}
+
+	total := fmt.Sprintf(" Workers: %d to %d", e.MinWorkers(), e.MaxWorkers())
+	minCPU, maxCPU, cpuOK := e.CPU()
+	minMemory, maxMemory, memoryOK := e.Memory()
+	if cpuOK && memoryOK {
+		total += fmt.Sprintf("\n Total: %s CPU, %s GiB when idle, up to %s CPU, %s GiB at max workers",
+			formatQuantity(minCPU), formatQuantity(minMemory), formatQuantity(maxCPU), formatQuantity(maxMemory))
+	}
+	fmt.Fprintln(out, total)
+}
+
+// PrintEstimate prints the estimate of deployment d with the worker queues queues to out
+func PrintEstimate(d *astro.Deployment, queues []astro.WorkerQueue, configOption *astro.DeploymentConfig, out io.Writer) {
+	estimate := EstimateResources(d, queues, configOption)
+	estimate.Print(out)
+}
+
+// Cost prints the estimated footprint of every deployment of workspace ws and the total for the workspace
+func Cost(ws string, client astro.Client, out io.Writer) error {
+	c, err := config.GetCurrentContext()
+	if err != nil {
+		return err
+	}
+	deployments, err := GetDeployments(ws, c.Organization, client)
+	if err != nil {
+		return err
+	}
+	tab := printutil.Table{
+		Padding:        []int{30, 30, 14, 12, 12, 10, 10, 12, 12},
+		DynamicPadding: true,
+		Header:         []string{"NAME", "SCHEDULER", "WORKER QUEUES", "MIN WORKERS", "MAX WORKERS", "MIN CPU", "MAX CPU", "MIN MEMORY", "MAX MEMORY"},
+		NoResultsMsg:   fmt.Sprintf("No Deployments found in workspace %s", ansi.Bold(ws)),
+	}
+	if len(deployments) == 0 {
+		return tab.Print(out)
+	}
+	configOption, err := client.GetDeploymentConfig()
+	if err != nil {
+		return err
+	}
+	var minWorkers, maxWorkers int
+	var minCPU, maxCPU, minMemory, maxMemory float64
+	totalOK := true
+	for i := range deployments {
+		estimate := EstimateResources(&deployments[i], deployments[i].WorkerQueues, &configOption)
+		scheduler := estimate.Scheduler
+		if estimate.Schedulers > 1 {
+			scheduler += fmt.Sprintf(" x %d", estimate.Schedulers)
+		}
+		minWorkers += estimate.MinWorkers()
+		maxWorkers += estimate.MaxWorkers()
+		row := []string{estimate.Label, scheduler, strconv.Itoa(len(estimate.WorkerQueues)), strconv.Itoa(estimate.MinWorkers()), strconv.Itoa(estimate.MaxWorkers())}
+		deploymentMinCPU, deploymentMaxCPU, cpuOK := estimate.CPU()
+		deploymentMinMemory, deploymentMaxMemory, memoryOK := estimate.Memory()
+		if cpuOK && memoryOK {
+			minCPU += deploymentMinCPU
+			maxCPU += deploymentMaxCPU
+			minMemory += deploymentMinMemory
+			maxMemory += deploymentMaxMemory
+			row = append(row, formatQuantity(deploymentMinCPU), formatQuantity(deploymentMaxCPU), formatQuantity(deploymentMinMemory)+" GiB", formatQuantity(deploymentMaxMemory)+" GiB")
+		} else {
+			totalOK = false
+			row = append(row, unknownResource, unknownResource, unknownResource, unknownResource)
+		}
+		tab.AddRow(row, false)
+	}
+	tab.SuccessMsg = fmt.Sprintf("\n Workers in the workspace: %d to %d", minWorkers, maxWorkers)
+	if totalOK {
+		tab.SuccessMsg += fmt.Sprintf("\n Total: %s CPU, %s GiB when idle, up to %s CPU, %s GiB at max workers",
+			formatQuantity(minCPU), formatQuantity(minMemory), formatQuantity(maxCPU), formatQuantity(maxMemory))
+	}
+	return tab.Print(out)
+}
+
+// parseCPU returns a number of CPUs from a Kubernetes quantity like 1 or 500m, or 0 if it can not be parsed
+func parseCPU(quantity string) float64 {
+	quantity = strings.TrimSpace(quantity)
+	divisor := 1.0
+	if strings.HasSuffix(quantity, "m") {
+		quantity = strings.TrimSuffix(quantity, "m")
+		divisor = 1000
+	}
+	cpu, err := strconv.ParseFloat(quantity, 64)
+	if err != nil {
+		return 0
+	}
+	return cpu / divisor
+}
+
+// parseMemory returns GiB from a Kubernetes quantity like 2Gi or 512Mi, or 0 if it can not be parsed
+func parseMemory(quantity string) float64 {
+	quantity = strings.TrimSuffix(strings.TrimSpace(quantity), "B")
+	units := []struct {
+		suffix string
+		gib    float64
+	}{
+		{"Ti", 1024}, {"Gi", 1}, {"Mi", 1.0 / 1024}, {"Ki", 1.0 / (1024 * 1024)},
+		{"T", 1000 * 1000 * 1000 * 1000 / float64(1<<30)}, {"G", 1000 * 1000 * 1000 / float64(1<<30)}, {"M", 1000 * 1000 / float64(1<<30)},
+	}
+	for _, unit := range units {
+		if strings.HasSuffix(quantity, unit.suffix) {
+			value, err := strconv.ParseFloat(strings.TrimSuffix(quantity, unit.suffix), 64)
+			if err != nil {
+				return 0
+			}
+			return value * unit.gib
+		}
+	}
+	value, err := strconv.ParseFloat(quantity, 64)
+	if err != nil {
+		return 0
+	}
+	return value / float64(1<<30)
+}
+
+// formatQuantity formats a quantity with at most two decimals
+func formatQuantity(quantity float64) string {
+	return strconv.FormatFloat(math.Round(quantity*100)/100, 'f', -1, 64)
+}
@@ -0,0 +1,164 @@
+package deployment
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/astronomer/astro-cli/astro-client"
+	astro_mocks "github.com/astronomer/astro-cli/astro-client/mocks"
+	testUtil "github.com/astronomer/astro-cli/pkg/testing"
+	"github.com/stretchr/testify/assert"
+)
+
+var estimateConfig = astro.DeploymentConfig{
+	AstronomerUnit: astro.AstronomerUnit{CPU: 100, Memory: 384},
+	AstroMachines: []astro.Machine{
+		{Type: "A5", CPU: "1", Memory: "2Gi"},
+		{Type: "A10", CPU: "2", Memory: "4Gi"},
+	},
+	SchedulerSizes: []astro.MachineUnit{
+		{Size: "SMALL", CPU: "1", Memory: "2Gi"},
+		{Size: "MEDIUM", CPU: "2", Memory: "4Gi"},
+	},
+}
+
+var hostedDeployment = astro.Deployment{
+	Label:              "hosted",
+	Type:               hostedShared,
+	SchedulerSize:      "small",
+	IsHighAvailability: true,
+	DeploymentSpec:     astro.DeploymentSpec{Executor: CeleryExecutor},
+	WorkerQueues: []astro.WorkerQueue{
+		{Name: "default", AstroMachine: "A5", MinWorkerCount: 1, MaxWorkerCount: 10},
+		{Name: "heavy", AstroMachine: "A10", MinWorkerCount: 0, MaxWorkerCount: 50},
+	},
+}
+
+var hybridDeployment = astro.Deployment{
+	Label:          "hybrid",
+	Cluster:        astro.Cluster{NodePools: []astro.NodePool{{ID: "pool-id", NodeInstanceType: "m5.xlarge"}}},
+	DeploymentSpec: astro.DeploymentSpec{Executor: CeleryExecutor, Scheduler: astro.Scheduler{AU: 10, Replicas: 2}},
+	WorkerQueues:   []astro.WorkerQueue{{Name: "default", NodePoolID: "pool-id", MinWorkerCount: 2, MaxWorkerCount: 20, PodCPU: "1", PodRAM: "2Gi"}},
+}
+
+func TestEstimateResources(t *testing.T) {
+	t.Run("hosted deployment", func(t *testing.T) {
+		estimate := EstimateResources(&hostedDeployment, hostedDeployment.WorkerQueues, &estimateConfig)
+		assert.Equal(t, ResourceEstimate{
+			Label:            "hosted",
+			Scheduler:        "small",
+			SchedulerCPU:     1,
+			SchedulerMemory:  2,
+			Schedulers:       2,
+			HighAvailability: true,
+			WorkerQueues: []QueueEstimate{
+				{Name: "default", WorkerType: "A5", CPU: 1, Memory: 2, MinWorkers: 1, MaxWorkers: 10},
+				{Name: "heavy", WorkerType: "A10", CPU: 2, Memory: 4, MinWorkers: 0, MaxWorkers: 50},
+			},
+		}, estimate)
+		assert.Equal(t, 1, estimate.MinWorkers())
+		assert.Equal(t, 60, estimate.MaxWorkers())
+		minimum, maximum, ok := estimate.CPU()
+		assert.True(t, ok)
+		assert.Equal(t, 3.0, minimum)
+		assert.Equal(t, 112.0, maximum)
+		minimum, maximum, ok = estimate.Memory()
+		assert.True(t, ok)
+		assert.Equal(t, 6.0, minimum)
+		assert.Equal(t, 224.0, maximum)
+
+		out := new(bytes.Buffer)
+		estimate.Print(out)
+		assert.Contains(t, out.String(), "Scheduler: small (1 CPU, 2 GiB) x 2, high availability enabled")
+		assert.Contains(t, out.String(), "heavy")
+		assert.Contains(t, out.String(), "Workers: 1 to 60")
+		assert.Contains(t, out.String(), "Total: 3 CPU, 6 GiB when idle, up to 112 CPU, 224 GiB at max workers")
+	})
+
+	t.Run("hybrid deployment", func(t *testing.T) {
+		estimate := EstimateResources(&hybridDeployment, hybridDeployment.WorkerQueues, &estimateConfig)
+		assert.Equal(t, "10 AU", estimate.Scheduler)
+		assert.Equal(t, 1.0, estimate.SchedulerCPU)
+		assert.Equal(t, 3.75, estimate.SchedulerMemory)
+		assert.Equal(t, 2, estimate.Schedulers)
+		assert.Equal(t, []QueueEstimate{{Name: "default", WorkerType: "m5.xlarge", CPU: 1, Memory: 2, MinWorkers: 2, MaxWorkers: 20}}, estimate.WorkerQueues)
+		minimum, maximum, ok := estimate.CPU()
+		assert.True(t, ok)
+		assert.Equal(t, 4.0, minimum)
+		assert.Equal(t, 22.0, maximum)
+
+		out := new(bytes.Buffer)
+		estimate.Print(out)
+		assert.Contains(t, out.String(), "Scheduler: 10 AU (1 CPU, 3.75 GiB) x 2 replicas")
+		assert.Contains(t, out.String(), "m5.xlarge")
+		assert.Contains(t, out.String(), "Workers: 2 to 20")
+		assert.Contains(t, out.String(), "Total: 4 CPU, 11.5 GiB when idle, up to 22 CPU, 47.5 GiB at max workers")
+	})
+
+	t.Run("hybrid deployment without worker pod size", func(t *testing.T) {
+		d := hybridDeployment
+		d.WorkerQueues = []astro.WorkerQueue{{Name: "default", NodePoolID: "pool-id", MinWorkerCount: 2, MaxWorkerCount: 20}}
+		estimate := EstimateResources(&d, d.WorkerQueues, &estimateConfig)
+		_, _, ok := estimate.CPU()
+		assert.False(t, ok)
+
+		out := new(bytes.Buffer)
+		estimate.Print(out)
+		assert.NotContains(t, out.String(), "Total")
+	})
+
+	t.Run("kubernetes executor has no worker count", func(t *testing.T) {
+		d := hostedDeployment
+		d.DeploymentSpec.Executor = KubeExecutor
+		estimate := EstimateResources(&d, d.WorkerQueues, &estimateConfig)
+		assert.Equal(t, 0, estimate.MaxWorkers())
+	})
+}
+
+func TestParseQuantities(t *testing.T) {
+	assert.Equal(t, 0.5, parseCPU("500m"))
+	assert.Equal(t, 2.0, parseCPU("2"))
+	assert.Equal(t, 0.0, parseCPU("two"))
+	assert.Equal(t, 2.0, parseMemory("2Gi"))
+	assert.Equal(t, 0.5, parseMemory("512Mi"))
+	assert.Equal(t, 1024.0, parseMemory("1TiB"))
+	assert.Equal(t, 0.0, parseMemory("lots"))
+	assert.Equal(t, "0.33", formatQuantity(1.0/3))
+}
+
+func TestCost(t *testing.T) {
+	testUtil.InitTestConfig(testUtil.CloudPlatform)
+
+	t.Run("summarizes the workspace", func(t *testing.T) {
+		mockClient := new(astro_mocks.Client)
+		mockClient.On("ListDeployments", org, ws).Return([]astro.Deployment{hostedDeployment, hybridDeployment}, nil).Once()
+		mockClient.On("GetDeploymentConfig").Return(estimateConfig, nil).Once()
+		out := new(bytes.Buffer)
+		err := Cost(ws, mockClient, out)
+		assert.NoError(t, err)
+		assert.Contains(t, out.String(), "small x 2")
+		assert.Contains(t, out.String(), "10 AU x 2")
+		assert.Contains(t, out.String(), "224 GiB")
+		assert.Contains(t, out.String(), "Workers in the workspace: 3 to 80")
+		assert.Contains(t, out.String(), "Total: 7 CPU, 17.5 GiB when idle, up to 134 CPU, 271.5 GiB at max workers")
+		mockClient.AssertExpectations(t)
+	})
+
+	t.Run("no deployments", func(t *testing.T) {
+		mockClient := new(astro_mocks.Client)
+		mockClient.On("ListDeployments", org, ws).Return([]astro.Deployment{}, nil).Once()
+		out := new(bytes.Buffer)
+		err := Cost(ws, mockClient, out)
+		assert.NoError(t, err)
+		assert.Contains(t, out.String(), "No Deployments found in workspace")
+		mockClient.AssertExpectations(t)
+	})
+
+	t.Run("list error", func(t *testing.T) {
+		mockClient := new(astro_mocks.Client)
+		mockClient.On("ListDeployments", org, ws).Return(nil, errMock).Once()
+		err := Cost(ws, mockClient, new(bytes.Buffer))
+		assert.ErrorIs(t, err, errMock)
+		mockClient.AssertExpectations(t)
+	})
+}
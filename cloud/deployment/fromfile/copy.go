@@ -0,0 +1,242 @@
+package fromfile
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
+	airflowclient "github.com/astronomer/astro-cli/airflow-client"
+	"github.com/astronomer/astro-cli/astro-client"
+	astrocore "github.com/astronomer/astro-cli/astro-client-core"
+	"github.com/astronomer/astro-cli/cloud/deployment"
+	"github.com/astronomer/astro-cli/cloud/deployment/inspect"
+	"github.com/astronomer/astro-cli/cloud/workspace"
+	"github.com/astronomer/astro-cli/config"
+)
+
+const webserverURLField = "metadata.webserver_url"
+
+var (
+	inspectDeployment = inspect.Inspect
+	inspectValue      = inspect.ReturnSpecifiedValue
+	createOrUpdate    = CreateOrUpdate
+	copyConnections   = deployment.CopyConnection
+	copyVariables     = deployment.CopyVariable
+	copyPools         = deployment.CopyPool
+
+	errAmbiguousDeployment = errors.New("matches more than one deployment, use its ID instead")
+)
+
+// CopyOptions are the parts of a deployment copied by Copy
+type CopyOptions struct {
+	// Name of the new deployment
+	Name string
+	// WorkspaceName is the workspace of the new deployment, the workspace of the source deployment when empty
+	WorkspaceName string
+	// EnvVars copies the environment variables which are not secret
+	EnvVars bool
+	// Connections copies the Airflow connections, without their password and extra fields
+	Connections bool
+	// AirflowVariables copies the Airflow variables
+	AirflowVariables bool
+	// Pools copies the Airflow pools
+	Pools bool
+	// Image deploys the image currently deployed to the source deployment
+	Image bool
+}
+
+// CopiedDeployment is the deployment created by Copy
+type CopiedDeployment struct {
+	ID           string
+	WorkspaceID  string
+	WebserverURL string
+}
+
+// Copy creates a deployment from the inspect template of the deployment deploymentID, the same way a deployment file is
+// created with CreateOrUpdate. The new deployment is returned along with any error hit while copying its Airflow objects,
+// since the deployment exists at that point.
+func Copy(wsID, deploymentID string, opts CopyOptions, client astro.Client, coreClient astrocore.CoreClient, airflowAPIClient airflowclient.Client, out io.Writer) (CopiedDeployment, error) {
+	// get the source deployment as a template
+	buf := new(bytes.Buffer)
+	err := inspectDeployment(wsID, "", deploymentID, jsonFormat, client, coreClient, buf, "", true)
+	if err != nil {
+		return CopiedDeployment{}, err
+	}
+	var template inspect.FormattedDeployment
+	if err = json.Unmarshal(buf.Bytes(), &template); err != nil {
+		return CopiedDeployment{}, err
+	}
+	template.Deployment.Configuration.Name = opts.Name
+	if opts.WorkspaceName != "" {
+		template.Deployment.Configuration.WorkspaceName = opts.WorkspaceName
+	}
+	envVars := []inspect.EnvironmentVariable{}
+	for _, envVar := range template.Deployment.EnvVars {
+		// variables without a value can not be created from a file
+		if opts.EnvVars && envVar.Value != "" {
+			envVars = append(envVars, envVar)
+		}
+	}
+	template.Deployment.EnvVars = envVars
+
+	// create the new deployment from the template
+	inputFile, err := writeTemplate(&template)
+	if err != nil {
+		return CopiedDeployment{}, err
+	}
+	defer os.Remove(inputFile)
+	buf.Reset()
+	if err = createOrUpdate(inputFile, createAction, client, coreClient, buf); err != nil {
+		return CopiedDeployment{}, err
+	}
+	var created inspect.FormattedDeployment
+	if err = json.Unmarshal(buf.Bytes(), &created); err != nil {
+		return CopiedDeployment{}, err
+	}
+	copied := CopiedDeployment{}
+	if metadata := created.Deployment.Metadata; metadata != nil {
+		copied.ID = stringValue(metadata.DeploymentID)
+		copied.WorkspaceID = stringValue(metadata.WorkspaceID)
+		copied.WebserverURL = stringValue(metadata.WebserverURL)
+	}
+	fmt.Fprintf(out, "Deployment %s was successfully created from %s\n", opts.Name, deploymentID)
+
+	if opts.Image {
+		if err = deploySourceImage(deploymentID, copied.ID, client, out); err != nil {
+			return copied, fmt.Errorf("failed to deploy the image: %w", err)
+		}
+	}
+	if !opts.Connections && !opts.AirflowVariables && !opts.Pools {
+		return copied, nil
+	}
+	sourceURL, err := inspectValue(wsID, "", deploymentID, client, coreClient, webserverURLField)
+	if err != nil {
+		return copied, err
+	}
+	fromAirflowURL := fmt.Sprintf("%v", sourceURL)
+	if opts.Connections {
+		if err = copyConnections(fromAirflowURL, copied.WebserverURL, airflowAPIClient, out); err != nil {
+			return copied, fmt.Errorf("failed to copy the Airflow connections: %w", err)
+		}
+	}
+	if opts.AirflowVariables {
+		if err = copyVariables(fromAirflowURL, copied.WebserverURL, airflowAPIClient, out); err != nil {
+			return copied, fmt.Errorf("failed to copy the Airflow variables: %w", err)
+		}
+	}
+	if opts.Pools {
+		if err = copyPools(fromAirflowURL, copied.WebserverURL, airflowAPIClient, out); err != nil {
+			return copied, fmt.Errorf("failed to copy the Airflow pools: %w", err)
+		}
+	}
+	return copied, nil
+}
+
+// CopyDeployment copies the deployment source, an ID or a name, to a new deployment in the workspace targetWorkspaceID.
+// The new deployment is created in the workspace of the source deployment when targetWorkspaceID is empty.
+func CopyDeployment(source, targetWorkspaceID string, opts CopyOptions, client astro.Client, coreClient astrocore.CoreClient, airflowAPIClient airflowclient.Client, out io.Writer) error {
+	c, err := config.GetCurrentContext()
+	if err != nil {
+		return err
+	}
+	deployments, err := deployment.GetDeployments("", c.Organization, client)
+	if err != nil {
+		return err
+	}
+	var sourceDeployment *astro.Deployment
+	for i := range deployments {
+		if deployments[i].ID == source {
+			sourceDeployment = &deployments[i]
+			break
+		}
+		if deployments[i].Label == source {
+			if sourceDeployment != nil {
+				return fmt.Errorf("deployment: %s %w", source, errAmbiguousDeployment)
+			}
+			sourceDeployment = &deployments[i]
+		}
+	}
+	if sourceDeployment == nil {
+		return fmt.Errorf("deployment: %s %w in organization: %s", source, errNotFound, c.Organization)
+	}
+	if targetWorkspaceID != "" && targetWorkspaceID != sourceDeployment.Workspace.ID {
+		workspaces, err := workspace.GetWorkspaces(coreClient)
+		if err != nil {
+			return err
+		}
+		for i := range workspaces {
+			if workspaces[i].Id == targetWorkspaceID {
+				opts.WorkspaceName = workspaces[i].Name
+			}
+		}
+		if opts.WorkspaceName == "" {
+			return fmt.Errorf("workspace: %s %w in organization: %s", targetWorkspaceID, errNotFound, c.Organization)
+		}
+	}
+	copied, err := Copy(sourceDeployment.Workspace.ID, sourceDeployment.ID, opts, client, coreClient, airflowAPIClient, out)
+	if copied.WebserverURL != "" {
+		fmt.Fprintf(out, "Airflow UI: %s\n", copied.WebserverURL)
+	}
+	return err
+}
+
+// deploySourceImage deploys the image tag currently deployed to the deployment sourceID to the deployment targetID
+func deploySourceImage(sourceID, targetID string, client astro.Client, out io.Writer) error {
+	source, err := client.GetDeployment(sourceID)
+	if err != nil {
+		return err
+	}
+	image := source.DeploymentSpec.Image
+	if image.Tag == "" {
+		fmt.Fprintf(out, "No image was deployed to %s, skipping the image deploy\n", sourceID)
+		return nil
+	}
+	created, err := client.CreateImage(astro.CreateImageInput{Tag: source.RuntimeRelease.Version, DeploymentID: targetID})
+	if err != nil {
+		return err
+	}
+	deployed, err := client.DeployImage(&astro.DeployImageInput{
+		ImageID:          created.ID,
+		DeploymentID:     targetID,
+		Repository:       image.Repository,
+		Tag:              image.Tag,
+		DagDeployEnabled: source.DagDeployEnabled,
+		Description:      "Copied from deployment " + source.Label,
+	})
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(out, "Deployed Image Tag: %s\n", deployed.Tag)
+	if source.DagDeployEnabled {
+		fmt.Fprintln(out, "DAGs deployed separately from the image are not copied, deploy them with astro deploy --dags")
+	}
+	return nil
+}
+
+// writeTemplate writes a deployment template to a temporary deployment file and returns its path
+func writeTemplate(template *inspect.FormattedDeployment) (string, error) {
+	data, err := json.Marshal(template)
+	if err != nil {
+		return "", err
+	}
+	f, err := os.CreateTemp("", "deployment-*.json")
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+	if _, err = f.Write(data); err != nil {
+		os.Remove(f.Name())
+		return "", err
+	}
+	return f.Name(), nil
+}
+
+func stringValue(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
@@ -0,0 +1,164 @@
+package fromfile
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"testing"
+
+	airflowclient "github.com/astronomer/astro-cli/airflow-client"
+	"github.com/astronomer/astro-cli/astro-client"
+	astrocore "github.com/astronomer/astro-cli/astro-client-core"
+	astrocore_mocks "github.com/astronomer/astro-cli/astro-client-core/mocks"
+	astro_mocks "github.com/astronomer/astro-cli/astro-client/mocks"
+	"github.com/astronomer/astro-cli/cloud/deployment"
+	"github.com/astronomer/astro-cli/cloud/deployment/inspect"
+	testUtil "github.com/astronomer/astro-cli/pkg/testing"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestCopy(t *testing.T) {
+	sourceTemplate := `{"deployment": {
+		"environment_variables": [
+			{"is_secret": false, "key": "FOO", "value": "bar"},
+			{"is_secret": false, "key": "EMPTY", "value": ""}
+		],
+		"configuration": {"name": "", "workspace_name": "source-ws", "executor": "CeleryExecutor", "cluster_name": "test-cluster"},
+		"worker_queues": [{"name": "default", "worker_type": "test-worker-1"}]
+	}}`
+	newID := "new-deployment-id"
+	newWs := "new-ws-id"
+	newURL := "new.astronomer.run/abc"
+
+	var created inspect.FormattedDeployment
+	origInspect, origCreate, origValue, origConnections, origVariables := inspectDeployment, createOrUpdate, inspectValue, copyConnections, copyVariables
+	defer func() {
+		inspectDeployment, createOrUpdate, inspectValue, copyConnections, copyVariables = origInspect, origCreate, origValue, origConnections, origVariables
+	}()
+	inspectDeployment = func(wsID, deploymentName, deploymentID, outputFormat string, client astro.Client, coreClient astrocore.CoreClient, out io.Writer, requestedField string, template bool) error {
+		assert.Equal(t, "source-deployment-id", deploymentID)
+		assert.Equal(t, jsonFormat, outputFormat)
+		assert.True(t, template)
+		_, err := out.Write([]byte(sourceTemplate))
+		return err
+	}
+	createOrUpdate = func(inputFile, action string, client astro.Client, coreClient astrocore.CoreClient, out io.Writer) error {
+		assert.Equal(t, createAction, action)
+		data, err := os.ReadFile(inputFile)
+		assert.NoError(t, err)
+		assert.NoError(t, json.Unmarshal(data, &created))
+		fmt.Fprintf(out, `{"deployment": {"metadata": {"deployment_id": %q, "workspace_id": %q, "webserver_url": %q}}}`, newID, newWs, newURL)
+		return nil
+	}
+	inspectValue = func(wsID, deploymentName, deploymentID string, client astro.Client, coreClient astrocore.CoreClient, requestedField string) (any, error) {
+		assert.Equal(t, webserverURLField, requestedField)
+		return "source.astronomer.run/abc", nil
+	}
+	copied := []string{}
+	copyConnections = func(fromAirflowURL, toAirflowURL string, airflowAPIClient airflowclient.Client, out io.Writer) error {
+		copied = append(copied, "connections "+fromAirflowURL+" "+toAirflowURL)
+		return nil
+	}
+	copyVariables = func(fromAirflowURL, toAirflowURL string, airflowAPIClient airflowclient.Client, out io.Writer) error {
+		copied = append(copied, "variables "+fromAirflowURL+" "+toAirflowURL)
+		return errTest
+	}
+
+	t.Run("copies the template without env vars", func(t *testing.T) {
+		copied = []string{}
+		out := new(bytes.Buffer)
+		deployment, err := Copy("source-ws-id", "source-deployment-id", CopyOptions{Name: "copy"}, nil, nil, nil, out)
+		assert.NoError(t, err)
+		assert.Equal(t, CopiedDeployment{ID: newID, WorkspaceID: newWs, WebserverURL: newURL}, deployment)
+		assert.Equal(t, "copy", created.Deployment.Configuration.Name)
+		assert.Equal(t, "source-ws", created.Deployment.Configuration.WorkspaceName)
+		assert.Empty(t, created.Deployment.EnvVars)
+		assert.Equal(t, []inspect.Workerq{{Name: "default", WorkerType: "test-worker-1"}}, created.Deployment.WorkerQs)
+		assert.Empty(t, copied)
+		assert.Contains(t, out.String(), "Deployment copy was successfully created from source-deployment-id")
+	})
+
+	t.Run("copies env vars and airflow objects to another workspace", func(t *testing.T) {
+		copied = []string{}
+		opts := CopyOptions{Name: "copy", WorkspaceName: "other-ws", EnvVars: true, Connections: true, AirflowVariables: true}
+		deployment, err := Copy("source-ws-id", "source-deployment-id", opts, nil, nil, nil, new(bytes.Buffer))
+		assert.ErrorIs(t, err, errTest)
+		assert.Equal(t, newID, deployment.ID)
+		assert.Equal(t, "other-ws", created.Deployment.Configuration.WorkspaceName)
+		assert.Equal(t, []inspect.EnvironmentVariable{{Key: "FOO", Value: "bar"}}, created.Deployment.EnvVars)
+		assert.Equal(t, []string{
+			"connections source.astronomer.run/abc " + newURL,
+			"variables source.astronomer.run/abc " + newURL,
+		}, copied)
+	})
+
+	t.Run("deploys the source image and copies pools", func(t *testing.T) {
+		copied = []string{}
+		copyPools = func(fromAirflowURL, toAirflowURL string, airflowAPIClient airflowclient.Client, out io.Writer) error {
+			copied = append(copied, "pools "+fromAirflowURL+" "+toAirflowURL)
+			return nil
+		}
+		defer func() { copyPools = deployment.CopyPool }()
+		mockClient := new(astro_mocks.Client)
+		mockClient.On("GetDeployment", "source-deployment-id").Return(astro.Deployment{
+			Label:            "prod",
+			DagDeployEnabled: true,
+			RuntimeRelease:   astro.RuntimeRelease{Version: "8.0.0"},
+			DeploymentSpec:   astro.DeploymentSpec{Image: astro.Image{Repository: "registry/org/source", Tag: "deploy-1"}},
+		}, nil).Once()
+		mockClient.On("CreateImage", astro.CreateImageInput{Tag: "8.0.0", DeploymentID: newID}).Return(&astro.Image{ID: "image-id"}, nil).Once()
+		mockClient.On("DeployImage", &astro.DeployImageInput{
+			ImageID: "image-id", DeploymentID: newID, Repository: "registry/org/source", Tag: "deploy-1", DagDeployEnabled: true, Description: "Copied from deployment prod",
+		}).Return(&astro.Image{Tag: "deploy-1"}, nil).Once()
+		out := new(bytes.Buffer)
+		_, err := Copy("source-ws-id", "source-deployment-id", CopyOptions{Name: "copy", Image: true, Pools: true}, mockClient, nil, nil, out)
+		assert.NoError(t, err)
+		assert.Contains(t, out.String(), "Deployed Image Tag: deploy-1")
+		assert.Contains(t, out.String(), "DAGs deployed separately from the image are not copied")
+		assert.Equal(t, []string{"pools source.astronomer.run/abc " + newURL}, copied)
+		mockClient.AssertExpectations(t)
+	})
+
+	t.Run("copies a deployment by name to another workspace", func(t *testing.T) {
+		testUtil.InitTestConfig(testUtil.CloudPlatform)
+		mockClient := new(astro_mocks.Client)
+		mockClient.On("ListDeployments", "test-org-id", "").Return([]astro.Deployment{
+			{ID: "source-deployment-id", Label: "prod", Workspace: astro.Workspace{ID: "source-ws-id"}},
+			{ID: "other-id", Label: "dev", Workspace: astro.Workspace{ID: "source-ws-id"}},
+		}, nil).Once()
+		mockCoreClient := new(astrocore_mocks.ClientWithResponsesInterface)
+		mockCoreClient.On("ListWorkspacesWithResponse", mock.Anything, mock.Anything, mock.Anything).Return(&astrocore.ListWorkspacesResponse{
+			HTTPResponse: &http.Response{StatusCode: 200},
+			JSON200:      &astrocore.WorkspacesPaginated{Workspaces: []astrocore.Workspace{{Id: "staging-id", Name: "staging"}}},
+		}, nil).Once()
+		out := new(bytes.Buffer)
+		err := CopyDeployment("prod", "staging-id", CopyOptions{Name: "prod-copy"}, mockClient, mockCoreClient, nil, out)
+		assert.NoError(t, err)
+		assert.Equal(t, "staging", created.Deployment.Configuration.WorkspaceName)
+		assert.Equal(t, "prod-copy", created.Deployment.Configuration.Name)
+		assert.Contains(t, out.String(), "Airflow UI: "+newURL)
+		mockClient.AssertExpectations(t)
+		mockCoreClient.AssertExpectations(t)
+	})
+
+	t.Run("source deployment not found", func(t *testing.T) {
+		testUtil.InitTestConfig(testUtil.CloudPlatform)
+		mockClient := new(astro_mocks.Client)
+		mockClient.On("ListDeployments", "test-org-id", "").Return([]astro.Deployment{}, nil).Once()
+		err := CopyDeployment("prod", "", CopyOptions{Name: "prod-copy"}, mockClient, nil, nil, new(bytes.Buffer))
+		assert.ErrorIs(t, err, errNotFound)
+		mockClient.AssertExpectations(t)
+	})
+
+	t.Run("create fails", func(t *testing.T) {
+		createOrUpdate = func(inputFile, action string, client astro.Client, coreClient astrocore.CoreClient, out io.Writer) error {
+			return errTest
+		}
+		_, err := Copy("source-ws-id", "source-deployment-id", CopyOptions{Name: "copy"}, nil, nil, nil, new(bytes.Buffer))
+		assert.ErrorIs(t, err, errTest)
+	})
+}
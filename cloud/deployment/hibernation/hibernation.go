@@ -0,0 +1,324 @@
+package hibernation
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/astronomer/astro-cli/astro-client"
+	astrocore "github.com/astronomer/astro-cli/astro-client-core"
+	"github.com/astronomer/astro-cli/cloud/deployment"
+	"github.com/astronomer/astro-cli/cloud/deployment/inspect"
+	"github.com/astronomer/astro-cli/cloud/deployment/workerqueue"
+	"github.com/ghodss/yaml"
+)
+
+const (
+	// StateVariable is the environment variable keeping the values of a deployment before it was hibernated
+	StateVariable = "ASTRO_HIBERNATION_STATE"
+
+	updateAction = "update"
+	timeFormat   = "15:04"
+)
+
+var (
+	errNoSchedule      = errors.New("has no hibernation_schedule")
+	errNoName          = errors.New("missing required field: deployment.configuration.name")
+	errInvalidSchedule = errors.New("invalid hibernation_schedule")
+
+	defaultAwakeDays = []string{"mon", "tue", "wed", "thu", "fri"}
+
+	updateWorkerQueue = workerqueue.CreateOrUpdate
+	updateDeployment  = deployment.Update
+)
+
+// State is what hibernating changes on a deployment, saved to restore it exactly on wake
+type State struct {
+	SchedulerAU        int          `json:"scheduler_au,omitempty"`
+	SchedulerReplicas  int          `json:"scheduler_replicas,omitempty"`
+	SchedulerSize      string       `json:"scheduler_size,omitempty"`
+	IsHighAvailability bool         `json:"is_high_availability,omitempty"`
+	WorkerQueues       []QueueState `json:"worker_queues,omitempty"`
+}
+
+// QueueState is the scaling of a worker queue before it was hibernated
+type QueueState struct {
+	Name              string `json:"name"`
+	WorkerType        string `json:"worker_type"`
+	MinWorkerCount    int    `json:"min_worker_count"`
+	MaxWorkerCount    int    `json:"max_worker_count"`
+	WorkerConcurrency int    `json:"worker_concurrency"`
+}
+
+// Hibernate scales the worker queues of a deployment to a minimum of 0 workers and reduces its scheduler to schedulerAU,
+// or to the smallest scheduler when schedulerAU is 0. The previous values are saved on the deployment for Wake.
+func Hibernate(ws, deploymentID, deploymentName string, schedulerAU int, client astro.Client, coreClient astrocore.CoreClient, out io.Writer) error {
+	d, err := deployment.GetDeployment(ws, deploymentID, deploymentName, false, client, coreClient)
+	if err != nil {
+		return err
+	}
+	if _, ok, err := savedState(&d); err != nil || ok {
+		if ok {
+			fmt.Fprintf(out, "Deployment %s is already hibernating\n", d.Label)
+		}
+		return err
+	}
+	configOption, err := client.GetDeploymentConfig()
+	if err != nil {
+		return err
+	}
+
+	state := State{
+		SchedulerAU:        d.DeploymentSpec.Scheduler.AU,
+		SchedulerReplicas:  d.DeploymentSpec.Scheduler.Replicas,
+		SchedulerSize:      d.SchedulerSize,
+		IsHighAvailability: d.IsHighAvailability,
+	}
+	if d.DeploymentSpec.Executor == deployment.CeleryExecutor {
+		for i := range d.WorkerQueues {
+			q := &d.WorkerQueues[i]
+			state.WorkerQueues = append(state.WorkerQueues, QueueState{
+				Name:              q.Name,
+				WorkerType:        workerType(&d, q),
+				MinWorkerCount:    q.MinWorkerCount,
+				MaxWorkerCount:    q.MaxWorkerCount,
+				WorkerConcurrency: q.WorkerConcurrency,
+			})
+		}
+	}
+	// the state is saved first, so a hibernation stopped half way can still be woken
+	if err = saveState(&d, &state, client); err != nil {
+		return err
+	}
+
+	ws = d.Workspace.ID
+	for _, q := range state.WorkerQueues {
+		if q.MinWorkerCount == 0 {
+			continue
+		}
+		err = updateWorkerQueue(ws, d.ID, "", q.Name, updateAction, q.WorkerType, 0, q.MaxWorkerCount, q.WorkerConcurrency, true, client, coreClient, out)
+		if err != nil {
+			return err
+		}
+	}
+	if isHosted(&d) {
+		if len(configOption.SchedulerSizes) > 0 && configOption.SchedulerSizes[0].Size != d.SchedulerSize {
+			err = updateDeployment(d.ID, "", ws, "", "", "", "", configOption.SchedulerSizes[0].Size, highAvailability(d.IsHighAvailability), 0, 0, nil, true, nil, client)
+		}
+	} else {
+		if schedulerAU == 0 {
+			schedulerAU = configOption.Components.Scheduler.AU.Default
+		}
+		if schedulerAU < d.DeploymentSpec.Scheduler.AU {
+			err = updateDeployment(d.ID, "", ws, "", "", "", "", "", "", schedulerAU, 0, nil, true, nil, client)
+		}
+	}
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(out, "Deployment %s is hibernating, run astro deployment wake to restore it\n", d.Label)
+	return nil
+}
+
+// Wake restores the worker queues and scheduler a deployment had before it was hibernated
+func Wake(ws, deploymentID, deploymentName string, client astro.Client, coreClient astrocore.CoreClient, out io.Writer) error {
+	d, err := deployment.GetDeployment(ws, deploymentID, deploymentName, false, client, coreClient)
+	if err != nil {
+		return err
+	}
+	state, ok, err := savedState(&d)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		fmt.Fprintf(out, "Deployment %s is not hibernating\n", d.Label)
+		return nil
+	}
+
+	ws = d.Workspace.ID
+	for _, q := range state.WorkerQueues {
+		if !hasQueue(&d, q.Name) {
+			fmt.Fprintf(out, "Worker queue %s was deleted while the deployment was hibernating, it is not restored\n", q.Name)
+			continue
+		}
+		err = updateWorkerQueue(ws, d.ID, "", q.Name, updateAction, q.WorkerType, q.MinWorkerCount, q.MaxWorkerCount, q.WorkerConcurrency, true, client, coreClient, out)
+		if err != nil {
+			return err
+		}
+	}
+	if isHosted(&d) {
+		err = updateDeployment(d.ID, "", ws, "", "", "", "", state.SchedulerSize, highAvailability(state.IsHighAvailability), 0, 0, nil, true, nil, client)
+	} else {
+		err = updateDeployment(d.ID, "", ws, "", "", "", "", "", "", state.SchedulerAU, state.SchedulerReplicas, nil, true, nil, client)
+	}
+	if err != nil {
+		return err
+	}
+	// the state is removed last, so a failed wake can be retried
+	if err = saveState(&d, nil, client); err != nil {
+		return err
+	}
+	fmt.Fprintf(out, "Deployment %s is awake\n", d.Label)
+	return nil
+}
+
+// ApplySchedule hibernates or wakes the deployment of a deployment file, following the hibernation_schedule of the file at the time now.
+// It is meant to run regularly, for example from a CI schedule, and does nothing when the deployment is already in the right state.
+func ApplySchedule(ws, deploymentFile string, now time.Time, client astro.Client, coreClient astrocore.CoreClient, out io.Writer) error {
+	dataBytes, err := os.ReadFile(deploymentFile)
+	if err != nil {
+		return err
+	}
+	var formattedDeployment inspect.FormattedDeployment
+	if err = yaml.Unmarshal(dataBytes, &formattedDeployment); err != nil {
+		return err
+	}
+	name := formattedDeployment.Deployment.Configuration.Name
+	if name == "" {
+		return errNoName
+	}
+	schedule := formattedDeployment.Deployment.Hibernation
+	if schedule == nil {
+		return fmt.Errorf("%s %w", deploymentFile, errNoSchedule)
+	}
+	awake, err := IsAwake(schedule, now)
+	if err != nil {
+		return err
+	}
+	if awake {
+		return Wake(ws, "", name, client, coreClient, out)
+	}
+	return Hibernate(ws, "", name, 0, client, coreClient, out)
+}
+
+// IsAwake returns whether a deployment following schedule is awake at the time now. A deployment is awake on its awake
+// days, Monday to Friday by default, from wake_at until hibernate_at. When hibernate_at is before wake_at, the deployment
+// stays awake past midnight.
+func IsAwake(schedule *inspect.HibernationSchedule, now time.Time) (bool, error) {
+	location := time.UTC
+	if schedule.Timezone != "" {
+		var err error
+		if location, err = time.LoadLocation(schedule.Timezone); err != nil {
+			return false, fmt.Errorf("%w: timezone %s: %s", errInvalidSchedule, schedule.Timezone, err.Error())
+		}
+	}
+	wakeAt, err := time.Parse(timeFormat, schedule.WakeAt)
+	if err != nil {
+		return false, fmt.Errorf("%w: wake_at must be formatted as HH:MM", errInvalidSchedule)
+	}
+	hibernateAt, err := time.Parse(timeFormat, schedule.HibernateAt)
+	if err != nil {
+		return false, fmt.Errorf("%w: hibernate_at must be formatted as HH:MM", errInvalidSchedule)
+	}
+	days := schedule.AwakeDays
+	if len(days) == 0 {
+		days = defaultAwakeDays
+	}
+	awakeDays := map[time.Weekday]bool{}
+	for _, day := range days {
+		weekday, ok := parseWeekday(day)
+		if !ok {
+			return false, fmt.Errorf("%w: awake_days has an unknown day %s", errInvalidSchedule, day)
+		}
+		awakeDays[weekday] = true
+	}
+
+	now = now.In(location)
+	minutes := now.Hour()*60 + now.Minute()                   //nolint:gomnd
+	wake := wakeAt.Hour()*60 + wakeAt.Minute()                //nolint:gomnd
+	hibernate := hibernateAt.Hour()*60 + hibernateAt.Minute() //nolint:gomnd
+	if wake <= hibernate {
+		return awakeDays[now.Weekday()] && minutes >= wake && minutes < hibernate, nil
+	}
+	// awake past midnight, the hours after midnight belong to the day before
+	if minutes >= wake {
+		return awakeDays[now.Weekday()], nil
+	}
+	return minutes < hibernate && awakeDays[now.AddDate(0, 0, -1).Weekday()], nil
+}
+
+func parseWeekday(day string) (time.Weekday, bool) {
+	day = strings.ToLower(strings.TrimSpace(day))
+	if len(day) < 3 { //nolint:gomnd
+		return 0, false
+	}
+	for weekday := time.Sunday; weekday <= time.Saturday; weekday++ {
+		name := strings.ToLower(weekday.String())
+		if strings.HasPrefix(name, day) {
+			return weekday, true
+		}
+	}
+	return 0, false
+}
+
+// savedState returns the state saved on a hibernating deployment
+func savedState(d *astro.Deployment) (State, bool, error) {
+	for _, envVar := range d.DeploymentSpec.EnvironmentVariablesObjects {
+		if envVar.Key != StateVariable {
+			continue
+		}
+		var state State
+		if err := json.Unmarshal([]byte(envVar.Value), &state); err != nil {
+			return State{}, false, fmt.Errorf("failed to read %s: %w", StateVariable, err)
+		}
+		return state, true, nil
+	}
+	return State{}, false, nil
+}
+
+// saveState saves state in the environment variables of a deployment, or removes it when state is nil
+func saveState(d *astro.Deployment, state *State, client astro.Client) error {
+	envVars := make([]astro.EnvironmentVariable, 0, len(d.DeploymentSpec.EnvironmentVariablesObjects)+1)
+	for _, envVar := range d.DeploymentSpec.EnvironmentVariablesObjects {
+		if envVar.Key == StateVariable {
+			continue
+		}
+		envVars = append(envVars, astro.EnvironmentVariable{Key: envVar.Key, Value: envVar.Value, IsSecret: envVar.IsSecret})
+	}
+	if state != nil {
+		value, err := json.Marshal(state)
+		if err != nil {
+			return err
+		}
+		envVars = append(envVars, astro.EnvironmentVariable{Key: StateVariable, Value: string(value)})
+	}
+	_, err := client.ModifyDeploymentVariable(astro.EnvironmentVariablesInput{DeploymentID: d.ID, EnvironmentVariables: envVars})
+	return err
+}
+
+// workerType returns the worker type of a queue, as expected by workerqueue.CreateOrUpdate
+func workerType(d *astro.Deployment, q *astro.WorkerQueue) string {
+	if isHosted(d) {
+		return q.AstroMachine
+	}
+	for _, pool := range d.Cluster.NodePools {
+		if pool.ID == q.NodePoolID {
+			return pool.NodeInstanceType
+		}
+	}
+	return ""
+}
+
+func hasQueue(d *astro.Deployment, name string) bool {
+	for i := range d.WorkerQueues {
+		if d.WorkerQueues[i].Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+func isHosted(d *astro.Deployment) bool {
+	return deployment.IsDeploymentHosted(d.Type) || deployment.IsDeploymentDedicated(d.Type)
+}
+
+func highAvailability(enabled bool) string {
+	if enabled {
+		return "enable"
+	}
+	return "disable"
+}
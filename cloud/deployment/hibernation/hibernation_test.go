@@ -0,0 +1,212 @@
+package hibernation
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/astronomer/astro-cli/astro-client"
+	astrocore "github.com/astronomer/astro-cli/astro-client-core"
+	astro_mocks "github.com/astronomer/astro-cli/astro-client/mocks"
+	"github.com/astronomer/astro-cli/cloud/deployment"
+	"github.com/astronomer/astro-cli/cloud/deployment/inspect"
+	testUtil "github.com/astronomer/astro-cli/pkg/testing"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+var devDeployment = astro.Deployment{
+	ID:        "dev-id",
+	Label:     "dev",
+	Workspace: astro.Workspace{ID: "ws-id"},
+	Cluster:   astro.Cluster{NodePools: []astro.NodePool{{ID: "pool-id", NodeInstanceType: "m5.xlarge"}}},
+	DeploymentSpec: astro.DeploymentSpec{
+		Executor:  deployment.CeleryExecutor,
+		Scheduler: astro.Scheduler{AU: 10, Replicas: 2},
+		EnvironmentVariablesObjects: []astro.EnvironmentVariablesObject{
+			{Key: "FOO", Value: "bar"},
+			{Key: "TOKEN", IsSecret: true},
+		},
+	},
+	WorkerQueues: []astro.WorkerQueue{
+		{Name: "default", NodePoolID: "pool-id", MinWorkerCount: 2, MaxWorkerCount: 10, WorkerConcurrency: 16},
+		{Name: "idle", NodePoolID: "pool-id", MinWorkerCount: 0, MaxWorkerCount: 5, WorkerConcurrency: 8},
+	},
+}
+
+type recorder struct {
+	calls []string
+}
+
+func (r *recorder) stub() func() {
+	origQueue, origDeployment := updateWorkerQueue, updateDeployment
+	updateWorkerQueue = func(ws, deploymentID, deploymentName, name, action, workerType string, wQueueMin, wQueueMax, wQueueConcurrency int, force bool, client astro.Client, coreClient astrocore.CoreClient, out io.Writer) error {
+		r.calls = append(r.calls, fmt.Sprintf("queue %s %s %s %d %d %d", ws, name, workerType, wQueueMin, wQueueMax, wQueueConcurrency))
+		return nil
+	}
+	updateDeployment = func(deploymentID, label, ws, description, deploymentName, dagDeploy, executor, schedulerSize, highAvailability string, schedulerAU, schedulerReplicas int, wQueueList []astro.WorkerQueue, forceDeploy bool, enforceCD *bool, client astro.Client) error {
+		r.calls = append(r.calls, fmt.Sprintf("scheduler %s %s %d %d", schedulerSize, highAvailability, schedulerAU, schedulerReplicas))
+		return nil
+	}
+	return func() { updateWorkerQueue, updateDeployment = origQueue, origDeployment }
+}
+
+func hibernatingDeployment(t *testing.T) astro.Deployment {
+	t.Helper()
+	state := State{SchedulerAU: 10, SchedulerReplicas: 2, WorkerQueues: []QueueState{
+		{Name: "default", WorkerType: "m5.xlarge", MinWorkerCount: 2, MaxWorkerCount: 10, WorkerConcurrency: 16},
+		{Name: "deleted", WorkerType: "m5.xlarge", MinWorkerCount: 1, MaxWorkerCount: 2, WorkerConcurrency: 16},
+	}}
+	value, err := json.Marshal(state)
+	assert.NoError(t, err)
+	d := devDeployment
+	d.DeploymentSpec.Scheduler.AU = 5
+	d.DeploymentSpec.EnvironmentVariablesObjects = append([]astro.EnvironmentVariablesObject{}, devDeployment.DeploymentSpec.EnvironmentVariablesObjects...)
+	d.DeploymentSpec.EnvironmentVariablesObjects = append(d.DeploymentSpec.EnvironmentVariablesObjects, astro.EnvironmentVariablesObject{Key: StateVariable, Value: string(value)})
+	return d
+}
+
+func TestHibernate(t *testing.T) {
+	testUtil.InitTestConfig(testUtil.CloudPlatform)
+
+	t.Run("saves the state and scales down", func(t *testing.T) {
+		r := &recorder{}
+		defer r.stub()()
+		mockClient := new(astro_mocks.Client)
+		mockClient.On("ListDeployments", "test-org-id", "ws-id").Return([]astro.Deployment{devDeployment}, nil).Once()
+		mockClient.On("GetDeploymentConfig").Return(astro.DeploymentConfig{Components: astro.Components{Scheduler: astro.SchedulerConfig{AU: astro.AuConfig{Default: 5}}}}, nil).Once()
+		var saved astro.EnvironmentVariablesInput
+		mockClient.On("ModifyDeploymentVariable", mock.MatchedBy(func(input astro.EnvironmentVariablesInput) bool { saved = input; return true })).Return([]astro.EnvironmentVariablesObject{}, nil).Once()
+
+		out := new(bytes.Buffer)
+		err := Hibernate("ws-id", "dev-id", "", 0, mockClient, nil, out)
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"queue ws-id default m5.xlarge 0 10 16", "scheduler   5 0"}, r.calls)
+		assert.Len(t, saved.EnvironmentVariables, 3)
+		assert.Equal(t, astro.EnvironmentVariable{Key: "TOKEN", IsSecret: true}, saved.EnvironmentVariables[1])
+		assert.Equal(t, StateVariable, saved.EnvironmentVariables[2].Key)
+		assert.JSONEq(t, `{"scheduler_au": 10, "scheduler_replicas": 2, "worker_queues": [
+			{"name": "default", "worker_type": "m5.xlarge", "min_worker_count": 2, "max_worker_count": 10, "worker_concurrency": 16},
+			{"name": "idle", "worker_type": "m5.xlarge", "min_worker_count": 0, "max_worker_count": 5, "worker_concurrency": 8}
+		]}`, saved.EnvironmentVariables[2].Value)
+		assert.Contains(t, out.String(), "Deployment dev is hibernating")
+		mockClient.AssertExpectations(t)
+	})
+
+	t.Run("already hibernating", func(t *testing.T) {
+		r := &recorder{}
+		defer r.stub()()
+		mockClient := new(astro_mocks.Client)
+		mockClient.On("ListDeployments", "test-org-id", "ws-id").Return([]astro.Deployment{hibernatingDeployment(t)}, nil).Once()
+		out := new(bytes.Buffer)
+		err := Hibernate("ws-id", "dev-id", "", 0, mockClient, nil, out)
+		assert.NoError(t, err)
+		assert.Empty(t, r.calls)
+		assert.Contains(t, out.String(), "Deployment dev is already hibernating")
+		mockClient.AssertExpectations(t)
+	})
+}
+
+func TestWake(t *testing.T) {
+	testUtil.InitTestConfig(testUtil.CloudPlatform)
+
+	t.Run("restores the saved state", func(t *testing.T) {
+		r := &recorder{}
+		defer r.stub()()
+		mockClient := new(astro_mocks.Client)
+		mockClient.On("ListDeployments", "test-org-id", "ws-id").Return([]astro.Deployment{hibernatingDeployment(t)}, nil).Once()
+		mockClient.On("ModifyDeploymentVariable", astro.EnvironmentVariablesInput{
+			DeploymentID:         "dev-id",
+			EnvironmentVariables: []astro.EnvironmentVariable{{Key: "FOO", Value: "bar"}, {Key: "TOKEN", IsSecret: true}},
+		}).Return([]astro.EnvironmentVariablesObject{}, nil).Once()
+
+		out := new(bytes.Buffer)
+		err := Wake("ws-id", "", "dev", mockClient, nil, out)
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"queue ws-id default m5.xlarge 2 10 16", "scheduler   10 2"}, r.calls)
+		assert.Contains(t, out.String(), "Worker queue deleted was deleted while the deployment was hibernating")
+		assert.Contains(t, out.String(), "Deployment dev is awake")
+		mockClient.AssertExpectations(t)
+	})
+
+	t.Run("not hibernating", func(t *testing.T) {
+		r := &recorder{}
+		defer r.stub()()
+		mockClient := new(astro_mocks.Client)
+		mockClient.On("ListDeployments", "test-org-id", "ws-id").Return([]astro.Deployment{devDeployment}, nil).Once()
+		out := new(bytes.Buffer)
+		err := Wake("ws-id", "dev-id", "", mockClient, nil, out)
+		assert.NoError(t, err)
+		assert.Empty(t, r.calls)
+		assert.Contains(t, out.String(), "Deployment dev is not hibernating")
+		mockClient.AssertExpectations(t)
+	})
+}
+
+func TestIsAwake(t *testing.T) {
+	// Monday 2023-06-05
+	monday := time.Date(2023, 6, 5, 0, 0, 0, 0, time.UTC)
+	office := &inspect.HibernationSchedule{WakeAt: "07:00", HibernateAt: "19:00"}
+	night := &inspect.HibernationSchedule{AwakeDays: []string{"Monday"}, WakeAt: "22:00", HibernateAt: "06:00", Timezone: "Europe/Paris"}
+	tests := []struct {
+		name     string
+		schedule *inspect.HibernationSchedule
+		now      time.Time
+		awake    bool
+	}{
+		{"office hours", office, monday.Add(8 * time.Hour), true},
+		{"before wake", office, monday.Add(6 * time.Hour), false},
+		{"at hibernate", office, monday.Add(19 * time.Hour), false},
+		{"weekend", office, monday.AddDate(0, 0, -1).Add(12 * time.Hour), false},
+		{"night shift starts", night, monday.Add(21 * time.Hour), true},
+		{"night shift past midnight", night, monday.Add(25 * time.Hour), true},
+		{"night shift ends", night, monday.Add(28 * time.Hour), false},
+		{"night shift off day", night, monday.Add(-2 * time.Hour), false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			awake, err := IsAwake(tt.schedule, tt.now)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.awake, awake)
+		})
+	}
+
+	_, err := IsAwake(&inspect.HibernationSchedule{WakeAt: "7am", HibernateAt: "19:00"}, monday)
+	assert.ErrorIs(t, err, errInvalidSchedule)
+	_, err = IsAwake(&inspect.HibernationSchedule{AwakeDays: []string{"funday"}, WakeAt: "07:00", HibernateAt: "19:00"}, monday)
+	assert.ErrorIs(t, err, errInvalidSchedule)
+}
+
+func TestApplySchedule(t *testing.T) {
+	testUtil.InitTestConfig(testUtil.CloudPlatform)
+	r := &recorder{}
+	defer r.stub()()
+	path := filepath.Join(t.TempDir(), "deployment.yaml")
+	err := os.WriteFile(path, []byte(`deployment:
+  configuration:
+    name: dev
+  hibernation_schedule:
+    wake_at: "07:00"
+    hibernate_at: "19:00"
+`), 0o600)
+	assert.NoError(t, err)
+
+	mockClient := new(astro_mocks.Client)
+	mockClient.On("ListDeployments", "test-org-id", "ws-id").Return([]astro.Deployment{devDeployment}, nil).Once()
+	out := new(bytes.Buffer)
+	// Monday at noon, dev is awake already
+	err = ApplySchedule("ws-id", path, time.Date(2023, 6, 5, 12, 0, 0, 0, time.UTC), mockClient, nil, out)
+	assert.NoError(t, err)
+	assert.Contains(t, out.String(), "Deployment dev is not hibernating")
+	mockClient.AssertExpectations(t)
+
+	err = os.WriteFile(path, []byte("deployment:\n  configuration:\n    name: dev\n"), 0o600)
+	assert.NoError(t, err)
+	err = ApplySchedule("ws-id", path, time.Now(), mockClient, nil, out)
+	assert.ErrorIs(t, err, errNoSchedule)
+}
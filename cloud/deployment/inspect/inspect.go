@@ -74,6 +74,15 @@ type orderedPieces struct {
 	WorkerQs      []Workerq             `mapstructure:"worker_queues" yaml:"worker_queues" json:"worker_queues"`
 	Metadata      *deploymentMetadata   `mapstructure:"metadata,omitempty" yaml:"metadata,omitempty" json:"metadata,omitempty"`
 	AlertEmails   []string              `mapstructure:"alert_emails,omitempty" yaml:"alert_emails,omitempty" json:"alert_emails,omitempty"`
+	Hibernation   *HibernationSchedule  `mapstructure:"hibernation_schedule,omitempty" yaml:"hibernation_schedule,omitempty" json:"hibernation_schedule,omitempty"`
+}
+
+// HibernationSchedule is when a deployment is awake, it is hibernated the rest of the time
+type HibernationSchedule struct {
+	Timezone    string   `mapstructure:"timezone,omitempty" yaml:"timezone,omitempty" json:"timezone,omitempty"`
+	AwakeDays   []string `mapstructure:"awake_days,omitempty" yaml:"awake_days,omitempty" json:"awake_days,omitempty"`
+	WakeAt      string   `mapstructure:"wake_at" yaml:"wake_at" json:"wake_at"`
+	HibernateAt string   `mapstructure:"hibernate_at" yaml:"hibernate_at" json:"hibernate_at"`
 }
 
 type FormattedDeployment struct {
@@ -47,6 +47,7 @@ func CreateOrUpdate(ws, deploymentID, deploymentName, name, action, workerType s
 		queueToCreateOrUpdate                *astro.WorkerQueue
 		listToCreate, existingQueues         []astro.WorkerQueue
 		defaultOptions                       astro.WorkerQueueDefaultOptions
+		configOptions                        astro.DeploymentConfig
 	)
 	// get or select the deployment
 	requestedDeployment, err = deployment.GetDeployment(ws, deploymentID, deploymentName, true, client, coreClient)
@@ -61,7 +62,7 @@ func CreateOrUpdate(ws, deploymentID, deploymentName, name, action, workerType s
 
 	if deployment.IsDeploymentHosted(requestedDeployment.Type) || deployment.IsDeploymentDedicated(requestedDeployment.Type) {
 		nodePoolID = requestedDeployment.Cluster.NodePools[0].ID
-		configOptions, err := client.GetDeploymentConfig()
+		configOptions, err = client.GetDeploymentConfig()
 		if err != nil {
 			return err
 		}
@@ -138,8 +139,12 @@ func CreateOrUpdate(ws, deploymentID, deploymentName, name, action, workerType s
 		// queueToCreateOrUpdate does not exist
 		// user requested create, so we add queueToCreateOrUpdate to the list
 		listToCreate = append(requestedDeployment.WorkerQueues, *queueToCreateOrUpdate) //nolint
+		deployment.PrintEstimate(&requestedDeployment, listToCreate, &configOptions, out)
 	case updateAction:
 		if QueueExists(existingQueues, queueToCreateOrUpdate) {
+			// show the footprint after the update, merging into a copy as the update can still be canceled
+			updatedQueues := updateQueueList(append([]astro.WorkerQueue{}, existingQueues...), queueToCreateOrUpdate, requestedDeployment.DeploymentSpec.Executor, wQueueMin, wQueueMax, wQueueConcurrency)
+			deployment.PrintEstimate(&requestedDeployment, updatedQueues, &configOptions, out)
 			if !force {
 				i, _ := input.Confirm(
 					fmt.Sprintf("\nAre you sure you want to %s the %s worker queue? If there are any tasks in your DAGs assigned to this worker queue, the tasks might get stuck in a queued state and fail to execute", action, ansi.Bold(queueToCreateOrUpdate.Name)))
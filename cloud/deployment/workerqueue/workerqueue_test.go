@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"errors"
 	"os"
+	"strings"
 	"testing"
 	"time"
 
@@ -407,7 +408,8 @@ func TestCreate(t *testing.T) {
 			mockClient.On("UpdateDeployment", mock.Anything).Return(deploymentRespNoQueues[0], nil).Once()
 			err := CreateOrUpdate("test-ws-id", "test-deployment-id", "", "test-worker-queue", createAction, "test-instance-type-1", 0, 0, 0, false, mockClient, mockCoreClient, out)
 			assert.NoError(t, err)
-			assert.Equal(t, expectedOutMessage, out.String())
+			assert.Contains(t, out.String(), "Estimated resources for Deployment test-deployment-label")
+			assert.True(t, strings.HasSuffix(out.String(), expectedOutMessage))
 			mockClient.AssertExpectations(t)
 		})
 		t.Run("happy path creates a new worker queue for a deployment when worker queues exist", func(t *testing.T) {
@@ -886,7 +888,8 @@ func TestUpdate(t *testing.T) {
 				mockClient.On("UpdateDeployment", &updateDeploymentInput).Return(deploymentRespWithQueues[0], nil).Once()
 				err := CreateOrUpdate("test-ws-id", "", "test-deployment-label", "test-queue-1", updateAction, "test-instance-type-1", -1, 0, 0, false, mockClient, mockCoreClient, out)
 				assert.NoError(t, err)
-				assert.Equal(t, expectedOutMessage, out.String())
+				assert.Contains(t, out.String(), "Estimated resources for Deployment test-deployment-label")
+				assert.True(t, strings.HasSuffix(out.String(), expectedOutMessage))
 				mockClient.AssertExpectations(t)
 			})
 			t.Run("cancels update if user does not confirm", func(t *testing.T) {
@@ -897,7 +900,8 @@ func TestUpdate(t *testing.T) {
 				mockClient.On("GetWorkerQueueOptions").Return(mockWorkerQueueDefaultOptions, nil).Once()
 				err := CreateOrUpdate("test-ws-id", "", "test-deployment-label", "test-queue-1", updateAction, "test-instance-type-1", 0, 0, 0, false, mockClient, mockCoreClient, out)
 				assert.NoError(t, err)
-				assert.Equal(t, expectedOutMessage, out.String())
+				assert.Contains(t, out.String(), "Estimated resources for Deployment test-deployment-label")
+				assert.True(t, strings.HasSuffix(out.String(), expectedOutMessage))
 				mockClient.AssertExpectations(t)
 			})
 		})
@@ -0,0 +1,362 @@
+package organization
+
+import (
+	httpContext "context"
+	"io"
+	"sort"
+	"strings"
+
+	astrocore "github.com/astronomer/astro-cli/astro-client-core"
+	"github.com/astronomer/astro-cli/cloud/team"
+	"github.com/astronomer/astro-cli/cloud/user"
+	"github.com/astronomer/astro-cli/cloud/workspace"
+	"github.com/astronomer/astro-cli/context"
+	"github.com/astronomer/astro-cli/pkg/printutil"
+)
+
+// Types of principals in the access report
+const (
+	PrincipalUser     = "USER"
+	PrincipalTeam     = "TEAM"
+	PrincipalAPIToken = "API_TOKEN"
+)
+
+// workspaceRoleRank orders the workspace roles from the least to the most privileged
+var workspaceRoleRank = map[string]int{
+	"WORKSPACE_MEMBER":   1,
+	"WORKSPACE_AUTHOR":   2,
+	"WORKSPACE_OPERATOR": 3,
+	"WORKSPACE_OWNER":    4,
+}
+
+// AccessReportEntry is the effective role of a principal on a workspace or a deployment. Entries without a workspace
+// list the principals which only have an organization role.
+type AccessReportEntry struct {
+	PrincipalType    string
+	Principal        string
+	PrincipalID      string
+	OrganizationRole string
+	WorkspaceID      string
+	WorkspaceName    string
+	DeploymentID     string
+	DeploymentName   string
+	Role             string
+	// GrantedBy explains where the role comes from, like a direct binding or a team
+	GrantedBy []string
+}
+
+// accessReportData is the live state of the organization the report is built from
+type accessReportData struct {
+	users          []astrocore.User
+	teams          []astrocore.Team
+	workspaces     []astrocore.Workspace
+	deployments    []astrocore.Deployment
+	workspaceUsers map[string][]astrocore.User
+	workspaceTeams map[string][]astrocore.Team
+	tokens         []astrocore.ApiToken
+}
+
+// AccessReport prints the effective roles of every user, team and API token of the organization on each workspace and deployment
+func AccessReport(out io.Writer, client astrocore.CoreClient) error {
+	entries, err := GetAccessReport(client)
+	if err != nil {
+		return err
+	}
+	tab := newAccessReportTable()
+	for i := range entries {
+		e := &entries[i]
+		tab.AddRow([]string{e.PrincipalType, e.Principal, e.PrincipalID, e.OrganizationRole, e.WorkspaceName, e.WorkspaceID, e.DeploymentName, e.DeploymentID, e.Role, strings.Join(e.GrantedBy, "; ")}, false)
+	}
+	return tab.Print(out)
+}
+
+// GetAccessReport joins the organization users, teams, team memberships, workspace role bindings and API tokens into
+// the effective roles of each principal
+func GetAccessReport(client astrocore.CoreClient) ([]AccessReportEntry, error) {
+	data, err := getAccessReportData(client)
+	if err != nil {
+		return nil, err
+	}
+	entries := []AccessReportEntry{}
+	entries = append(entries, userAccessEntries(data)...)
+	entries = append(entries, teamAccessEntries(data)...)
+	entries = append(entries, tokenAccessEntries(data)...)
+	sort.SliceStable(entries, func(i, j int) bool {
+		a, b := &entries[i], &entries[j]
+		if a.PrincipalType != b.PrincipalType {
+			return a.PrincipalType > b.PrincipalType
+		}
+		if a.Principal != b.Principal {
+			return strings.ToLower(a.Principal) < strings.ToLower(b.Principal)
+		}
+		if a.WorkspaceName != b.WorkspaceName {
+			return a.WorkspaceName < b.WorkspaceName
+		}
+		return a.DeploymentName < b.DeploymentName
+	})
+	return entries, nil
+}
+
+func getAccessReportData(client astrocore.CoreClient) (*accessReportData, error) {
+	ctx, err := context.GetCurrentContext()
+	if err != nil {
+		return nil, err
+	}
+	if ctx.OrganizationShortName == "" {
+		return nil, user.ErrNoShortName
+	}
+	data := &accessReportData{workspaceUsers: map[string][]astrocore.User{}, workspaceTeams: map[string][]astrocore.Team{}}
+	if data.users, err = user.GetOrgUsers(client); err != nil {
+		return nil, err
+	}
+	orgTeams, err := team.GetOrgTeams(client)
+	if err != nil {
+		return nil, err
+	}
+	// teams are listed without their members
+	for i := range orgTeams {
+		t, err := team.GetTeam(client, orgTeams[i].Id)
+		if err != nil {
+			return nil, err
+		}
+		data.teams = append(data.teams, t)
+	}
+	if data.workspaces, err = workspace.GetWorkspaces(client); err != nil {
+		return nil, err
+	}
+	for i := range data.workspaces {
+		id := data.workspaces[i].Id
+		if data.workspaceUsers[id], err = user.GetWorkspaceUsers(client, id, rbacPageLimit); err != nil {
+			return nil, err
+		}
+		if data.workspaceTeams[id], err = team.GetWorkspaceTeams(client, id, rbacPageLimit); err != nil {
+			return nil, err
+		}
+	}
+	if data.deployments, err = getOrganizationDeployments(ctx.OrganizationShortName, client); err != nil {
+		return nil, err
+	}
+	if data.tokens, err = getAccessReportTokens(ctx.OrganizationShortName, data.deployments, client); err != nil {
+		return nil, err
+	}
+	return data, nil
+}
+
+func getOrganizationDeployments(orgShortName string, client astrocore.CoreClient) ([]astrocore.Deployment, error) {
+	offset := 0
+	limit := rbacPageLimit
+	deployments := []astrocore.Deployment{}
+	for {
+		resp, err := client.ListDeploymentsWithResponse(httpContext.Background(), orgShortName, &astrocore.ListDeploymentsParams{Offset: &offset, Limit: &limit})
+		if err != nil {
+			return nil, err
+		}
+		if err := astrocore.NormalizeAPIError(resp.HTTPResponse, resp.Body); err != nil {
+			return nil, err
+		}
+		deployments = append(deployments, resp.JSON200.Deployments...)
+		offset += limit
+		if resp.JSON200.TotalCount <= offset {
+			return deployments, nil
+		}
+	}
+}
+
+// getAccessReportTokens returns the organization, workspace and deployment API tokens, each listed once with all its roles
+func getAccessReportTokens(orgShortName string, deployments []astrocore.Deployment, client astrocore.CoreClient) ([]astrocore.ApiToken, error) {
+	audited, err := getAllTokens(orgShortName, client)
+	if err != nil {
+		return nil, err
+	}
+	tokens := []astrocore.ApiToken{}
+	seen := map[string]bool{}
+	for i := range audited {
+		seen[audited[i].Token.Id] = true
+		tokens = append(tokens, audited[i].Token)
+	}
+	for i := range deployments {
+		resp, err := client.ListDeploymentApiTokensWithResponse(httpContext.Background(), orgShortName, deployments[i].Id, &astrocore.ListDeploymentApiTokensParams{})
+		if err != nil {
+			return nil, err
+		}
+		if err := astrocore.NormalizeAPIError(resp.HTTPResponse, resp.Body); err != nil {
+			return nil, err
+		}
+		for j := range resp.JSON200.ApiTokens {
+			if !seen[resp.JSON200.ApiTokens[j].Id] {
+				seen[resp.JSON200.ApiTokens[j].Id] = true
+				tokens = append(tokens, resp.JSON200.ApiTokens[j])
+			}
+		}
+	}
+	return tokens, nil
+}
+
+func userAccessEntries(data *accessReportData) []AccessReportEntry {
+	// workspace roles granted to each user through their teams
+	teamRoles := map[string]map[string][]string{}
+	for i := range data.workspaces {
+		wsID := data.workspaces[i].Id
+		for _, t := range data.workspaceTeams[wsID] {
+			role := teamWorkspaceRole(&t, wsID)
+			members := teamMembers(data, t.Id)
+			for _, member := range members {
+				if teamRoles[member.UserId] == nil {
+					teamRoles[member.UserId] = map[string][]string{}
+				}
+				teamRoles[member.UserId][wsID] = append(teamRoles[member.UserId][wsID], role+" through team "+t.Name)
+			}
+		}
+	}
+
+	entries := []AccessReportEntry{}
+	for i := range data.users {
+		u := &data.users[i]
+		orgRole := stringValue(u.OrgRole)
+		base := AccessReportEntry{PrincipalType: PrincipalUser, Principal: u.Username, PrincipalID: u.Id, OrganizationRole: orgRole}
+		hasWorkspace := false
+		for j := range data.workspaces {
+			ws := &data.workspaces[j]
+			grants := []string{}
+			for _, wsUser := range data.workspaceUsers[ws.Id] {
+				if wsUser.Id == u.Id && wsUser.WorkspaceRole != nil {
+					grants = append(grants, *wsUser.WorkspaceRole+" directly")
+				}
+			}
+			grants = append(grants, teamRoles[u.Id][ws.Id]...)
+			if orgRole == organizationOwnerRole {
+				grants = append(grants, workspaceOwnerRole+" as "+organizationOwnerRole)
+			}
+			if len(grants) == 0 {
+				continue
+			}
+			hasWorkspace = true
+			entry := base
+			entry.WorkspaceID, entry.WorkspaceName = ws.Id, ws.Name
+			entry.Role = highestWorkspaceRole(grants)
+			entry.GrantedBy = grants
+			entries = append(entries, entry)
+		}
+		if !hasWorkspace {
+			base.GrantedBy = []string{orgRole + " directly"}
+			entries = append(entries, base)
+		}
+	}
+	return entries
+}
+
+func teamAccessEntries(data *accessReportData) []AccessReportEntry {
+	entries := []AccessReportEntry{}
+	for i := range data.teams {
+		t := &data.teams[i]
+		base := AccessReportEntry{PrincipalType: PrincipalTeam, Principal: t.Name, PrincipalID: t.Id, OrganizationRole: t.OrganizationRole}
+		hasWorkspace := false
+		for j := range data.workspaces {
+			ws := &data.workspaces[j]
+			for _, wsTeam := range data.workspaceTeams[ws.Id] {
+				if wsTeam.Id != t.Id {
+					continue
+				}
+				hasWorkspace = true
+				entry := base
+				entry.WorkspaceID, entry.WorkspaceName = ws.Id, ws.Name
+				entry.Role = teamWorkspaceRole(&wsTeam, ws.Id)
+				entry.GrantedBy = []string{entry.Role + " directly"}
+				entries = append(entries, entry)
+			}
+		}
+		if !hasWorkspace {
+			base.GrantedBy = []string{t.OrganizationRole + " directly"}
+			entries = append(entries, base)
+		}
+	}
+	return entries
+}
+
+func tokenAccessEntries(data *accessReportData) []AccessReportEntry {
+	workspaceNames := map[string]string{}
+	for i := range data.workspaces {
+		workspaceNames[data.workspaces[i].Id] = data.workspaces[i].Name
+	}
+	deployments := map[string]*astrocore.Deployment{}
+	for i := range data.deployments {
+		deployments[data.deployments[i].Id] = &data.deployments[i]
+	}
+
+	entries := []AccessReportEntry{}
+	for i := range data.tokens {
+		token := &data.tokens[i]
+		base := AccessReportEntry{PrincipalType: PrincipalAPIToken, Principal: token.Name, PrincipalID: token.Id}
+		for _, role := range token.Roles {
+			if role.EntityType == astrocore.ApiTokenRoleEntityTypeORGANIZATION {
+				base.OrganizationRole = role.Role
+			}
+		}
+		scoped := false
+		for _, role := range token.Roles {
+			entry := base
+			switch role.EntityType {
+			case astrocore.ApiTokenRoleEntityTypeWORKSPACE:
+				entry.WorkspaceID, entry.WorkspaceName = role.EntityId, workspaceNames[role.EntityId]
+			case astrocore.ApiTokenRoleEntityTypeDEPLOYMENT:
+				entry.DeploymentID = role.EntityId
+				if d, ok := deployments[role.EntityId]; ok {
+					entry.DeploymentName = d.Name
+					entry.WorkspaceID, entry.WorkspaceName = d.WorkspaceId, workspaceNames[d.WorkspaceId]
+				}
+			default:
+				continue
+			}
+			scoped = true
+			entry.Role = role.Role
+			entry.GrantedBy = []string{role.Role + " directly"}
+			entries = append(entries, entry)
+		}
+		if !scoped {
+			base.GrantedBy = []string{base.OrganizationRole + " directly"}
+			entries = append(entries, base)
+		}
+	}
+	return entries
+}
+
+func teamMembers(data *accessReportData, teamID string) []astrocore.TeamMember {
+	for i := range data.teams {
+		if data.teams[i].Id == teamID && data.teams[i].Members != nil {
+			return *data.teams[i].Members
+		}
+	}
+	return nil
+}
+
+func teamWorkspaceRole(t *astrocore.Team, workspaceID string) string {
+	if t.Roles == nil {
+		return ""
+	}
+	for _, role := range *t.Roles {
+		if role.EntityType == workspaceEntity && role.EntityId == workspaceID {
+			return role.Role
+		}
+	}
+	return ""
+}
+
+// highestWorkspaceRole returns the most privileged role of a list of grants, each starting with a role
+func highestWorkspaceRole(grants []string) string {
+	highest := ""
+	for _, grant := range grants {
+		role := strings.Fields(grant)[0]
+		if workspaceRoleRank[role] > workspaceRoleRank[highest] {
+			highest = role
+		}
+	}
+	return highest
+}
+
+func newAccessReportTable() *printutil.Table {
+	return &printutil.Table{
+		DynamicPadding: true,
+		Header:         []string{"TYPE", "PRINCIPAL", "ID", "ORGANIZATION ROLE", "WORKSPACE", "WORKSPACE ID", "DEPLOYMENT", "DEPLOYMENT ID", "EFFECTIVE ROLE", "GRANTED BY"},
+		NoResultsMsg:   "No users, teams or API tokens found in your organization",
+	}
+}
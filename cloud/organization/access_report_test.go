@@ -0,0 +1,136 @@
+package organization
+
+import (
+	"bytes"
+	"net/http"
+	"testing"
+
+	astrocore "github.com/astronomer/astro-cli/astro-client-core"
+	astrocore_mocks "github.com/astronomer/astro-cli/astro-client-core/mocks"
+	"github.com/astronomer/astro-cli/pkg/printutil"
+	testUtil "github.com/astronomer/astro-cli/pkg/testing"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func mockAccessReportClient() *astrocore_mocks.ClientWithResponsesInterface {
+	memberRole := "ORGANIZATION_MEMBER"
+	ownerRole := "ORGANIZATION_OWNER"
+	workspaceMemberRole := "WORKSPACE_MEMBER"
+	ok := &http.Response{StatusCode: 200}
+	engRoles := []astrocore.TeamRole{{EntityType: "WORKSPACE", EntityId: "ws-1", Role: "WORKSPACE_OPERATOR"}}
+
+	mockClient := new(astrocore_mocks.ClientWithResponsesInterface)
+	mockClient.On("ListOrgUsersWithResponse", mock.Anything, mock.Anything, mock.Anything).Return(&astrocore.ListOrgUsersResponse{
+		HTTPResponse: ok,
+		JSON200: &astrocore.UsersPaginated{Users: []astrocore.User{
+			{Id: "alice-id", Username: "alice@example.com", OrgRole: &ownerRole},
+			{Id: "bob-id", Username: "bob@example.com", OrgRole: &memberRole},
+			{Id: "carol-id", Username: "carol@example.com", OrgRole: &memberRole},
+		}},
+	}, nil)
+	mockClient.On("ListOrganizationTeamsWithResponse", mock.Anything, mock.Anything, mock.Anything).Return(&astrocore.ListOrganizationTeamsResponse{
+		HTTPResponse: ok,
+		JSON200:      &astrocore.TeamsPaginated{Teams: []astrocore.Team{{Id: "eng-id", Name: "eng", OrganizationRole: memberRole}}},
+	}, nil)
+	mockClient.On("GetTeamWithResponse", mock.Anything, mock.Anything, "eng-id").Return(&astrocore.GetTeamResponse{
+		HTTPResponse: ok,
+		JSON200: &astrocore.Team{Id: "eng-id", Name: "eng", OrganizationRole: memberRole, Members: &[]astrocore.TeamMember{
+			{UserId: "bob-id", Username: "bob@example.com"},
+		}},
+	}, nil)
+	mockClient.On("ListWorkspacesWithResponse", mock.Anything, mock.Anything, mock.Anything).Return(&astrocore.ListWorkspacesResponse{
+		HTTPResponse: ok,
+		JSON200:      &astrocore.WorkspacesPaginated{Workspaces: []astrocore.Workspace{{Id: "ws-1", Name: "sandbox"}}},
+	}, nil)
+	mockClient.On("ListWorkspaceUsersWithResponse", mock.Anything, mock.Anything, "ws-1", mock.Anything).Return(&astrocore.ListWorkspaceUsersResponse{
+		HTTPResponse: ok,
+		JSON200:      &astrocore.UsersPaginated{Users: []astrocore.User{{Id: "bob-id", Username: "bob@example.com", WorkspaceRole: &workspaceMemberRole}}},
+	}, nil)
+	mockClient.On("ListWorkspaceTeamsWithResponse", mock.Anything, mock.Anything, "ws-1", mock.Anything).Return(&astrocore.ListWorkspaceTeamsResponse{
+		HTTPResponse: ok,
+		JSON200:      &astrocore.TeamsPaginated{Teams: []astrocore.Team{{Id: "eng-id", Name: "eng", Roles: &engRoles}}},
+	}, nil)
+	mockClient.On("ListDeploymentsWithResponse", mock.Anything, mock.Anything, mock.Anything).Return(&astrocore.ListDeploymentsResponse{
+		HTTPResponse: ok,
+		JSON200:      &astrocore.DeploymentsPaginated{TotalCount: 1, Deployments: []astrocore.Deployment{{Id: "dep-1", Name: "etl", WorkspaceId: "ws-1"}}},
+	}, nil).Once()
+	ciToken := astrocore.ApiToken{Id: "ci-id", Name: "ci", Roles: []astrocore.ApiTokenRole{
+		{EntityType: astrocore.ApiTokenRoleEntityTypeORGANIZATION, Role: memberRole},
+		{EntityType: astrocore.ApiTokenRoleEntityTypeWORKSPACE, EntityId: "ws-1", Role: "WORKSPACE_AUTHOR"},
+	}}
+	mockClient.On("ListOrganizationApiTokensWithResponse", mock.Anything, mock.Anything, mock.Anything).Return(&astrocore.ListOrganizationApiTokensResponse{
+		HTTPResponse: ok,
+		JSON200:      &astrocore.ListApiTokensPaginated{ApiTokens: []astrocore.ApiToken{ciToken}},
+	}, nil)
+	mockClient.On("ListWorkspaceApiTokensWithResponse", mock.Anything, mock.Anything, "ws-1", mock.Anything).Return(&astrocore.ListWorkspaceApiTokensResponse{
+		HTTPResponse: ok,
+		JSON200:      &astrocore.ListApiTokensPaginated{ApiTokens: []astrocore.ApiToken{ciToken}},
+	}, nil)
+	mockClient.On("ListDeploymentApiTokensWithResponse", mock.Anything, mock.Anything, "dep-1", mock.Anything).Return(&astrocore.ListDeploymentApiTokensResponse{
+		HTTPResponse: ok,
+		JSON200: &astrocore.ListApiTokensPaginated{ApiTokens: []astrocore.ApiToken{
+			{Id: "dep-token-id", Name: "etl deploy", Roles: []astrocore.ApiTokenRole{{EntityType: astrocore.ApiTokenRoleEntityTypeDEPLOYMENT, EntityId: "dep-1", Role: "DEPLOYMENT_ADMIN"}}},
+		}},
+	}, nil)
+	return mockClient
+}
+
+func TestGetAccessReport(t *testing.T) {
+	testUtil.InitTestConfig(testUtil.CloudPlatform)
+	mockClient := mockAccessReportClient()
+
+	entries, err := GetAccessReport(mockClient)
+	assert.NoError(t, err)
+	assert.Equal(t, []AccessReportEntry{
+		{
+			PrincipalType: PrincipalUser, Principal: "alice@example.com", PrincipalID: "alice-id", OrganizationRole: "ORGANIZATION_OWNER",
+			WorkspaceID: "ws-1", WorkspaceName: "sandbox", Role: "WORKSPACE_OWNER", GrantedBy: []string{"WORKSPACE_OWNER as ORGANIZATION_OWNER"},
+		},
+		{
+			PrincipalType: PrincipalUser, Principal: "bob@example.com", PrincipalID: "bob-id", OrganizationRole: "ORGANIZATION_MEMBER",
+			WorkspaceID: "ws-1", WorkspaceName: "sandbox", Role: "WORKSPACE_OPERATOR", GrantedBy: []string{"WORKSPACE_MEMBER directly", "WORKSPACE_OPERATOR through team eng"},
+		},
+		{
+			PrincipalType: PrincipalUser, Principal: "carol@example.com", PrincipalID: "carol-id", OrganizationRole: "ORGANIZATION_MEMBER",
+			GrantedBy: []string{"ORGANIZATION_MEMBER directly"},
+		},
+		{
+			PrincipalType: PrincipalTeam, Principal: "eng", PrincipalID: "eng-id", OrganizationRole: "ORGANIZATION_MEMBER",
+			WorkspaceID: "ws-1", WorkspaceName: "sandbox", Role: "WORKSPACE_OPERATOR", GrantedBy: []string{"WORKSPACE_OPERATOR directly"},
+		},
+		{
+			PrincipalType: PrincipalAPIToken, Principal: "ci", PrincipalID: "ci-id", OrganizationRole: "ORGANIZATION_MEMBER",
+			WorkspaceID: "ws-1", WorkspaceName: "sandbox", Role: "WORKSPACE_AUTHOR", GrantedBy: []string{"WORKSPACE_AUTHOR directly"},
+		},
+		{
+			PrincipalType: PrincipalAPIToken, Principal: "etl deploy", PrincipalID: "dep-token-id",
+			WorkspaceID: "ws-1", WorkspaceName: "sandbox", DeploymentID: "dep-1", DeploymentName: "etl", Role: "DEPLOYMENT_ADMIN", GrantedBy: []string{"DEPLOYMENT_ADMIN directly"},
+		},
+	}, entries)
+	mockClient.AssertExpectations(t)
+}
+
+func TestAccessReport(t *testing.T) {
+	testUtil.InitTestConfig(testUtil.CloudPlatform)
+
+	t.Run("table", func(t *testing.T) {
+		out := new(bytes.Buffer)
+		err := AccessReport(out, mockAccessReportClient())
+		assert.NoError(t, err)
+		assert.Regexp(t, `USER\s+bob@example.com\s+bob-id\s+ORGANIZATION_MEMBER\s+sandbox\s+ws-1\s+WORKSPACE_OPERATOR`, out.String())
+	})
+
+	t.Run("csv", func(t *testing.T) {
+		format, err := printutil.ParseOutputFormat(printutil.CSVFormat)
+		assert.NoError(t, err)
+		printutil.SetOutputFormat(format)
+		defer printutil.SetOutputFormat(printutil.OutputFormat{})
+
+		out := new(bytes.Buffer)
+		err = AccessReport(out, mockAccessReportClient())
+		assert.NoError(t, err)
+		assert.Contains(t, out.String(), "Type,Principal,ID,OrganizationRole,Workspace,WorkspaceID,Deployment,DeploymentID,EffectiveRole,GrantedBy\n")
+		assert.Contains(t, out.String(), "USER,bob@example.com,bob-id,ORGANIZATION_MEMBER,sandbox,ws-1,,,WORKSPACE_OPERATOR,WORKSPACE_MEMBER directly; WORKSPACE_OPERATOR through team eng\n")
+	})
+}
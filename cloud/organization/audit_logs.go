@@ -0,0 +1,266 @@
+package organization
+
+import (
+	"bufio"
+	"compress/gzip"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	astro "github.com/astronomer/astro-cli/astro-client"
+	"github.com/astronomer/astro-cli/pkg/printutil"
+)
+
+const (
+	// the audit logs API returns at most 90 days of history
+	auditLogsMinEarliestDays = 1
+	auditLogsMaxEarliestDays = 90
+
+	auditLogMaxLineSize = 10 * 1024 * 1024
+)
+
+var (
+	errInvalidAuditLogWindow = errors.New("the start of the time window must be before its end")
+
+	auditLogsNow = time.Now
+)
+
+// AuditLogQuery filters the audit log events returned by QueryAuditLogs
+type AuditLogQuery struct {
+	// Actor, Action and Target match case-insensitively, either as a substring or as a glob pattern when they contain a *
+	Actor  string
+	Action string
+	Target string
+	// Since and Until bound the time window of the events, a zero value leaves the window open on that side
+	Since time.Time
+	Until time.Time
+	// StateFile keeps the timestamp of the last event returned, so that subsequent queries only return newer events
+	StateFile string
+	// NDJSON prints the matching events unchanged, one per line, instead of a table
+	NDJSON bool
+}
+
+// AuditLogEvent is a single event of the audit logs
+type AuditLogEvent struct {
+	Timestamp time.Time
+	Actor     string
+	Action    string
+	Target    string
+	Raw       []byte
+}
+
+type auditLogRecord struct {
+	Timestamp string          `json:"timestamp"`
+	Action    string          `json:"action"`
+	Actor     json.RawMessage `json:"actor"`
+	Target    json.RawMessage `json:"target"`
+}
+
+type auditLogEntity struct {
+	ID      string `json:"id"`
+	Type    string `json:"type"`
+	Subject string `json:"subject"`
+	Name    string `json:"name"`
+}
+
+// QueryAuditLogs downloads the audit logs of an organization and prints the events matching the query
+func QueryAuditLogs(client astro.Client, out io.Writer, orgName string, query AuditLogQuery) error {
+	if !query.Since.IsZero() && !query.Until.IsZero() && !query.Since.Before(query.Until) {
+		return errInvalidAuditLogWindow
+	}
+	if query.StateFile != "" {
+		last, err := readAuditLogState(query.StateFile)
+		if err != nil {
+			return err
+		}
+		if last.After(query.Since) {
+			// events at exactly the last timestamp were returned by the previous query
+			query.Since = last.Add(time.Nanosecond)
+		}
+	}
+
+	logStream, err := client.GetOrganizationAuditLogs(orgName, auditLogsEarliestDays(query.Since, auditLogsNow()))
+	if err != nil {
+		return err
+	}
+	defer logStream.Close()
+
+	tab := printutil.Table{
+		Padding:        []int{30, 40, 40, 50},
+		DynamicPadding: true,
+		Header:         []string{"TIMESTAMP", "ACTOR", "ACTION", "TARGET"},
+		NoResultsMsg:   "No audit log events found",
+	}
+	var last time.Time
+	err = readAuditLogEvents(logStream, func(event *AuditLogEvent) error {
+		if !query.matches(event) {
+			return nil
+		}
+		if event.Timestamp.After(last) {
+			last = event.Timestamp
+		}
+		if query.NDJSON {
+			_, err := fmt.Fprintln(out, string(event.Raw))
+			return err
+		}
+		tab.AddRow([]string{event.Timestamp.Format(time.RFC3339), event.Actor, event.Action, event.Target}, false)
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	if !query.NDJSON {
+		if err := tab.Print(out); err != nil {
+			return err
+		}
+	}
+	if query.StateFile != "" && !last.IsZero() {
+		return writeAuditLogState(query.StateFile, last)
+	}
+	return nil
+}
+
+// readAuditLogEvents decompresses a GZIP stream of newline delimited JSON events and calls fn for each event
+func readAuditLogEvents(stream io.Reader, fn func(*AuditLogEvent) error) error {
+	reader, err := gzip.NewReader(stream)
+	if err != nil {
+		return fmt.Errorf("error reading audit logs: %w", err)
+	}
+	defer reader.Close()
+
+	scanner := bufio.NewScanner(reader)
+	scanner.Buffer(make([]byte, 0, bufio.MaxScanTokenSize), auditLogMaxLineSize)
+	lineNumber := 0
+	for scanner.Scan() {
+		lineNumber++
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+		event, err := parseAuditLogEvent([]byte(line))
+		if err != nil {
+			return fmt.Errorf("error parsing audit log event on line %d: %w", lineNumber, err)
+		}
+		if err := fn(event); err != nil {
+			return err
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return fmt.Errorf("error reading audit logs: %w", err)
+	}
+	return nil
+}
+
+func parseAuditLogEvent(line []byte) (*AuditLogEvent, error) {
+	var record auditLogRecord
+	if err := json.Unmarshal(line, &record); err != nil {
+		return nil, err
+	}
+	timestamp, err := time.Parse(time.RFC3339Nano, record.Timestamp)
+	if err != nil {
+		return nil, err
+	}
+	return &AuditLogEvent{
+		Timestamp: timestamp,
+		Actor:     auditLogEntityName(record.Actor, true),
+		Action:    record.Action,
+		Target:    auditLogEntityName(record.Target, false),
+		Raw:       line,
+	}, nil
+}
+
+// auditLogEntityName describes an actor or a target, which can either be a plain string or an object
+func auditLogEntityName(raw json.RawMessage, isActor bool) string {
+	if len(raw) == 0 || string(raw) == "null" {
+		return ""
+	}
+	var name string
+	if err := json.Unmarshal(raw, &name); err == nil {
+		return name
+	}
+	var entity auditLogEntity
+	if err := json.Unmarshal(raw, &entity); err != nil {
+		return string(raw)
+	}
+	if isActor {
+		// users are better identified by their email than their ID
+		for _, name := range []string{entity.Subject, entity.Name, entity.ID} {
+			if name != "" {
+				return name
+			}
+		}
+		return ""
+	}
+	name = entity.ID
+	if entity.Name != "" {
+		name = entity.Name + " (" + entity.ID + ")"
+	}
+	if entity.Type != "" {
+		name = entity.Type + "/" + name
+	}
+	return name
+}
+
+func (q *AuditLogQuery) matches(event *AuditLogEvent) bool {
+	if !q.Since.IsZero() && event.Timestamp.Before(q.Since) {
+		return false
+	}
+	if !q.Until.IsZero() && !event.Timestamp.Before(q.Until) {
+		return false
+	}
+	return matchAuditLogFilter(event.Actor, q.Actor) && matchAuditLogFilter(event.Action, q.Action) && matchAuditLogFilter(event.Target, q.Target)
+}
+
+func matchAuditLogFilter(value, filter string) bool {
+	if filter == "" {
+		return true
+	}
+	value, filter = strings.ToLower(value), strings.ToLower(filter)
+	if strings.Contains(filter, "*") {
+		matched, err := filepath.Match(filter, value)
+		return err == nil && matched
+	}
+	return strings.Contains(value, filter)
+}
+
+// auditLogsEarliestDays returns the number of days of history to request so that events since the given time are included
+func auditLogsEarliestDays(since, now time.Time) int {
+	if since.IsZero() {
+		return auditLogsMinEarliestDays
+	}
+	days := int(math.Ceil(now.Sub(since).Hours() / 24)) //nolint:gomnd
+	if days < auditLogsMinEarliestDays {
+		return auditLogsMinEarliestDays
+	}
+	if days > auditLogsMaxEarliestDays {
+		return auditLogsMaxEarliestDays
+	}
+	return days
+}
+
+func readAuditLogState(path string) (time.Time, error) {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return time.Time{}, nil
+	}
+	if err != nil {
+		return time.Time{}, err
+	}
+	last, err := time.Parse(time.RFC3339Nano, strings.TrimSpace(string(data)))
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid timestamp in audit logs state file %s: %w", path, err)
+	}
+	return last, nil
+}
+
+func writeAuditLogState(path string, last time.Time) error {
+	var filePerms os.FileMode = 0o600
+	return os.WriteFile(path, []byte(last.UTC().Format(time.RFC3339Nano)+"\n"), filePerms)
+}
@@ -0,0 +1,132 @@
+package organization
+
+import (
+	"bytes"
+	"compress/gzip"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	astro_mocks "github.com/astronomer/astro-cli/astro-client/mocks"
+	testUtil "github.com/astronomer/astro-cli/pkg/testing"
+	"github.com/stretchr/testify/assert"
+)
+
+var mockAuditLogEvents = []string{
+	`{"timestamp":"2023-05-08T10:00:00Z","action":"deployment.create","actor":{"id":"user-1","type":"USER","subject":"jane@example.com"},"target":{"id":"dep-1","type":"DEPLOYMENT"}}`,
+	`{"timestamp":"2023-05-09T10:00:00.5Z","action":"deployment.delete","actor":{"id":"user-2","type":"USER","subject":"john@example.com"},"target":{"id":"dep-1","type":"DEPLOYMENT"}}`,
+	``,
+	`{"timestamp":"2023-05-10T10:00:00Z","action":"workspace.update","actor":"api-token-1","target":{"id":"ws-1","type":"WORKSPACE","name":"prod"}}`,
+}
+
+func gzipAuditLogs(t *testing.T, lines ...string) io.ReadCloser {
+	t.Helper()
+	buf := new(bytes.Buffer)
+	w := gzip.NewWriter(buf)
+	_, err := w.Write([]byte(strings.Join(lines, "\n")))
+	assert.NoError(t, err)
+	assert.NoError(t, w.Close())
+	return io.NopCloser(buf)
+}
+
+func TestQueryAuditLogs(t *testing.T) {
+	testUtil.InitTestConfig(testUtil.CloudPlatform)
+	orgNow := auditLogsNow
+	auditLogsNow = func() time.Time { return time.Date(2023, 5, 11, 0, 0, 0, 0, time.UTC) }
+	defer func() { auditLogsNow = orgNow }()
+
+	t.Run("table with filters", func(t *testing.T) {
+		mockClient := new(astro_mocks.Client)
+		mockClient.On("GetOrganizationAuditLogs", "org", 1).Return(gzipAuditLogs(t, mockAuditLogEvents...), nil).Once()
+
+		buf := new(bytes.Buffer)
+		err := QueryAuditLogs(mockClient, buf, "org", AuditLogQuery{Action: "deployment.*", Actor: "JOHN"})
+		assert.NoError(t, err)
+		assert.Contains(t, buf.String(), "2023-05-09T10:00:00Z")
+		assert.Contains(t, buf.String(), "john@example.com")
+		assert.Contains(t, buf.String(), "DEPLOYMENT/dep-1")
+		assert.NotContains(t, buf.String(), "jane@example.com")
+		assert.NotContains(t, buf.String(), "workspace.update")
+		mockClient.AssertExpectations(t)
+	})
+
+	t.Run("ndjson within a time window", func(t *testing.T) {
+		mockClient := new(astro_mocks.Client)
+		mockClient.On("GetOrganizationAuditLogs", "org", 3).Return(gzipAuditLogs(t, mockAuditLogEvents...), nil).Once()
+
+		buf := new(bytes.Buffer)
+		query := AuditLogQuery{
+			Since:  time.Date(2023, 5, 8, 12, 0, 0, 0, time.UTC),
+			Until:  time.Date(2023, 5, 10, 10, 0, 0, 0, time.UTC),
+			NDJSON: true,
+		}
+		err := QueryAuditLogs(mockClient, buf, "org", query)
+		assert.NoError(t, err)
+		assert.Equal(t, mockAuditLogEvents[1]+"\n", buf.String())
+		mockClient.AssertExpectations(t)
+	})
+
+	t.Run("resume from the state file", func(t *testing.T) {
+		stateFile := filepath.Join(t.TempDir(), "state")
+
+		mockClient := new(astro_mocks.Client)
+		mockClient.On("GetOrganizationAuditLogs", "org", 1).Return(gzipAuditLogs(t, mockAuditLogEvents[:2]...), nil).Once()
+		buf := new(bytes.Buffer)
+		err := QueryAuditLogs(mockClient, buf, "org", AuditLogQuery{StateFile: stateFile, NDJSON: true})
+		assert.NoError(t, err)
+		assert.Equal(t, 2, strings.Count(buf.String(), "\n"))
+		state, err := os.ReadFile(stateFile)
+		assert.NoError(t, err)
+		assert.Equal(t, "2023-05-09T10:00:00.5Z\n", string(state))
+
+		mockClient.On("GetOrganizationAuditLogs", "org", 2).Return(gzipAuditLogs(t, mockAuditLogEvents...), nil).Once()
+		buf = new(bytes.Buffer)
+		err = QueryAuditLogs(mockClient, buf, "org", AuditLogQuery{StateFile: stateFile, NDJSON: true})
+		assert.NoError(t, err)
+		assert.Equal(t, mockAuditLogEvents[3]+"\n", buf.String())
+		state, err = os.ReadFile(stateFile)
+		assert.NoError(t, err)
+		assert.Equal(t, "2023-05-10T10:00:00Z\n", string(state))
+		mockClient.AssertExpectations(t)
+	})
+
+	t.Run("invalid event", func(t *testing.T) {
+		mockClient := new(astro_mocks.Client)
+		mockClient.On("GetOrganizationAuditLogs", "org", 1).Return(gzipAuditLogs(t, mockAuditLogEvents[0], "{"), nil).Once()
+		err := QueryAuditLogs(mockClient, new(bytes.Buffer), "org", AuditLogQuery{})
+		assert.ErrorContains(t, err, "error parsing audit log event on line 2")
+	})
+
+	t.Run("not a gzip stream", func(t *testing.T) {
+		mockClient := new(astro_mocks.Client)
+		mockClient.On("GetOrganizationAuditLogs", "org", 1).Return(io.NopCloser(strings.NewReader(mockAuditLogEvents[0])), nil).Once()
+		err := QueryAuditLogs(mockClient, new(bytes.Buffer), "org", AuditLogQuery{})
+		assert.ErrorContains(t, err, "error reading audit logs")
+	})
+
+	t.Run("api error", func(t *testing.T) {
+		errMock := errors.New("api error")
+		mockClient := new(astro_mocks.Client)
+		mockClient.On("GetOrganizationAuditLogs", "org", 1).Return(nil, errMock).Once()
+		err := QueryAuditLogs(mockClient, new(bytes.Buffer), "org", AuditLogQuery{})
+		assert.ErrorIs(t, err, errMock)
+	})
+
+	t.Run("invalid time window", func(t *testing.T) {
+		now := time.Now()
+		err := QueryAuditLogs(new(astro_mocks.Client), new(bytes.Buffer), "org", AuditLogQuery{Since: now, Until: now})
+		assert.ErrorIs(t, err, errInvalidAuditLogWindow)
+	})
+}
+
+func TestAuditLogsEarliestDays(t *testing.T) {
+	now := time.Date(2023, 5, 11, 0, 0, 0, 0, time.UTC)
+	assert.Equal(t, 1, auditLogsEarliestDays(time.Time{}, now))
+	assert.Equal(t, 1, auditLogsEarliestDays(now.Add(-time.Hour), now))
+	assert.Equal(t, 3, auditLogsEarliestDays(now.Add(-50*time.Hour), now))
+	assert.Equal(t, 90, auditLogsEarliestDays(now.Add(-200*24*time.Hour), now))
+}
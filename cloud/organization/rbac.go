@@ -0,0 +1,919 @@
+package organization
+
+import (
+	"bytes"
+	httpContext "context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+
+	astrocore "github.com/astronomer/astro-cli/astro-client-core"
+	"github.com/astronomer/astro-cli/cloud/team"
+	"github.com/astronomer/astro-cli/cloud/user"
+	"github.com/astronomer/astro-cli/cloud/workspace"
+	"github.com/astronomer/astro-cli/context"
+	"github.com/astronomer/astro-cli/pkg/input"
+	"github.com/astronomer/astro-cli/pkg/printutil"
+	"github.com/astronomer/astro-cli/pkg/util"
+	"github.com/golang-jwt/jwt/v4"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	rbacPageLimit = 100
+
+	removeAction = "remove"
+	deleteAction = "delete"
+)
+
+var (
+	errRBACApplyFailed        = errors.New("some access changes could not be applied")
+	errAccessFileWorkspace    = errors.New("every workspace of the access file needs an id or a name")
+	errAccessFileEmptyEmail   = errors.New("every user of the access file needs an email")
+	errAccessFileEmptyName    = errors.New("every team and API token of the access file needs a name")
+	errAccessFileDuplicate    = errors.New("the access file declares the same entry twice")
+	errAccessFileUnknownTeam  = errors.New("the access file binds a team which neither exists nor is declared")
+	errAccessFileUnknownUser  = errors.New("the access file binds a user who is neither a member of the organization nor declared")
+	errWorkspaceNotFound      = errors.New("no workspace was found for the id or name you provided")
+	errWorkspaceNameAmbiguous = errors.New("more than one workspace has the name you provided, use its id instead")
+)
+
+// AccessFile declares the users, teams, workspace role bindings and API tokens of an organization
+type AccessFile struct {
+	Users      []AccessUser      `yaml:"users"`
+	Teams      []AccessTeam      `yaml:"teams"`
+	Workspaces []AccessWorkspace `yaml:"workspaces"`
+	Tokens     []AccessToken     `yaml:"tokens"`
+}
+
+// AccessUser is a user and its organization or workspace role
+type AccessUser struct {
+	Email string `yaml:"email"`
+	Role  string `yaml:"role"`
+}
+
+// AccessTeam is a team, its organization role and its members, identified by their email
+type AccessTeam struct {
+	Name        string `yaml:"name"`
+	Description string `yaml:"description"`
+	Role        string `yaml:"role"`
+	// Members are only managed when the list is present in the file
+	Members []string `yaml:"members"`
+}
+
+// AccessWorkspace is the user and team role bindings of a workspace, identified by its id or its name
+type AccessWorkspace struct {
+	ID    string              `yaml:"id"`
+	Name  string              `yaml:"name"`
+	Users []AccessUser        `yaml:"users"`
+	Teams []AccessTeamBinding `yaml:"teams"`
+}
+
+// AccessTeamBinding is the workspace role of a team
+type AccessTeamBinding struct {
+	Name string `yaml:"name"`
+	Role string `yaml:"role"`
+}
+
+// AccessToken is an organization API token with its organization role and workspace roles
+type AccessToken struct {
+	Name        string `yaml:"name"`
+	Description string `yaml:"description"`
+	Role        string `yaml:"role"`
+	// Expiration is the number of days new tokens are valid for, 0 for tokens which never expire
+	Expiration int                    `yaml:"expiration"`
+	Workspaces []AccessTokenWorkspace `yaml:"workspaces"`
+}
+
+// AccessTokenWorkspace is the role of an API token on a workspace, identified by its id or its name
+type AccessTokenWorkspace struct {
+	ID   string `yaml:"id"`
+	Name string `yaml:"name"`
+	Role string `yaml:"role"`
+}
+
+// RBACApplyOptions controls how an access file is applied
+type RBACApplyOptions struct {
+	// Prune removes the users, team members, teams, workspace role bindings and API tokens which are not
+	// declared, in every section present in the file
+	Prune bool
+	// DryRun only prints the changes
+	DryRun bool
+	// Force applies the changes without asking for confirmation
+	Force bool
+}
+
+// RBACChange is a single change needed to make the organization match an access file
+type RBACChange struct {
+	Action string
+	Kind   string
+	Target string
+	Detail string
+	apply  func(state *rbacState) error
+}
+
+// rbacState is shared by the changes while they are applied, so that teams created by a change
+// can be referenced by the following ones
+type rbacState struct {
+	orgShortName string
+	client       astrocore.CoreClient
+	out          io.Writer
+	teamIDs      map[string]string
+	// declaredTeams are the teams of the access file, which may only be created when the changes are applied
+	declaredTeams map[string]bool
+	// currentUser is the email of the logged in user, who is never pruned so that they keep their access
+	currentUser string
+	// currentTokenID is the ID of the API token the CLI authenticates with, which is never pruned either
+	currentTokenID string
+}
+
+// LoadAccessFile reads and validates an access file
+func LoadAccessFile(path string) (*AccessFile, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var file AccessFile
+	decoder := yaml.NewDecoder(bytes.NewReader(data))
+	decoder.KnownFields(true)
+	if err := decoder.Decode(&file); err != nil && !errors.Is(err, io.EOF) {
+		return nil, fmt.Errorf("error parsing access file %s: %w", path, err)
+	}
+	if err := file.validate(); err != nil {
+		return nil, err
+	}
+	return &file, nil
+}
+
+func (f *AccessFile) validate() error {
+	emails := map[string]bool{}
+	for _, u := range f.Users {
+		if u.Email == "" {
+			return errAccessFileEmptyEmail
+		}
+		if err := user.IsOrganizationRoleValid(u.Role); err != nil {
+			return fmt.Errorf("user %s: %w", u.Email, err)
+		}
+		if emails[strings.ToLower(u.Email)] {
+			return fmt.Errorf("%w: user %s", errAccessFileDuplicate, u.Email)
+		}
+		emails[strings.ToLower(u.Email)] = true
+	}
+
+	teams := map[string]bool{}
+	for _, t := range f.Teams {
+		if t.Name == "" {
+			return errAccessFileEmptyName
+		}
+		if err := user.IsOrganizationRoleValid(t.Role); err != nil {
+			return fmt.Errorf("team %s: %w", t.Name, err)
+		}
+		if teams[t.Name] {
+			return fmt.Errorf("%w: team %s", errAccessFileDuplicate, t.Name)
+		}
+		teams[t.Name] = true
+		for _, member := range t.Members {
+			if member == "" {
+				return fmt.Errorf("team %s: %w", t.Name, errAccessFileEmptyEmail)
+			}
+		}
+	}
+
+	for _, ws := range f.Workspaces {
+		if ws.ID == "" && ws.Name == "" {
+			return errAccessFileWorkspace
+		}
+		bound := map[string]bool{}
+		for _, u := range ws.Users {
+			if u.Email == "" {
+				return errAccessFileEmptyEmail
+			}
+			if err := user.IsWorkspaceRoleValid(u.Role); err != nil {
+				return fmt.Errorf("workspace %s, user %s: %w", ws.ID+ws.Name, u.Email, err)
+			}
+			if bound["user:"+strings.ToLower(u.Email)] {
+				return fmt.Errorf("%w: user %s in workspace %s", errAccessFileDuplicate, u.Email, ws.ID+ws.Name)
+			}
+			bound["user:"+strings.ToLower(u.Email)] = true
+		}
+		for _, t := range ws.Teams {
+			if t.Name == "" {
+				return errAccessFileEmptyName
+			}
+			if err := user.IsWorkspaceRoleValid(t.Role); err != nil {
+				return fmt.Errorf("workspace %s, team %s: %w", ws.ID+ws.Name, t.Name, err)
+			}
+			if bound["team:"+t.Name] {
+				return fmt.Errorf("%w: team %s in workspace %s", errAccessFileDuplicate, t.Name, ws.ID+ws.Name)
+			}
+			bound["team:"+t.Name] = true
+		}
+	}
+
+	tokens := map[string]bool{}
+	for _, t := range f.Tokens {
+		if t.Name == "" {
+			return errAccessFileEmptyName
+		}
+		if err := user.IsOrganizationRoleValid(t.Role); err != nil {
+			return fmt.Errorf("API token %s: %w", t.Name, err)
+		}
+		if tokens[t.Name] {
+			return fmt.Errorf("%w: API token %s", errAccessFileDuplicate, t.Name)
+		}
+		tokens[t.Name] = true
+		for _, ws := range t.Workspaces {
+			if ws.ID == "" && ws.Name == "" {
+				return errAccessFileWorkspace
+			}
+			if err := user.IsWorkspaceRoleValid(ws.Role); err != nil {
+				return fmt.Errorf("API token %s, workspace %s: %w", t.Name, ws.ID+ws.Name, err)
+			}
+		}
+	}
+	return nil
+}
+
+// ApplyAccessFile computes the changes needed to make the organization match an access file, prints them and applies them
+func ApplyAccessFile(path string, opts RBACApplyOptions, out io.Writer, client astrocore.CoreClient) error {
+	file, err := LoadAccessFile(path)
+	if err != nil {
+		return err
+	}
+	ctx, err := context.GetCurrentContext()
+	if err != nil {
+		return err
+	}
+	if ctx.OrganizationShortName == "" {
+		return user.ErrNoShortName
+	}
+	state := &rbacState{
+		orgShortName:   ctx.OrganizationShortName,
+		client:         client,
+		out:            out,
+		teamIDs:        map[string]string{},
+		declaredTeams:  map[string]bool{},
+		currentUser:    strings.ToLower(ctx.UserEmail),
+		currentTokenID: currentAPITokenID(ctx.Token),
+	}
+	changes, err := planAccessChanges(file, opts.Prune, state)
+	if err != nil {
+		return err
+	}
+
+	tab := printutil.Table{
+		DynamicPadding: true,
+		Header:         []string{"ACTION", "KIND", "TARGET", "CHANGE"},
+		NoResultsMsg:   "No changes, the organization already matches the access file",
+	}
+	for i := range changes {
+		tab.AddRow([]string{changes[i].Action, changes[i].Kind, changes[i].Target, changes[i].Detail}, false)
+	}
+	if err := tab.Print(out); err != nil {
+		return err
+	}
+	if opts.DryRun || len(changes) == 0 {
+		return nil
+	}
+	if !opts.Force {
+		removals := []string{}
+		for i := range changes {
+			if changes[i].Action == removeAction || changes[i].Action == deleteAction {
+				removals = append(removals, fmt.Sprintf("  %s %s %s", changes[i].Action, changes[i].Kind, changes[i].Target))
+			}
+		}
+		if len(removals) > 0 {
+			fmt.Fprintf(out, "\nWARNING: %d of these changes remove access and cannot be undone:\n%s\n", len(removals), strings.Join(removals, "\n"))
+		}
+		if ok, _ := input.Confirm(fmt.Sprintf("\nAre you sure you want to apply %d changes?", len(changes))); !ok {
+			fmt.Fprintln(out, "Canceling access changes")
+			return nil
+		}
+	}
+
+	failed := 0
+	for i := range changes {
+		change := &changes[i]
+		if err := change.apply(state); err != nil {
+			failed++
+			fmt.Fprintf(out, "Failed to %s %s %s: %s\n", change.Action, change.Kind, change.Target, err.Error())
+			continue
+		}
+		fmt.Fprintf(out, "%s %s %s: done\n", change.Action, change.Kind, change.Target)
+	}
+	if failed > 0 {
+		return fmt.Errorf("%w: %d of %d failed", errRBACApplyFailed, failed, len(changes))
+	}
+	return nil
+}
+
+// planAccessChanges compares an access file with the live state of the organization and returns the changes to apply, in order
+func planAccessChanges(file *AccessFile, prune bool, state *rbacState) ([]RBACChange, error) {
+	orgUsers, err := user.GetOrgUsers(state.client)
+	if err != nil {
+		return nil, err
+	}
+	usersByEmail := map[string]astrocore.User{}
+	for i := range orgUsers {
+		usersByEmail[strings.ToLower(orgUsers[i].Username)] = orgUsers[i]
+	}
+	invited := map[string]bool{}
+	for _, t := range file.Teams {
+		state.declaredTeams[t.Name] = true
+	}
+
+	changes := []RBACChange{}
+	changes = append(changes, planOrgUsers(file, prune, usersByEmail, invited, state)...)
+
+	teamChanges, err := planTeams(file, prune, usersByEmail, invited, state)
+	if err != nil {
+		return nil, err
+	}
+	changes = append(changes, teamChanges...)
+
+	workspaces, err := workspace.GetWorkspaces(state.client)
+	if err != nil {
+		return nil, err
+	}
+	for i := range file.Workspaces {
+		wsChanges, err := planWorkspace(&file.Workspaces[i], workspaces, prune, usersByEmail, invited, state)
+		if err != nil {
+			return nil, err
+		}
+		changes = append(changes, wsChanges...)
+	}
+
+	if file.Tokens != nil {
+		tokenChanges, err := planTokens(file, prune, workspaces, state)
+		if err != nil {
+			return nil, err
+		}
+		changes = append(changes, tokenChanges...)
+	}
+	return changes, nil
+}
+
+func planOrgUsers(file *AccessFile, prune bool, usersByEmail map[string]astrocore.User, invited map[string]bool, state *rbacState) []RBACChange {
+	changes := []RBACChange{}
+	// users who are only members of a team or bound to a workspace are declared too, and must stay in the organization
+	declared := referencedUsers(file)
+	for _, u := range file.Users {
+		u := u
+		email := strings.ToLower(u.Email)
+		declared[email] = true
+		live, ok := usersByEmail[email]
+		if !ok {
+			invited[email] = true
+			changes = append(changes, RBACChange{Action: "invite", Kind: "user", Target: u.Email, Detail: u.Role, apply: func(s *rbacState) error {
+				resp, err := s.client.CreateUserInviteWithResponse(httpContext.Background(), s.orgShortName, astrocore.CreateUserInviteRequest{InviteeEmail: u.Email, Role: u.Role})
+				if err != nil {
+					return err
+				}
+				return astrocore.NormalizeAPIError(resp.HTTPResponse, resp.Body)
+			}})
+			continue
+		}
+		if live.OrgRole == nil || *live.OrgRole != u.Role {
+			userID := live.Id
+			changes = append(changes, RBACChange{Action: "update", Kind: "user", Target: u.Email, Detail: roleChange(live.OrgRole, u.Role), apply: func(s *rbacState) error {
+				resp, err := s.client.MutateOrgUserRoleWithResponse(httpContext.Background(), s.orgShortName, userID, astrocore.MutateOrgUserRoleRequest{Role: u.Role})
+				if err != nil {
+					return err
+				}
+				return astrocore.NormalizeAPIError(resp.HTTPResponse, resp.Body)
+			}})
+		}
+	}
+	if !prune || file.Users == nil {
+		return changes
+	}
+	for _, email := range sortedKeys(usersByEmail) {
+		if declared[email] {
+			continue
+		}
+		if email == state.currentUser {
+			fmt.Fprintf(state.out, "You (%s) are not in the access file, your own access is left unchanged\n", email)
+			continue
+		}
+		live := usersByEmail[email]
+		changes = append(changes, RBACChange{Action: removeAction, Kind: "user", Target: live.Username, Detail: stringValue(live.OrgRole), apply: func(s *rbacState) error {
+			resp, err := s.client.DeleteOrgUserWithResponse(httpContext.Background(), s.orgShortName, live.Id)
+			if err != nil {
+				return err
+			}
+			return astrocore.NormalizeAPIError(resp.HTTPResponse, resp.Body)
+		}})
+	}
+	return changes
+}
+
+// referencedUsers returns the lowercased emails of the users of every section of the access file
+func referencedUsers(file *AccessFile) map[string]bool {
+	referenced := map[string]bool{}
+	for _, u := range file.Users {
+		referenced[strings.ToLower(u.Email)] = true
+	}
+	for _, t := range file.Teams {
+		for _, email := range t.Members {
+			referenced[strings.ToLower(email)] = true
+		}
+	}
+	for _, ws := range file.Workspaces {
+		for _, u := range ws.Users {
+			referenced[strings.ToLower(u.Email)] = true
+		}
+	}
+	return referenced
+}
+
+//nolint:gocognit
+func planTeams(file *AccessFile, prune bool, usersByEmail map[string]astrocore.User, invited map[string]bool, state *rbacState) ([]RBACChange, error) {
+	orgTeams, err := team.GetOrgTeams(state.client)
+	if err != nil {
+		return nil, err
+	}
+	teamsByName := map[string]astrocore.Team{}
+	for i := range orgTeams {
+		teamsByName[orgTeams[i].Name] = orgTeams[i]
+		state.teamIDs[orgTeams[i].Name] = orgTeams[i].Id
+	}
+
+	changes := []RBACChange{}
+	declared := map[string]bool{}
+	for _, t := range file.Teams {
+		t := t
+		declared[t.Name] = true
+		live, ok := teamsByName[t.Name]
+		if !ok {
+			memberIDs, pending, err := teamMemberIDs(t.Members, usersByEmail, invited)
+			if err != nil {
+				return nil, err
+			}
+			if len(pending) > 0 {
+				fmt.Fprintf(state.out, "Team %s: %s will be added once their invite is accepted\n", t.Name, strings.Join(pending, ", "))
+			}
+			detail := t.Role
+			if len(memberIDs) > 0 {
+				detail += fmt.Sprintf(" with %d of %d members", len(memberIDs), len(t.Members))
+			}
+			changes = append(changes, RBACChange{Action: "create", Kind: "team", Target: t.Name, Detail: detail, apply: func(s *rbacState) error {
+				request := astrocore.CreateTeamRequest{Name: t.Name, Description: &t.Description, OrganizationRole: &t.Role}
+				if len(memberIDs) > 0 {
+					request.MemberIds = &memberIDs
+				}
+				resp, err := s.client.CreateTeamWithResponse(httpContext.Background(), s.orgShortName, request)
+				if err != nil {
+					return err
+				}
+				if err := astrocore.NormalizeAPIError(resp.HTTPResponse, resp.Body); err != nil {
+					return err
+				}
+				s.teamIDs[t.Name] = resp.JSON200.Id
+				return nil
+			}})
+			continue
+		}
+
+		teamID := live.Id
+		if t.Description != "" && t.Description != stringValue(live.Description) {
+			changes = append(changes, RBACChange{Action: "update", Kind: "team", Target: t.Name, Detail: "description", apply: func(s *rbacState) error {
+				resp, err := s.client.UpdateTeamWithResponse(httpContext.Background(), s.orgShortName, teamID, astrocore.UpdateTeamRequest{Name: t.Name, Description: t.Description})
+				if err != nil {
+					return err
+				}
+				return astrocore.NormalizeAPIError(resp.HTTPResponse, resp.Body)
+			}})
+		}
+		if live.OrganizationRole != t.Role {
+			changes = append(changes, RBACChange{Action: "update", Kind: "team", Target: t.Name, Detail: roleChange(&live.OrganizationRole, t.Role), apply: func(s *rbacState) error {
+				resp, err := s.client.MutateOrgTeamRoleWithResponse(httpContext.Background(), s.orgShortName, teamID, astrocore.MutateOrgTeamRoleRequest{Role: t.Role})
+				if err != nil {
+					return err
+				}
+				return astrocore.NormalizeAPIError(resp.HTTPResponse, resp.Body)
+			}})
+		}
+		if t.Members == nil {
+			continue
+		}
+		if live.IsIdpManaged {
+			fmt.Fprintf(state.out, "Team %s is managed by your identity provider, its members are left unchanged\n", t.Name)
+			continue
+		}
+		memberChanges, err := planTeamMembers(&t, teamID, prune, usersByEmail, invited, state)
+		if err != nil {
+			return nil, err
+		}
+		changes = append(changes, memberChanges...)
+	}
+
+	if !prune || file.Teams == nil {
+		return changes, nil
+	}
+	for _, name := range sortedKeys(teamsByName) {
+		live := teamsByName[name]
+		if declared[name] || live.IsIdpManaged {
+			continue
+		}
+		changes = append(changes, RBACChange{Action: deleteAction, Kind: "team", Target: name, Detail: live.OrganizationRole, apply: func(s *rbacState) error {
+			resp, err := s.client.DeleteTeamWithResponse(httpContext.Background(), s.orgShortName, live.Id)
+			if err != nil {
+				return err
+			}
+			return astrocore.NormalizeAPIError(resp.HTTPResponse, resp.Body)
+		}})
+	}
+	return changes, nil
+}
+
+func planTeamMembers(t *AccessTeam, teamID string, prune bool, usersByEmail map[string]astrocore.User, invited map[string]bool, state *rbacState) ([]RBACChange, error) {
+	live, err := team.GetTeam(state.client, teamID)
+	if err != nil {
+		return nil, err
+	}
+	members := map[string]astrocore.TeamMember{}
+	if live.Members != nil {
+		for _, member := range *live.Members {
+			members[strings.ToLower(member.Username)] = member
+		}
+	}
+
+	changes := []RBACChange{}
+	declared := map[string]bool{}
+	for _, email := range t.Members {
+		declared[strings.ToLower(email)] = true
+		if _, ok := members[strings.ToLower(email)]; ok {
+			continue
+		}
+		memberIDs, pending, err := teamMemberIDs([]string{email}, usersByEmail, invited)
+		if err != nil {
+			return nil, err
+		}
+		if len(pending) > 0 {
+			fmt.Fprintf(state.out, "Team %s: %s will be added once their invite is accepted\n", t.Name, email)
+			continue
+		}
+		changes = append(changes, RBACChange{Action: "add", Kind: "team member", Target: t.Name, Detail: email, apply: func(s *rbacState) error {
+			resp, err := s.client.AddTeamMembersWithResponse(httpContext.Background(), s.orgShortName, teamID, astrocore.AddTeamMembersRequest{MemberIds: memberIDs})
+			if err != nil {
+				return err
+			}
+			return astrocore.NormalizeAPIError(resp.HTTPResponse, resp.Body)
+		}})
+	}
+	if !prune {
+		return changes, nil
+	}
+	for _, email := range sortedKeys(members) {
+		if declared[email] {
+			continue
+		}
+		if email == state.currentUser {
+			fmt.Fprintf(state.out, "Team %s: you (%s) are not in the access file, your own membership is left unchanged\n", t.Name, email)
+			continue
+		}
+		member := members[email]
+		changes = append(changes, RBACChange{Action: removeAction, Kind: "team member", Target: t.Name, Detail: member.Username, apply: func(s *rbacState) error {
+			resp, err := s.client.RemoveTeamMemberWithResponse(httpContext.Background(), s.orgShortName, teamID, member.UserId)
+			if err != nil {
+				return err
+			}
+			return astrocore.NormalizeAPIError(resp.HTTPResponse, resp.Body)
+		}})
+	}
+	return changes, nil
+}
+
+// teamMemberIDs returns the IDs of the organization users with the given emails, and the emails of the users who are only invited
+func teamMemberIDs(emails []string, usersByEmail map[string]astrocore.User, invited map[string]bool) (ids, pending []string, err error) {
+	ids = []string{}
+	for _, email := range emails {
+		if u, ok := usersByEmail[strings.ToLower(email)]; ok {
+			ids = append(ids, u.Id)
+			continue
+		}
+		if !invited[strings.ToLower(email)] {
+			return nil, nil, fmt.Errorf("%w: %s", errAccessFileUnknownUser, email)
+		}
+		pending = append(pending, email)
+	}
+	return ids, pending, nil
+}
+
+//nolint:gocognit
+func planWorkspace(ws *AccessWorkspace, workspaces []astrocore.Workspace, prune bool, usersByEmail map[string]astrocore.User, invited map[string]bool, state *rbacState) ([]RBACChange, error) {
+	workspaceID, workspaceName, err := resolveWorkspace(ws.ID, ws.Name, workspaces)
+	if err != nil {
+		return nil, err
+	}
+	changes := []RBACChange{}
+
+	if ws.Users != nil {
+		liveUsers, err := user.GetWorkspaceUsers(state.client, workspaceID, rbacPageLimit)
+		if err != nil {
+			return nil, err
+		}
+		roles := map[string]astrocore.User{}
+		for i := range liveUsers {
+			roles[strings.ToLower(liveUsers[i].Username)] = liveUsers[i]
+		}
+		declared := map[string]bool{}
+		for _, u := range ws.Users {
+			u := u
+			email := strings.ToLower(u.Email)
+			declared[email] = true
+			orgUser, ok := usersByEmail[email]
+			if !ok {
+				if invited[email] {
+					fmt.Fprintf(state.out, "Workspace %s: %s will be added once their invite is accepted\n", workspaceName, u.Email)
+					continue
+				}
+				return nil, fmt.Errorf("%w: %s", errAccessFileUnknownUser, u.Email)
+			}
+			action := "add"
+			detail := u.Role
+			if live, ok := roles[email]; ok {
+				if live.WorkspaceRole != nil && *live.WorkspaceRole == u.Role {
+					continue
+				}
+				action = "update"
+				detail = roleChange(live.WorkspaceRole, u.Role)
+			}
+			userID := orgUser.Id
+			changes = append(changes, RBACChange{Action: action, Kind: "workspace user", Target: workspaceName + "/" + u.Email, Detail: detail, apply: func(s *rbacState) error {
+				resp, err := s.client.MutateWorkspaceUserRoleWithResponse(httpContext.Background(), s.orgShortName, workspaceID, userID, astrocore.MutateWorkspaceUserRoleRequest{Role: u.Role})
+				if err != nil {
+					return err
+				}
+				return astrocore.NormalizeAPIError(resp.HTTPResponse, resp.Body)
+			}})
+		}
+		if prune {
+			for _, email := range sortedKeys(roles) {
+				if declared[email] {
+					continue
+				}
+				if email == state.currentUser {
+					fmt.Fprintf(state.out, "Workspace %s: you (%s) are not in the access file, your own access is left unchanged\n", workspaceName, email)
+					continue
+				}
+				live := roles[email]
+				changes = append(changes, RBACChange{Action: removeAction, Kind: "workspace user", Target: workspaceName + "/" + live.Username, Detail: stringValue(live.WorkspaceRole), apply: func(s *rbacState) error {
+					resp, err := s.client.DeleteWorkspaceUserWithResponse(httpContext.Background(), s.orgShortName, workspaceID, live.Id)
+					if err != nil {
+						return err
+					}
+					return astrocore.NormalizeAPIError(resp.HTTPResponse, resp.Body)
+				}})
+			}
+		}
+	}
+
+	if ws.Teams != nil {
+		liveTeams, err := team.GetWorkspaceTeams(state.client, workspaceID, rbacPageLimit)
+		if err != nil {
+			return nil, err
+		}
+		roles := map[string]string{}
+		ids := map[string]string{}
+		for i := range liveTeams {
+			ids[liveTeams[i].Name] = liveTeams[i].Id
+			roles[liveTeams[i].Name] = ""
+			if liveTeams[i].Roles != nil {
+				for _, role := range *liveTeams[i].Roles {
+					if role.EntityType == workspaceEntity && role.EntityId == workspaceID {
+						roles[liveTeams[i].Name] = role.Role
+					}
+				}
+			}
+		}
+		declared := map[string]bool{}
+		for _, t := range ws.Teams {
+			t := t
+			declared[t.Name] = true
+			if _, ok := state.teamIDs[t.Name]; !ok && !state.declaredTeams[t.Name] {
+				return nil, fmt.Errorf("%w: %s", errAccessFileUnknownTeam, t.Name)
+			}
+			action := "add"
+			detail := t.Role
+			if role, ok := roles[t.Name]; ok {
+				if role == t.Role {
+					continue
+				}
+				action = "update"
+				detail = roleChange(&role, t.Role)
+			}
+			changes = append(changes, RBACChange{Action: action, Kind: "workspace team", Target: workspaceName + "/" + t.Name, Detail: detail, apply: func(s *rbacState) error {
+				resp, err := s.client.MutateWorkspaceTeamRoleWithResponse(httpContext.Background(), s.orgShortName, workspaceID, s.teamIDs[t.Name], astrocore.MutateWorkspaceTeamRoleRequest{Role: t.Role})
+				if err != nil {
+					return err
+				}
+				return astrocore.NormalizeAPIError(resp.HTTPResponse, resp.Body)
+			}})
+		}
+		if prune {
+			for _, name := range sortedKeys(roles) {
+				if declared[name] {
+					continue
+				}
+				teamID := ids[name]
+				changes = append(changes, RBACChange{Action: removeAction, Kind: "workspace team", Target: workspaceName + "/" + name, Detail: roles[name], apply: func(s *rbacState) error {
+					resp, err := s.client.DeleteWorkspaceTeamWithResponse(httpContext.Background(), s.orgShortName, workspaceID, teamID)
+					if err != nil {
+						return err
+					}
+					return astrocore.NormalizeAPIError(resp.HTTPResponse, resp.Body)
+				}})
+			}
+		}
+	}
+	return changes, nil
+}
+
+func planTokens(file *AccessFile, prune bool, workspaces []astrocore.Workspace, state *rbacState) ([]RBACChange, error) {
+	liveTokens, err := getOrganizationTokens(state.client)
+	if err != nil {
+		return nil, err
+	}
+	tokensByName := map[string]astrocore.ApiToken{}
+	for i := range liveTokens {
+		tokensByName[liveTokens[i].Name] = liveTokens[i]
+	}
+
+	changes := []RBACChange{}
+	declared := map[string]bool{}
+	for _, t := range file.Tokens {
+		t := t
+		declared[t.Name] = true
+		workspaceRoles := []astrocore.ApiTokenWorkspaceRoleRequest{}
+		for _, ws := range t.Workspaces {
+			workspaceID, _, err := resolveWorkspace(ws.ID, ws.Name, workspaces)
+			if err != nil {
+				return nil, fmt.Errorf("API token %s: %w", t.Name, err)
+			}
+			workspaceRoles = append(workspaceRoles, astrocore.ApiTokenWorkspaceRoleRequest{EntityId: workspaceID, Role: ws.Role})
+		}
+
+		live, ok := tokensByName[t.Name]
+		if !ok {
+			changes = append(changes, RBACChange{Action: "create", Kind: "API token", Target: t.Name, Detail: tokenRolesDetail(t.Role, workspaceRoles), apply: func(s *rbacState) error {
+				return createAccessToken(&t, workspaceRoles, s)
+			}})
+			continue
+		}
+
+		liveOrgRole := ""
+		liveWorkspaceRoles := []astrocore.ApiTokenWorkspaceRoleRequest{}
+		for _, role := range live.Roles {
+			switch role.EntityType {
+			case organizationEntity:
+				liveOrgRole = role.Role
+			case workspaceEntity:
+				liveWorkspaceRoles = append(liveWorkspaceRoles, astrocore.ApiTokenWorkspaceRoleRequest{EntityId: role.EntityId, Role: role.Role})
+			}
+		}
+		description := live.Description
+		if t.Description != "" {
+			description = t.Description
+		}
+		if liveOrgRole == t.Role && description == live.Description && tokenRolesDetail("", liveWorkspaceRoles) == tokenRolesDetail("", workspaceRoles) {
+			continue
+		}
+		tokenID := live.Id
+		changes = append(changes, RBACChange{Action: "update", Kind: "API token", Target: t.Name, Detail: tokenRolesDetail(t.Role, workspaceRoles), apply: func(s *rbacState) error {
+			request := astrocore.UpdateOrganizationApiTokenRequest{
+				Name:        t.Name,
+				Description: description,
+				Roles:       astrocore.UpdateOrganizationApiTokenRolesRequest{Organization: t.Role, Workspace: &workspaceRoles},
+			}
+			resp, err := s.client.UpdateOrganizationApiTokenWithResponse(httpContext.Background(), s.orgShortName, tokenID, request)
+			if err != nil {
+				return err
+			}
+			return astrocore.NormalizeAPIError(resp.HTTPResponse, resp.Body)
+		}})
+	}
+
+	if !prune {
+		return changes, nil
+	}
+	for _, name := range sortedKeys(tokensByName) {
+		if declared[name] {
+			continue
+		}
+		tokenID := tokensByName[name].Id
+		if tokenID != "" && tokenID == state.currentTokenID {
+			fmt.Fprintf(state.out, "The API token %s is used by this command and is not in the access file, it is left unchanged\n", name)
+			continue
+		}
+		changes = append(changes, RBACChange{Action: deleteAction, Kind: "API token", Target: name, apply: func(s *rbacState) error {
+			resp, err := s.client.DeleteOrganizationApiTokenWithResponse(httpContext.Background(), s.orgShortName, tokenID)
+			if err != nil {
+				return err
+			}
+			return astrocore.NormalizeAPIError(resp.HTTPResponse, resp.Body)
+		}})
+	}
+	return changes, nil
+}
+
+// currentAPITokenID returns the ID of the API token in the Authorization header value token, or an empty string when
+// the CLI authenticates as a user
+func currentAPITokenID(token string) string {
+	claims := &util.CustomClaims{}
+	if _, _, err := jwt.NewParser().ParseUnverified(strings.TrimPrefix(token, "Bearer "), claims); err != nil {
+		return ""
+	}
+	return claims.APITokenID
+}
+
+// createAccessToken creates an organization API token, grants its workspace roles and prints its value, which is only shown once
+func createAccessToken(t *AccessToken, workspaceRoles []astrocore.ApiTokenWorkspaceRoleRequest, s *rbacState) error {
+	request := astrocore.CreateOrganizationApiTokenRequest{Name: t.Name, Description: &t.Description, Role: t.Role}
+	if t.Expiration != 0 {
+		request.TokenExpiryPeriodInDays = &t.Expiration
+	}
+	resp, err := s.client.CreateOrganizationApiTokenWithResponse(httpContext.Background(), s.orgShortName, request)
+	if err != nil {
+		return err
+	}
+	if err := astrocore.NormalizeAPIError(resp.HTTPResponse, resp.Body); err != nil {
+		return err
+	}
+	token := resp.JSON200
+	if token.Token != nil {
+		fmt.Fprintf(s.out, "Copy and paste the API token %s for your records, you will not be shown it again:\n%s\n", t.Name, *token.Token)
+	}
+	if len(workspaceRoles) == 0 {
+		return nil
+	}
+	update := astrocore.UpdateOrganizationApiTokenRequest{
+		Name:        t.Name,
+		Description: t.Description,
+		Roles:       astrocore.UpdateOrganizationApiTokenRolesRequest{Organization: t.Role, Workspace: &workspaceRoles},
+	}
+	updateResp, err := s.client.UpdateOrganizationApiTokenWithResponse(httpContext.Background(), s.orgShortName, token.Id, update)
+	if err != nil {
+		return err
+	}
+	return astrocore.NormalizeAPIError(updateResp.HTTPResponse, updateResp.Body)
+}
+
+// resolveWorkspace returns the id and the name of a workspace identified by its id or its name
+func resolveWorkspace(id, name string, workspaces []astrocore.Workspace) (workspaceID, workspaceName string, err error) {
+	var found *astrocore.Workspace
+	for i := range workspaces {
+		if id != "" && workspaces[i].Id == id {
+			return workspaces[i].Id, workspaces[i].Name, nil
+		}
+		if id == "" && workspaces[i].Name == name {
+			if found != nil {
+				return "", "", fmt.Errorf("%w: %s", errWorkspaceNameAmbiguous, name)
+			}
+			found = &workspaces[i]
+		}
+	}
+	if found == nil {
+		return "", "", fmt.Errorf("%w: %s", errWorkspaceNotFound, id+name)
+	}
+	return found.Id, found.Name, nil
+}
+
+// tokenRolesDetail describes the roles of an API token, sorting the workspace roles so that it can be used to compare them
+func tokenRolesDetail(orgRole string, workspaceRoles []astrocore.ApiTokenWorkspaceRoleRequest) string {
+	roles := []string{}
+	for _, role := range workspaceRoles {
+		roles = append(roles, role.EntityId+"="+role.Role)
+	}
+	sort.Strings(roles)
+	if orgRole != "" {
+		roles = append([]string{orgRole}, roles...)
+	}
+	return strings.Join(roles, ", ")
+}
+
+func roleChange(from *string, to string) string {
+	if from == nil || *from == "" {
+		return to
+	}
+	return *from + " -> " + to
+}
+
+func stringValue(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
+
+func sortedKeys[T any](m map[string]T) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
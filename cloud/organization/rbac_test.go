@@ -0,0 +1,299 @@
+package organization
+
+import (
+	"bytes"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+
+	astrocore "github.com/astronomer/astro-cli/astro-client-core"
+	astrocore_mocks "github.com/astronomer/astro-cli/astro-client-core/mocks"
+	"github.com/astronomer/astro-cli/context"
+	testUtil "github.com/astronomer/astro-cli/pkg/testing"
+	"github.com/astronomer/astro-cli/pkg/util"
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+var (
+	rbacMemberRole = "ORGANIZATION_MEMBER"
+	rbacOwnerRole  = "ORGANIZATION_OWNER"
+	rbacAuthorRole = "WORKSPACE_AUTHOR"
+
+	rbacAccessFile = `
+users:
+  - email: alice@example.com
+    role: ORGANIZATION_OWNER
+  - email: bob@example.com
+    role: ORGANIZATION_OWNER
+  - email: dave@example.com
+    role: ORGANIZATION_MEMBER
+teams:
+  - name: eng
+    role: ORGANIZATION_MEMBER
+    members: [alice@example.com, bob@example.com]
+  - name: data
+    role: ORGANIZATION_MEMBER
+    members: [alice@example.com, dave@example.com]
+workspaces:
+  - name: sandbox
+    users:
+      - email: alice@example.com
+        role: WORKSPACE_AUTHOR
+      - email: bob@example.com
+        role: WORKSPACE_OWNER
+    teams:
+      - name: data
+        role: WORKSPACE_OPERATOR
+tokens:
+  - name: ci
+    role: ORGANIZATION_MEMBER
+    workspaces:
+      - id: ws-1
+        role: WORKSPACE_AUTHOR
+  - name: deploy
+    role: ORGANIZATION_MEMBER
+    expiration: 30
+`
+)
+
+func writeAccessFile(t *testing.T, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "access.yaml")
+	assert.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+	return path
+}
+
+func mockRBACClient() *astrocore_mocks.ClientWithResponsesInterface {
+	mockClient := new(astrocore_mocks.ClientWithResponsesInterface)
+	mockClient.On("ListOrgUsersWithResponse", mock.Anything, mock.Anything, mock.Anything).Return(&astrocore.ListOrgUsersResponse{
+		HTTPResponse: &http.Response{StatusCode: 200},
+		JSON200: &astrocore.UsersPaginated{TotalCount: 3, Users: []astrocore.User{
+			{Id: "alice-id", Username: "alice@example.com", OrgRole: &rbacMemberRole},
+			{Id: "bob-id", Username: "Bob@example.com", OrgRole: &rbacOwnerRole},
+			{Id: "carol-id", Username: "carol@example.com", OrgRole: &rbacMemberRole},
+		}},
+	}, nil)
+	mockClient.On("ListOrganizationTeamsWithResponse", mock.Anything, mock.Anything, mock.Anything).Return(&astrocore.ListOrganizationTeamsResponse{
+		HTTPResponse: &http.Response{StatusCode: 200},
+		JSON200: &astrocore.TeamsPaginated{TotalCount: 2, Teams: []astrocore.Team{
+			{Id: "eng-id", Name: "eng", OrganizationRole: rbacMemberRole},
+			{Id: "old-id", Name: "old", OrganizationRole: rbacMemberRole},
+		}},
+	}, nil)
+	mockClient.On("GetTeamWithResponse", mock.Anything, mock.Anything, "eng-id").Return(&astrocore.GetTeamResponse{
+		HTTPResponse: &http.Response{StatusCode: 200},
+		JSON200: &astrocore.Team{Id: "eng-id", Name: "eng", OrganizationRole: rbacMemberRole, Members: &[]astrocore.TeamMember{
+			{UserId: "alice-id", Username: "alice@example.com"},
+			{UserId: "carol-id", Username: "carol@example.com"},
+		}},
+	}, nil)
+	mockClient.On("ListWorkspacesWithResponse", mock.Anything, mock.Anything, mock.Anything).Return(&astrocore.ListWorkspacesResponse{
+		HTTPResponse: &http.Response{StatusCode: 200},
+		JSON200:      &astrocore.WorkspacesPaginated{Workspaces: []astrocore.Workspace{{Id: "ws-1", Name: "sandbox"}}},
+	}, nil)
+	mockClient.On("ListWorkspaceUsersWithResponse", mock.Anything, mock.Anything, "ws-1", mock.Anything).Return(&astrocore.ListWorkspaceUsersResponse{
+		HTTPResponse: &http.Response{StatusCode: 200},
+		JSON200: &astrocore.UsersPaginated{TotalCount: 2, Users: []astrocore.User{
+			{Id: "alice-id", Username: "alice@example.com", WorkspaceRole: &rbacAuthorRole},
+			{Id: "carol-id", Username: "carol@example.com", WorkspaceRole: &rbacAuthorRole},
+		}},
+	}, nil)
+	mockClient.On("ListWorkspaceTeamsWithResponse", mock.Anything, mock.Anything, "ws-1", mock.Anything).Return(&astrocore.ListWorkspaceTeamsResponse{
+		HTTPResponse: &http.Response{StatusCode: 200},
+		JSON200:      &astrocore.TeamsPaginated{Teams: []astrocore.Team{}},
+	}, nil)
+	mockClient.On("ListOrganizationApiTokensWithResponse", mock.Anything, mock.Anything, mock.Anything).Return(&astrocore.ListOrganizationApiTokensResponse{
+		HTTPResponse: &http.Response{StatusCode: 200},
+		JSON200: &astrocore.ListApiTokensPaginated{ApiTokens: []astrocore.ApiToken{
+			{Id: "ci-id", Name: "ci", Roles: []astrocore.ApiTokenRole{{EntityType: organizationEntity, Role: rbacMemberRole}}},
+		}},
+	}, nil)
+	return mockClient
+}
+
+func TestLoadAccessFile(t *testing.T) {
+	t.Run("valid file", func(t *testing.T) {
+		file, err := LoadAccessFile(writeAccessFile(t, rbacAccessFile))
+		assert.NoError(t, err)
+		assert.Len(t, file.Users, 3)
+		assert.Equal(t, []string{"alice@example.com", "dave@example.com"}, file.Teams[1].Members)
+		assert.Equal(t, 30, file.Tokens[1].Expiration)
+	})
+
+	t.Run("invalid organization role", func(t *testing.T) {
+		_, err := LoadAccessFile(writeAccessFile(t, "users:\n  - email: a@example.com\n    role: WORKSPACE_OWNER\n"))
+		assert.ErrorContains(t, err, "user a@example.com: requested role is invalid")
+	})
+
+	t.Run("invalid workspace role", func(t *testing.T) {
+		_, err := LoadAccessFile(writeAccessFile(t, "workspaces:\n  - id: ws-1\n    teams:\n      - name: eng\n        role: ORGANIZATION_OWNER\n"))
+		assert.ErrorContains(t, err, "workspace ws-1, team eng: requested role is invalid")
+	})
+
+	t.Run("duplicate user", func(t *testing.T) {
+		_, err := LoadAccessFile(writeAccessFile(t, "users:\n  - email: a@example.com\n    role: ORGANIZATION_OWNER\n  - email: A@example.com\n    role: ORGANIZATION_MEMBER\n"))
+		assert.ErrorIs(t, err, errAccessFileDuplicate)
+	})
+
+	t.Run("unknown field", func(t *testing.T) {
+		_, err := LoadAccessFile(writeAccessFile(t, "user:\n  - email: a@example.com\n"))
+		assert.ErrorContains(t, err, "field user not found")
+	})
+
+	t.Run("workspace without id or name", func(t *testing.T) {
+		_, err := LoadAccessFile(writeAccessFile(t, "workspaces:\n  - users: []\n"))
+		assert.ErrorIs(t, err, errAccessFileWorkspace)
+	})
+}
+
+func TestApplyAccessFile(t *testing.T) {
+	testUtil.InitTestConfig(testUtil.CloudPlatform)
+	path := writeAccessFile(t, rbacAccessFile)
+
+	t.Run("dry run prints the changes", func(t *testing.T) {
+		mockClient := mockRBACClient()
+		out := new(bytes.Buffer)
+		err := ApplyAccessFile(path, RBACApplyOptions{DryRun: true}, out, mockClient)
+		assert.NoError(t, err)
+		assert.Contains(t, out.String(), "Team data: dave@example.com will be added once their invite is accepted")
+		assert.Regexp(t, `update\s+user\s+alice@example.com\s+ORGANIZATION_MEMBER -> ORGANIZATION_OWNER`, out.String())
+		assert.Regexp(t, `invite\s+user\s+dave@example.com\s+ORGANIZATION_MEMBER`, out.String())
+		assert.Regexp(t, `add\s+team member\s+eng\s+bob@example.com`, out.String())
+		assert.Regexp(t, `create\s+team\s+data\s+ORGANIZATION_MEMBER with 1 of 2 members`, out.String())
+		assert.Regexp(t, `add\s+workspace user\s+sandbox/bob@example.com\s+WORKSPACE_OWNER`, out.String())
+		assert.Regexp(t, `add\s+workspace team\s+sandbox/data\s+WORKSPACE_OPERATOR`, out.String())
+		assert.Regexp(t, `update\s+API token\s+ci\s+ORGANIZATION_MEMBER, ws-1=WORKSPACE_AUTHOR`, out.String())
+		assert.Regexp(t, `create\s+API token\s+deploy`, out.String())
+		// unchanged bindings and undeclared entries are left alone without --prune
+		assert.NotContains(t, out.String(), "sandbox/alice@example.com")
+		assert.NotContains(t, out.String(), "carol")
+		mockClient.AssertExpectations(t)
+	})
+
+	t.Run("prune removes undeclared entries", func(t *testing.T) {
+		mockClient := mockRBACClient()
+		out := new(bytes.Buffer)
+		err := ApplyAccessFile(path, RBACApplyOptions{DryRun: true, Prune: true}, out, mockClient)
+		assert.NoError(t, err)
+		assert.Regexp(t, `remove\s+user\s+carol@example.com`, out.String())
+		assert.Regexp(t, `remove\s+team member\s+eng\s+carol@example.com`, out.String())
+		assert.Regexp(t, `delete\s+team\s+old`, out.String())
+		assert.Regexp(t, `remove\s+workspace user\s+sandbox/carol@example.com`, out.String())
+	})
+
+	t.Run("prune keeps referenced users and the current user", func(t *testing.T) {
+		ctx, err := context.GetCurrentContext()
+		assert.NoError(t, err)
+		ctx.UserEmail = "Carol@example.com"
+		assert.NoError(t, ctx.SetContext())
+		defer testUtil.InitTestConfig(testUtil.CloudPlatform)
+
+		mockClient := mockRBACClient()
+		path := writeAccessFile(t, `
+users:
+  - email: alice@example.com
+    role: ORGANIZATION_MEMBER
+teams:
+  - name: eng
+    role: ORGANIZATION_MEMBER
+    members: [alice@example.com, bob@example.com]
+`)
+		out := new(bytes.Buffer)
+		err = ApplyAccessFile(path, RBACApplyOptions{DryRun: true, Prune: true}, out, mockClient)
+		assert.NoError(t, err)
+		assert.NotRegexp(t, `remove\s+user\s+Bob@example.com`, out.String())
+		assert.NotRegexp(t, `remove\s+user\s+carol@example.com`, out.String())
+		assert.NotRegexp(t, `remove\s+team member\s+eng\s+carol@example.com`, out.String())
+		assert.Contains(t, out.String(), "You (carol@example.com) are not in the access file, your own access is left unchanged")
+		assert.Regexp(t, `delete\s+team\s+old`, out.String())
+	})
+
+	t.Run("prune keeps the API token of the current context", func(t *testing.T) {
+		ctx, err := context.GetCurrentContext()
+		assert.NoError(t, err)
+		token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, &util.CustomClaims{APITokenID: "ci-id"}).SignedString([]byte("secret"))
+		assert.NoError(t, err)
+		ctx.Token = "Bearer " + token
+		ctx.UserEmail = ""
+		assert.NoError(t, ctx.SetContext())
+		defer testUtil.InitTestConfig(testUtil.CloudPlatform)
+
+		mockClient := mockRBACClient()
+		path := writeAccessFile(t, "tokens: []\n")
+		out := new(bytes.Buffer)
+		err = ApplyAccessFile(path, RBACApplyOptions{DryRun: true, Prune: true}, out, mockClient)
+		assert.NoError(t, err)
+		assert.NotRegexp(t, `delete\s+API token\s+ci`, out.String())
+		assert.Contains(t, out.String(), "The API token ci is used by this command and is not in the access file, it is left unchanged")
+	})
+
+	t.Run("warns about every removal", func(t *testing.T) {
+		mockClient := mockRBACClient()
+		ok := &http.Response{StatusCode: 200}
+		mockClient.On("DeleteOrganizationApiTokenWithResponse", mock.Anything, mock.Anything, "ci-id").Return(&astrocore.DeleteOrganizationApiTokenResponse{HTTPResponse: ok}, nil).Once()
+		path := writeAccessFile(t, "tokens: []\n")
+		defer testUtil.MockUserInput(t, "y")()
+		out := new(bytes.Buffer)
+		err := ApplyAccessFile(path, RBACApplyOptions{Prune: true}, out, mockClient)
+		assert.NoError(t, err)
+		assert.Contains(t, out.String(), "WARNING: 1 of these changes remove access and cannot be undone:\n  delete API token ci\n")
+		assert.Contains(t, out.String(), "delete API token ci: done")
+	})
+
+	t.Run("applies the changes", func(t *testing.T) {
+		mockClient := mockRBACClient()
+		ok := &http.Response{StatusCode: 200}
+		mockClient.On("MutateOrgUserRoleWithResponse", mock.Anything, mock.Anything, "alice-id", astrocore.MutateOrgUserRoleRequest{Role: rbacOwnerRole}).Return(&astrocore.MutateOrgUserRoleResponse{HTTPResponse: ok}, nil).Once()
+		mockClient.On("CreateUserInviteWithResponse", mock.Anything, mock.Anything, astrocore.CreateUserInviteRequest{InviteeEmail: "dave@example.com", Role: rbacMemberRole}).Return(&astrocore.CreateUserInviteResponse{HTTPResponse: ok}, nil).Once()
+		mockClient.On("AddTeamMembersWithResponse", mock.Anything, mock.Anything, "eng-id", astrocore.AddTeamMembersRequest{MemberIds: []string{"bob-id"}}).Return(&astrocore.AddTeamMembersResponse{HTTPResponse: ok}, nil).Once()
+		mockClient.On("CreateTeamWithResponse", mock.Anything, mock.Anything, mock.MatchedBy(func(r astrocore.CreateTeamRequest) bool {
+			return r.Name == "data" && *r.MemberIds != nil && (*r.MemberIds)[0] == "alice-id"
+		})).Return(&astrocore.CreateTeamResponse{HTTPResponse: ok, JSON200: &astrocore.Team{Id: "data-id"}}, nil).Once()
+		mockClient.On("MutateWorkspaceUserRoleWithResponse", mock.Anything, mock.Anything, "ws-1", "bob-id", astrocore.MutateWorkspaceUserRoleRequest{Role: "WORKSPACE_OWNER"}).Return(&astrocore.MutateWorkspaceUserRoleResponse{HTTPResponse: ok}, nil).Once()
+		// the team is bound with the ID it was created with
+		mockClient.On("MutateWorkspaceTeamRoleWithResponse", mock.Anything, mock.Anything, "ws-1", "data-id", astrocore.MutateWorkspaceTeamRoleRequest{Role: "WORKSPACE_OPERATOR"}).Return(&astrocore.MutateWorkspaceTeamRoleResponse{HTTPResponse: ok}, nil).Once()
+		mockClient.On("UpdateOrganizationApiTokenWithResponse", mock.Anything, mock.Anything, "ci-id", mock.Anything).Return(&astrocore.UpdateOrganizationApiTokenResponse{HTTPResponse: ok}, nil).Once()
+		newToken := "new-token"
+		mockClient.On("CreateOrganizationApiTokenWithResponse", mock.Anything, mock.Anything, mock.MatchedBy(func(r astrocore.CreateOrganizationApiTokenRequest) bool {
+			return r.Name == "deploy" && *r.TokenExpiryPeriodInDays == 30
+		})).Return(&astrocore.CreateOrganizationApiTokenResponse{HTTPResponse: ok, JSON200: &astrocore.ApiToken{Id: "deploy-id", Token: &newToken}}, nil).Once()
+
+		out := new(bytes.Buffer)
+		err := ApplyAccessFile(path, RBACApplyOptions{Force: true}, out, mockClient)
+		assert.NoError(t, err)
+		assert.Contains(t, out.String(), "you will not be shown it again:\nnew-token")
+		mockClient.AssertExpectations(t)
+	})
+
+	t.Run("reports failed changes", func(t *testing.T) {
+		mockClient := mockRBACClient()
+		path := writeAccessFile(t, "users:\n  - email: alice@example.com\n    role: ORGANIZATION_OWNER\n")
+		mockClient.On("MutateOrgUserRoleWithResponse", mock.Anything, mock.Anything, "alice-id", mock.Anything).Return(&astrocore.MutateOrgUserRoleResponse{
+			HTTPResponse: &http.Response{StatusCode: 403},
+			Body:         []byte(`{"message": "forbidden"}`),
+		}, nil).Once()
+		out := new(bytes.Buffer)
+		err := ApplyAccessFile(path, RBACApplyOptions{Force: true}, out, mockClient)
+		assert.ErrorIs(t, err, errRBACApplyFailed)
+		assert.Contains(t, out.String(), "Failed to update user alice@example.com: forbidden")
+	})
+
+	t.Run("unknown team binding", func(t *testing.T) {
+		mockClient := mockRBACClient()
+		path := writeAccessFile(t, "workspaces:\n  - id: ws-1\n    teams:\n      - name: missing\n        role: WORKSPACE_MEMBER\n")
+		err := ApplyAccessFile(path, RBACApplyOptions{DryRun: true}, new(bytes.Buffer), mockClient)
+		assert.ErrorIs(t, err, errAccessFileUnknownTeam)
+	})
+
+	t.Run("no changes", func(t *testing.T) {
+		mockClient := mockRBACClient()
+		path := writeAccessFile(t, "users:\n  - email: bob@example.com\n    role: ORGANIZATION_OWNER\n")
+		out := new(bytes.Buffer)
+		err := ApplyAccessFile(path, RBACApplyOptions{}, out, mockClient)
+		assert.NoError(t, err)
+		assert.Contains(t, out.String(), "No changes, the organization already matches the access file")
+	})
+}
@@ -0,0 +1,400 @@
+package organization
+
+import (
+	httpContext "context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"regexp"
+	"runtime"
+	"strings"
+	"time"
+
+	astrocore "github.com/astronomer/astro-cli/astro-client-core"
+	"github.com/astronomer/astro-cli/cloud/user"
+	"github.com/astronomer/astro-cli/context"
+	"github.com/astronomer/astro-cli/pkg/input"
+	"github.com/astronomer/astro-cli/pkg/printutil"
+)
+
+const (
+	tokenSinkCommandPrefix = "cmd:"
+
+	organizationOwnerRole = "ORGANIZATION_OWNER"
+	workspaceOwnerRole    = "WORKSPACE_OWNER"
+)
+
+var (
+	errNoTokenSink          = errors.New("bulk token rotation requires --write-to, a file or cmd:<command> receiving the new tokens")
+	errTokenRotateFailed    = errors.New("some API tokens could not be rotated")
+	errTokenEnvKeyCollision = errors.New("several API tokens would be written to the same env file key, rename them or use --write-to cmd:<command>")
+	errOrganizationNotFound = errors.New("no organization was found for the id you provided")
+
+	tokenAuditNow = time.Now
+
+	envKeyInvalidChars = regexp.MustCompile(`[^A-Z0-9_]+`)
+)
+
+// TokenAuditOptions are the criteria used to flag API tokens
+type TokenAuditOptions struct {
+	// OrganizationID is the organization whose tokens are audited, the current one when empty
+	OrganizationID string
+	// ExpiringWithin flags tokens expiring in less than this duration, 0 disables the check
+	ExpiringWithin time.Duration
+	// UnusedFor flags tokens which have not been used for this duration, 0 disables the check
+	UnusedFor time.Duration
+	// IncludeOverBroad flags tokens with an owner role
+	IncludeOverBroad bool
+}
+
+// AuditedToken is an organization or workspace API token, with the reasons it was flagged
+type AuditedToken struct {
+	Token       astrocore.ApiToken
+	WorkspaceID string
+	Findings    []string
+}
+
+// GetAuditedTokens returns the organization and workspace API tokens matching at least one of the audit criteria
+func GetAuditedTokens(opts TokenAuditOptions, client astrocore.CoreClient) ([]AuditedToken, error) {
+	orgShortName, err := tokenAuditOrganization(opts.OrganizationID, client)
+	if err != nil {
+		return nil, err
+	}
+	return getAuditedTokens(orgShortName, opts, client)
+}
+
+func getAuditedTokens(orgShortName string, opts TokenAuditOptions, client astrocore.CoreClient) ([]AuditedToken, error) {
+	tokens, err := getAllTokens(orgShortName, client)
+	if err != nil {
+		return nil, err
+	}
+	now := tokenAuditNow()
+	audited := []AuditedToken{}
+	for i := range tokens {
+		tokens[i].Findings = auditToken(&tokens[i].Token, opts, now)
+		if len(tokens[i].Findings) > 0 {
+			audited = append(audited, tokens[i])
+		}
+	}
+	return audited, nil
+}
+
+// AuditTokens prints the organization and workspace API tokens which expire soon, are unused or have over-broad roles
+func AuditTokens(opts TokenAuditOptions, out io.Writer, client astrocore.CoreClient) error {
+	audited, err := GetAuditedTokens(opts, client)
+	if err != nil {
+		return err
+	}
+	tab := printutil.Table{
+		DynamicPadding: true,
+		Header:         []string{"ID", "NAME", "SCOPE", "WORKSPACE ID", "EXPIRES", "LAST USED", "FINDINGS"},
+		NoResultsMsg:   "No API tokens need attention",
+	}
+	for i := range audited {
+		token := &audited[i].Token
+		expires := "Never"
+		if token.EndAt != nil {
+			expires = token.EndAt.Format(time.RFC3339)
+		}
+		lastUsed := "Never"
+		if token.LastUsedAt != nil {
+			lastUsed = TimeAgo(*token.LastUsedAt)
+		}
+		tab.AddRow([]string{token.Id, token.Name, string(token.Type), audited[i].WorkspaceID, expires, lastUsed, strings.Join(audited[i].Findings, "; ")}, false)
+	}
+	return tab.Print(out)
+}
+
+// RotateExpiringTokens rotates every organization and workspace API token expiring within the given duration and
+// hands each new token to writeTo, either a file in the env file format or a command prefixed with cmd:. Tokens which
+// have already expired are not rotated, they are only listed since whatever used them is already broken.
+func RotateExpiringTokens(organizationID string, expiringWithin time.Duration, writeTo string, force bool, out io.Writer, client astrocore.CoreClient) error {
+	if writeTo == "" {
+		return errNoTokenSink
+	}
+	orgShortName, err := tokenAuditOrganization(organizationID, client)
+	if err != nil {
+		return err
+	}
+	tokens, err := getAuditedTokens(orgShortName, TokenAuditOptions{ExpiringWithin: expiringWithin}, client)
+	if err != nil {
+		return err
+	}
+	now := tokenAuditNow()
+	audited := []AuditedToken{}
+	for i := range tokens {
+		if tokens[i].Token.EndAt != nil && !tokens[i].Token.EndAt.After(now) {
+			fmt.Fprintf(out, "API token %s (%s) has already expired and will not be rotated, create a new token instead\n", tokens[i].Token.Name, tokens[i].Token.Id)
+			continue
+		}
+		audited = append(audited, tokens[i])
+	}
+	if len(audited) == 0 {
+		fmt.Fprintln(out, "No API tokens expire within "+expiringWithin.String())
+		return nil
+	}
+	if !strings.HasPrefix(writeTo, tokenSinkCommandPrefix) {
+		if err := checkTokenEnvKeys(audited); err != nil {
+			return err
+		}
+	}
+
+	if !force {
+		fmt.Fprintln(out, "The following API tokens will be rotated:")
+		for i := range audited {
+			fmt.Fprintf(out, "  %s (%s): %s\n", audited[i].Token.Name, audited[i].Token.Id, strings.Join(audited[i].Findings, "; "))
+		}
+		fmt.Fprintln(out, "WARNING: API Token rotation will invalidate the current tokens and cannot be undone.")
+		if ok, _ := input.Confirm(fmt.Sprintf("\nAre you sure you want to rotate %d API tokens?", len(audited))); !ok {
+			fmt.Fprintln(out, "Canceling token rotation")
+			return nil
+		}
+	}
+
+	failed := 0
+	for i := range audited {
+		token := &audited[i].Token
+		newToken, err := rotateToken(orgShortName, audited[i].WorkspaceID, token.Id, client)
+		if err != nil {
+			failed++
+			fmt.Fprintf(out, "Failed to rotate API token %s (%s): %s\n", token.Name, token.Id, err.Error())
+			continue
+		}
+		if err := writeTokenToSink(writeTo, &audited[i], newToken); err != nil {
+			// the previous token is already invalid, so the new one must not be lost
+			failed++
+			fmt.Fprintf(out, "API token %s (%s) was rotated but could not be written to %s: %s\nCopy and paste this API token for your records, you will not be shown it again:\n%s\n",
+				token.Name, token.Id, writeTo, err.Error(), newToken)
+			continue
+		}
+		fmt.Fprintf(out, "API token %s (%s) was successfully rotated\n", token.Name, token.Id)
+	}
+	if failed > 0 {
+		return fmt.Errorf("%w: %d of %d failed", errTokenRotateFailed, failed, len(audited))
+	}
+	return nil
+}
+
+// tokenAuditOrganization returns the short name of the organization organizationID, or of the current one when it is empty
+func tokenAuditOrganization(organizationID string, client astrocore.CoreClient) (string, error) {
+	if organizationID == "" {
+		ctx, err := context.GetCurrentContext()
+		if err != nil {
+			return "", err
+		}
+		if ctx.OrganizationShortName == "" {
+			return "", user.ErrNoShortName
+		}
+		return ctx.OrganizationShortName, nil
+	}
+	orgs, err := ListOrganizations(client)
+	if err != nil {
+		return "", err
+	}
+	for i := range orgs {
+		if orgs[i].Id == organizationID {
+			return orgs[i].ShortName, nil
+		}
+	}
+	return "", fmt.Errorf("%w: %s", errOrganizationNotFound, organizationID)
+}
+
+// getAllTokens returns the organization API tokens and the API tokens of every workspace of the organization
+func getAllTokens(orgShortName string, client astrocore.CoreClient) ([]AuditedToken, error) {
+	orgResp, err := client.ListOrganizationApiTokensWithResponse(httpContext.Background(), orgShortName, &astrocore.ListOrganizationApiTokensParams{})
+	if err != nil {
+		return nil, err
+	}
+	if err := astrocore.NormalizeAPIError(orgResp.HTTPResponse, orgResp.Body); err != nil {
+		return nil, err
+	}
+	tokens := []AuditedToken{}
+	seen := map[string]bool{}
+	for _, token := range orgResp.JSON200.ApiTokens {
+		seen[token.Id] = true
+		tokens = append(tokens, AuditedToken{Token: token})
+	}
+
+	limit := 1000
+	sorts := []astrocore.ListWorkspacesParamsSorts{"name:asc"}
+	wsResp, err := client.ListWorkspacesWithResponse(httpContext.Background(), orgShortName, &astrocore.ListWorkspacesParams{Limit: &limit, Sorts: &sorts})
+	if err != nil {
+		return nil, err
+	}
+	if err := astrocore.NormalizeAPIError(wsResp.HTTPResponse, wsResp.Body); err != nil {
+		return nil, err
+	}
+	workspaces := wsResp.JSON200.Workspaces
+	for i := range workspaces {
+		resp, err := client.ListWorkspaceApiTokensWithResponse(httpContext.Background(), orgShortName, workspaces[i].Id, &astrocore.ListWorkspaceApiTokensParams{})
+		if err != nil {
+			return nil, err
+		}
+		if err := astrocore.NormalizeAPIError(resp.HTTPResponse, resp.Body); err != nil {
+			return nil, err
+		}
+		for j := range resp.JSON200.ApiTokens {
+			token := resp.JSON200.ApiTokens[j]
+			// organization tokens added to a workspace are listed with the workspace tokens
+			if seen[token.Id] {
+				continue
+			}
+			seen[token.Id] = true
+			tokens = append(tokens, AuditedToken{Token: token, WorkspaceID: workspaces[i].Id})
+		}
+	}
+	return tokens, nil
+}
+
+func auditToken(token *astrocore.ApiToken, opts TokenAuditOptions, now time.Time) []string {
+	findings := []string{}
+	if opts.ExpiringWithin > 0 && token.EndAt != nil {
+		remaining := token.EndAt.Sub(now)
+		switch {
+		case remaining <= 0:
+			findings = append(findings, "expired")
+		case remaining <= opts.ExpiringWithin:
+			findings = append(findings, fmt.Sprintf("expires in %s", formatDays(remaining)))
+		}
+	}
+	if opts.UnusedFor > 0 {
+		switch {
+		case token.LastUsedAt == nil && now.Sub(token.CreatedAt) > opts.UnusedFor:
+			findings = append(findings, "never used")
+		case token.LastUsedAt != nil && now.Sub(*token.LastUsedAt) > opts.UnusedFor:
+			findings = append(findings, fmt.Sprintf("unused for %s", formatDays(now.Sub(*token.LastUsedAt))))
+		}
+	}
+	if opts.IncludeOverBroad {
+		for _, role := range token.Roles {
+			if role.Role == organizationOwnerRole || role.Role == workspaceOwnerRole {
+				findings = append(findings, "over-broad role "+role.Role)
+			}
+		}
+	}
+	return findings
+}
+
+func formatDays(d time.Duration) string {
+	days := int(d.Hours() / 24) //nolint:gomnd
+	if days < 1 {
+		return "less than a day"
+	}
+	if days == 1 {
+		return "1 day"
+	}
+	return fmt.Sprintf("%d days", days)
+}
+
+func rotateToken(orgShortName, workspaceID, tokenID string, client astrocore.CoreClient) (string, error) {
+	if workspaceID == "" {
+		resp, err := client.RotateOrganizationApiTokenWithResponse(httpContext.Background(), orgShortName, tokenID)
+		if err != nil {
+			return "", err
+		}
+		if err := astrocore.NormalizeAPIError(resp.HTTPResponse, resp.Body); err != nil {
+			return "", err
+		}
+		return *resp.JSON200.Token, nil
+	}
+	resp, err := client.RotateWorkspaceApiTokenWithResponse(httpContext.Background(), orgShortName, workspaceID, tokenID)
+	if err != nil {
+		return "", err
+	}
+	if err := astrocore.NormalizeAPIError(resp.HTTPResponse, resp.Body); err != nil {
+		return "", err
+	}
+	return *resp.JSON200.Token, nil
+}
+
+// writeTokenToSink hands a new token to a command, on its stdin, or stores it in an env file under a key derived from its name
+func writeTokenToSink(writeTo string, audited *AuditedToken, newToken string) error {
+	if strings.HasPrefix(writeTo, tokenSinkCommandPrefix) {
+		return runTokenSinkCommand(strings.TrimPrefix(writeTo, tokenSinkCommandPrefix), audited, newToken)
+	}
+	return writeEnvFileKey(writeTo, TokenEnvKey(audited.Token.Name), newToken)
+}
+
+func runTokenSinkCommand(command string, audited *AuditedToken, newToken string) error {
+	var cmd *exec.Cmd
+	if runtime.GOOS == "windows" {
+		cmd = exec.Command("cmd", "/C", command)
+	} else {
+		cmd = exec.Command("sh", "-c", command)
+	}
+	cmd.Stdin = strings.NewReader(newToken)
+	cmd.Stdout = os.Stderr
+	cmd.Stderr = os.Stderr
+	cmd.Env = append(os.Environ(),
+		"ASTRO_API_TOKEN_ID="+audited.Token.Id,
+		"ASTRO_API_TOKEN_NAME="+audited.Token.Name,
+		"ASTRO_API_TOKEN_ENV_KEY="+TokenEnvKey(audited.Token.Name),
+		"ASTRO_API_TOKEN_SCOPE="+string(audited.Token.Type),
+		"ASTRO_API_TOKEN_WORKSPACE_ID="+audited.WorkspaceID,
+	)
+	return cmd.Run()
+}
+
+// checkTokenEnvKeys makes sure no two tokens are written to the same env file key, which would lose one of the new tokens
+func checkTokenEnvKeys(audited []AuditedToken) error {
+	keys := map[string]*astrocore.ApiToken{}
+	for i := range audited {
+		key := TokenEnvKey(audited[i].Token.Name)
+		if other, ok := keys[key]; ok {
+			return fmt.Errorf("%w: %s (%s) and %s (%s) are both written to %s", errTokenEnvKeyCollision, other.Name, other.Id, audited[i].Token.Name, audited[i].Token.Id, key)
+		}
+		keys[key] = &audited[i].Token
+	}
+	return nil
+}
+
+// TokenEnvKey returns the environment variable name a token is stored under, e.g. "ci deploy" becomes CI_DEPLOY
+func TokenEnvKey(tokenName string) string {
+	key := strings.Trim(envKeyInvalidChars.ReplaceAllString(strings.ToUpper(tokenName), "_"), "_")
+	if key == "" || (key[0] >= '0' && key[0] <= '9') {
+		key = "ASTRO_API_TOKEN_" + key
+	}
+	return key
+}
+
+// writeEnvFileKey sets a key of an env file, replacing its previous value if there is one
+func writeEnvFileKey(path, key, value string) error {
+	var filePerms os.FileMode = 0o600
+	content, err := os.ReadFile(path)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+	lines := []string{}
+	if len(content) > 0 {
+		lines = strings.Split(strings.TrimSuffix(string(content), "\n"), "\n")
+	}
+	line := key + "=" + value
+	replaced := false
+	for i := range lines {
+		if strings.HasPrefix(lines[i], key+"=") {
+			lines[i] = line
+			replaced = true
+		}
+	}
+	if !replaced {
+		lines = append(lines, line)
+	}
+
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, filePerms)
+	if err != nil {
+		return err
+	}
+	// the permissions passed to OpenFile only apply to new files, an existing env file is restricted before the
+	// secrets are written to it
+	if err := f.Chmod(filePerms); err != nil {
+		f.Close()
+		return err
+	}
+	if _, err := f.WriteString(strings.Join(lines, "\n") + "\n"); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
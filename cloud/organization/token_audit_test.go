@@ -0,0 +1,223 @@
+package organization
+
+import (
+	"bytes"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	astrocore "github.com/astronomer/astro-cli/astro-client-core"
+	astrocore_mocks "github.com/astronomer/astro-cli/astro-client-core/mocks"
+	testUtil "github.com/astronomer/astro-cli/pkg/testing"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+var auditNow = time.Date(2023, 6, 1, 0, 0, 0, 0, time.UTC)
+
+func auditTime(days int) *time.Time {
+	t := auditNow.Add(time.Duration(days) * 24 * time.Hour)
+	return &t
+}
+
+func mockTokenAuditClient(orgTokens, workspaceTokens []astrocore.ApiToken) *astrocore_mocks.ClientWithResponsesInterface {
+	mockClient := new(astrocore_mocks.ClientWithResponsesInterface)
+	mockClient.On("ListOrganizationApiTokensWithResponse", mock.Anything, mock.Anything, mock.Anything).Return(&astrocore.ListOrganizationApiTokensResponse{
+		HTTPResponse: &http.Response{StatusCode: 200},
+		JSON200:      &astrocore.ListApiTokensPaginated{ApiTokens: orgTokens},
+	}, nil)
+	mockClient.On("ListWorkspacesWithResponse", mock.Anything, mock.Anything, mock.Anything).Return(&astrocore.ListWorkspacesResponse{
+		HTTPResponse: &http.Response{StatusCode: 200},
+		JSON200:      &astrocore.WorkspacesPaginated{Workspaces: []astrocore.Workspace{{Id: "ws-1", Name: "ws"}}},
+	}, nil)
+	mockClient.On("ListWorkspaceApiTokensWithResponse", mock.Anything, mock.Anything, "ws-1", mock.Anything).Return(&astrocore.ListWorkspaceApiTokensResponse{
+		HTTPResponse: &http.Response{StatusCode: 200},
+		JSON200:      &astrocore.ListApiTokensPaginated{ApiTokens: workspaceTokens},
+	}, nil)
+	return mockClient
+}
+
+func useAuditNow(t *testing.T) {
+	t.Helper()
+	orgNow := tokenAuditNow
+	tokenAuditNow = func() time.Time { return auditNow }
+	t.Cleanup(func() { tokenAuditNow = orgNow })
+}
+
+var (
+	expiringOrgToken = astrocore.ApiToken{Id: "org-expiring", Name: "ci deploy", Type: astrocore.ApiTokenTypeORGANIZATION, CreatedAt: *auditTime(-10), LastUsedAt: auditTime(-1), EndAt: auditTime(3),
+		Roles: []astrocore.ApiTokenRole{{EntityType: astrocore.ApiTokenRoleEntityTypeORGANIZATION, Role: "ORGANIZATION_MEMBER"}}}
+	ownerOrgToken = astrocore.ApiToken{Id: "org-owner", Name: "admin", Type: astrocore.ApiTokenTypeORGANIZATION, CreatedAt: *auditTime(-10), LastUsedAt: auditTime(-1),
+		Roles: []astrocore.ApiTokenRole{{EntityType: astrocore.ApiTokenRoleEntityTypeORGANIZATION, Role: "ORGANIZATION_OWNER"}}}
+	neverUsedWorkspaceToken = astrocore.ApiToken{Id: "ws-never-used", Name: "never", Type: astrocore.ApiTokenTypeWORKSPACE, CreatedAt: *auditTime(-100),
+		Roles: []astrocore.ApiTokenRole{{EntityType: astrocore.ApiTokenRoleEntityTypeWORKSPACE, EntityId: "ws-1", Role: "WORKSPACE_OWNER"}}}
+	unusedWorkspaceToken = astrocore.ApiToken{Id: "ws-unused", Name: "old", Type: astrocore.ApiTokenTypeWORKSPACE, CreatedAt: *auditTime(-200), LastUsedAt: auditTime(-120),
+		Roles: []astrocore.ApiTokenRole{{EntityType: astrocore.ApiTokenRoleEntityTypeWORKSPACE, EntityId: "ws-1", Role: "WORKSPACE_MEMBER"}}}
+	expiredWorkspaceToken = astrocore.ApiToken{Id: "ws-expired", Name: "expired", Type: astrocore.ApiTokenTypeWORKSPACE, CreatedAt: *auditTime(-20), EndAt: auditTime(-1),
+		Roles: []astrocore.ApiTokenRole{{EntityType: astrocore.ApiTokenRoleEntityTypeWORKSPACE, EntityId: "ws-1", Role: "WORKSPACE_MEMBER"}}}
+)
+
+func TestAuditTokens(t *testing.T) {
+	testUtil.InitTestConfig(testUtil.CloudPlatform)
+	useAuditNow(t)
+
+	t.Run("flags expiring, unused and owner tokens", func(t *testing.T) {
+		mockClient := mockTokenAuditClient([]astrocore.ApiToken{expiringOrgToken, ownerOrgToken}, []astrocore.ApiToken{expiringOrgToken, unusedWorkspaceToken, expiredWorkspaceToken, neverUsedWorkspaceToken})
+		audited, err := GetAuditedTokens(TokenAuditOptions{ExpiringWithin: 7 * 24 * time.Hour, UnusedFor: 90 * 24 * time.Hour, IncludeOverBroad: true}, mockClient)
+		assert.NoError(t, err)
+		assert.Equal(t, []AuditedToken{
+			{Token: expiringOrgToken, Findings: []string{"expires in 3 days"}},
+			{Token: ownerOrgToken, Findings: []string{"over-broad role ORGANIZATION_OWNER"}},
+			{Token: unusedWorkspaceToken, WorkspaceID: "ws-1", Findings: []string{"unused for 120 days"}},
+			{Token: expiredWorkspaceToken, WorkspaceID: "ws-1", Findings: []string{"expired"}},
+			{Token: neverUsedWorkspaceToken, WorkspaceID: "ws-1", Findings: []string{"never used", "over-broad role WORKSPACE_OWNER"}},
+		}, audited)
+	})
+
+	t.Run("prints a table", func(t *testing.T) {
+		mockClient := mockTokenAuditClient([]astrocore.ApiToken{expiringOrgToken, ownerOrgToken}, nil)
+		out := new(bytes.Buffer)
+		err := AuditTokens(TokenAuditOptions{ExpiringWithin: 7 * 24 * time.Hour}, out, mockClient)
+		assert.NoError(t, err)
+		assert.Contains(t, out.String(), "org-expiring")
+		assert.Contains(t, out.String(), "expires in 3 days")
+		assert.NotContains(t, out.String(), "org-owner")
+	})
+
+	t.Run("nothing to report", func(t *testing.T) {
+		mockClient := mockTokenAuditClient([]astrocore.ApiToken{ownerOrgToken}, nil)
+		out := new(bytes.Buffer)
+		err := AuditTokens(TokenAuditOptions{ExpiringWithin: 7 * 24 * time.Hour}, out, mockClient)
+		assert.NoError(t, err)
+		assert.Contains(t, out.String(), "No API tokens need attention")
+	})
+}
+
+func TestRotateExpiringTokens(t *testing.T) {
+	testUtil.InitTestConfig(testUtil.CloudPlatform)
+	useAuditNow(t)
+	newOrgToken := "new-org-token"
+	newWorkspaceToken := "new-workspace-token"
+
+	t.Run("writes rotated tokens to an env file", func(t *testing.T) {
+		envFile := filepath.Join(t.TempDir(), "tokens.env")
+		// an existing env file readable by others is restricted to the current user
+		assert.NoError(t, os.WriteFile(envFile, []byte("OTHER=value\nCI_DEPLOY=old\n"), 0o644)) //nolint:gosec
+
+		expiringWorkspaceToken := astrocore.ApiToken{Id: "ws-expiring", Name: "ws deploy", Type: astrocore.ApiTokenTypeWORKSPACE, CreatedAt: *auditTime(-10), EndAt: auditTime(1)}
+		mockClient := mockTokenAuditClient([]astrocore.ApiToken{expiringOrgToken, ownerOrgToken}, []astrocore.ApiToken{expiringWorkspaceToken, expiredWorkspaceToken})
+		mockClient.On("RotateOrganizationApiTokenWithResponse", mock.Anything, mock.Anything, "org-expiring").Return(&astrocore.RotateOrganizationApiTokenResponse{
+			HTTPResponse: &http.Response{StatusCode: 200},
+			JSON200:      &astrocore.ApiToken{Token: &newOrgToken},
+		}, nil).Once()
+		mockClient.On("RotateWorkspaceApiTokenWithResponse", mock.Anything, mock.Anything, "ws-1", "ws-expiring").Return(&astrocore.RotateWorkspaceApiTokenResponse{
+			HTTPResponse: &http.Response{StatusCode: 200},
+			JSON200:      &astrocore.ApiToken{Token: &newWorkspaceToken},
+		}, nil).Once()
+
+		out := new(bytes.Buffer)
+		err := RotateExpiringTokens("", 7*24*time.Hour, envFile, true, out, mockClient)
+		assert.NoError(t, err)
+		content, err := os.ReadFile(envFile)
+		assert.NoError(t, err)
+		assert.Equal(t, "OTHER=value\nCI_DEPLOY=new-org-token\nWS_DEPLOY=new-workspace-token\n", string(content))
+		info, err := os.Stat(envFile)
+		assert.NoError(t, err)
+		assert.Equal(t, os.FileMode(0o600), info.Mode().Perm())
+		assert.NotContains(t, out.String(), newOrgToken)
+		assert.Contains(t, out.String(), "API token expired (ws-expired) has already expired and will not be rotated")
+		mockClient.AssertExpectations(t)
+	})
+
+	t.Run("fails before rotating tokens sharing an env file key", func(t *testing.T) {
+		sameNameToken := expiringOrgToken
+		sameNameToken.Id = "ws-same-name"
+		mockClient := mockTokenAuditClient([]astrocore.ApiToken{expiringOrgToken}, []astrocore.ApiToken{sameNameToken})
+
+		err := RotateExpiringTokens("", 7*24*time.Hour, filepath.Join(t.TempDir(), "tokens.env"), true, new(bytes.Buffer), mockClient)
+		assert.ErrorIs(t, err, errTokenEnvKeyCollision)
+		assert.Contains(t, err.Error(), "CI_DEPLOY")
+		mockClient.AssertNotCalled(t, "RotateOrganizationApiTokenWithResponse", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("rotates the tokens of another organization", func(t *testing.T) {
+		mockClient := mockTokenAuditClient([]astrocore.ApiToken{expiringOrgToken}, nil)
+		mockClient.On("ListOrganizationsWithResponse", mock.Anything, mock.Anything).Return(&astrocore.ListOrganizationsResponse{
+			HTTPResponse: &http.Response{StatusCode: 200},
+			JSON200:      &[]astrocore.Organization{{Id: "other-org-id", ShortName: "other"}},
+		}, nil).Once()
+		mockClient.On("RotateOrganizationApiTokenWithResponse", mock.Anything, "other", "org-expiring").Return(&astrocore.RotateOrganizationApiTokenResponse{
+			HTTPResponse: &http.Response{StatusCode: 200},
+			JSON200:      &astrocore.ApiToken{Token: &newOrgToken},
+		}, nil).Once()
+
+		err := RotateExpiringTokens("other-org-id", 7*24*time.Hour, filepath.Join(t.TempDir(), "tokens.env"), true, new(bytes.Buffer), mockClient)
+		assert.NoError(t, err)
+		mockClient.AssertCalled(t, "ListOrganizationApiTokensWithResponse", mock.Anything, "other", mock.Anything)
+		mockClient.AssertExpectations(t)
+	})
+
+	t.Run("unknown organization", func(t *testing.T) {
+		mockClient := new(astrocore_mocks.ClientWithResponsesInterface)
+		mockClient.On("ListOrganizationsWithResponse", mock.Anything, mock.Anything).Return(&astrocore.ListOrganizationsResponse{
+			HTTPResponse: &http.Response{StatusCode: 200},
+			JSON200:      &[]astrocore.Organization{{Id: "other-org-id", ShortName: "other"}},
+		}, nil).Once()
+
+		err := RotateExpiringTokens("unknown", 7*24*time.Hour, "tokens.env", true, new(bytes.Buffer), mockClient)
+		assert.ErrorIs(t, err, errOrganizationNotFound)
+	})
+
+	t.Run("hands rotated tokens to a command", func(t *testing.T) {
+		if _, err := os.Stat("/bin/sh"); err != nil {
+			t.Skip("requires a POSIX shell")
+		}
+		outFile := filepath.Join(t.TempDir(), "out")
+		mockClient := mockTokenAuditClient([]astrocore.ApiToken{expiringOrgToken}, nil)
+		mockClient.On("RotateOrganizationApiTokenWithResponse", mock.Anything, mock.Anything, "org-expiring").Return(&astrocore.RotateOrganizationApiTokenResponse{
+			HTTPResponse: &http.Response{StatusCode: 200},
+			JSON200:      &astrocore.ApiToken{Token: &newOrgToken},
+		}, nil).Once()
+
+		err := RotateExpiringTokens("", 7*24*time.Hour, `cmd:echo "$ASTRO_API_TOKEN_ENV_KEY=$(cat)" > `+outFile, true, new(bytes.Buffer), mockClient)
+		assert.NoError(t, err)
+		content, err := os.ReadFile(outFile)
+		assert.NoError(t, err)
+		assert.Equal(t, "CI_DEPLOY=new-org-token\n", string(content))
+	})
+
+	t.Run("keeps the token when the sink fails", func(t *testing.T) {
+		mockClient := mockTokenAuditClient([]astrocore.ApiToken{expiringOrgToken}, nil)
+		mockClient.On("RotateOrganizationApiTokenWithResponse", mock.Anything, mock.Anything, "org-expiring").Return(&astrocore.RotateOrganizationApiTokenResponse{
+			HTTPResponse: &http.Response{StatusCode: 200},
+			JSON200:      &astrocore.ApiToken{Token: &newOrgToken},
+		}, nil).Once()
+
+		out := new(bytes.Buffer)
+		err := RotateExpiringTokens("", 7*24*time.Hour, filepath.Join(t.TempDir(), "missing", "tokens.env"), true, out, mockClient)
+		assert.ErrorIs(t, err, errTokenRotateFailed)
+		assert.Contains(t, out.String(), newOrgToken)
+	})
+
+	t.Run("requires a sink", func(t *testing.T) {
+		err := RotateExpiringTokens("", 7*24*time.Hour, "", true, new(bytes.Buffer), new(astrocore_mocks.ClientWithResponsesInterface))
+		assert.ErrorIs(t, err, errNoTokenSink)
+	})
+
+	t.Run("nothing to rotate", func(t *testing.T) {
+		mockClient := mockTokenAuditClient([]astrocore.ApiToken{ownerOrgToken}, nil)
+		out := new(bytes.Buffer)
+		err := RotateExpiringTokens("", 7*24*time.Hour, "tokens.env", false, out, mockClient)
+		assert.NoError(t, err)
+		assert.Contains(t, out.String(), "No API tokens expire within")
+	})
+}
+
+func TestTokenEnvKey(t *testing.T) {
+	assert.Equal(t, "CI_DEPLOY", TokenEnvKey("ci deploy"))
+	assert.Equal(t, "MY_TOKEN", TokenEnvKey("--my.token--"))
+	assert.Equal(t, "ASTRO_API_TOKEN_1ST", TokenEnvKey("1st"))
+	assert.Equal(t, "ASTRO_API_TOKEN_", TokenEnvKey("ü"))
+}
@@ -0,0 +1,278 @@
+package user
+
+import (
+	httpContext "context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+
+	astrocore "github.com/astronomer/astro-cli/astro-client-core"
+	"github.com/astronomer/astro-cli/context"
+	"github.com/astronomer/astro-cli/pkg/printutil"
+)
+
+// Operations run for every row of a users file
+const (
+	BulkInvite                  = "invite"
+	BulkAddWorkspaceUser        = "add"
+	BulkUpdateWorkspaceUserRole = "update"
+)
+
+// BulkConcurrency is the number of rows of a users file processed in parallel
+var BulkConcurrency = 5
+
+var (
+	ErrInvalidUsersFile = errors.New("the users file is invalid, no change was made")
+	ErrBulkFailed       = errors.New("some rows of the users file failed")
+	errNoWorkspace      = errors.New("no workspace id, set it in the workspace_id column or with --workspace-id")
+	errUserNotInOrg     = errors.New("the user is not a member of the organization")
+	errUserNotInWs      = errors.New("the user is not a member of the workspace")
+	errDuplicateRow     = errors.New("the same user appears on an earlier row")
+)
+
+// BulkUserRow is a row of a users file
+type BulkUserRow struct {
+	Line      int
+	Email     string
+	Role      string
+	Workspace string
+}
+
+// bulkResult is the outcome of a row
+type bulkResult struct {
+	row *BulkUserRow
+	err error
+}
+
+// ReadUsersFile reads a CSV file of users, with the email, role and workspace_id columns. The header row is optional, without it
+// the columns are read in that order. Empty roles and workspaces are replaced by the given defaults.
+func ReadUsersFile(path, defaultRole, defaultWorkspace string) ([]BulkUserRow, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	reader := csv.NewReader(f)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+	reader.Comment = '#'
+
+	columns := map[string]int{"email": 0, "role": 1, "workspace_id": 2} //nolint:gomnd
+	rows := []BulkUserRow{}
+	for first := true; ; first = false {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("error reading users file %s: %w", path, err)
+		}
+		if first && isUsersFileHeader(record) {
+			columns = map[string]int{}
+			for i, name := range record {
+				name = strings.ToLower(strings.TrimSpace(name))
+				if name == "workspace" {
+					name = "workspace_id"
+				}
+				columns[name] = i
+			}
+			continue
+		}
+		line, _ := reader.FieldPos(0)
+		row := BulkUserRow{
+			Line:      line,
+			Email:     strings.ToLower(csvField(record, columns, "email")),
+			Role:      strings.ToUpper(csvField(record, columns, "role")),
+			Workspace: csvField(record, columns, "workspace_id"),
+		}
+		if row.Role == "" {
+			row.Role = defaultRole
+		}
+		if row.Workspace == "" {
+			row.Workspace = defaultWorkspace
+		}
+		rows = append(rows, row)
+	}
+	return rows, nil
+}
+
+func isUsersFileHeader(record []string) bool {
+	for _, name := range record {
+		if strings.EqualFold(strings.TrimSpace(name), "email") {
+			return true
+		}
+	}
+	return false
+}
+
+func csvField(record []string, columns map[string]int, name string) string {
+	i, ok := columns[name]
+	if !ok || i >= len(record) {
+		return ""
+	}
+	return strings.TrimSpace(record[i])
+}
+
+// ValidateUsersFile checks every row of a users file for the given operation and returns one error per invalid row, keyed by line
+func ValidateUsersFile(operation string, rows []BulkUserRow) map[int]error {
+	invalid := map[int]error{}
+	seen := map[string]bool{}
+	for i := range rows {
+		row := &rows[i]
+		var err error
+		switch {
+		case row.Email == "" || !strings.Contains(row.Email, "@"):
+			err = ErrInvalidEmail
+		case operation == BulkInvite:
+			err = IsOrganizationRoleValid(row.Role)
+		case row.Workspace == "":
+			err = errNoWorkspace
+		default:
+			err = IsWorkspaceRoleValid(row.Role)
+		}
+		key := row.Email + "/" + row.Workspace
+		if err == nil && seen[key] {
+			err = errDuplicateRow
+		}
+		seen[key] = true
+		if err != nil {
+			invalid[row.Line] = err
+		}
+	}
+	return invalid
+}
+
+// BulkUsers runs an operation, an invite or a workspace role change, for every row of a users file. Every row is validated
+// before any change is made, then rows are processed in parallel and the result of each row is printed.
+func BulkUsers(operation, path, defaultRole, defaultWorkspace string, dryRun bool, out io.Writer, client astrocore.CoreClient) error {
+	ctx, err := context.GetCurrentContext()
+	if err != nil {
+		return err
+	}
+	if ctx.OrganizationShortName == "" {
+		return ErrNoShortName
+	}
+	if defaultWorkspace == "" && operation != BulkInvite {
+		defaultWorkspace = ctx.Workspace
+	}
+	rows, err := ReadUsersFile(path, defaultRole, defaultWorkspace)
+	if err != nil {
+		return err
+	}
+	if invalid := ValidateUsersFile(operation, rows); len(invalid) > 0 {
+		for i := range rows {
+			if err, ok := invalid[rows[i].Line]; ok {
+				fmt.Fprintf(out, "Line %d (%s): %s\n", rows[i].Line, rows[i].Email, err.Error())
+			}
+		}
+		return fmt.Errorf("%w: %d of %d rows are invalid", ErrInvalidUsersFile, len(invalid), len(rows))
+	}
+
+	run, err := bulkOperation(operation, ctx.OrganizationShortName, rows, client)
+	if err != nil {
+		return err
+	}
+	results := make([]bulkResult, len(rows))
+	sem := make(chan struct{}, BulkConcurrency)
+	var wg sync.WaitGroup
+	for i := range rows {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(i int) {
+			defer func() { <-sem; wg.Done() }()
+			results[i] = bulkResult{row: &rows[i], err: run(&rows[i], dryRun)}
+		}(i)
+	}
+	wg.Wait()
+
+	tab := printutil.Table{
+		DynamicPadding: true,
+		Header:         []string{"LINE", "EMAIL", "ROLE", "WORKSPACE ID", "RESULT"},
+	}
+	failed := 0
+	for _, result := range results {
+		status := "done"
+		switch {
+		case result.err != nil:
+			failed++
+			status = "failed: " + result.err.Error()
+		case dryRun:
+			status = "valid, skipped by --dry-run"
+		}
+		workspace := result.row.Workspace
+		if operation == BulkInvite {
+			workspace = ""
+		}
+		tab.AddRow([]string{strconv.Itoa(result.row.Line), result.row.Email, result.row.Role, workspace, status}, false)
+	}
+	if err := tab.Print(out); err != nil {
+		return err
+	}
+	if failed > 0 {
+		return fmt.Errorf("%w: %d of %d failed", ErrBulkFailed, failed, len(rows))
+	}
+	return nil
+}
+
+// bulkOperation returns the function run for each row, looking up the users once instead of once per row
+func bulkOperation(operation, orgShortName string, rows []BulkUserRow, client astrocore.CoreClient) (func(row *BulkUserRow, dryRun bool) error, error) {
+	switch operation {
+	case BulkInvite:
+		return func(row *BulkUserRow, dryRun bool) error {
+			if dryRun {
+				return nil
+			}
+			return CreateInvite(row.Email, row.Role, io.Discard, client)
+		}, nil
+	case BulkAddWorkspaceUser:
+		users, err := GetOrgUsers(client)
+		if err != nil {
+			return nil, err
+		}
+		return func(row *BulkUserRow, dryRun bool) error {
+			return mutateBulkWorkspaceUser(orgShortName, row, users, errUserNotInOrg, dryRun, client)
+		}, nil
+	case BulkUpdateWorkspaceUserRole:
+		workspaceUsers := map[string][]astrocore.User{}
+		for i := range rows {
+			if _, ok := workspaceUsers[rows[i].Workspace]; ok {
+				continue
+			}
+			users, err := GetWorkspaceUsers(client, rows[i].Workspace, userPagnationLimit)
+			if err != nil {
+				return nil, err
+			}
+			workspaceUsers[rows[i].Workspace] = users
+		}
+		return func(row *BulkUserRow, dryRun bool) error {
+			return mutateBulkWorkspaceUser(orgShortName, row, workspaceUsers[row.Workspace], errUserNotInWs, dryRun, client)
+		}, nil
+	}
+	return nil, fmt.Errorf("unknown users file operation %s", operation) //nolint:goerr113
+}
+
+func mutateBulkWorkspaceUser(orgShortName string, row *BulkUserRow, users []astrocore.User, errNotFound error, dryRun bool, client astrocore.CoreClient) error {
+	userID := ""
+	for i := range users {
+		if strings.EqualFold(users[i].Username, row.Email) {
+			userID = users[i].Id
+		}
+	}
+	if userID == "" {
+		return errNotFound
+	}
+	if dryRun {
+		return nil
+	}
+	resp, err := client.MutateWorkspaceUserRoleWithResponse(httpContext.Background(), orgShortName, row.Workspace, userID, astrocore.MutateWorkspaceUserRoleRequest{Role: row.Role})
+	if err != nil {
+		return err
+	}
+	return astrocore.NormalizeAPIError(resp.HTTPResponse, resp.Body)
+}
@@ -0,0 +1,117 @@
+package user
+
+import (
+	"bytes"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+
+	astrocore "github.com/astronomer/astro-cli/astro-client-core"
+	astrocore_mocks "github.com/astronomer/astro-cli/astro-client-core/mocks"
+	testUtil "github.com/astronomer/astro-cli/pkg/testing"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func writeUsersFile(t *testing.T, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "users.csv")
+	assert.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+	return path
+}
+
+func TestReadUsersFile(t *testing.T) {
+	t.Run("with a header", func(t *testing.T) {
+		path := writeUsersFile(t, "role,Email\nworkspace_author,A@example.com\n# comment\n,b@example.com\n")
+		rows, err := ReadUsersFile(path, "WORKSPACE_MEMBER", "ws-1")
+		assert.NoError(t, err)
+		assert.Equal(t, []BulkUserRow{
+			{Line: 2, Email: "a@example.com", Role: "WORKSPACE_AUTHOR", Workspace: "ws-1"},
+			{Line: 4, Email: "b@example.com", Role: "WORKSPACE_MEMBER", Workspace: "ws-1"},
+		}, rows)
+	})
+
+	t.Run("without a header", func(t *testing.T) {
+		path := writeUsersFile(t, "a@example.com, ORGANIZATION_OWNER\nb@example.com,,ws-2\n")
+		rows, err := ReadUsersFile(path, "ORGANIZATION_MEMBER", "")
+		assert.NoError(t, err)
+		assert.Equal(t, []BulkUserRow{
+			{Line: 1, Email: "a@example.com", Role: "ORGANIZATION_OWNER"},
+			{Line: 2, Email: "b@example.com", Role: "ORGANIZATION_MEMBER", Workspace: "ws-2"},
+		}, rows)
+	})
+}
+
+func TestValidateUsersFile(t *testing.T) {
+	rows := []BulkUserRow{
+		{Line: 1, Email: "a@example.com", Role: "ORGANIZATION_OWNER"},
+		{Line: 2, Email: "not-an-email", Role: "ORGANIZATION_OWNER"},
+		{Line: 3, Email: "b@example.com", Role: "WORKSPACE_OWNER"},
+		{Line: 4, Email: "a@example.com", Role: "ORGANIZATION_MEMBER"},
+	}
+	invalid := ValidateUsersFile(BulkInvite, rows)
+	assert.Equal(t, map[int]error{2: ErrInvalidEmail, 3: ErrInvalidOrganizationRole, 4: errDuplicateRow}, invalid)
+
+	invalid = ValidateUsersFile(BulkAddWorkspaceUser, []BulkUserRow{
+		{Line: 1, Email: "a@example.com", Role: "WORKSPACE_OWNER", Workspace: "ws-1"},
+		{Line: 2, Email: "a@example.com", Role: "WORKSPACE_OWNER", Workspace: "ws-2"},
+		{Line: 3, Email: "b@example.com", Role: "WORKSPACE_OWNER"},
+		{Line: 4, Email: "c@example.com", Role: "ORGANIZATION_OWNER", Workspace: "ws-1"},
+	})
+	assert.Equal(t, map[int]error{3: errNoWorkspace, 4: ErrInvalidWorkspaceRole}, invalid)
+}
+
+func TestBulkUsers(t *testing.T) {
+	testUtil.InitTestConfig(testUtil.CloudPlatform)
+
+	t.Run("invalid rows stop before any change", func(t *testing.T) {
+		mockClient := new(astrocore_mocks.ClientWithResponsesInterface)
+		path := writeUsersFile(t, "email,role\na@example.com,ORGANIZATION_MEMBER\nb@example.com,ORGANIZATION_ADMIN\n")
+		out := new(bytes.Buffer)
+		err := BulkUsers(BulkInvite, path, "ORGANIZATION_MEMBER", "", false, out, mockClient)
+		assert.ErrorIs(t, err, ErrInvalidUsersFile)
+		assert.Contains(t, out.String(), "Line 3 (b@example.com): requested role is invalid")
+		mockClient.AssertExpectations(t)
+	})
+
+	t.Run("invites every user", func(t *testing.T) {
+		mockClient := new(astrocore_mocks.ClientWithResponsesInterface)
+		mockClient.On("CreateUserInviteWithResponse", mock.Anything, mock.Anything, astrocore.CreateUserInviteRequest{InviteeEmail: "a@example.com", Role: "ORGANIZATION_MEMBER"}).
+			Return(&astrocore.CreateUserInviteResponse{HTTPResponse: &http.Response{StatusCode: 200}}, nil).Once()
+		mockClient.On("CreateUserInviteWithResponse", mock.Anything, mock.Anything, astrocore.CreateUserInviteRequest{InviteeEmail: "b@example.com", Role: "ORGANIZATION_OWNER"}).
+			Return(&astrocore.CreateUserInviteResponse{HTTPResponse: &http.Response{StatusCode: 400}, Body: []byte(`{"message": "already invited"}`)}, nil).Once()
+		path := writeUsersFile(t, "a@example.com\nb@example.com,ORGANIZATION_OWNER\n")
+		out := new(bytes.Buffer)
+		err := BulkUsers(BulkInvite, path, "ORGANIZATION_MEMBER", "", false, out, mockClient)
+		assert.ErrorIs(t, err, ErrBulkFailed)
+		assert.Regexp(t, `1\s+a@example.com\s+ORGANIZATION_MEMBER\s+done`, out.String())
+		assert.Regexp(t, `2\s+b@example.com\s+ORGANIZATION_OWNER\s+failed: already invited`, out.String())
+		mockClient.AssertExpectations(t)
+	})
+
+	t.Run("dry run adds no user", func(t *testing.T) {
+		mockClient := new(astrocore_mocks.ClientWithResponsesInterface)
+		mockClient.On("ListOrgUsersWithResponse", mock.Anything, mock.Anything, mock.Anything).Return(&ListOrgUsersResponseOK, nil)
+		path := writeUsersFile(t, "user@1.com,WORKSPACE_AUTHOR\nmissing@example.com,WORKSPACE_AUTHOR\n")
+		out := new(bytes.Buffer)
+		err := BulkUsers(BulkAddWorkspaceUser, path, "WORKSPACE_MEMBER", "ws-1", true, out, mockClient)
+		assert.ErrorIs(t, err, ErrBulkFailed)
+		assert.Regexp(t, `user@1.com\s+WORKSPACE_AUTHOR\s+ws-1\s+valid, skipped by --dry-run`, out.String())
+		assert.Regexp(t, `missing@example.com\s+WORKSPACE_AUTHOR\s+ws-1\s+failed: the user is not a member of the organization`, out.String())
+		mockClient.AssertExpectations(t)
+	})
+
+	t.Run("updates workspace roles", func(t *testing.T) {
+		mockClient := new(astrocore_mocks.ClientWithResponsesInterface)
+		mockClient.On("ListWorkspaceUsersWithResponse", mock.Anything, mock.Anything, "ws-1", mock.Anything).Return(&ListWorkspaceUsersResponseOK, nil)
+		mockClient.On("MutateWorkspaceUserRoleWithResponse", mock.Anything, mock.Anything, "ws-1", "user1-id", astrocore.MutateWorkspaceUserRoleRequest{Role: "WORKSPACE_OWNER"}).
+			Return(&MutateWorkspaceUserRoleResponseOK, nil).Once()
+		path := writeUsersFile(t, "email,role,workspace_id\nuser@1.com,WORKSPACE_OWNER,ws-1\n")
+		out := new(bytes.Buffer)
+		err := BulkUsers(BulkUpdateWorkspaceUserRole, path, "", "", false, out, mockClient)
+		assert.NoError(t, err)
+		assert.Regexp(t, `user@1.com\s+WORKSPACE_OWNER\s+ws-1\s+done`, out.String())
+		mockClient.AssertExpectations(t)
+	})
+}
@@ -0,0 +1,247 @@
+package clone
+
+import (
+	httpContext "context"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	airflowclient "github.com/astronomer/astro-cli/airflow-client"
+	"github.com/astronomer/astro-cli/astro-client"
+	astrocore "github.com/astronomer/astro-cli/astro-client-core"
+	"github.com/astronomer/astro-cli/cloud/deployment"
+	"github.com/astronomer/astro-cli/cloud/deployment/fromfile"
+	"github.com/astronomer/astro-cli/cloud/team"
+	"github.com/astronomer/astro-cli/cloud/user"
+	"github.com/astronomer/astro-cli/cloud/workspace"
+	"github.com/astronomer/astro-cli/context"
+)
+
+const pageLimit = 100
+
+var (
+	ErrCloneIncomplete   = errors.New("the workspace was created but some of it could not be copied")
+	errWorkspaceExists   = errors.New("a workspace with this name already exists")
+	errNewWorkspaceFound = errors.New("the new workspace was not found")
+
+	copyDeployment = fromfile.Copy
+)
+
+// Options are the parts of a workspace copied by Clone
+type Options struct {
+	// Name of the new workspace
+	Name string
+	// Description of the new workspace, the description of the source workspace when empty
+	Description string
+	// EnvVars copies the deployment environment variables which are not secret
+	EnvVars bool
+	// Connections copies the Airflow connections of every deployment
+	Connections bool
+	// AirflowVariables copies the Airflow variables of every deployment
+	AirflowVariables bool
+	// Tokens creates a new workspace API token for each workspace API token of the source workspace
+	Tokens bool
+}
+
+// Clone creates a workspace with the settings of the workspace sourceID, then recreates every deployment of the source
+// workspace in it and gives its users and teams the roles they have in the source workspace, except for the user running
+// the clone who keeps owning the new workspace. Deployments are named after
+// the source deployment and the new workspace, since deployment names are unique in an organization.
+// Clone keeps going when part of the copy fails and returns ErrCloneIncomplete.
+func Clone(sourceID string, opts Options, client astro.Client, coreClient astrocore.CoreClient, airflowAPIClient airflowclient.Client, out io.Writer) error {
+	if opts.Name == "" {
+		return workspace.ErrInvalidName
+	}
+	ctx, err := context.GetCurrentContext()
+	if err != nil {
+		return err
+	}
+	if ctx.OrganizationShortName == "" {
+		return user.ErrNoShortName
+	}
+	workspaces, err := workspace.GetWorkspaces(coreClient)
+	if err != nil {
+		return err
+	}
+	var source *astrocore.Workspace
+	for i := range workspaces {
+		if workspaces[i].Name == opts.Name {
+			return fmt.Errorf("%w: %s", errWorkspaceExists, opts.Name)
+		}
+		if workspaces[i].Id == sourceID {
+			source = &workspaces[i]
+		}
+	}
+	if source == nil {
+		return workspace.ErrWorkspaceNotFound
+	}
+	deployments, err := deployment.GetDeployments(source.Id, ctx.Organization, client)
+	if err != nil {
+		return err
+	}
+
+	// create the workspace
+	description := opts.Description
+	if description == "" && source.Description != nil {
+		description = *source.Description
+	}
+	enforceCD := "OFF"
+	if source.ApiKeyOnlyDeploymentsDefault {
+		enforceCD = "ON"
+	}
+	if err = workspace.Create(opts.Name, description, enforceCD, out, coreClient); err != nil {
+		return err
+	}
+	newID, err := workspaceID(opts.Name, coreClient)
+	if err != nil {
+		return err
+	}
+
+	failed := 0
+	fail := func(action string, err error) {
+		failed++
+		fmt.Fprintf(out, "Failed to %s: %s\n", action, err.Error())
+	}
+
+	// deployments are copied first, while the user running the copy owns the new workspace
+	for i := range deployments {
+		name := fmt.Sprintf("%s-%s", deployments[i].Label, opts.Name)
+		_, err := copyDeployment(source.Id, deployments[i].ID, fromfile.CopyOptions{
+			Name:             name,
+			WorkspaceName:    opts.Name,
+			EnvVars:          opts.EnvVars,
+			Connections:      opts.Connections,
+			AirflowVariables: opts.AirflowVariables,
+		}, client, coreClient, airflowAPIClient, out)
+		if err != nil {
+			fail("copy deployment "+deployments[i].Label, err)
+		}
+	}
+
+	if opts.Tokens {
+		tokens, err := workspaceTokens(ctx.OrganizationShortName, source.Id, coreClient)
+		if err != nil {
+			fail("copy the workspace API tokens", err)
+		}
+		for i := range tokens {
+			if err := copyToken(&tokens[i], source.Id, newID, out, coreClient); err != nil {
+				fail("copy API token "+tokens[i].Name, err)
+			}
+		}
+	}
+
+	users, err := user.GetWorkspaceUsers(coreClient, source.Id, pageLimit)
+	if err != nil {
+		fail("copy the workspace users", err)
+	}
+	for i := range users {
+		if users[i].WorkspaceRole == nil {
+			continue
+		}
+		// the user running the clone owns the new workspace, copying a lower role would leave them without the rights
+		// to copy the team roles
+		if ctx.UserEmail != "" && strings.EqualFold(users[i].Username, ctx.UserEmail) {
+			fmt.Fprintf(out, "You (%s) keep the owner role of the new workspace instead of your role %s in the source workspace\n", users[i].Username, *users[i].WorkspaceRole)
+			continue
+		}
+		resp, err := coreClient.MutateWorkspaceUserRoleWithResponse(httpContext.Background(), ctx.OrganizationShortName, newID, users[i].Id,
+			astrocore.MutateWorkspaceUserRoleRequest{Role: *users[i].WorkspaceRole})
+		if err == nil {
+			err = astrocore.NormalizeAPIError(resp.HTTPResponse, resp.Body)
+		}
+		if err != nil {
+			fail("copy the role of user "+users[i].Username, err)
+			continue
+		}
+		fmt.Fprintf(out, "The user %s was added to the workspace with the role %s\n", users[i].Username, *users[i].WorkspaceRole)
+	}
+
+	teams, err := team.GetWorkspaceTeams(coreClient, source.Id, pageLimit)
+	if err != nil {
+		fail("copy the workspace teams", err)
+	}
+	for i := range teams {
+		role := teamWorkspaceRole(&teams[i], source.Id)
+		if role == "" {
+			continue
+		}
+		resp, err := coreClient.MutateWorkspaceTeamRoleWithResponse(httpContext.Background(), ctx.OrganizationShortName, newID, teams[i].Id,
+			astrocore.MutateWorkspaceTeamRoleRequest{Role: role})
+		if err == nil {
+			err = astrocore.NormalizeAPIError(resp.HTTPResponse, resp.Body)
+		}
+		if err != nil {
+			fail("copy the role of team "+teams[i].Name, err)
+			continue
+		}
+		fmt.Fprintf(out, "The team %s was added to the workspace with the role %s\n", teams[i].Name, role)
+	}
+
+	if failed > 0 {
+		return fmt.Errorf("%w: %d failures", ErrCloneIncomplete, failed)
+	}
+	fmt.Fprintf(out, "Astro Workspace %s was successfully cloned from %s\n", opts.Name, source.Name)
+	return nil
+}
+
+// workspaceID returns the ID of the workspace named name
+func workspaceID(name string, client astrocore.CoreClient) (string, error) {
+	workspaces, err := workspace.GetWorkspaces(client)
+	if err != nil {
+		return "", err
+	}
+	for i := range workspaces {
+		if workspaces[i].Name == name {
+			return workspaces[i].Id, nil
+		}
+	}
+	return "", fmt.Errorf("%w: %s", errNewWorkspaceFound, name)
+}
+
+// workspaceTokens returns the workspace API tokens of a workspace, leaving out organization tokens with a role in it
+func workspaceTokens(orgShortName, workspaceID string, client astrocore.CoreClient) ([]astrocore.ApiToken, error) {
+	resp, err := client.ListWorkspaceApiTokensWithResponse(httpContext.Background(), orgShortName, workspaceID, &astrocore.ListWorkspaceApiTokensParams{})
+	if err != nil {
+		return nil, err
+	}
+	if err = astrocore.NormalizeAPIError(resp.HTTPResponse, resp.Body); err != nil {
+		return nil, err
+	}
+	tokens := []astrocore.ApiToken{}
+	for _, token := range resp.JSON200.ApiTokens {
+		if token.Type == astrocore.ApiTokenTypeWORKSPACE {
+			tokens = append(tokens, token)
+		}
+	}
+	return tokens, nil
+}
+
+// copyToken creates a token with the name, description, role and expiration of token in the workspace newID.
+// The value of the new token is printed once, like when a token is created with workspace token create.
+func copyToken(token *astrocore.ApiToken, sourceID, newID string, out io.Writer, client astrocore.CoreClient) error {
+	role := ""
+	for _, tokenRole := range token.Roles {
+		if tokenRole.EntityType == astrocore.ApiTokenRoleEntityTypeWORKSPACE && tokenRole.EntityId == sourceID {
+			role = tokenRole.Role
+		}
+	}
+	expiration := 0
+	if token.ExpiryPeriodInDays != nil {
+		expiration = *token.ExpiryPeriodInDays
+	}
+	return workspace.CreateToken(token.Name, token.Description, role, newID, expiration, false, out, client)
+}
+
+// teamWorkspaceRole returns the role of a team in a workspace
+func teamWorkspaceRole(t *astrocore.Team, workspaceID string) string {
+	if t.Roles == nil {
+		return ""
+	}
+	for _, role := range *t.Roles {
+		if role.EntityType == "WORKSPACE" && role.EntityId == workspaceID {
+			return role.Role
+		}
+	}
+	return ""
+}
@@ -0,0 +1,129 @@
+package clone
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"net/http"
+	"testing"
+
+	airflowclient "github.com/astronomer/astro-cli/airflow-client"
+	"github.com/astronomer/astro-cli/astro-client"
+	astrocore "github.com/astronomer/astro-cli/astro-client-core"
+	astrocore_mocks "github.com/astronomer/astro-cli/astro-client-core/mocks"
+	astro_mocks "github.com/astronomer/astro-cli/astro-client/mocks"
+	"github.com/astronomer/astro-cli/cloud/deployment/fromfile"
+	"github.com/astronomer/astro-cli/context"
+	testUtil "github.com/astronomer/astro-cli/pkg/testing"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+var (
+	errMock     = errors.New("mock error")
+	ok          = &http.Response{StatusCode: 200}
+	description = "source description"
+	sourceWs    = astrocore.Workspace{Id: "source-id", Name: "source", Description: &description, ApiKeyOnlyDeploymentsDefault: true}
+	newWs       = astrocore.Workspace{Id: "new-id", Name: "sandbox"}
+)
+
+func listWorkspacesResponse(workspaces ...astrocore.Workspace) *astrocore.ListWorkspacesResponse {
+	return &astrocore.ListWorkspacesResponse{HTTPResponse: ok, JSON200: &astrocore.WorkspacesPaginated{Workspaces: workspaces}}
+}
+
+func TestClone(t *testing.T) {
+	testUtil.InitTestConfig(testUtil.CloudPlatform)
+	origCopy := copyDeployment
+	defer func() { copyDeployment = origCopy }()
+
+	t.Run("clones deployments, tokens and roles", func(t *testing.T) {
+		ctx, err := context.GetCurrentContext()
+		assert.NoError(t, err)
+		ctx.UserEmail = "me@example.com"
+		assert.NoError(t, ctx.SetContext())
+		defer testUtil.InitTestConfig(testUtil.CloudPlatform)
+
+		copied := []fromfile.CopyOptions{}
+		copyDeployment = func(wsID, deploymentID string, opts fromfile.CopyOptions, client astro.Client, coreClient astrocore.CoreClient, airflowAPIClient airflowclient.Client, out io.Writer) (fromfile.CopiedDeployment, error) {
+			assert.Equal(t, "source-id", wsID)
+			copied = append(copied, opts)
+			if deploymentID == "broken-id" {
+				return fromfile.CopiedDeployment{}, errMock
+			}
+			return fromfile.CopiedDeployment{ID: "copy-of-" + deploymentID}, nil
+		}
+		memberRole := "WORKSPACE_MEMBER"
+		teamRoles := []astrocore.TeamRole{{EntityType: "WORKSPACE", EntityId: "other-id", Role: "WORKSPACE_OWNER"}, {EntityType: "WORKSPACE", EntityId: "source-id", Role: "WORKSPACE_OPERATOR"}}
+		expiry := 30
+
+		mockClient := new(astro_mocks.Client)
+		mockClient.On("ListDeployments", "test-org-id", "source-id").Return([]astro.Deployment{{ID: "etl-id", Label: "etl"}, {ID: "broken-id", Label: "broken"}}, nil).Once()
+		mockCoreClient := new(astrocore_mocks.ClientWithResponsesInterface)
+		mockCoreClient.On("ListWorkspacesWithResponse", mock.Anything, mock.Anything, mock.Anything).Return(listWorkspacesResponse(sourceWs), nil).Once()
+		mockCoreClient.On("CreateWorkspaceWithResponse", mock.Anything, mock.Anything, mock.MatchedBy(func(body astrocore.CreateWorkspaceJSONRequestBody) bool {
+			return body.Name == "sandbox" && *body.Description == description && *body.ApiKeyOnlyDeploymentsDefault
+		})).Return(&astrocore.CreateWorkspaceResponse{HTTPResponse: ok}, nil).Once()
+		mockCoreClient.On("ListWorkspacesWithResponse", mock.Anything, mock.Anything, mock.Anything).Return(listWorkspacesResponse(sourceWs, newWs), nil).Once()
+		mockCoreClient.On("ListWorkspaceApiTokensWithResponse", mock.Anything, mock.Anything, "source-id", mock.Anything).Return(&astrocore.ListWorkspaceApiTokensResponse{
+			HTTPResponse: ok,
+			JSON200: &astrocore.ListApiTokensPaginated{ApiTokens: []astrocore.ApiToken{
+				{Name: "ci", Description: "deploys", Type: astrocore.ApiTokenTypeWORKSPACE, ExpiryPeriodInDays: &expiry, Roles: []astrocore.ApiTokenRole{{EntityType: astrocore.ApiTokenRoleEntityTypeWORKSPACE, EntityId: "source-id", Role: "WORKSPACE_AUTHOR"}}},
+				{Name: "org token", Type: astrocore.ApiTokenTypeORGANIZATION},
+			}},
+		}, nil).Once()
+		token := "new-token"
+		mockCoreClient.On("CreateWorkspaceApiTokenWithResponse", mock.Anything, mock.Anything, "new-id", astrocore.CreateWorkspaceApiTokenJSONRequestBody{
+			Name: "ci", Description: &[]string{"deploys"}[0], Role: "WORKSPACE_AUTHOR", TokenExpiryPeriodInDays: &expiry,
+		}).Return(&astrocore.CreateWorkspaceApiTokenResponse{HTTPResponse: ok, JSON200: &astrocore.ApiToken{Token: &token}}, nil).Once()
+		mockCoreClient.On("ListWorkspaceUsersWithResponse", mock.Anything, mock.Anything, "source-id", mock.Anything).Return(&astrocore.ListWorkspaceUsersResponse{
+			HTTPResponse: ok,
+			JSON200: &astrocore.UsersPaginated{Users: []astrocore.User{
+				{Id: "bob-id", Username: "bob@example.com", WorkspaceRole: &memberRole},
+				{Id: "me-id", Username: "Me@example.com", WorkspaceRole: &memberRole},
+			}},
+		}, nil).Once()
+		mockCoreClient.On("MutateWorkspaceUserRoleWithResponse", mock.Anything, mock.Anything, "new-id", "bob-id", astrocore.MutateWorkspaceUserRoleRequest{Role: memberRole}).
+			Return(&astrocore.MutateWorkspaceUserRoleResponse{HTTPResponse: ok}, nil).Once()
+		mockCoreClient.On("ListWorkspaceTeamsWithResponse", mock.Anything, mock.Anything, "source-id", mock.Anything).Return(&astrocore.ListWorkspaceTeamsResponse{
+			HTTPResponse: ok,
+			JSON200:      &astrocore.TeamsPaginated{Teams: []astrocore.Team{{Id: "eng-id", Name: "eng", Roles: &teamRoles}}},
+		}, nil).Once()
+		mockCoreClient.On("MutateWorkspaceTeamRoleWithResponse", mock.Anything, mock.Anything, "new-id", "eng-id", astrocore.MutateWorkspaceTeamRoleRequest{Role: "WORKSPACE_OPERATOR"}).
+			Return(&astrocore.MutateWorkspaceTeamRoleResponse{HTTPResponse: ok}, nil).Once()
+
+		out := new(bytes.Buffer)
+		err = Clone("source-id", Options{Name: "sandbox", EnvVars: true, Tokens: true}, mockClient, mockCoreClient, nil, out)
+		assert.ErrorIs(t, err, ErrCloneIncomplete)
+		assert.Equal(t, []fromfile.CopyOptions{
+			{Name: "etl-sandbox", WorkspaceName: "sandbox", EnvVars: true},
+			{Name: "broken-sandbox", WorkspaceName: "sandbox", EnvVars: true},
+		}, copied)
+		assert.Contains(t, out.String(), "Failed to copy deployment broken: mock error")
+		assert.Contains(t, out.String(), "The user bob@example.com was added to the workspace with the role WORKSPACE_MEMBER")
+		assert.Contains(t, out.String(), "You (Me@example.com) keep the owner role of the new workspace")
+		assert.Contains(t, out.String(), "The team eng was added to the workspace with the role WORKSPACE_OPERATOR")
+		mockClient.AssertExpectations(t)
+		mockCoreClient.AssertExpectations(t)
+	})
+
+	t.Run("name already used", func(t *testing.T) {
+		mockCoreClient := new(astrocore_mocks.ClientWithResponsesInterface)
+		mockCoreClient.On("ListWorkspacesWithResponse", mock.Anything, mock.Anything, mock.Anything).Return(listWorkspacesResponse(sourceWs, newWs), nil).Once()
+		err := Clone("source-id", Options{Name: "sandbox"}, nil, mockCoreClient, nil, new(bytes.Buffer))
+		assert.ErrorIs(t, err, errWorkspaceExists)
+		mockCoreClient.AssertExpectations(t)
+	})
+
+	t.Run("source not found", func(t *testing.T) {
+		mockCoreClient := new(astrocore_mocks.ClientWithResponsesInterface)
+		mockCoreClient.On("ListWorkspacesWithResponse", mock.Anything, mock.Anything, mock.Anything).Return(listWorkspacesResponse(newWs), nil).Once()
+		err := Clone("source-id", Options{Name: "copy"}, nil, mockCoreClient, nil, new(bytes.Buffer))
+		assert.Equal(t, "no workspace was found for the ID you provided", err.Error())
+		mockCoreClient.AssertExpectations(t)
+	})
+
+	t.Run("no name", func(t *testing.T) {
+		err := Clone("source-id", Options{}, nil, nil, nil, new(bytes.Buffer))
+		assert.Equal(t, "no name provided for the workspace. Retry with a valid name", err.Error())
+	})
+}
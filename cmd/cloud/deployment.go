@@ -3,6 +3,8 @@ package cloud
 import (
 	"fmt"
 	"io"
+	"log"
+	"time"
 
 	"github.com/astronomer/astro-cli/astro-client"
 
@@ -10,6 +12,7 @@ import (
 	astrocore "github.com/astronomer/astro-cli/astro-client-core"
 	"github.com/astronomer/astro-cli/cloud/deployment"
 	"github.com/astronomer/astro-cli/cloud/deployment/fromfile"
+	"github.com/astronomer/astro-cli/cloud/deployment/hibernation"
 	"github.com/astronomer/astro-cli/cloud/organization"
 	"github.com/astronomer/astro-cli/pkg/httputil"
 	"github.com/pkg/errors"
@@ -55,6 +58,12 @@ var (
 	deploymentCreateEnforceCD     bool
 	deploymentUpdateEnforceCD     bool
 	clusterType                   = standard
+	copyOptions                   fromfile.CopyOptions
+	deploymentCopy                = fromfile.CopyDeployment
+	hibernateSchedulerAU          int
+	deploymentHibernate           = hibernation.Hibernate
+	deploymentWake                = hibernation.Wake
+	deploymentHibernationSchedule = hibernation.ApplySchedule
 	deploymentVariableListExample = `
 		# List a deployment's variables
 		$ astro deployment variable list --deployment-id <deployment-id> --key FOO
@@ -89,10 +98,14 @@ func newDeploymentRootCmd(out io.Writer) *cobra.Command {
 	cmd.PersistentFlags().StringVar(&workspaceID, "workspace-id", "", "workspace assigned to deployment")
 	cmd.AddCommand(
 		newDeploymentListCmd(out),
+		newDeploymentCostCmd(out),
 		newDeploymentDeleteCmd(),
 		newDeploymentCreateCmd(out),
 		newDeploymentLogsCmd(),
 		newDeploymentUpdateCmd(out),
+		newDeploymentCopyCmd(out),
+		newDeploymentHibernateCmd(out),
+		newDeploymentWakeCmd(out),
 		newDeploymentVariableRootCmd(out),
 		newDeploymentWorkerQueueRootCmd(out),
 		newDeploymentInspectCmd(out),
@@ -117,6 +130,19 @@ func newDeploymentListCmd(out io.Writer) *cobra.Command {
 	return cmd
 }
 
+func newDeploymentCostCmd(out io.Writer) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "cost",
+		Short: "Estimate the resources used by the Deployments in your Astronomer Workspace",
+		Long: "Estimate the resources used by the Deployments in your Astronomer Workspace. " +
+			"For each Deployment, show its scheduler and the CPU and memory of its workers when idle and when all worker queues scale up to their max worker count.",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return deploymentCost(cmd, out)
+		},
+	}
+	return cmd
+}
+
 func newDeploymentLogsCmd() *cobra.Command {
 	cmd := &cobra.Command{
 		Use:     "logs [Deployment-ID]",
@@ -196,6 +222,66 @@ func newDeploymentUpdateCmd(out io.Writer) *cobra.Command {
 	return cmd
 }
 
+func newDeploymentCopyCmd(out io.Writer) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "copy [DEPLOYMENT-ID or DEPLOYMENT-NAME]",
+		Aliases: []string{"cp"},
+		Short:   "Create a copy of an Astro Deployment",
+		Long: "Create a new Astro Deployment with the configuration, worker queues and non-secret environment variables of an existing Deployment. " +
+			"The inspect template of the source Deployment is used to create the copy.\n" +
+			"$astro deployment copy [DEPLOYMENT-ID] --name [new deployment name]",
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return deploymentCopyRun(cmd, args, out)
+		},
+	}
+	cmd.Flags().StringVarP(&label, "name", "n", "", "The new Deployment's name. If the name contains a space, specify the entire name within quotes \"\" ")
+	cmd.Flags().StringVarP(&workspaceID, "workspace-id", "w", "", "Workspace to create the copy in. Defaults to the Workspace of the source Deployment")
+	cmd.Flags().BoolVar(&copyOptions.EnvVars, "env-vars", true, "Copy the environment variables of the Deployment. Secret environment variables are never copied")
+	cmd.Flags().BoolVar(&copyOptions.Connections, "connections", false, "Copy the Airflow connections. The password and extra fields are not copied")
+	cmd.Flags().BoolVar(&copyOptions.AirflowVariables, "airflow-variables", false, "Copy the Airflow variables")
+	cmd.Flags().BoolVar(&copyOptions.Pools, "pools", false, "Copy the Airflow pools")
+	cmd.Flags().BoolVar(&copyOptions.Image, "deploy-image", false, "Deploy the image tag currently deployed to the source Deployment")
+	err := cmd.MarkFlagRequired("name")
+	if err != nil {
+		log.Fatalf("Error marking name flag as required in astro deployment copy command: %s", err.Error())
+	}
+	return cmd
+}
+
+func newDeploymentHibernateCmd(out io.Writer) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "hibernate [DEPLOYMENT-ID]",
+		Short: "Scale an Astro Deployment down while it is not used",
+		Long: "Scale the worker queues of an Astro Deployment down to zero workers and its scheduler to the smallest size. " +
+			"The current configuration is saved on the Deployment so that astro deployment wake restores it.\n" +
+			"With --deployment-file, the Deployment is hibernated or woken according to the hibernation_schedule of the file. " +
+			"Run it regularly, for example from a scheduled CI job, to follow the schedule.",
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return deploymentHibernateRun(cmd, args, out)
+		},
+	}
+	cmd.Flags().StringVarP(&deploymentName, "deployment-name", "n", "", "Name of the deployment to hibernate")
+	cmd.Flags().IntVarP(&hibernateSchedulerAU, "scheduler-au", "s", 0, "The scheduler AU to use while hibernating. Defaults to the smallest scheduler AU")
+	cmd.Flags().StringVarP(&inputFile, "deployment-file", "", "", "Location of a deployment file with a hibernation_schedule. File can be in either JSON or YAML format.")
+	return cmd
+}
+
+func newDeploymentWakeCmd(out io.Writer) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "wake [DEPLOYMENT-ID]",
+		Short: "Restore a hibernating Astro Deployment",
+		Long:  "Restore the scheduler and worker queues an Astro Deployment had before astro deployment hibernate scaled it down.",
+		Args:  cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return deploymentWakeRun(cmd, args, out)
+		},
+	}
+	cmd.Flags().StringVarP(&deploymentName, "deployment-name", "n", "", "Name of the deployment to wake")
+	return cmd
+}
+
 func newDeploymentDeleteCmd() *cobra.Command {
 	cmd := &cobra.Command{
 		Use:     "delete DEPLOYMENT-ID",
@@ -310,6 +396,18 @@ func deploymentList(cmd *cobra.Command, out io.Writer) error {
 	return deployment.List(ws, allDeployments, astroClient, out)
 }
 
+func deploymentCost(cmd *cobra.Command, out io.Writer) error {
+	ws, err := coalesceWorkspace()
+	if err != nil {
+		return errors.Wrap(err, "failed to find a valid workspace")
+	}
+
+	// Silence Usage as we have now validated command input
+	cmd.SilenceUsage = true
+
+	return deployment.Cost(ws, astroClient, out)
+}
+
 func deploymentLogs(cmd *cobra.Command, args []string) error {
 	// Get release name from args, if passed
 	if len(args) > 0 {
@@ -439,6 +537,60 @@ func deploymentUpdate(cmd *cobra.Command, args []string, out io.Writer) error {
 	return deployment.Update(deploymentID, label, ws, description, deploymentName, dagDeploy, executor, schedulerSize, highAvailability, updateSchedulerAU, updateSchedulerReplicas, []astro.WorkerQueue{}, forceUpdate, &deploymentUpdateEnforceCD, astroClient)
 }
 
+func deploymentCopyRun(cmd *cobra.Command, args []string, out io.Writer) error {
+	// Silence Usage as we have now validated command input
+	cmd.SilenceUsage = true
+
+	opts := copyOptions
+	opts.Name = label
+	if opts.Connections {
+		fmt.Println(warningConnectionCopyCMD)
+	}
+	if opts.AirflowVariables {
+		fmt.Println(warningVariableCopyCMD)
+	}
+	return deploymentCopy(args[0], workspaceID, opts, astroClient, astroCoreClient, airflowAPIClient, out)
+}
+
+func deploymentHibernateRun(cmd *cobra.Command, args []string, out io.Writer) error {
+	ws, err := coalesceWorkspace()
+	if err != nil {
+		return errors.Wrap(err, "failed to find a valid Workspace")
+	}
+	if inputFile != "" {
+		if len(args) > 0 || deploymentName != "" || hibernateSchedulerAU != 0 {
+			return errFlag
+		}
+		cmd.SilenceUsage = true
+		return deploymentHibernationSchedule(ws, inputFile, time.Now(), astroClient, astroCoreClient, out)
+	}
+
+	// Silence Usage as we have now validated command input
+	cmd.SilenceUsage = true
+
+	// Get release name from args, if passed
+	if len(args) > 0 {
+		deploymentID = args[0]
+	}
+	return deploymentHibernate(ws, deploymentID, deploymentName, hibernateSchedulerAU, astroClient, astroCoreClient, out)
+}
+
+func deploymentWakeRun(cmd *cobra.Command, args []string, out io.Writer) error {
+	ws, err := coalesceWorkspace()
+	if err != nil {
+		return errors.Wrap(err, "failed to find a valid Workspace")
+	}
+
+	// Silence Usage as we have now validated command input
+	cmd.SilenceUsage = true
+
+	// Get release name from args, if passed
+	if len(args) > 0 {
+		deploymentID = args[0]
+	}
+	return deploymentWake(ws, deploymentID, deploymentName, astroClient, astroCoreClient, out)
+}
+
 func deploymentDelete(cmd *cobra.Command, args []string) error {
 	ws, err := coalesceWorkspace()
 	if err != nil {
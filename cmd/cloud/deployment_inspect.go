@@ -5,14 +5,15 @@ import (
 
 	"github.com/astronomer/astro-cli/cloud/deployment"
 	"github.com/astronomer/astro-cli/cloud/deployment/inspect"
+	"github.com/astronomer/astro-cli/pkg/printutil"
 
 	"github.com/spf13/cobra"
 )
 
 var (
-	outputFormat, requestedField string
-	template                     bool
-	cleanOutput                  bool
+	requestedField string
+	template       bool
+	cleanOutput    bool
 )
 
 func newDeploymentInspectCmd(out io.Writer) *cobra.Command {
@@ -26,7 +27,7 @@ func newDeploymentInspectCmd(out io.Writer) *cobra.Command {
 		},
 	}
 	cmd.Flags().StringVarP(&deploymentName, "deployment-name", "n", "", "Name of the deployment to inspect.")
-	cmd.Flags().StringVarP(&outputFormat, "output", "o", "yaml", "Output format can be one of: yaml or json. By default the inspected deployment will be in YAML format.")
+	cmd.Flags().VarP(printutil.OutputFlag{Default: printutil.YAMLFormat}, "output", "o", "Output format can be one of: yaml or json. By default the inspected deployment will be in YAML format.")
 	cmd.Flags().BoolVarP(&template, "template", "t", false, "Create a template from the deployment being inspected.")
 	cmd.Flags().StringVarP(&requestedField, "key", "k", "", "A specific key for the deployment. Use --key configuration.cluster_id to get a deployment's cluster id.")
 	cmd.Flags().BoolVarP(&cleanOutput, "clean-output", "c", false, "clean output to only include inspect yaml or json file in any situation.")
@@ -36,6 +37,11 @@ func newDeploymentInspectCmd(out io.Writer) *cobra.Command {
 func deploymentInspect(cmd *cobra.Command, args []string, out io.Writer) error {
 	cmd.SilenceUsage = true
 
+	outputFormat, err := printutil.DocumentFormat(printutil.YAMLFormat, printutil.YAMLFormat, printutil.JSONFormat)
+	if err != nil {
+		return err
+	}
+
 	wsID, err := coalesceWorkspace()
 	if err != nil {
 		return err
@@ -3,17 +3,21 @@ package cloud
 import (
 	"bytes"
 	"encoding/json"
+	"fmt"
 	"io"
 	"net/http"
 	"os"
 	"testing"
+	"time"
 
+	airflowclient "github.com/astronomer/astro-cli/airflow-client"
 	airflowversions "github.com/astronomer/astro-cli/airflow_versions"
 	astro "github.com/astronomer/astro-cli/astro-client"
 	astrocore "github.com/astronomer/astro-cli/astro-client-core"
 	astrocore_mocks "github.com/astronomer/astro-cli/astro-client-core/mocks"
 	astro_mocks "github.com/astronomer/astro-cli/astro-client/mocks"
 	"github.com/astronomer/astro-cli/cloud/deployment"
+	"github.com/astronomer/astro-cli/cloud/deployment/fromfile"
 	"github.com/astronomer/astro-cli/config"
 	"github.com/astronomer/astro-cli/context"
 	"github.com/astronomer/astro-cli/pkg/fileutil"
@@ -90,6 +94,25 @@ func TestDeploymentList(t *testing.T) {
 	mockClient.AssertExpectations(t)
 }
 
+func TestDeploymentCost(t *testing.T) {
+	testUtil.InitTestConfig(testUtil.CloudPlatform)
+
+	mockClient := new(astro_mocks.Client)
+	mockClient.On("ListDeployments", mock.Anything, "ck05r3bor07h40d02y2hw4n4v").Return([]astro.Deployment{{
+		Label:          "test-deployment",
+		DeploymentSpec: astro.DeploymentSpec{Executor: deployment.CeleryExecutor, Scheduler: astro.Scheduler{AU: 5, Replicas: 1}},
+		WorkerQueues:   []astro.WorkerQueue{{Name: "default", MinWorkerCount: 1, MaxWorkerCount: 50}},
+	}}, nil).Once()
+	mockClient.On("GetDeploymentConfig").Return(astro.DeploymentConfig{}, nil).Once()
+	astroClient = mockClient
+
+	resp, err := execDeploymentCmd("cost")
+	assert.NoError(t, err)
+	assert.Contains(t, resp, "test-deployment")
+	assert.Contains(t, resp, "Workers in the workspace: 1 to 50")
+	mockClient.AssertExpectations(t)
+}
+
 func TestDeploymentLogs(t *testing.T) {
 	testUtil.InitTestConfig(testUtil.CloudPlatform)
 
@@ -195,6 +218,7 @@ func TestDeploymentCreate(t *testing.T) {
 		},
 	}
 	mockClient.On("CreateDeployment", &deploymentCreateInput2).Return(astro.Deployment{ID: "test-id"}, nil).Once()
+	mockClient.On("GetWorkerQueueOptions").Return(astro.WorkerQueueDefaultOptions{}, nil)
 	astroClient = mockClient
 
 	mockResponse := &airflowversions.Response{
@@ -880,3 +904,67 @@ func TestIsValidCloudProvider(t *testing.T) {
 		assert.False(t, actual)
 	})
 }
+
+func TestDeploymentCopy(t *testing.T) {
+	testUtil.InitTestConfig(testUtil.CloudPlatform)
+	origCopy := deploymentCopy
+	defer func() { deploymentCopy = origCopy }()
+
+	t.Run("name is required", func(t *testing.T) {
+		_, err := execDeploymentCmd("copy", "prod")
+		assert.ErrorContains(t, err, `required flag(s) "name" not set`)
+	})
+	t.Run("copies the deployment with the requested options", func(t *testing.T) {
+		var gotSource, gotWorkspace string
+		var gotOpts fromfile.CopyOptions
+		deploymentCopy = func(source, targetWorkspaceID string, opts fromfile.CopyOptions, client astro.Client, coreClient astrocore.CoreClient, airflowAPIClient airflowclient.Client, out io.Writer) error {
+			gotSource, gotWorkspace, gotOpts = source, targetWorkspaceID, opts
+			return nil
+		}
+		_, err := execDeploymentCmd("copy", "prod", "--name", "staging", "--workspace-id", "staging-ws", "--pools", "--deploy-image")
+		assert.NoError(t, err)
+		assert.Equal(t, "prod", gotSource)
+		assert.Equal(t, "staging-ws", gotWorkspace)
+		assert.Equal(t, fromfile.CopyOptions{Name: "staging", EnvVars: true, Pools: true, Image: true}, gotOpts)
+
+		_, err = execDeploymentCmd("copy", "prod", "--name", "staging", "--env-vars=false")
+		assert.NoError(t, err)
+		assert.Equal(t, fromfile.CopyOptions{Name: "staging"}, gotOpts)
+	})
+}
+
+func TestDeploymentHibernate(t *testing.T) {
+	testUtil.InitTestConfig(testUtil.CloudPlatform)
+	origHibernate, origWake, origSchedule := deploymentHibernate, deploymentWake, deploymentHibernationSchedule
+	defer func() {
+		deploymentHibernate, deploymentWake, deploymentHibernationSchedule = origHibernate, origWake, origSchedule
+	}()
+	var calls []string
+	deploymentHibernate = func(ws, deploymentID, deploymentName string, schedulerAU int, client astro.Client, coreClient astrocore.CoreClient, out io.Writer) error {
+		calls = append(calls, fmt.Sprintf("hibernate %s %s %d", ws, deploymentID, schedulerAU))
+		return nil
+	}
+	deploymentWake = func(ws, deploymentID, deploymentName string, client astro.Client, coreClient astrocore.CoreClient, out io.Writer) error {
+		calls = append(calls, fmt.Sprintf("wake %s %s", ws, deploymentID))
+		return nil
+	}
+	deploymentHibernationSchedule = func(ws, deploymentFile string, now time.Time, client astro.Client, coreClient astrocore.CoreClient, out io.Writer) error {
+		calls = append(calls, "schedule "+deploymentFile)
+		return nil
+	}
+
+	_, err := execDeploymentCmd("hibernate", "test-id-1", "--scheduler-au", "5")
+	assert.NoError(t, err)
+	_, err = execDeploymentCmd("wake", "test-id-1")
+	assert.NoError(t, err)
+	_, err = execDeploymentCmd("hibernate", "--deployment-file", "deployment.yaml")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{
+		"hibernate ck05r3bor07h40d02y2hw4n4v test-id-1 5",
+		"wake ck05r3bor07h40d02y2hw4n4v test-id-1",
+		"schedule deployment.yaml",
+	}, calls)
+
+	_, err = execDeploymentCmd("hibernate", "test-id-1", "--deployment-file", "deployment.yaml")
+	assert.ErrorIs(t, err, errFlag)
+}
@@ -3,6 +3,7 @@ package cloud
 import (
 	"bytes"
 	"os"
+	"strings"
 	"testing"
 	"time"
 
@@ -203,7 +204,8 @@ func TestNewDeploymentWorkerQueueCreateCmd(t *testing.T) {
 		cmdArgs := []string{"worker-queue", "create", "-n", "test-queue", "-t", "test-instance-type"}
 		actualOut, err := execDeploymentCmd(cmdArgs...)
 		assert.NoError(t, err)
-		assert.Equal(t, expectedoutput, actualOut)
+		assert.Contains(t, actualOut, "Estimated resources for Deployment test-deployment-label")
+		assert.True(t, strings.HasSuffix(actualOut, expectedoutput))
 		mockClient.AssertExpectations(t)
 	})
 	t.Run("create worker queue when deployment id was provided", func(t *testing.T) {
@@ -245,7 +247,8 @@ func TestNewDeploymentWorkerQueueCreateCmd(t *testing.T) {
 		cmdArgs := []string{"worker-queue", "create", "-d", "test-deployment-id", "-t", "test-instance-type", "-n", "test-queue"}
 		actualOut, err := execDeploymentCmd(cmdArgs...)
 		assert.NoError(t, err)
-		assert.Equal(t, expectedoutput, actualOut)
+		assert.Contains(t, actualOut, "Estimated resources for Deployment test-deployment-label")
+		assert.True(t, strings.HasSuffix(actualOut, expectedoutput))
 		mockClient.AssertExpectations(t)
 	})
 	t.Run("create worker queue when deployment name was provided", func(t *testing.T) {
@@ -287,7 +290,8 @@ func TestNewDeploymentWorkerQueueCreateCmd(t *testing.T) {
 		cmdArgs := []string{"worker-queue", "create", "--deployment-name", "test-deployment-label", "-t", "test-instance-type", "-n", "test-queue"}
 		actualOut, err := execDeploymentCmd(cmdArgs...)
 		assert.NoError(t, err)
-		assert.Equal(t, expectedoutput, actualOut)
+		assert.Contains(t, actualOut, "Estimated resources for Deployment test-deployment-label")
+		assert.True(t, strings.HasSuffix(actualOut, expectedoutput))
 		mockClient.AssertExpectations(t)
 	})
 	t.Run("create worker queue when no name was provided", func(t *testing.T) {
@@ -329,7 +333,8 @@ func TestNewDeploymentWorkerQueueCreateCmd(t *testing.T) {
 		cmdArgs := []string{"worker-queue", "create", "-d", "test-deployment-id", "-t", "test-instance-type"}
 		actualOut, err := execDeploymentCmd(cmdArgs...)
 		assert.NoError(t, err)
-		assert.Equal(t, expectedoutput, actualOut)
+		assert.Contains(t, actualOut, "Estimated resources for Deployment test-deployment-label")
+		assert.True(t, strings.HasSuffix(actualOut, expectedoutput))
 		mockClient.AssertExpectations(t)
 	})
 	t.Run("returns an error when getting workspace fails", func(t *testing.T) {
@@ -20,6 +20,7 @@ import (
 	"github.com/astronomer/astro-cli/cloud/team"
 	"github.com/astronomer/astro-cli/cloud/user"
 	"github.com/astronomer/astro-cli/pkg/input"
+	"github.com/astronomer/astro-cli/pkg/util"
 )
 
 var (
@@ -27,10 +28,24 @@ var (
 	orgList                            = organization.List
 	orgSwitch                          = organization.Switch
 	orgExportAuditLogs                 = organization.ExportAuditLogs
+	orgQueryAuditLogs                  = organization.QueryAuditLogs
+	orgApplyAccessFile                 = organization.ApplyAccessFile
+	orgAccessReport                    = organization.AccessReport
 	orgName                            string
 	auditLogsOutputFilePath            string
 	auditLogsEarliestParam             int
 	auditLogsEarliestParamDefaultValue = 1
+	auditLogsQuery                     organization.AuditLogQuery
+	auditLogsSince                     string
+	auditLogsUntil                     string
+	tokenExpiringWithin                string
+	tokenAuditExpiringWithin           string
+	tokenUnusedFor                     string
+	tokenCheckRoles                    bool
+	tokenWriteTo                       string
+	rbacAccessFile                     string
+	rbacApplyOptions                   organization.RBACApplyOptions
+	errTokenIDWithExpiringWithin       = errors.New("a token ID or name cannot be used with --expiring-within")
 	shouldDisplayLoginLink             bool
 	role                               string
 	updateRole                         string
@@ -57,6 +72,8 @@ func newOrganizationCmd(out io.Writer) *cobra.Command {
 		newOrganizationTeamRootCmd(out),
 		newOrganizationAuditLogs(out),
 		newOrganizationTokenRootCmd(out),
+		newOrganizationRBACRootCmd(out),
+		newOrganizationAccessReportCmd(out),
 	)
 	return cmd
 }
@@ -99,6 +116,7 @@ func newOrganizationAuditLogs(out io.Writer) *cobra.Command {
 	}
 	cmd.AddCommand(
 		newOrganizationExportAuditLogs(out),
+		newOrganizationQueryAuditLogs(out),
 	)
 	return cmd
 }
@@ -124,6 +142,81 @@ func newOrganizationExportAuditLogs(_ io.Writer) *cobra.Command {
 	return cmd
 }
 
+func newOrganizationQueryAuditLogs(out io.Writer) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "query",
+		Aliases: []string{"q"},
+		Short:   "Query your Organization audit logs. Requires Organization Owner permissions.",
+		Long: "Query your Organization audit logs and print the matching events as a table or as newline delimited JSON. " +
+			"Requires Organization Owner permissions.\n$astro organization audit-logs query --organization-name [name] --action 'deployment.*' --since 7d --ndjson",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return organizationQueryAuditLogs(cmd, out)
+		},
+	}
+	cmd.Flags().StringVarP(&orgName, "organization-name", "n", "", "Name of the Organization to query audit logs for.")
+	err := cmd.MarkFlagRequired("organization-name")
+	if err != nil {
+		log.Fatalf("Error marking organization-name flag as required in astro Organization audit-logs command: %s", err.Error())
+	}
+	cmd.Flags().StringVar(&auditLogsQuery.Actor, "actor", "", "Only include events of actors matching this value, either a substring or a glob pattern like '*@example.com'")
+	cmd.Flags().StringVar(&auditLogsQuery.Action, "action", "", "Only include events with an action matching this value, either a substring or a glob pattern like 'deployment.*'")
+	cmd.Flags().StringVar(&auditLogsQuery.Target, "target", "", "Only include events on target resources matching this value, either a substring or a glob pattern")
+	cmd.Flags().StringVar(&auditLogsSince, "since", "", "Only include events after this time, either an RFC 3339 timestamp, a date or a duration like 24h or 7d. Maximum: 90 days ago. Default: 1 day ago.")
+	cmd.Flags().StringVar(&auditLogsUntil, "until", "", "Only include events before this time, either an RFC 3339 timestamp, a date or a duration like 24h or 7d")
+	cmd.Flags().StringVar(&auditLogsQuery.StateFile, "state-file", "", "File keeping the timestamp of the last event returned. Subsequent queries with the same file only return newer events.")
+	cmd.Flags().BoolVar(&auditLogsQuery.NDJSON, "ndjson", false, "Print the matching events unchanged as newline delimited JSON")
+	return cmd
+}
+
+func newOrganizationRBACRootCmd(out io.Writer) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "rbac",
+		Short: "Manage users, teams, Workspace roles and API tokens of your Astro Organization from a file",
+		Long:  "Manage users, teams, Workspace roles and API tokens of your Astro Organization from a file",
+	}
+	cmd.AddCommand(
+		newOrganizationRBACApplyCmd(out),
+	)
+	return cmd
+}
+
+func newOrganizationRBACApplyCmd(out io.Writer) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "apply",
+		Short: "Make the users, teams, Workspace roles and API tokens of your Astro Organization match an access file",
+		Long: "Make the users, teams, Workspace roles and API tokens of your Astro Organization match an access file. " +
+			"The changes are computed against the current Organization and shown before being applied\n" +
+			"$astro organization rbac apply -f access.yaml --dry-run",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return organizationRBACApply(cmd, out)
+		},
+	}
+	cmd.Flags().StringVarP(&rbacAccessFile, "file", "f", "", "Location of the YAML access file declaring users, teams, workspaces and tokens")
+	cmd.Flags().BoolVar(&rbacApplyOptions.Prune, "prune", false, "Remove the users, team members, teams, Workspace roles and API tokens which are not in the access file. "+
+		"Only the sections present in the file are pruned, users referenced anywhere in the file, your own user and the API token the command runs with are never removed")
+	cmd.Flags().BoolVar(&rbacApplyOptions.DryRun, "dry-run", false, "Only show the changes, without applying them")
+	cmd.Flags().BoolVar(&rbacApplyOptions.Force, "force", false, "Apply the changes, including removals, without asking for confirmation")
+	err := cmd.MarkFlagRequired("file")
+	if err != nil {
+		log.Fatalf("Error marking file flag as required in astro organization rbac apply command: %s", err.Error())
+	}
+	return cmd
+}
+
+func newOrganizationAccessReportCmd(out io.Writer) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "access-report",
+		Short: "Show the effective roles of every user, team and API token on each Workspace and Deployment",
+		Long: "Show the effective roles of every user, team and API token of your Astro Organization on each Workspace and Deployment, " +
+			"including the roles granted through teams. Use --output csv or --output json to export the report\n" +
+			"$astro organization access-report --output csv > access.csv",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return organizationAccessReport(cmd, out)
+		},
+	}
+	return cmd
+}
+
 func newOrganizationUserRootCmd(out io.Writer) *cobra.Command {
 	cmd := &cobra.Command{
 		Use:     "user",
@@ -153,6 +246,9 @@ func newOrganizationUserInviteCmd(out io.Writer) *cobra.Command {
 	}
 	cmd.Flags().StringVarP(&role, "role", "r", "ORGANIZATION_MEMBER", "The role for the "+
 		"user. Possible values are ORGANIZATION_MEMBER, ORGANIZATION_BILLING_ADMIN and ORGANIZATION_OWNER ")
+	cmd.Flags().StringVar(&usersFile, "from-file", "", "Location of a CSV file with email and role columns, to invite every user of the file. "+
+		"The header row is optional, empty roles default to --role")
+	cmd.Flags().BoolVar(&usersDryRun, "dry-run", false, "Only validate the users file, without sending any invite")
 	return cmd
 }
 
@@ -226,9 +322,48 @@ func organizationExportAuditLogs(cmd *cobra.Command) error {
 	return orgExportAuditLogs(astroClient, out, orgName, auditLogsEarliestParam)
 }
 
+func organizationQueryAuditLogs(cmd *cobra.Command, out io.Writer) error {
+	query := auditLogsQuery
+	now := time.Now()
+	var err error
+	if auditLogsSince != "" {
+		if query.Since, err = util.ParseTime(auditLogsSince, now); err != nil {
+			return err
+		}
+	}
+	if auditLogsUntil != "" {
+		if query.Until, err = util.ParseTime(auditLogsUntil, now); err != nil {
+			return err
+		}
+	}
+
+	// Silence Usage as we have now validated command input
+	cmd.SilenceUsage = true
+
+	return orgQueryAuditLogs(astroClient, out, orgName, query)
+}
+
+func organizationRBACApply(cmd *cobra.Command, out io.Writer) error {
+	cmd.SilenceUsage = true
+	return orgApplyAccessFile(rbacAccessFile, rbacApplyOptions, out, astroCoreClient)
+}
+
+func organizationAccessReport(cmd *cobra.Command, out io.Writer) error {
+	cmd.SilenceUsage = true
+	return orgAccessReport(out, astroCoreClient)
+}
+
 func userInvite(cmd *cobra.Command, args []string, out io.Writer) error {
 	var email string
 
+	if usersFile != "" {
+		if len(args) > 0 {
+			return errUsersFileWithEmail
+		}
+		cmd.SilenceUsage = true
+		return user.BulkUsers(user.BulkInvite, usersFile, role, "", usersDryRun, out, astroCoreClient)
+	}
+
 	// if an email was provided in the args we use it
 	if len(args) > 0 {
 		// make sure the email is lowercase
@@ -479,6 +614,7 @@ func newOrganizationTokenRootCmd(out io.Writer) *cobra.Command {
 		newOrganizationTokenUpdateCmd(out),
 		newOrganizationTokenRotateCmd(out),
 		newOrganizationTokenDeleteCmd(out),
+		newOrganizationTokenAuditCmd(out),
 	)
 	cmd.PersistentFlags().StringVar(&organizationID, "organization-id", "", "organization where you would like to manage tokens")
 	return cmd
@@ -566,10 +702,30 @@ func newOrganizationTokenRotateCmd(out io.Writer) *cobra.Command {
 	cmd.Flags().BoolVarP(&cleanTokenOutput, "clean-output", "c", false, "Print only the token as output. For use of the command in scripts")
 	cmd.Flags().StringVarP(&name, "name", "t", "", "The name of the token to be rotated. If the name contains a space, specify the entire name within quotes \"\" ")
 	cmd.Flags().BoolVarP(&forceRotate, "force", "f", false, "Rotate the Organization API token without showing a warning")
+	cmd.Flags().StringVar(&tokenExpiringWithin, "expiring-within", "", "Rotate every Organization and Workspace API token expiring within this duration, like 7d or 36h, instead of a single token. Tokens which have already expired are listed but not rotated")
+	cmd.Flags().StringVar(&tokenWriteTo, "write-to", "", "Where to write the new tokens with --expiring-within: an env file, updated with one NAME=token line per token, "+
+		"or cmd:<command>, run for each token with the token on stdin and ASTRO_API_TOKEN_ID, ASTRO_API_TOKEN_NAME, ASTRO_API_TOKEN_ENV_KEY, ASTRO_API_TOKEN_SCOPE and ASTRO_API_TOKEN_WORKSPACE_ID set")
 
 	return cmd
 }
 
+func newOrganizationTokenAuditCmd(out io.Writer) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "audit",
+		Aliases: []string{"au"},
+		Short:   "List the Organization and Workspace API tokens which expire soon, are unused or have over-broad roles",
+		Long: "List the Organization and Workspace API tokens which expire soon, are unused or have over-broad roles\n" +
+			"$astro organization token audit --expiring-within 30d --unused-for 90d",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return auditOrganizationTokens(cmd, out)
+		},
+	}
+	cmd.Flags().StringVar(&tokenAuditExpiringWithin, "expiring-within", "30d", "Flag tokens expiring within this duration, like 7d or 36h. Set to 0 to skip the check")
+	cmd.Flags().StringVar(&tokenUnusedFor, "unused-for", "90d", "Flag tokens which have not been used for this duration. Set to 0 to skip the check")
+	cmd.Flags().BoolVar(&tokenCheckRoles, "check-roles", true, "Flag tokens with an Organization or Workspace owner role")
+	return cmd
+}
+
 //nolint:dupl
 func newOrganizationTokenDeleteCmd(out io.Writer) *cobra.Command {
 	cmd := &cobra.Command{
@@ -643,10 +799,37 @@ func rotateOrganizationToken(cmd *cobra.Command, args []string, out io.Writer) e
 		tokenID = strings.ToLower(args[0])
 	}
 
+	if tokenExpiringWithin != "" {
+		if len(args) > 0 || name != "" {
+			return errTokenIDWithExpiringWithin
+		}
+		expiringWithin, err := util.ParseDuration(tokenExpiringWithin)
+		if err != nil {
+			return err
+		}
+		cmd.SilenceUsage = true
+		return organization.RotateExpiringTokens(organizationID, expiringWithin, tokenWriteTo, forceRotate, out, astroCoreClient)
+	}
+
 	cmd.SilenceUsage = true
 	return organization.RotateToken(tokenID, name, cleanTokenOutput, forceRotate, out, astroCoreClient)
 }
 
+func auditOrganizationTokens(cmd *cobra.Command, out io.Writer) error {
+	expiringWithin, err := util.ParseDuration(tokenAuditExpiringWithin)
+	if err != nil {
+		return err
+	}
+	unusedFor, err := util.ParseDuration(tokenUnusedFor)
+	if err != nil {
+		return err
+	}
+
+	cmd.SilenceUsage = true
+	opts := organization.TokenAuditOptions{OrganizationID: organizationID, ExpiringWithin: expiringWithin, UnusedFor: unusedFor, IncludeOverBroad: tokenCheckRoles}
+	return organization.AuditTokens(opts, out, astroCoreClient)
+}
+
 //nolint:dupl
 func deleteOrganizationToken(cmd *cobra.Command, args []string, out io.Writer) error {
 	// if an id was provided in the args we use it
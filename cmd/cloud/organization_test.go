@@ -14,6 +14,7 @@ import (
 	astro "github.com/astronomer/astro-cli/astro-client"
 	astrocore "github.com/astronomer/astro-cli/astro-client-core"
 	astrocore_mocks "github.com/astronomer/astro-cli/astro-client-core/mocks"
+	"github.com/astronomer/astro-cli/cloud/organization"
 	"github.com/astronomer/astro-cli/cloud/user"
 	"github.com/astronomer/astro-cli/config"
 	testUtil "github.com/astronomer/astro-cli/pkg/testing"
@@ -117,6 +118,77 @@ func TestOrganizationExportAuditLogs(t *testing.T) {
 	os.Remove("test.json")
 }
 
+func TestOrganizationQueryAuditLogs(t *testing.T) {
+	testUtil.InitTestConfig(testUtil.CloudPlatform)
+	config.CFG.AuditLogs.SetHomeString("true")
+	var calledQuery organization.AuditLogQuery
+	orgQueryAuditLogs = func(client astro.Client, out io.Writer, orgName string, query organization.AuditLogQuery) error {
+		calledQuery = query
+		return nil
+	}
+	defer func() { orgQueryAuditLogs = organization.QueryAuditLogs }()
+
+	t.Run("Fails without organization name", func(t *testing.T) {
+		_, err := execOrganizationCmd("audit-logs", "query")
+		assert.ErrorContains(t, err, "required flag(s) \"organization-name\" not set")
+	})
+
+	t.Run("With filters", func(t *testing.T) {
+		cmdArgs := []string{"audit-logs", "query", "--organization-name", "Astronomer", "--action", "deployment.*", "--since", "2023-05-01T00:00:00Z", "--until", "2d", "--ndjson"}
+		_, err := execOrganizationCmd(cmdArgs...)
+		assert.NoError(t, err)
+		assert.Equal(t, "deployment.*", calledQuery.Action)
+		assert.Equal(t, time.Date(2023, 5, 1, 0, 0, 0, 0, time.UTC), calledQuery.Since)
+		assert.WithinDuration(t, time.Now().Add(-48*time.Hour), calledQuery.Until, time.Minute)
+		assert.True(t, calledQuery.NDJSON)
+	})
+
+	t.Run("Fails with an invalid time", func(t *testing.T) {
+		_, err := execOrganizationCmd("audit-logs", "query", "--organization-name", "Astronomer", "--since", "yesterday")
+		assert.ErrorContains(t, err, "invalid time")
+	})
+}
+
+func TestOrganizationRBACApply(t *testing.T) {
+	testUtil.InitTestConfig(testUtil.CloudPlatform)
+	var (
+		calledPath string
+		calledOpts organization.RBACApplyOptions
+	)
+	orgApplyAccessFile = func(path string, opts organization.RBACApplyOptions, out io.Writer, client astrocore.CoreClient) error {
+		calledPath = path
+		calledOpts = opts
+		return nil
+	}
+	defer func() { orgApplyAccessFile = organization.ApplyAccessFile }()
+
+	t.Run("Fails without a file", func(t *testing.T) {
+		_, err := execOrganizationCmd("rbac", "apply")
+		assert.ErrorContains(t, err, "required flag(s) \"file\" not set")
+	})
+
+	t.Run("Passes the options", func(t *testing.T) {
+		_, err := execOrganizationCmd("rbac", "apply", "-f", "access.yaml", "--prune", "--dry-run")
+		assert.NoError(t, err)
+		assert.Equal(t, "access.yaml", calledPath)
+		assert.Equal(t, organization.RBACApplyOptions{Prune: true, DryRun: true}, calledOpts)
+	})
+}
+
+func TestOrganizationAccessReport(t *testing.T) {
+	testUtil.InitTestConfig(testUtil.CloudPlatform)
+	called := false
+	orgAccessReport = func(out io.Writer, client astrocore.CoreClient) error {
+		called = true
+		return nil
+	}
+	defer func() { orgAccessReport = organization.AccessReport }()
+
+	_, err := execOrganizationCmd("access-report")
+	assert.NoError(t, err)
+	assert.True(t, called)
+}
+
 // test organization user commands
 
 var (
@@ -1351,6 +1423,53 @@ func TestOrganizationTokenRotate(t *testing.T) {
 	})
 }
 
+func TestOrganizationTokenAudit(t *testing.T) {
+	testUtil.InitTestConfig(testUtil.CloudPlatform)
+	mockListWorkspaces := func(mockClient *astrocore_mocks.ClientWithResponsesInterface) {
+		mockClient.On("ListWorkspacesWithResponse", mock.Anything, mock.Anything, mock.Anything).Return(&astrocore.ListWorkspacesResponse{
+			HTTPResponse: &http.Response{StatusCode: 200},
+			JSON200:      &astrocore.WorkspacesPaginated{Workspaces: []astrocore.Workspace{}},
+		}, nil)
+	}
+
+	t.Run("lists tokens needing attention", func(t *testing.T) {
+		mockClient := new(astrocore_mocks.ClientWithResponsesInterface)
+		mockClient.On("ListOrganizationApiTokensWithResponse", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(&ListOrganizationAPITokensResponseOK, nil)
+		mockListWorkspaces(mockClient)
+		astroCoreClient = mockClient
+		resp, err := execOrganizationCmd("token", "audit", "--expiring-within", "7d", "--unused-for", "0")
+		assert.NoError(t, err)
+		assert.Contains(t, resp, "No API tokens need attention")
+	})
+
+	t.Run("invalid duration", func(t *testing.T) {
+		_, err := execOrganizationCmd("token", "audit", "--unused-for", "a while")
+		assert.ErrorContains(t, err, "invalid duration")
+	})
+
+	t.Run("bulk rotation requires a sink", func(t *testing.T) {
+		mockClient := new(astrocore_mocks.ClientWithResponsesInterface)
+		astroCoreClient = mockClient
+		_, err := execOrganizationCmd("token", "rotate", "--expiring-within", "7d", "--force")
+		assert.ErrorContains(t, err, "requires --write-to")
+	})
+
+	t.Run("bulk rotation with nothing expiring", func(t *testing.T) {
+		mockClient := new(astrocore_mocks.ClientWithResponsesInterface)
+		mockClient.On("ListOrganizationApiTokensWithResponse", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(&ListOrganizationAPITokensResponseOK, nil)
+		mockListWorkspaces(mockClient)
+		astroCoreClient = mockClient
+		resp, err := execOrganizationCmd("token", "rotate", "--expiring-within", "7d", "--write-to", "tokens.env", "--force")
+		assert.NoError(t, err)
+		assert.Contains(t, resp, "No API tokens expire within")
+	})
+
+	t.Run("bulk rotation does not take a token", func(t *testing.T) {
+		_, err := execOrganizationCmd("token", "rotate", "--name", "token", "--expiring-within", "7d", "--write-to", "tokens.env")
+		assert.ErrorIs(t, err, errTokenIDWithExpiringWithin)
+	})
+}
+
 func TestOrganizationTokenDelete(t *testing.T) {
 	expectedHelp := "Delete a Organization API token or remove an Organization API token from a Organization"
 	testUtil.InitTestConfig(testUtil.CloudPlatform)
@@ -3,6 +3,7 @@ package cloud
 import (
 	"fmt"
 	"io"
+	"log"
 	"os"
 	"strconv"
 	"strings"
@@ -11,6 +12,7 @@ import (
 	"github.com/astronomer/astro-cli/cloud/team"
 	"github.com/astronomer/astro-cli/cloud/user"
 	"github.com/astronomer/astro-cli/cloud/workspace"
+	"github.com/astronomer/astro-cli/cloud/workspace/clone"
 	"github.com/astronomer/astro-cli/pkg/input"
 	"github.com/astronomer/astro-cli/pkg/printutil"
 
@@ -35,6 +37,11 @@ var (
 	cleanTokenOutput           bool
 	forceRotate                bool
 	tokenExpiration            int
+	usersFile                  string
+	usersDryRun                bool
+	errUsersFileWithEmail      = errors.New("an email cannot be used with --from-file")
+	cloneOptions               clone.Options
+	workspaceClone             = clone.Clone
 )
 
 func newWorkspaceCmd(out io.Writer) *cobra.Command {
@@ -50,6 +57,7 @@ func newWorkspaceCmd(out io.Writer) *cobra.Command {
 		newWorkspaceCreateCmd(out),
 		newWorkspaceUpdateCmd(out),
 		newWorkspaceDeleteCmd(out),
+		newWorkspaceCloneCmd(out),
 		newWorkspaceUserRootCmd(out),
 		newWorkspaceTokenRootCmd(out),
 		newWorkspaceTeamRootCmd(out),
@@ -131,6 +139,31 @@ func newWorkspaceDeleteCmd(out io.Writer) *cobra.Command {
 	return cmd
 }
 
+func newWorkspaceCloneCmd(out io.Writer) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "clone [workspace_id]",
+		Short: "Create a copy of an Astro Workspace",
+		Long: "Create a new Astro Workspace with the settings, Deployments and user and team roles of an existing Workspace. " +
+			"Each Deployment is recreated from its template and named after the source Deployment and the new Workspace.\n" +
+			"$astro workspace clone [workspace_id] --name [new workspace name]",
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return workspaceCloneRun(cmd, out, args)
+		},
+	}
+	cmd.Flags().StringVarP(&workspaceName, "name", "n", "", "The new Workspace's name. If the name contains a space, specify the entire name within quotes \"\" ")
+	cmd.Flags().StringVarP(&workspaceDescription, "description", "d", "", "Description of the new Workspace. Defaults to the description of the source Workspace")
+	cmd.Flags().BoolVar(&cloneOptions.EnvVars, "env-vars", false, "Copy the environment variables of each Deployment. Secret environment variables are never copied")
+	cmd.Flags().BoolVar(&cloneOptions.Connections, "connections", false, "Copy the Airflow connections of each Deployment. The password and extra fields are not copied")
+	cmd.Flags().BoolVar(&cloneOptions.AirflowVariables, "airflow-variables", false, "Copy the Airflow variables of each Deployment")
+	cmd.Flags().BoolVar(&cloneOptions.Tokens, "tokens", false, "Create a Workspace API token in the new Workspace for each Workspace API token of the source Workspace. The new token values are printed once")
+	err := cmd.MarkFlagRequired("name")
+	if err != nil {
+		log.Fatalf("Error marking name flag as required in astro workspace clone command: %s", err.Error())
+	}
+	return cmd
+}
+
 func newWorkspaceUserRootCmd(out io.Writer) *cobra.Command {
 	cmd := &cobra.Command{
 		Use:     "user",
@@ -162,6 +195,7 @@ func newWorkspaceUserAddCmd(out io.Writer) *cobra.Command {
 	}
 	cmd.Flags().StringVarP(&addWorkspaceRole, "role", "r", "WORKSPACE_MEMBER", "The role for the "+
 		"new user. Possible values are WORKSPACE_MEMBER, WORKSPACE_AUTHOR, WORKSPACE_OPERATOR and WORKSPACE_OWNER ")
+	addUsersFileFlags(cmd, "add")
 	return cmd
 }
 
@@ -191,6 +225,7 @@ func newWorkspaceUserUpdateCmd(out io.Writer) *cobra.Command {
 	}
 	cmd.Flags().StringVarP(&updateWorkspaceRole, "role", "r", "", "The new role for the "+
 		"user. Possible values are WORKSPACE_MEMBER, WORKSPACE_AUTHOR, WORKSPACE_OPERATOR and WORKSPACE_OWNER ")
+	addUsersFileFlags(cmd, "update")
 	return cmd
 }
 
@@ -504,9 +539,43 @@ func workspaceDelete(cmd *cobra.Command, out io.Writer, args []string) error {
 	return workspace.Delete(id, out, astroCoreClient)
 }
 
+func workspaceCloneRun(cmd *cobra.Command, out io.Writer, args []string) error {
+	id := ""
+	if len(args) == 1 {
+		id = args[0]
+	}
+	cmd.SilenceUsage = true
+	var err error
+	if id == "" {
+		id, err = workspace.GetWorkspaceSelection(astroCoreClient, out)
+		if err != nil {
+			return err
+		}
+	}
+	opts := cloneOptions
+	opts.Name = workspaceName
+	opts.Description = workspaceDescription
+	return workspaceClone(id, opts, astroClient, astroCoreClient, airflowAPIClient, out)
+}
+
+// addUsersFileFlags adds the flags running a user command for every row of a CSV file
+func addUsersFileFlags(cmd *cobra.Command, verb string) {
+	cmd.Flags().StringVar(&usersFile, "from-file", "", "Location of a CSV file with email, role and workspace_id columns, to "+verb+" every user of the file. "+
+		"The header row is optional, empty roles and workspace ids default to --role and --workspace-id")
+	cmd.Flags().BoolVar(&usersDryRun, "dry-run", false, "Only validate the users file, without making any change")
+}
+
 func addWorkspaceUser(cmd *cobra.Command, args []string, out io.Writer) error {
 	var email string
 
+	if usersFile != "" {
+		if len(args) > 0 {
+			return errUsersFileWithEmail
+		}
+		cmd.SilenceUsage = true
+		return user.BulkUsers(user.BulkAddWorkspaceUser, usersFile, addWorkspaceRole, workspaceID, usersDryRun, out, astroCoreClient)
+	}
+
 	// if an email was provided in the args we use it
 	if len(args) > 0 {
 		// make sure the email is lowercase
@@ -525,6 +594,14 @@ func listWorkspaceUser(cmd *cobra.Command, out io.Writer) error {
 func updateWorkspaceUser(cmd *cobra.Command, args []string, out io.Writer) error {
 	var email string
 
+	if usersFile != "" {
+		if len(args) > 0 {
+			return errUsersFileWithEmail
+		}
+		cmd.SilenceUsage = true
+		return user.BulkUsers(user.BulkUpdateWorkspaceUserRole, usersFile, updateWorkspaceRole, workspaceID, usersDryRun, out, astroCoreClient)
+	}
+
 	// if an email was provided in the args we use it
 	if len(args) > 0 {
 		// make sure the email is lowercase
@@ -4,15 +4,20 @@ import (
 	"bytes"
 	"encoding/json"
 	"fmt"
+	"io"
 	"net/http"
 	"os"
+	"path/filepath"
 	"testing"
 	"time"
 
+	airflowclient "github.com/astronomer/astro-cli/airflow-client"
+	"github.com/astronomer/astro-cli/astro-client"
 	astrocore "github.com/astronomer/astro-cli/astro-client-core"
 	astrocore_mocks "github.com/astronomer/astro-cli/astro-client-core/mocks"
 	"github.com/astronomer/astro-cli/cloud/user"
 	"github.com/astronomer/astro-cli/cloud/workspace"
+	"github.com/astronomer/astro-cli/cloud/workspace/clone"
 	testUtil "github.com/astronomer/astro-cli/pkg/testing"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
@@ -1598,3 +1603,57 @@ func TestWorkspaceTokenAdd(t *testing.T) {
 		assert.NoError(t, err)
 	})
 }
+
+func TestWorkspaceUserFromFile(t *testing.T) {
+	testUtil.InitTestConfig(testUtil.CloudPlatform)
+	usersFilePath := filepath.Join(t.TempDir(), "users.csv")
+	err := os.WriteFile(usersFilePath, []byte("email,role\nuser@1.com,WORKSPACE_AUTHOR\n"), 0o600)
+	assert.NoError(t, err)
+
+	t.Run("an email cannot be used with a file", func(t *testing.T) {
+		_, err := execWorkspaceCmd("user", "add", "user@1.com", "--from-file", usersFilePath)
+		assert.ErrorIs(t, err, errUsersFileWithEmail)
+	})
+	t.Run("adds every user of the file", func(t *testing.T) {
+		mockClient := new(astrocore_mocks.ClientWithResponsesInterface)
+		mockClient.On("ListOrgUsersWithResponse", mock.Anything, mock.Anything, mock.Anything).Return(&ListOrgUsersResponseOK, nil).Twice()
+		mockClient.On("MutateWorkspaceUserRoleWithResponse", mock.Anything, mock.Anything, "ws-1", "user1-id", astrocore.MutateWorkspaceUserRoleRequest{Role: "WORKSPACE_AUTHOR"}).Return(&MutateWorkspaceUserRoleResponseOK, nil).Once()
+		astroCoreClient = mockClient
+		resp, err := execWorkspaceCmd("user", "add", "--from-file", usersFilePath, "--workspace-id", "ws-1")
+		assert.NoError(t, err)
+		assert.Regexp(t, `user@1.com\s+WORKSPACE_AUTHOR\s+ws-1\s+done`, resp)
+		mockClient.AssertExpectations(t)
+	})
+	t.Run("dry run updates no role", func(t *testing.T) {
+		mockClient := new(astrocore_mocks.ClientWithResponsesInterface)
+		mockClient.On("ListWorkspaceUsersWithResponse", mock.Anything, mock.Anything, "ws-1", mock.Anything).Return(&ListWorkspaceUsersResponseOK, nil).Twice()
+		astroCoreClient = mockClient
+		resp, err := execWorkspaceCmd("user", "update", "--from-file", usersFilePath, "--workspace-id", "ws-1", "--dry-run")
+		assert.NoError(t, err)
+		assert.Contains(t, resp, "valid, skipped by --dry-run")
+		mockClient.AssertExpectations(t)
+	})
+}
+
+func TestWorkspaceClone(t *testing.T) {
+	testUtil.InitTestConfig(testUtil.CloudPlatform)
+	origClone := workspaceClone
+	defer func() { workspaceClone = origClone }()
+
+	t.Run("name is required", func(t *testing.T) {
+		_, err := execWorkspaceCmd("clone", "source-id")
+		assert.ErrorContains(t, err, `required flag(s) "name" not set`)
+	})
+	t.Run("clones the workspace with the requested options", func(t *testing.T) {
+		var gotID string
+		var gotOpts clone.Options
+		workspaceClone = func(sourceID string, opts clone.Options, client astro.Client, coreClient astrocore.CoreClient, airflowAPIClient airflowclient.Client, out io.Writer) error {
+			gotID, gotOpts = sourceID, opts
+			return nil
+		}
+		_, err := execWorkspaceCmd("clone", "source-id", "--name", "sandbox", "--env-vars", "--connections", "--tokens")
+		assert.NoError(t, err)
+		assert.Equal(t, "source-id", gotID)
+		assert.Equal(t, clone.Options{Name: "sandbox", EnvVars: true, Connections: true, Tokens: true}, gotOpts)
+	})
+}
@@ -8,7 +8,11 @@ import (
 	"github.com/astronomer/astro-cli/context"
 )
 
-var noPrompt bool
+var (
+	noPrompt     bool
+	noProxyHosts string
+	unsetProxy   bool
+)
 
 func newContextCmd(out io.Writer) *cobra.Command {
 	cmd := &cobra.Command{
@@ -21,6 +25,7 @@ func newContextCmd(out io.Writer) *cobra.Command {
 		newContextListCmd(out),
 		newContextSwitchCmd(),
 		newContextDeleteCmd(),
+		newContextProxyCmd(out),
 	)
 	return cmd
 }
@@ -65,3 +70,29 @@ func newContextDeleteCmd() *cobra.Command {
 	cmd.Flags().BoolVarP(&noPrompt, "force", "f", false, "Don't prompt a user before context delete; assume \"yes\" as answer to all prompts and run non-interactively.")
 	return cmd
 }
+
+func newContextProxyCmd(out io.Writer) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "proxy [proxy-url]",
+		Short: "Set the proxy of the current context",
+		Long: "Set the HTTP(S) proxy used to reach the current context. Without a proxy the HTTPS_PROXY and NO_PROXY environment variables are used.\n\n" +
+			"Registry logins and image pushes are performed by the Docker daemon, which doesn't use this proxy nor the tls.ca_bundle, tls.client_cert and tls.client_key settings. " +
+			"Configure the daemon proxy (https://docs.docker.com/config/daemon/systemd/#httphttps-proxy) and copy the certificates to /etc/docker/certs.d/<registry>/ (https://docs.docker.com/engine/security/certificates/)",
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) == 0 && !unsetProxy {
+				return cmd.Help()
+			}
+			cmd.SilenceUsage = true
+			proxyURL := ""
+			if len(args) == 1 && !unsetProxy {
+				proxyURL = args[0]
+			}
+			return context.SetProxy(proxyURL, noProxyHosts, out)
+		},
+	}
+
+	cmd.Flags().StringVar(&noProxyHosts, "no-proxy", "", "Comma separated list of hosts to reach without the proxy, in the NO_PROXY format")
+	cmd.Flags().BoolVar(&unsetProxy, "unset", false, "Remove the proxy of the current context")
+	return cmd
+}
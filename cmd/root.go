@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"net/http"
 	"os"
+	"sync"
 	"time"
 
 	"github.com/astronomer/astro-cli/cmd/registry"
@@ -18,6 +19,7 @@ import (
 	"github.com/astronomer/astro-cli/houston"
 	"github.com/astronomer/astro-cli/pkg/ansi"
 	"github.com/astronomer/astro-cli/pkg/httputil"
+	"github.com/astronomer/astro-cli/pkg/printutil"
 	"github.com/astronomer/astro-cli/version"
 
 	"github.com/google/go-github/v48/github"
@@ -27,24 +29,33 @@ import (
 
 var (
 	verboseLevel   string
+	debugHTTP      string
 	houstonClient  houston.ClientInterface
 	houstonVersion string
+
+	registerHTTPTracing sync.Once
 )
 
 const (
 	softwarePlatform = "Astronomer Software"
 	cloudPlatform    = "Astro"
+
+	debugHTTPStderr = "stderr"
 )
 
 // NewRootCmd adds all of the primary commands for the cli
 func NewRootCmd() *cobra.Command {
 	var err error
+	printutil.SetOutputFormat(printutil.OutputFormat{})
+	if err = httputil.SetTransportOptions(context.TransportOptions()); err != nil {
+		softwareCmd.InitDebugLogs = append(softwareCmd.InitDebugLogs, "Error configuring the network transport: "+err.Error())
+	}
 	httpClient := houston.NewHTTPClient()
 	houstonClient = houston.NewClient(httpClient)
 
-	airflowClient := airflowclient.NewAirflowClient(httputil.NewHTTPClient())
-	astroClient := astro.NewAstroClient(httputil.NewHTTPClient())
-	astroCoreClient := astrocore.NewCoreClient(httputil.NewHTTPClient())
+	airflowClient := airflowclient.NewAirflowClient(newAPIHTTPClient())
+	astroClient := astro.NewAstroClient(newAPIHTTPClient())
+	astroCoreClient := astrocore.NewCoreClient(newAPIHTTPClient())
 
 	ctx := cloudPlatform
 	isCloudCtx := context.IsCloudContext()
@@ -70,8 +81,8 @@ func NewRootCmd() *cobra.Command {
 
 Welcome to the Astro CLI, the modern command line interface for data orchestration. You can use it for Astro, Astronomer Software, or Local Development.`,
 		PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
-			// Check for latest version
-			if config.CFG.UpgradeMessage.GetBool() {
+			// Check for latest version, unless the output is meant to be parsed
+			if config.CFG.UpgradeMessage.GetBool() && !printutil.IsStructuredOutput() {
 				// create github client with 3 second timeout, setting an aggressive timeout since its not mandatory to get a response in each command execution
 				githubClient := github.NewClient(&http.Client{Timeout: 3 * time.Second})
 				// compare current version to latest
@@ -104,6 +115,7 @@ Welcome to the Astro CLI, the modern command line interface for data orchestrati
 		newContextCmd(os.Stdout),
 		newConfigRootCmd(os.Stdout),
 		newRunCommand(),
+		newRuntimeRootCmd(os.Stdout),
 	)
 
 	if context.IsCloudContext() { // Include all the commands to be exposed for cloud users
@@ -123,10 +135,45 @@ Welcome to the Astro CLI, the modern command line interface for data orchestrati
 
 	rootCmd.SetHelpTemplate(getResourcesHelpTemplate(houstonVersion, ctx))
 	rootCmd.PersistentFlags().StringVarP(&verboseLevel, "verbosity", "", logrus.WarnLevel.String(), "Log level (debug, info, warn, error, fatal, panic")
+	rootCmd.PersistentFlags().StringVar(&debugHTTP, "debug-http", "", "Dump every API request and response, with secrets redacted, to stderr or with --debug-http=<file>.har to a HAR file")
+	rootCmd.PersistentFlags().Lookup("debug-http").NoOptDefVal = debugHTTPStderr
+	rootCmd.PersistentFlags().Var(printutil.OutputFlag{}, "output", "Output format of tables: table, json, yaml, csv or template='<go template>', e.g. template='{{.ID}}', messages are printed to stderr in formats other than table")
+	// subcommands override PersistentPreRunE, so tracing is set up in an initializer which runs for every command
+	registerHTTPTracing.Do(func() {
+		cobra.OnInitialize(setUpHTTPTracing)
+		cobra.OnFinalize(closeHTTPTracing)
+	})
 
 	return rootCmd
 }
 
+// setUpHTTPTracing installs the tracer requested through --debug-http for every API client
+func setUpHTTPTracing() {
+	switch debugHTTP {
+	case "":
+		httputil.SetTracer(nil)
+	case debugHTTPStderr:
+		httputil.SetTracer(httputil.NewTextTracer(os.Stderr))
+	default:
+		httputil.SetTracer(httputil.NewHARTracer(debugHTTP, version.CurrVersion))
+	}
+}
+
+// closeHTTPTracing writes the exchanges buffered by the tracer once the command has run
+func closeHTTPTracing() {
+	if err := httputil.CloseTracer(); err != nil {
+		fmt.Fprintf(os.Stderr, "Unable to write HTTP trace to %s: %s\n", debugHTTP, err.Error())
+	}
+}
+
+// newAPIHTTPClient returns an HTTP client with the retry and response cache settings from the config applied
+func newAPIHTTPClient() *httputil.HTTPClient {
+	httpClient := httputil.NewHTTPClient()
+	httpClient.SetMaxRetries(config.CFG.HTTPMaxRetries.GetInt())
+	httpClient.EnableCache(config.HTTPCacheDir(), time.Duration(config.CFG.HTTPCacheTTL.GetInt())*time.Second)
+	return httpClient
+}
+
 func getResourcesHelpTemplate(houstonVersion, ctx string) string {
 	return fmt.Sprintf(`{{with (or .Long .Short)}}{{. | trimTrailingWhitespaces}}
 
@@ -4,6 +4,8 @@ import (
 	"bytes"
 	"testing"
 
+	"github.com/astronomer/astro-cli/pkg/httputil"
+	"github.com/astronomer/astro-cli/pkg/printutil"
 	testUtil "github.com/astronomer/astro-cli/pkg/testing"
 	"github.com/astronomer/astro-cli/version"
 	"github.com/spf13/cobra"
@@ -73,3 +75,41 @@ func TestRootCommandSoftwareContext(t *testing.T) {
 	assert.Contains(t, output, "run")
 	assert.NotContains(t, output, "Run flow commands")
 }
+
+func TestRootCommandDebugHTTPFlag(t *testing.T) {
+	testUtil.InitTestConfig(testUtil.LocalPlatform)
+	output, err := executeCommand("help")
+	assert.NoError(t, err)
+	assert.Contains(t, output, "--debug-http")
+
+	defer func() {
+		debugHTTP = ""
+		httputil.SetTracer(nil)
+	}()
+	_, err = executeCommand("config", "get", "-g", "context", "--debug-http")
+	assert.NoError(t, err)
+	assert.Equal(t, debugHTTPStderr, debugHTTP)
+}
+
+func TestRootCommandOutputFlag(t *testing.T) {
+	testUtil.InitTestConfig(testUtil.LocalPlatform)
+	defer printutil.SetOutputFormat(printutil.OutputFormat{})
+
+	output, err := executeCommand("runtime", "list", "--output", "xml")
+	assert.ErrorContains(t, err, "invalid output format")
+	assert.Contains(t, output, "--output format")
+
+	_, err = executeCommand("config", "get", "-g", "context", "--output", "json")
+	assert.NoError(t, err)
+	assert.Equal(t, printutil.JSONFormat, printutil.CurrentOutputFormat().Name)
+}
+
+func TestRootCommandDocumentOutputFlag(t *testing.T) {
+	testUtil.InitTestConfig(testUtil.SoftwarePlatform)
+	defer printutil.SetOutputFormat(printutil.OutputFormat{})
+
+	output, err := executeCommand("deployment", "inspect", "--help")
+	assert.NoError(t, err)
+	assert.Contains(t, output, "-o, --output format")
+	assert.Contains(t, output, "(default yaml)")
+}
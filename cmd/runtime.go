@@ -0,0 +1,45 @@
+package cmd
+
+import (
+	"io"
+
+	airflowversions "github.com/astronomer/astro-cli/airflow_versions"
+	"github.com/astronomer/astro-cli/pkg/httputil"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	showPreReleases bool
+
+	runtimeHTTPClient = httputil.NewHTTPClient
+)
+
+func newRuntimeRootCmd(out io.Writer) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "runtime",
+		Short: "Explore Astro Runtime versions",
+		Long:  "Explore the Astro Runtime versions available to run Airflow, locally and on Astronomer",
+	}
+	cmd.AddCommand(
+		newRuntimeListCmd(out),
+	)
+	return cmd
+}
+
+func newRuntimeListCmd(out io.Writer) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "list",
+		Aliases: []string{"ls"},
+		Short:   "List Astro Runtime versions",
+		Long:    "List stable, deprecated and end of life Astro Runtime versions with their Airflow version and release date. The last fetched list is used when offline",
+		Args:    cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cmd.SilenceUsage = true
+			httpClient := airflowversions.NewClient(runtimeHTTPClient(), false)
+			return airflowversions.ListRuntimeReleases(httpClient, showPreReleases, out)
+		},
+	}
+	cmd.Flags().BoolVarP(&showPreReleases, "all", "a", false, "Include pre-release versions, like alpha and beta versions")
+	return cmd
+}
@@ -0,0 +1,122 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	airflowversions "github.com/astronomer/astro-cli/airflow_versions"
+	"github.com/astronomer/astro-cli/config"
+	"github.com/astronomer/astro-cli/pkg/httputil"
+	testUtil "github.com/astronomer/astro-cli/pkg/testing"
+	"github.com/stretchr/testify/assert"
+)
+
+var mockRuntimeCatalog = airflowversions.Response{RuntimeVersions: map[string]airflowversions.RuntimeVersion{
+	"4.2.5":        {Metadata: airflowversions.RuntimeVersionMetadata{AirflowVersion: "2.2.5", Channel: airflowversions.VersionChannelDeprecated, ReleaseDate: "2022-04-07", EndOfSupport: "2022-10-07"}},
+	"7.0.0":        {Metadata: airflowversions.RuntimeVersionMetadata{AirflowVersion: "2.5.0", Channel: airflowversions.VersionChannelStable, ReleaseDate: "2022-12-05", EndOfSupport: "2999-12-05"}},
+	"7.1.0-alpha1": {Metadata: airflowversions.RuntimeVersionMetadata{AirflowVersion: "2.5.1", Channel: "alpha", ReleaseDate: "2023-01-01"}},
+}}
+
+func execRuntimeCmd(args ...string) (string, error) {
+	buf := new(bytes.Buffer)
+	cmd := newRuntimeRootCmd(buf)
+	cmd.SetOut(buf)
+	cmd.SetArgs(args)
+	testUtil.SetupOSArgsForGinkgo()
+	_, err := cmd.ExecuteC()
+	return buf.String(), err
+}
+
+// useRuntimeHTTPClient makes runtime list use client, and keeps the catalog cache in a temporary directory
+func useRuntimeHTTPClient(t *testing.T, client *httputil.HTTPClient) string {
+	origHomeConfigPath := config.HomeConfigPath
+	config.HomeConfigPath = t.TempDir()
+	runtimeHTTPClient = func() *httputil.HTTPClient { return client }
+	t.Cleanup(func() {
+		config.HomeConfigPath = origHomeConfigPath
+		runtimeHTTPClient = httputil.NewHTTPClient
+		showPreReleases = false
+	})
+	return config.HomeConfigPath
+}
+
+func TestRuntimeRootCommand(t *testing.T) {
+	testUtil.InitTestConfig(testUtil.LocalPlatform)
+	output, err := executeCommand("runtime")
+	assert.NoError(t, err)
+	assert.Contains(t, output, "list")
+
+	output, err = executeCommand("runtime", "list", "--help")
+	assert.NoError(t, err)
+	assert.Contains(t, output, "--all")
+}
+
+func TestRuntimeList(t *testing.T) {
+	testUtil.InitTestConfig(testUtil.LocalPlatform)
+
+	t.Run("fetched catalog", func(t *testing.T) {
+		body, err := json.Marshal(mockRuntimeCatalog)
+		assert.NoError(t, err)
+		homeConfigPath := useRuntimeHTTPClient(t, testUtil.NewTestClient(func(req *http.Request) *http.Response {
+			assert.Equal(t, airflowversions.RuntimeReleaseURL, req.URL.String())
+			return &http.Response{
+				StatusCode: 200,
+				Body:       io.NopCloser(bytes.NewBuffer(body)),
+				Header:     make(http.Header),
+			}
+		}))
+
+		output, err := execRuntimeCmd("list")
+		assert.NoError(t, err)
+		assert.Regexp(t, `7\.0\.0\s+2\.5\.0\s+2022-12-05\s+2999-12-05\s+Stable`, output)
+		assert.Regexp(t, `4\.2\.5\s+2\.2\.5\s+2022-04-07\s+2022-10-07\s+End of life`, output)
+		assert.NotContains(t, output, "7.1.0-alpha1")
+		assert.FileExists(t, filepath.Join(homeConfigPath, "cache", "astro-runtime-versions.json"))
+
+		output, err = execRuntimeCmd("list", "--all")
+		assert.NoError(t, err)
+		assert.Contains(t, output, "7.1.0-alpha1")
+	})
+
+	t.Run("cached catalog when offline", func(t *testing.T) {
+		offline := httputil.NewHTTPClient()
+		offline.HTTPClient.Transport = roundTripError{err: errRuntimeCatalogOffline}
+		homeConfigPath := useRuntimeHTTPClient(t, offline)
+
+		cache, err := json.Marshal(map[string]interface{}{"fetched_at": time.Date(2023, 1, 2, 0, 0, 0, 0, time.UTC), "response": mockRuntimeCatalog})
+		assert.NoError(t, err)
+		assert.NoError(t, os.MkdirAll(filepath.Join(homeConfigPath, "cache"), 0o700))
+		assert.NoError(t, os.WriteFile(filepath.Join(homeConfigPath, "cache", "astro-runtime-versions.json"), cache, 0o600))
+
+		output, err := execRuntimeCmd("list")
+		assert.NoError(t, err)
+		assert.Regexp(t, `7\.0\.0\s+2\.5\.0\s+2022-12-05\s+2999-12-05\s+Stable`, output)
+		assert.Regexp(t, `4\.2\.5\s+2\.2\.5\s+2022-04-07\s+2022-10-07\s+End of life`, output)
+	})
+
+	t.Run("offline without a cached catalog", func(t *testing.T) {
+		offline := httputil.NewHTTPClient()
+		offline.HTTPClient.Transport = roundTripError{err: errRuntimeCatalogOffline}
+		useRuntimeHTTPClient(t, offline)
+
+		_, err := execRuntimeCmd("list")
+		assert.ErrorIs(t, err, errRuntimeCatalogOffline)
+	})
+}
+
+var errRuntimeCatalogOffline = errors.New("dial tcp: lookup updates.astronomer.io: no such host")
+
+type roundTripError struct {
+	err error
+}
+
+func (r roundTripError) RoundTrip(*http.Request) (*http.Response, error) {
+	return nil, r.err
+}
@@ -1,6 +1,7 @@
 package software
 
 import (
+	"errors"
 	"fmt"
 
 	"github.com/astronomer/astro-cli/cmd/utils"
@@ -15,11 +16,14 @@ var (
 	forceDeploy      bool
 	forcePrompt      bool
 	saveDeployConfig bool
+	dags             bool
+	imageTags        []string
 
 	ignoreCacheDeploy = false
 
 	EnsureProjectDir   = utils.EnsureProjectDir
 	DeployAirflowImage = deploy.Airflow
+	DagsOnlyDeploy     = deploy.DagsOnlyDeploy
 )
 
 var deployExample = `
@@ -30,12 +34,26 @@ Deployment you would like to deploy to Airflow cluster:
 Menu will be presented if you do not specify a deployment name:
 
   $ astro deploy
+
+Push only the dags folder to a deployment using DAG-only deploys:
+
+  $ astro deploy <deployment-id> --dags
+
+Push the image to a bring-your-own registry with the git SHA and the git tag of the project:
+
+  $ astro deploy <deployment-id> --image-tag='{{.ReleaseName}}-{{.GitSHA}}' --image-tag='{{.GitTag}}'
 `
 
 const (
 	registryUncommittedChanges = "Project directory has uncommmited changes, use `astro deploy <deployment-id> -f` to force deploy."
+
+	imageTagFlagUsage = "Template of a tag of the image pushed to a bring-your-own registry, can be repeated. " +
+		"Templates can use {{.ReleaseName}}, {{.GitSHA}}, {{.GitTag}}, {{.Timestamp}}, {{.RuntimeVersion}} and {{.AirflowVersion}}. " +
+		"Existing tags are never overwritten and the deployment is updated to the digest of the image. Defaults to " + deploy.DefaultImageTagTemplate
 )
 
+var errImageTagWithoutBYO = errors.New("--image-tag can only be used when a bring-your-own registry is enabled on the platform")
+
 func NewDeployCmd() *cobra.Command {
 	cmd := &cobra.Command{
 		Use:     "deploy [DEPLOYMENT ID]",
@@ -50,7 +68,9 @@ func NewDeployCmd() *cobra.Command {
 	cmd.Flags().BoolVarP(&forcePrompt, "prompt", "p", false, "Force prompt to choose target deployment")
 	cmd.Flags().BoolVarP(&saveDeployConfig, "save", "s", false, "Save deployment in config for future deploys")
 	cmd.Flags().BoolVarP(&ignoreCacheDeploy, "no-cache", "", false, "Do not use cache when building container image")
+	cmd.Flags().BoolVarP(&dags, "dags", "d", false, "Push only DAGs to your Deployment. The Deployment must use the dag_deploy DAG deployment type")
 	cmd.Flags().StringVar(&workspaceID, "workspace-id", "", "workspace assigned to deployment")
+	cmd.Flags().StringArrayVar(&imageTags, "image-tag", nil, imageTagFlagUsage)
 	return cmd
 }
 
@@ -83,6 +103,10 @@ func deployAirflow(cmd *cobra.Command, args []string) error {
 	// Silence Usage as we have now validated command input
 	cmd.SilenceUsage = true
 
+	if dags {
+		return DagsOnlyDeploy(houstonClient, appConfig, config.WorkingPath, deploymentID, ws, forcePrompt)
+	}
+
 	var byoRegistryEnabled bool
 	var byoRegistryDomain string
 	if appConfig != nil && appConfig.Flags.BYORegistryEnabled {
@@ -90,5 +114,9 @@ func deployAirflow(cmd *cobra.Command, args []string) error {
 		byoRegistryDomain = appConfig.BYORegistryDomain
 	}
 
-	return DeployAirflowImage(houstonClient, config.WorkingPath, deploymentID, ws, byoRegistryDomain, ignoreCacheDeploy, byoRegistryEnabled, forcePrompt)
+	if len(imageTags) > 0 && !byoRegistryEnabled {
+		return errImageTagWithoutBYO
+	}
+
+	return DeployAirflowImage(houstonClient, config.WorkingPath, deploymentID, ws, byoRegistryDomain, imageTags, ignoreCacheDeploy, byoRegistryEnabled, forcePrompt)
 }
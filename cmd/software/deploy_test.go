@@ -5,6 +5,7 @@ import (
 
 	"github.com/astronomer/astro-cli/houston"
 	testUtil "github.com/astronomer/astro-cli/pkg/testing"
+	"github.com/astronomer/astro-cli/software/deploy"
 	"github.com/spf13/cobra"
 	"github.com/stretchr/testify/assert"
 )
@@ -28,7 +29,7 @@ func TestDeploy(t *testing.T) {
 	EnsureProjectDir = func(cmd *cobra.Command, args []string) error {
 		return nil
 	}
-	DeployAirflowImage = func(houstonClient houston.ClientInterface, path, deploymentID, wsID, byoRegistryDomain string, ignoreCacheDeploy, byoRegistryEnabled, prompt bool) error {
+	DeployAirflowImage = func(houstonClient houston.ClientInterface, path, deploymentID, wsID, byoRegistryDomain string, byoTagTemplates []string, ignoreCacheDeploy, byoRegistryEnabled, prompt bool) error {
 		return nil
 	}
 
@@ -41,3 +42,48 @@ func TestDeploy(t *testing.T) {
 	err = execDeployCmd([]string{"test-deployment-id", "--save"}...)
 	assert.NoError(t, err)
 }
+
+func TestDeployDags(t *testing.T) {
+	testUtil.InitTestConfig(testUtil.SoftwarePlatform)
+	appConfig = &houston.AppConfig{Flags: houston.FeatureFlags{DagOnlyDeployment: true}}
+	EnsureProjectDir = func(cmd *cobra.Command, args []string) error {
+		return nil
+	}
+	DeployAirflowImage = func(houstonClient houston.ClientInterface, path, deploymentID, wsID, byoRegistryDomain string, byoTagTemplates []string, ignoreCacheDeploy, byoRegistryEnabled, prompt bool) error {
+		t.Error("the image should not be deployed")
+		return nil
+	}
+	defer func() { DagsOnlyDeploy = deploy.DagsOnlyDeploy }()
+	var gotDeploymentID string
+	DagsOnlyDeploy = func(houstonClient houston.ClientInterface, appConfig *houston.AppConfig, path, deploymentID, wsID string, prompt bool) error {
+		assert.True(t, appConfig.Flags.DagOnlyDeployment)
+		gotDeploymentID = deploymentID
+		return nil
+	}
+
+	err := execDeployCmd("-f", "test-deployment-id", "--dags")
+	assert.NoError(t, err)
+	assert.Equal(t, "test-deployment-id", gotDeploymentID)
+}
+
+func TestDeployImageTags(t *testing.T) {
+	testUtil.InitTestConfig(testUtil.SoftwarePlatform)
+	EnsureProjectDir = func(cmd *cobra.Command, args []string) error {
+		return nil
+	}
+	defer func() { DeployAirflowImage = deploy.Airflow }()
+	var gotTemplates []string
+	DeployAirflowImage = func(houstonClient houston.ClientInterface, path, deploymentID, wsID, byoRegistryDomain string, byoTagTemplates []string, ignoreCacheDeploy, byoRegistryEnabled, prompt bool) error {
+		gotTemplates = byoTagTemplates
+		return nil
+	}
+
+	appConfig = &houston.AppConfig{BYORegistryDomain: "test.registry.io", Flags: houston.FeatureFlags{BYORegistryEnabled: true}}
+	err := execDeployCmd("-f", "test-deployment-id", "--image-tag={{.GitSHA}}", "--image-tag={{.GitTag}}")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"{{.GitSHA}}", "{{.GitTag}}"}, gotTemplates)
+
+	appConfig = &houston.AppConfig{}
+	err = execDeployCmd("-f", "test-deployment-id", "--image-tag={{.GitSHA}}")
+	assert.ErrorIs(t, err, errImageTagWithoutBYO)
+}
@@ -1,10 +1,16 @@
 package software
 
 import (
+	"errors"
 	"fmt"
 	"io"
+	"time"
 
+	"github.com/astronomer/astro-cli/config"
+	"github.com/astronomer/astro-cli/houston"
 	"github.com/astronomer/astro-cli/pkg/input"
+	"github.com/astronomer/astro-cli/pkg/printutil"
+	"github.com/astronomer/astro-cli/software/deploy"
 	"github.com/astronomer/astro-cli/software/deployment"
 	"github.com/spf13/cobra"
 )
@@ -46,6 +52,20 @@ var (
 	knowHosts               string
 	runtimeVersion          string
 	desiredRuntimeVersion   string
+	deploymentFile          string
+	inspectTemplate         bool
+	deploymentListLabel     string
+	upgradeWait             bool
+	upgradeTimeout          time.Duration
+	upgradeLabelFilter      string
+	setBaseImage            = deploy.SetBaseImage
+	upgradeInterval         = 10 * time.Second
+	errDeploymentFileFlag   = errors.New("--deployment-file can not be used with other arguments")
+	errLabelRequired        = errors.New(`required flag(s) "label" not set`)
+	errUpgradeTarget        = errors.New("one of --deployment-id or --label-filter is required, but not both")
+	errUpgradeCancelFlags   = errors.New("--cancel can not be used with --wait or --label-filter")
+	errUpgradeWaitBatch     = errors.New("--wait can not be used with --label-filter, as it deploys the project of the current directory to the upgraded deployment")
+	errUpgradeWaitVersion   = errors.New("--wait is not supported by the connected platform, it requires Astronomer Software 0.34.0 or later to report the deployment health")
 	deploymentCreateExample = `
 # Create new deployment with Celery executor (default: celery without params).
 $ astro deployment create --label=new-deployment-name --executor=celery
@@ -58,6 +78,9 @@ $ astro deployment create --label=new-deployment-name-k8s --executor=k8s --airfl
 
 # Create new deployment with Astronomer Runtime.
 $ astro deployment create --label=my-new-deployment --executor=k8s --runtime-version=6.0.1
+
+# Create new deployment from a deployment file, see astro deployment inspect --template.
+$ astro deployment create --deployment-file=deployment.yaml
 `
 	createExampleDagDeployment = `
 # Create new deployment with Kubernetes executor and dag deployment type volume and nfs location.
@@ -68,16 +91,28 @@ $ astro deployment create --label=my-new-deployment --executor=k8s --airflow-ver
 
 # Abort the initial airflow upgrade step:
   $ astro deployment airflow upgrade --cancel --deployment-id=<deployment-id>
+
+# Deploy the project on the new Airflow image and wait for the deployment to be healthy:
+  $ astro deployment airflow upgrade --deployment-id=<deployment-id> --desired-airflow-version=<desired-airflow-version> --wait --timeout=20m
+
+# Upgrade every deployment of the workspace whose label matches a pattern, one at a time:
+  $ astro deployment airflow upgrade --label-filter="prod-*" --desired-airflow-version=<desired-airflow-version>
 `
 	deploymentRuntimeUpgradeExample = `
 $ astro deployment runtime upgrade --deployment-id=<deployment-id> --desired-runtime-version=<desired-runtime-version>
 # Abort the initial runtime upgrade step:
 $ astro deployment runtime upgrade --deployment-id=<deployment-id> --cancel
+# Deploy the project on the new Runtime image and wait for the deployment to be healthy:
+$ astro deployment runtime upgrade --deployment-id=<deployment-id> --desired-runtime-version=<desired-runtime-version> --wait
+# Upgrade every deployment of the workspace whose label matches a pattern, one at a time:
+$ astro deployment runtime upgrade --label-filter="prod-*" --desired-runtime-version=<desired-runtime-version>
 `
 	deploymentRuntimeMigrateExample = `
 $ astro deployment runtime migrate --deployment-id=<deployment-id>
 # Abort the initial runtime migrate step:
 $ astro deployment runtime migrate --deployment-id=<deployment-id> --cancel
+# Migrate every deployment of the workspace whose label matches a pattern, one at a time:
+$ astro deployment runtime migrate --label-filter="prod-*"
 `
 )
 
@@ -94,11 +129,13 @@ func newDeploymentRootCmd(out io.Writer) *cobra.Command {
 		newDeploymentListCmd(out),
 		newDeploymentUpdateCmd(out),
 		newDeploymentDeleteCmd(out),
+		newDeploymentInspectCmd(out),
 		newLogsCmd(out),
 		newDeploymentSaRootCmd(out),
 		newDeploymentUserRootCmd(out),
 		newDeploymentAirflowRootCmd(out),
 		newDeploymentTeamRootCmd(out),
+		newDeploymentVariableRootCmd(out),
 	)
 
 	if appConfig != nil && appConfig.Flags.AstroRuntimeEnabled {
@@ -130,7 +167,7 @@ func newDeploymentCreateCmd(out io.Writer) *cobra.Command {
 
 	// let's hide under feature flag
 	if nfsMountDAGDeploymentEnabled || gitSyncDAGDeploymentEnabled {
-		cmd.Flags().StringVarP(&dagDeploymentType, "dag-deployment-type", "t", "", "DAG Deployment mechanism: image, volume, git_sync")
+		cmd.Flags().StringVarP(&dagDeploymentType, "dag-deployment-type", "t", "", "DAG Deployment mechanism: image, volume, git_sync, dag_deploy")
 	}
 
 	if nfsMountDAGDeploymentEnabled {
@@ -155,7 +192,7 @@ func newDeploymentCreateCmd(out io.Writer) *cobra.Command {
 	cmd.Flags().StringVarP(&airflowVersion, "airflow-version", "a", "", "Add desired Airflow version parameter: e.g: 1.10.5 or 1.10.7")
 	cmd.Flags().StringVarP(&releaseName, "release-name", "r", "", "Set custom release-name if possible")
 	cmd.Flags().StringVarP(&cloudRole, "cloud-role", "c", "", "Set cloud role to annotate service accounts in deployment")
-	_ = cmd.MarkFlagRequired("label")
+	cmd.Flags().StringVarP(&deploymentFile, "deployment-file", "", "", "Location of file containing the deployment to create. File can be in either JSON or YAML format.")
 	return cmd
 }
 
@@ -181,30 +218,38 @@ func newDeploymentListCmd(out io.Writer) *cobra.Command {
 		Use:     "list",
 		Aliases: []string{"ls"},
 		Short:   "List Airflow Deployment",
-		Long:    "List Airflow Deployment",
+		Long:    "List Airflow Deployment\n\nEvery page is listed with --all-pages, where the other list commands use --all, as --all lists the Deployments of every Workspace here",
 		RunE: func(cmd *cobra.Command, args []string) error {
 			return deploymentList(cmd, out)
 		},
 	}
 	cmd.Flags().BoolVarP(&allDeployments, "all", "a", false, "Show Deployments across all Workspaces")
+	cmd.Flags().StringVarP(&deploymentListLabel, "label", "l", "", "Only list the Deployments with this label")
+	cmd.Flags().IntVar(&listOptions.PageSize, "page-size", 0, pageSizeFlagUsage)
+	cmd.Flags().StringVar(&listOptions.Cursor, "cursor", "", cursorFlagUsage)
+	// --all already lists the Deployments of every Workspace, so listing every page has its own flag here
+	cmd.Flags().BoolVar(&listOptions.All, "all-pages", false, allPagesFlagUsage+". Named --all on the other list commands")
 	return cmd
 }
 
 func newDeploymentUpdateCmd(out io.Writer) *cobra.Command {
 	example := `
 # update executor for given deployment
-$ astro deployment update [deployment ID] --executor=celery`
+$ astro deployment update [deployment ID] --executor=celery
+
+# update the deployment of a deployment file, found by name
+$ astro deployment update --deployment-file=deployment.yaml`
 	updateExampleDagDeployment := `
 
 # update dag deployment strategy
 $ astro deployment update [deployment ID] --dag-deployment-type=volume --nfs-location=test:/test`
 	cmd := &cobra.Command{
-		Use:     "update",
+		Use:     "update [deployment ID]",
 		Aliases: []string{"up"},
 		Short:   "Update Airflow Deployments",
 		Long:    "Update Airflow Deployments",
 		Example: example,
-		Args:    cobra.ExactArgs(1),
+		Args:    cobra.MaximumNArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			return deploymentUpdate(cmd, args, dagDeploymentType, nfsLocation, out)
 		},
@@ -221,7 +266,7 @@ $ astro deployment update [deployment ID] --dag-deployment-type=volume --nfs-loc
 
 	// let's hide under feature flag
 	if nfsMountDAGDeploymentEnabled || gitSyncDAGDeploymentEnabled {
-		cmd.Flags().StringVarP(&dagDeploymentType, "dag-deployment-type", "t", "", "DAG Deployment mechanism: image, volume, git_sync")
+		cmd.Flags().StringVarP(&dagDeploymentType, "dag-deployment-type", "t", "", "DAG Deployment mechanism: image, volume, git_sync, dag_deploy")
 	}
 
 	if nfsMountDAGDeploymentEnabled {
@@ -241,6 +286,29 @@ $ astro deployment update [deployment ID] --dag-deployment-type=volume --nfs-loc
 	cmd.Flags().StringVarP(&deploymentUpdateDescription, "description", "d", "", "Set description to update in deployment")
 	cmd.Flags().StringVarP(&deploymentUpdateLabel, "label", "l", "", "Set label to update in deployment")
 	cmd.Flags().StringVarP(&cloudRole, "cloud-role", "c", "", "Set cloud role to annotate service accounts in deployment")
+	cmd.Flags().StringVarP(&deploymentFile, "deployment-file", "", "", "Location of file containing the deployment to update. File can be in either JSON or YAML format.")
+	return cmd
+}
+
+func newDeploymentInspectCmd(out io.Writer) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "inspect [deployment ID]",
+		Aliases: []string{"in"},
+		Short:   "Inspect an Airflow Deployment",
+		Long:    "Print the configuration of an Airflow Deployment, including its executor, resources, DAG deployment, users and teams. The output can be used with --deployment-file to create or update Deployments.",
+		Example: `
+# export a deployment to a file
+$ astro deployment inspect [deployment ID] > deployment.yaml
+
+# export a template to create new deployments from
+$ astro deployment inspect [deployment ID] --template > template.yaml`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return deploymentInspect(cmd, args, out)
+		},
+	}
+	cmd.Flags().VarP(printutil.OutputFlag{Default: printutil.YAMLFormat}, "output", "o", "Output format can be one of: yaml or json")
+	cmd.Flags().BoolVarP(&inspectTemplate, "template", "t", false, "Leave out the name, release name and metadata of the deployment, to create new deployments from the output")
 	return cmd
 }
 
@@ -284,13 +352,16 @@ func newDeploymentAirflowUpgradeCmd(out io.Writer) *cobra.Command {
 	cmd.Flags().StringVarP(&deploymentID, "deployment-id", "d", "", "ID of the deployment to upgrade")
 	cmd.Flags().StringVarP(&desiredAirflowVersion, "desired-airflow-version", "v", "", "Desired Airflow version to upgrade to")
 	cmd.Flags().BoolVarP(&cancel, "cancel", "c", false, "Abort the initial airflow upgrade step")
-	err := cmd.MarkFlagRequired("deployment-id")
-	if err != nil {
-		fmt.Println("error adding deployment-id flag: ", err.Error())
-	}
+	addUpgradeFlags(cmd)
 	return cmd
 }
 
+func addUpgradeFlags(cmd *cobra.Command) {
+	cmd.Flags().BoolVarP(&upgradeWait, "wait", "w", false, "Deploy the project on the upgraded image and wait for every component of the deployment to be healthy. Run it from the project directory, the Dockerfile is restored after the deploy. Can not be used with --label-filter")
+	cmd.Flags().DurationVarP(&upgradeTimeout, "timeout", "t", 15*time.Minute, "How long to wait for a deployment to be healthy with --wait") //nolint:gomnd
+	cmd.Flags().StringVarP(&upgradeLabelFilter, "label-filter", "l", "", "Upgrade one at a time every deployment of the workspace whose label matches this pattern, like prod-*. Stops at the first failure")
+}
+
 func newDeploymentRuntimeRootCmd(out io.Writer) *cobra.Command {
 	cmd := &cobra.Command{
 		Use:     "runtime",
@@ -319,10 +390,7 @@ func newDeploymentRuntimeUpgradeCmd(out io.Writer) *cobra.Command { //nolint:dup
 	cmd.Flags().StringVarP(&deploymentID, "deployment-id", "d", "", "ID of the deployment to upgrade")
 	cmd.Flags().StringVarP(&desiredRuntimeVersion, "desired-runtime-version", "v", "", "Desired Runtime version you wish to upgrade your deployment to")
 	cmd.Flags().BoolVarP(&cancel, "cancel", "c", false, "Abort the initial runtime upgrade step")
-	err := cmd.MarkFlagRequired("deployment-id")
-	if err != nil {
-		fmt.Println("error adding deployment-id flag: ", err.Error())
-	}
+	addUpgradeFlags(cmd)
 	return cmd
 }
 
@@ -339,10 +407,7 @@ func newDeploymentRuntimeMigrateCmd(out io.Writer) *cobra.Command {
 	}
 	cmd.Flags().StringVarP(&deploymentID, "deployment-id", "d", "", "ID of the deployment to migrate")
 	cmd.Flags().BoolVarP(&cancel, "cancel", "c", false, "Abort the initial runtime migrate step")
-	err := cmd.MarkFlagRequired("deployment-id")
-	if err != nil {
-		fmt.Println("error adding deployment-id flag: ", err.Error())
-	}
+	addUpgradeFlags(cmd)
 	return cmd
 }
 
@@ -352,6 +417,18 @@ func deploymentCreate(cmd *cobra.Command, out io.Writer) error {
 		return fmt.Errorf("failed to find a valid workspace: %w", err)
 	}
 
+	// request is to create from a file
+	if deploymentFile != "" {
+		if countLocalFlags(cmd) > 1 {
+			return errDeploymentFileFlag
+		}
+		cmd.SilenceUsage = true
+		return deployment.CreateOrUpdateFromFile(deploymentFile, deployment.CreateAction, ws, houstonClient, out)
+	}
+	if deploymentCreateLabel == "" {
+		return errLabelRequired
+	}
+
 	// Silence Usage as we have now validated command input
 	cmd.SilenceUsage = true
 
@@ -424,13 +501,36 @@ func deploymentList(cmd *cobra.Command, out io.Writer) error {
 		ws = ""
 	}
 
+	if err := listOptions.Validate(); err != nil {
+		return err
+	}
+
 	// Silence Usage as we have now validated command input
 	cmd.SilenceUsage = true
 
+	if listOptions.Paginated() || deploymentListLabel != "" {
+		return deployment.PaginatedList(ws, deploymentListLabel, listPages(listOptions), houstonClient, out)
+	}
 	return deployment.List(ws, allDeployments, houstonClient, out)
 }
 
 func deploymentUpdate(cmd *cobra.Command, args []string, dagDeploymentType, nfsLocation string, out io.Writer) error {
+	// request is to update from a file
+	if deploymentFile != "" {
+		if countLocalFlags(cmd) > 1 || len(args) > 0 {
+			return errDeploymentFileFlag
+		}
+		ws, err := coalesceWorkspace()
+		if err != nil {
+			return fmt.Errorf("failed to find a valid workspace: %w", err)
+		}
+		cmd.SilenceUsage = true
+		return deployment.CreateOrUpdateFromFile(deploymentFile, deployment.UpdateAction, ws, houstonClient, out)
+	}
+	if len(args) == 0 {
+		return cobra.ExactArgs(1)(cmd, args)
+	}
+
 	argsMap := map[string]string{}
 	if deploymentUpdateDescription != "" {
 		argsMap["description"] = deploymentUpdateDescription
@@ -469,28 +569,150 @@ func deploymentUpdate(cmd *cobra.Command, args []string, dagDeploymentType, nfsL
 }
 
 func deploymentAirflowUpgrade(cmd *cobra.Command, out io.Writer) error {
-	// Silence Usage as we have now validated command input
-	cmd.SilenceUsage = true
-	if cancel {
-		return deployment.AirflowUpgradeCancel(deploymentID, houstonClient, out)
-	}
-	return deployment.AirflowUpgrade(deploymentID, desiredAirflowVersion, houstonClient, out)
+	return runDeploymentUpgrade(cmd, deployment.BatchUpgrade{Kind: deployment.AirflowUpgradeKind, DesiredVersion: desiredAirflowVersion}, out)
 }
 
 func deploymentRuntimeUpgrade(cmd *cobra.Command, out io.Writer) error {
+	return runDeploymentUpgrade(cmd, deployment.BatchUpgrade{Kind: deployment.RuntimeUpgradeKind, DesiredVersion: desiredRuntimeVersion}, out)
+}
+
+func deploymentRuntimeMigrate(cmd *cobra.Command, out io.Writer) error {
+	return runDeploymentUpgrade(cmd, deployment.BatchUpgrade{Kind: deployment.RuntimeMigrateKind}, out)
+}
+
+// runDeploymentUpgrade upgrades the deployment or, with --label-filter, every matching deployment of the workspace.
+// With --wait the project is deployed on the upgraded image and the deployment has to be healthy.
+func runDeploymentUpgrade(cmd *cobra.Command, upgrade deployment.BatchUpgrade, out io.Writer) error {
+	if (deploymentID == "") == (upgradeLabelFilter == "") {
+		return errUpgradeTarget
+	}
+	if cancel && (upgradeWait || upgradeLabelFilter != "") {
+		return errUpgradeCancelFlags
+	}
+	if upgradeWait && upgradeLabelFilter != "" {
+		return errUpgradeWaitBatch
+	}
+	if upgradeWait {
+		// the deployment health polled by --wait is only reported by newer platforms
+		if !houston.VerifyVersionMatch(houstonVersion, houston.VersionRestrictions{GTE: "0.34.0"}) {
+			return errUpgradeWaitVersion
+		}
+		if err := EnsureProjectDir(cmd, nil); err != nil {
+			return err
+		}
+	}
 	// Silence Usage as we have now validated command input
 	cmd.SilenceUsage = true
+
 	if cancel {
-		return deployment.RuntimeUpgradeCancel(deploymentID, houstonClient, out)
+		switch upgrade.Kind {
+		case deployment.AirflowUpgradeKind:
+			return deployment.AirflowUpgradeCancel(deploymentID, houstonClient, out)
+		case deployment.RuntimeUpgradeKind:
+			return deployment.RuntimeUpgradeCancel(deploymentID, houstonClient, out)
+		default:
+			return deployment.RuntimeMigrateCancel(deploymentID, houstonClient, out)
+		}
+	}
+
+	if upgradeLabelFilter != "" {
+		ws, err := coalesceWorkspace()
+		if err != nil {
+			return err
+		}
+		return deployment.UpgradeBatch(ws, upgradeLabelFilter, upgrade, houstonClient, out)
+	}
+
+	var wait *deployment.UpgradeWaitOptions
+	if upgradeWait {
+		ws, err := coalesceWorkspace()
+		if err != nil {
+			return err
+		}
+		wait = &deployment.UpgradeWaitOptions{
+			Deploy:   upgradeDeploy(ws),
+			Timeout:  upgradeTimeout,
+			Interval: upgradeInterval,
+		}
+	}
+
+	var err error
+	switch upgrade.Kind {
+	case deployment.AirflowUpgradeKind:
+		err = deployment.AirflowUpgrade(deploymentID, upgrade.DesiredVersion, houstonClient, out)
+	case deployment.RuntimeUpgradeKind:
+		err = deployment.RuntimeUpgrade(deploymentID, upgrade.DesiredVersion, houstonClient, out)
+	default:
+		err = deployment.RuntimeMigrate(deploymentID, houstonClient, out)
+	}
+	if err != nil || wait == nil {
+		return err
 	}
-	return deployment.RuntimeUpgrade(deploymentID, desiredRuntimeVersion, houstonClient, out)
+	return deployment.WaitForUpgrade(deploymentID, *wait, houstonClient, out)
 }
 
-func deploymentRuntimeMigrate(cmd *cobra.Command, out io.Writer) error {
+// upgradeDeploy returns a function that deploys the project on the upgraded base image, the Dockerfile of the project
+// is restored once the image is built and pushed
+func upgradeDeploy(ws string) func(deploymentID, baseImage string) error {
+	var byoRegistryEnabled bool
+	var byoRegistryDomain string
+	if appConfig != nil && appConfig.Flags.BYORegistryEnabled {
+		byoRegistryEnabled = true
+		byoRegistryDomain = appConfig.BYORegistryDomain
+	}
+	return func(deploymentID, baseImage string) error {
+		restore, err := setBaseImage(config.WorkingPath, baseImage)
+		if err != nil {
+			return err
+		}
+		err = DeployAirflowImage(houstonClient, config.WorkingPath, deploymentID, ws, byoRegistryDomain, nil, ignoreCacheDeploy, byoRegistryEnabled, false)
+		if restoreErr := restore(); restoreErr != nil && err == nil {
+			return fmt.Errorf("failed to restore the Dockerfile: %w", restoreErr)
+		}
+		return err
+	}
+}
+
+func deploymentInspect(cmd *cobra.Command, args []string, out io.Writer) error {
+	outputFormat, err := printutil.DocumentFormat(printutil.YAMLFormat, printutil.YAMLFormat, printutil.JSONFormat)
+	if err != nil {
+		return err
+	}
+
+	var id string
+	if len(args) > 0 {
+		id = args[0]
+	} else {
+		ws, err := coalesceWorkspace()
+		if err != nil {
+			return fmt.Errorf("failed to find a valid workspace: %w", err)
+		}
+		deployments, err := deployment.GetDeployments(ws, houstonClient)
+		if err != nil {
+			return err
+		}
+		selected, err := deployment.SelectDeployment(deployments, "Select which Deployment you want to inspect")
+		if err != nil {
+			return err
+		}
+		if selected.ID == "" {
+			fmt.Fprintf(out, "No Deployments found in workspace %s\n", ws)
+			return nil
+		}
+		id = selected.ID
+	}
+
 	// Silence Usage as we have now validated command input
 	cmd.SilenceUsage = true
-	if cancel {
-		return deployment.RuntimeMigrateCancel(deploymentID, houstonClient, out)
+
+	return deployment.Inspect(id, outputFormat, inspectTemplate, houstonClient, out)
+}
+
+// countLocalFlags returns the number of flags set on cmd, leaving out the persistent --workspace-id
+func countLocalFlags(cmd *cobra.Command) int {
+	count := cmd.Flags().NFlag()
+	if cmd.Flags().Changed("workspace-id") {
+		count--
 	}
-	return deployment.RuntimeMigrate(deploymentID, houstonClient, out)
+	return count
 }
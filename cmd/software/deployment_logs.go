@@ -1,9 +1,12 @@
 package software
 
 import (
+	"errors"
+	"fmt"
 	"io"
 	"time"
 
+	"github.com/astronomer/astro-cli/pkg/printutil"
 	"github.com/astronomer/astro-cli/software/deployment"
 
 	"github.com/spf13/cobra"
@@ -16,11 +19,27 @@ const (
 	logTriggerer = "triggerer"
 )
 
+const bytesInMB = 1024 * 1024
+
+var (
+	errUntilWithFollow     = errors.New("--until can not be used with --follow")
+	errInvalidUntil        = errors.New("--until must be a relative duration like 5m, or a time like 2023-06-05T10:00:00Z")
+	errLogsOutputFormat    = errors.New("logs can only be printed in the table or json output format")
+	errInvalidLogRotation  = errors.New("--max-file-size and --max-files can't be negative")
+	errInvalidLogComponent = errors.New("invalid component, must be one of webserver, scheduler, worker or triggerer")
+)
+
 var (
-	search      string
-	follow      bool
-	since       time.Duration
-	logsExample = `
+	search         string
+	follow         bool
+	since          time.Duration
+	until          string
+	logRegex       []string
+	logComponents  []string
+	logsToFile     string
+	logMaxFileSize int
+	logMaxFiles    int
+	logsExample    = `
   # Return logs for last 5 minutes of webserver logs and output them.
   astro deployment logs webserver example-deployment-uuid
 
@@ -32,6 +51,12 @@ var (
 
   # Subscribe logs from airflow scheduler.
   astro deployment logs scheduler example-deployment-uuid -f
+
+  # Return scheduler and worker logs between 2h and 1h ago, merged in timestamp order, which match a regex.
+  astro deployment logs all example-deployment-uuid --component scheduler --component worker --since 2h --until 1h --regex "ERROR|WARNING"
+
+  # Export the last day of webserver logs as JSON to a file rotated every 50MB.
+  astro deployment logs webserver example-deployment-uuid --since 24h --output json --to-file webserver.log --max-file-size 50
 `
 )
 
@@ -47,6 +72,7 @@ func newLogsCmd(out io.Writer) *cobra.Command {
 		newWebserverLogsCmd(out),
 		newSchedulerLogsCmd(out),
 		newWorkersLogsCmd(out),
+		newAllLogsCmd(out),
 	)
 
 	if appConfig != nil && appConfig.Flags.TriggererEnabled {
@@ -67,13 +93,10 @@ astro deployment logs webserver YOU_DEPLOYMENT_ID -s string-to-find
 `,
 		Args: cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
-			return fetchRemoteLogs(logWebserver, args, out)
+			return fetchRemoteLogs([]string{logWebserver}, args, out)
 		},
 	}
-	cmd.Flags().StringVarP(&search, "search", "s", "", "Search term inside logs")
-	cmd.Flags().BoolVarP(&follow, "follow", "f", false, "Subscribe to watch more logs")
-	cmd.Flags().DurationVarP(&since, "since", "t", 0, "Only return logs newer than a relative duration like 5m, 1h, or 24h")
-	cmd.Flags().BoolP("help", "h", false, "Help for "+cmd.Name())
+	addLogsFlags(cmd)
 	return cmd
 }
 
@@ -88,13 +111,10 @@ astro deployment logs scheduler YOU_DEPLOYMENT_ID -s string-to-find
 `,
 		Args: cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
-			return fetchRemoteLogs(logScheduler, args, out)
+			return fetchRemoteLogs([]string{logScheduler}, args, out)
 		},
 	}
-	cmd.Flags().StringVarP(&search, "search", "s", "", "Search term inside logs")
-	cmd.Flags().BoolVarP(&follow, "follow", "f", false, "Subscribe to watch more logs")
-	cmd.Flags().DurationVarP(&since, "since", "t", 0, "Only return logs newer than a relative duration like 5m, 1h, or 24h")
-	cmd.Flags().BoolP("help", "h", false, "Help for "+cmd.Name())
+	addLogsFlags(cmd)
 	return cmd
 }
 
@@ -109,13 +129,10 @@ astro deployment logs workers YOU_DEPLOYMENT_ID -s string-to-find
 `,
 		Args: cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
-			return fetchRemoteLogs(logWorker, args, out)
+			return fetchRemoteLogs([]string{logWorker}, args, out)
 		},
 	}
-	cmd.Flags().StringVarP(&search, "search", "s", "", "Search term inside logs")
-	cmd.Flags().BoolVarP(&follow, "follow", "f", false, "Subscribe to watch more logs")
-	cmd.Flags().DurationVarP(&since, "since", "t", 0, "Only return logs newer than a relative duration like 5m, 1h, or 24h")
-	cmd.Flags().BoolP("help", "h", false, "Help for "+cmd.Name())
+	addLogsFlags(cmd)
 	// get airflow workers logs
 	return cmd
 }
@@ -131,20 +148,103 @@ astro deployment logs triggerer YOU_DEPLOYMENT_ID -s string-to-find
 `,
 		Args: cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
-			return fetchRemoteLogs(logTriggerer, args, out)
+			return fetchRemoteLogs([]string{logTriggerer}, args, out)
 		},
 	}
+	addLogsFlags(cmd)
+	// get airflow workers logs
+	return cmd
+}
+
+func newAllLogsCmd(out io.Writer) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "all",
+		Aliases: []string{"merged"},
+		Short:   "Stream logs from several Airflow components",
+		Long: `Stream logs from several Airflow components, merged in timestamp order. For example:
+
+astro deployment logs all YOU_DEPLOYMENT_ID --component scheduler --component worker -s string-to-find
+`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			components := logComponents
+			for _, component := range components {
+				switch component {
+				case logWebserver, logScheduler, logWorker, logTriggerer:
+				default:
+					return fmt.Errorf("%w: %s", errInvalidLogComponent, component)
+				}
+			}
+			if len(components) == 0 {
+				components = []string{logWebserver, logScheduler, logWorker}
+				if appConfig != nil && appConfig.Flags.TriggererEnabled {
+					components = append(components, logTriggerer)
+				}
+			}
+			return fetchRemoteLogs(components, args, out)
+		},
+	}
+	cmd.Flags().StringSliceVarP(&logComponents, "component", "c", []string{}, "Component to get logs from, one of webserver, scheduler, worker or triggerer. Can be repeated, all components by default")
+	addLogsFlags(cmd)
+	return cmd
+}
+
+func addLogsFlags(cmd *cobra.Command) {
 	cmd.Flags().StringVarP(&search, "search", "s", "", "Search term inside logs")
 	cmd.Flags().BoolVarP(&follow, "follow", "f", false, "Subscribe to watch more logs")
 	cmd.Flags().DurationVarP(&since, "since", "t", 0, "Only return logs newer than a relative duration like 5m, 1h, or 24h")
+	cmd.Flags().StringVarP(&until, "until", "u", "", "Only return logs older than a relative duration like 5m, or than a time like 2023-06-05T10:00:00Z")
+	cmd.Flags().StringArrayVarP(&logRegex, "regex", "r", []string{}, "Only return logs matching a regular expression. Can be repeated, logs matching any of them are returned")
+	cmd.Flags().StringVarP(&logsToFile, "to-file", "", "", "Write the logs to this file instead of the terminal")
+	cmd.Flags().IntVarP(&logMaxFileSize, "max-file-size", "", 100, "Size in MB after which the --to-file file is rotated, 0 to never rotate it")
+	cmd.Flags().IntVarP(&logMaxFiles, "max-files", "", 5, "Number of rotated --to-file files to keep, at least 1 when the file is rotated")
 	cmd.Flags().BoolP("help", "h", false, "Help for "+cmd.Name())
-	// get airflow workers logs
-	return cmd
 }
 
-func fetchRemoteLogs(component string, args []string, out io.Writer) error {
+// parseUntil parses --until, either a duration before now or an RFC3339 time
+func parseUntil(value string, now time.Time) (time.Time, error) {
+	if value == "" {
+		return time.Time{}, nil
+	}
+	if d, err := time.ParseDuration(value); err == nil {
+		return now.Add(-d), nil
+	}
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("%w: %s", errInvalidUntil, value)
+	}
+	return t, nil
+}
+
+func fetchRemoteLogs(components []string, args []string, out io.Writer) error {
+	if follow && until != "" {
+		return errUntilWithFollow
+	}
+	untilTime, err := parseUntil(until, time.Now().UTC())
+	if err != nil {
+		return err
+	}
+	if logMaxFileSize < 0 || logMaxFiles < 0 {
+		return errInvalidLogRotation
+	}
+	outputFormat := printutil.CurrentOutputFormat().Name
+	if outputFormat != printutil.TableFormat && outputFormat != printutil.JSONFormat {
+		return errLogsOutputFormat
+	}
+
+	opts := deployment.LogOptions{
+		Components:  components,
+		Search:      search,
+		Since:       since,
+		Until:       untilTime,
+		Regex:       logRegex,
+		JSON:        outputFormat == printutil.JSONFormat,
+		ToFile:      logsToFile,
+		MaxFileSize: int64(logMaxFileSize) * bytesInMB,
+		MaxFiles:    logMaxFiles,
+	}
 	if follow {
-		return deployment.SubscribeDeploymentLog(args[0], component, search, since)
+		return deployment.SubscribeDeploymentLog(args[0], opts, out)
 	}
-	return deployment.Log(args[0], component, search, since, houstonClient, out)
+	return deployment.Log(args[0], opts, houstonClient, out)
 }
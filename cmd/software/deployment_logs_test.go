@@ -4,11 +4,13 @@ import (
 	"errors"
 	"fmt"
 	"testing"
+	"time"
 
 	mocks "github.com/astronomer/astro-cli/houston/mocks"
 	"github.com/stretchr/testify/mock"
 
 	"github.com/astronomer/astro-cli/houston"
+	"github.com/astronomer/astro-cli/pkg/printutil"
 	testUtil "github.com/astronomer/astro-cli/pkg/testing"
 
 	"github.com/stretchr/testify/assert"
@@ -103,3 +105,94 @@ func TestDeploymentLogsWebServerRemoteLogs(t *testing.T) {
 		})
 	}
 }
+
+func TestDeploymentLogsAllComponents(t *testing.T) {
+	testUtil.InitTestConfig(testUtil.SoftwarePlatform)
+	appConfig = &houston.AppConfig{
+		Flags: houston.FeatureFlags{
+			TriggererEnabled: false,
+		},
+	}
+
+	t.Run("merges every component by default", func(t *testing.T) {
+		api := new(mocks.ClientInterface)
+		for _, component := range []string{logWebserver, logScheduler, logWorker} {
+			component := component
+			api.On("ListDeploymentLogs", mock.MatchedBy(func(r houston.ListDeploymentLogsRequest) bool { return r.Component == component })).
+				Return([]houston.DeploymentLog{{ID: component, CreatedAt: "2019-10-16T21:14:22.105Z", Log: component + " log"}}, nil).Once()
+		}
+
+		houstonClient = api
+		output, err := execDeploymentCmd("logs", "all", mockDeployment.ID)
+		assert.NoError(t, err)
+		assert.Contains(t, output, "[webserver] webserver log")
+		assert.Contains(t, output, "[scheduler] scheduler log")
+		assert.Contains(t, output, "[worker] worker log")
+		api.AssertExpectations(t)
+	})
+
+	t.Run("selected components", func(t *testing.T) {
+		api := new(mocks.ClientInterface)
+		api.On("ListDeploymentLogs", mock.MatchedBy(func(r houston.ListDeploymentLogsRequest) bool { return r.Component == logScheduler })).
+			Return(getTestLogs(logScheduler), nil).Once()
+
+		houstonClient = api
+		output, err := execDeploymentCmd("logs", "all", mockDeployment.ID, "--component", logScheduler, "--regex", "^second")
+		assert.NoError(t, err)
+		assert.Equal(t, "second test\n", output)
+		api.AssertExpectations(t)
+	})
+
+	t.Run("invalid component", func(t *testing.T) {
+		_, err := execDeploymentCmd("logs", "all", mockDeployment.ID, "--component", "unknown")
+		assert.ErrorIs(t, err, errInvalidLogComponent)
+	})
+}
+
+func TestDeploymentLogsFlagValidation(t *testing.T) {
+	testUtil.InitTestConfig(testUtil.SoftwarePlatform)
+
+	t.Run("until with follow", func(t *testing.T) {
+		_, err := execDeploymentCmd("logs", "scheduler", mockDeployment.ID, "--follow", "--until", "5m")
+		assert.ErrorIs(t, err, errUntilWithFollow)
+	})
+
+	t.Run("invalid until", func(t *testing.T) {
+		_, err := execDeploymentCmd("logs", "scheduler", mockDeployment.ID, "--until", "yesterday")
+		assert.ErrorIs(t, err, errInvalidUntil)
+	})
+
+	t.Run("negative rotation", func(t *testing.T) {
+		_, err := execDeploymentCmd("logs", "scheduler", mockDeployment.ID, "--max-files", "-1")
+		assert.ErrorIs(t, err, errInvalidLogRotation)
+	})
+
+	t.Run("unsupported output format", func(t *testing.T) {
+		format, err := printutil.ParseOutputFormat(printutil.YAMLFormat)
+		assert.NoError(t, err)
+		printutil.SetOutputFormat(format)
+		defer printutil.SetOutputFormat(printutil.OutputFormat{})
+
+		_, err = execDeploymentCmd("logs", "scheduler", mockDeployment.ID)
+		assert.ErrorIs(t, err, errLogsOutputFormat)
+	})
+}
+
+func TestParseUntil(t *testing.T) {
+	now := time.Date(2023, 6, 5, 10, 0, 0, 0, time.UTC)
+
+	until, err := parseUntil("", now)
+	assert.NoError(t, err)
+	assert.True(t, until.IsZero())
+
+	until, err = parseUntil("1h", now)
+	assert.NoError(t, err)
+	assert.Equal(t, time.Date(2023, 6, 5, 9, 0, 0, 0, time.UTC), until)
+
+	until, err = parseUntil("2023-06-04T08:00:00Z", now)
+	assert.NoError(t, err)
+	assert.Equal(t, time.Date(2023, 6, 4, 8, 0, 0, 0, time.UTC), until)
+
+	_, err = parseUntil("yesterday", now)
+	assert.ErrorIs(t, err, errInvalidUntil)
+}
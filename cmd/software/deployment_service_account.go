@@ -1,6 +1,7 @@
 package software
 
 import (
+	"errors"
 	"fmt"
 	"io"
 
@@ -13,6 +14,11 @@ var (
 	deploymentSACreateLabel    string
 	deploymentSACreateCategory string
 	deploymentSACreateRole     string
+	deploymentSAListLabel      string
+
+	deploymentSARotateOptions sa.RotateOptions
+
+	errGracePeriodWithoutDelete = errors.New("--grace-period can only be used with --delete-old")
 
 	deploymentSaCreateExample = `
 # Create service-account
@@ -21,9 +27,22 @@ var (
 	deploymentSaListExample = `
   # Get deployment service-accounts
   $ astro deployment service-account list --deployment-id=<deployment-id>
+
+  # Get every deployment service-account with a label, 200 at a time
+  $ astro deployment service-account list --deployment-id=<deployment-id> --label=ci --all --page-size=200
 `
 	deploymentSaDeleteExample = `
   $ astro deployment service-account delete <service-account-id> --deployment-id=<deployment-id>
+`
+	deploymentSaRotateExample = `
+  # Replace a service-account and write the new API key to a file
+  $ astro deployment service-account rotate <service-account-id> --deployment-id=<deployment-id> --key-file=api-key.txt
+
+  # Replace a service-account and delete the old one right away
+  $ astro deployment service-account rotate <service-account-id> --deployment-id=<deployment-id> --delete-old
+
+  # Replace a service-account and print the command deleting the old one, to run once the new API key is rolled out
+  $ astro deployment service-account rotate <service-account-id> --deployment-id=<deployment-id> --delete-old --grace-period=1h
 `
 )
 
@@ -38,6 +57,7 @@ func newDeploymentSaRootCmd(out io.Writer) *cobra.Command {
 		newDeploymentSaCreateCmd(out),
 		newDeploymentSaListCmd(out),
 		newDeploymentSaDeleteCmd(out),
+		newDeploymentSaRotateCmd(out),
 	)
 	return cmd
 }
@@ -74,6 +94,10 @@ func newDeploymentSaListCmd(out io.Writer) *cobra.Command {
 		},
 	}
 	cmd.Flags().StringVarP(&deploymentID, "deployment-id", "d", "", "ID of the deployment in which you wish to manage Service Accounts")
+	cmd.Flags().StringVarP(&deploymentSAListLabel, "label", "l", "", "Only list the Service Accounts with this label")
+	cmd.Flags().IntVar(&listOptions.PageSize, "page-size", 0, pageSizeFlagUsage)
+	cmd.Flags().StringVar(&listOptions.Cursor, "cursor", "", cursorFlagUsage)
+	cmd.Flags().BoolVar(&listOptions.All, "all", false, allPagesFlagUsage)
 	_ = cmd.MarkFlagRequired("deployment-id")
 	return cmd
 }
@@ -95,6 +119,27 @@ func newDeploymentSaDeleteCmd(out io.Writer) *cobra.Command {
 	return cmd
 }
 
+func newDeploymentSaRotateCmd(out io.Writer) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "rotate [service-account ID]",
+		Aliases: []string{"ro"},
+		Short:   "Rotate the API key of a service-account in the astronomer platform",
+		Long:    "Replace a service-account with a new one with the same label, category and role, and optionally delete the old one. With --delete-old the old service-account is deleted right away. With --grace-period it is kept, and the command prints when and how to delete it: deleting it once the grace period is over is left to you",
+		Example: deploymentSaRotateExample,
+		Args:    cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return deploymentSaRotate(cmd, args, out)
+		},
+	}
+	cmd.Flags().StringVarP(&deploymentID, "deployment-id", "d", "", "ID of the deployment in which you wish to manage Service Accounts")
+	cmd.Flags().StringVarP(&deploymentSARotateOptions.Role, "role", "r", "", "Role of the new Service Account, the role of the rotated Service Account is kept by default")
+	cmd.Flags().StringVar(&deploymentSARotateOptions.KeyFile, "key-file", "", "Write the new API key to this file instead of printing it")
+	cmd.Flags().BoolVar(&deploymentSARotateOptions.DeleteOld, "delete-old", false, "Delete the rotated Service Account right away, or with --grace-period print the command deleting it once the grace period has passed")
+	cmd.Flags().DurationVar(&deploymentSARotateOptions.GracePeriod, "grace-period", 0, "Time the rotated Service Account is kept for before it can be deleted, used with --delete-old")
+	_ = cmd.MarkFlagRequired("deployment-id")
+	return cmd
+}
+
 func deploymentSaCreate(cmd *cobra.Command, out io.Writer) error {
 	if err := validateDeploymentRole(deploymentSACreateRole); err != nil {
 		return fmt.Errorf("failed to find a valid role: %w", err)
@@ -105,9 +150,16 @@ func deploymentSaCreate(cmd *cobra.Command, out io.Writer) error {
 }
 
 func deploymentSaList(cmd *cobra.Command, out io.Writer) error {
+	if err := listOptions.Validate(); err != nil {
+		return err
+	}
+
 	// Silence Usage as we have now validated command input
 	cmd.SilenceUsage = true
 
+	if listOptions.Paginated() || deploymentSAListLabel != "" {
+		return sa.PaginatedDeploymentServiceAccounts(deploymentID, deploymentSAListLabel, listPages(listOptions), houstonClient, out)
+	}
 	return sa.GetDeploymentServiceAccounts(deploymentID, houstonClient, out)
 }
 
@@ -117,3 +169,18 @@ func deploymentSaDelete(cmd *cobra.Command, args []string, out io.Writer) error
 
 	return sa.DeleteUsingDeploymentUUID(args[0], deploymentID, houstonClient, out)
 }
+
+func deploymentSaRotate(cmd *cobra.Command, args []string, out io.Writer) error {
+	if deploymentSARotateOptions.Role != "" {
+		if err := validateDeploymentRole(deploymentSARotateOptions.Role); err != nil {
+			return fmt.Errorf("failed to find a valid role: %w", err)
+		}
+	}
+	if deploymentSARotateOptions.GracePeriod != 0 && !deploymentSARotateOptions.DeleteOld {
+		return errGracePeriodWithoutDelete
+	}
+	// Silence Usage as we have now validated command input
+	cmd.SilenceUsage = true
+
+	return sa.RotateDeploymentServiceAccount(deploymentID, args[0], deploymentSARotateOptions, houstonClient, out)
+}
@@ -41,6 +41,21 @@ func TestDeploymentSAListCommand(t *testing.T) {
 	assert.Contains(t, output, mockSA.APIKey)
 }
 
+func TestDeploymentSAListPaginatedCommand(t *testing.T) {
+	testUtil.InitTestConfig(testUtil.SoftwarePlatform)
+	mockSA := houston.ServiceAccount{ID: "sa-id", APIKey: "sa-api-key", Label: "ci"}
+
+	api := new(mocks.ClientInterface)
+	api.On("PaginatedListDeploymentServiceAccounts", houston.PaginatedServiceAccountsRequest{DeploymentID: mockDeployment.ID, Take: 1}).Return([]houston.ServiceAccount{mockSA}, nil)
+	houstonClient = api
+
+	output, err := execDeploymentCmd("sa", "list", "--deployment-id="+mockDeployment.ID, "--page-size=1")
+	assert.NoError(t, err)
+	assert.Contains(t, output, "sa-api-key")
+	assert.Contains(t, output, "--cursor=sa-id")
+	api.AssertExpectations(t)
+}
+
 func TestDeploymentSaDeleteWoKeyIdCommand(t *testing.T) {
 	testUtil.InitTestConfig(testUtil.SoftwarePlatform)
 	_, err := execDeploymentCmd("service-account", "delete", "--deployment-id=1234")
@@ -109,3 +124,31 @@ func TestDeploymentSaCreateCommand(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Equal(t, expectedOut, output)
 }
+
+func TestDeploymentSaRotateCommand(t *testing.T) {
+	testUtil.InitTestConfig(testUtil.SoftwarePlatform)
+	oldSA := houston.ServiceAccount{ID: "old-sa-id", Label: "ci", Category: "default", RoleBinding: &houston.RoleBinding{Role: houston.DeploymentEditorRole}}
+
+	api := new(mocks.ClientInterface)
+	api.On("ListDeploymentServiceAccounts", mockDeployment.ID).Return([]houston.ServiceAccount{oldSA}, nil)
+	api.On("CreateDeploymentServiceAccount", &houston.CreateServiceAccountRequest{DeploymentID: mockDeployment.ID, Label: "ci", Category: "default", Role: houston.DeploymentEditorRole}).
+		Return(&houston.DeploymentServiceAccount{ID: "new-sa-id", APIKey: "new-api-key", Label: "ci", Category: "default"}, nil)
+	api.On("DeleteDeploymentServiceAccount", houston.DeleteServiceAccountRequest{DeploymentID: mockDeployment.ID, ServiceAccountID: "old-sa-id"}).Return(&oldSA, nil)
+	houstonClient = api
+
+	output, err := execDeploymentCmd("sa", "rotate", "old-sa-id", "--deployment-id="+mockDeployment.ID, "--delete-old")
+	assert.NoError(t, err)
+	assert.Contains(t, output, "new-api-key")
+	assert.Contains(t, output, "Service Account ci (old-sa-id) successfully deleted")
+	api.AssertExpectations(t)
+}
+
+func TestDeploymentSaRotateFlagsValidation(t *testing.T) {
+	testUtil.InitTestConfig(testUtil.SoftwarePlatform)
+
+	_, err := execDeploymentCmd("sa", "rotate", "old-sa-id", "--deployment-id="+mockDeployment.ID, "--role=invalid-role")
+	assert.ErrorContains(t, err, "failed to find a valid role")
+
+	_, err = execDeploymentCmd("sa", "rotate", "old-sa-id", "--deployment-id="+mockDeployment.ID, "--grace-period=1h")
+	assert.ErrorIs(t, err, errGracePeriodWithoutDelete)
+}
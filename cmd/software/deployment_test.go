@@ -3,12 +3,18 @@ package software
 import (
 	"bytes"
 	"os"
+	"path/filepath"
 	"testing"
 	"time"
 
+	"github.com/astronomer/astro-cli/cmd/utils"
 	"github.com/astronomer/astro-cli/houston"
 	mocks "github.com/astronomer/astro-cli/houston/mocks"
+	"github.com/astronomer/astro-cli/pkg/printutil"
 	testUtil "github.com/astronomer/astro-cli/pkg/testing"
+	"github.com/astronomer/astro-cli/software/deploy"
+	softwareUtils "github.com/astronomer/astro-cli/software/utils"
+	"github.com/spf13/cobra"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 )
@@ -189,7 +195,7 @@ func TestDeploymentCreateCommandNfsMountEnabled(t *testing.T) {
 	}{
 		{cmdArgs: []string{"create", "--label=new-deployment-name", "--executor=celery", "--dag-deployment-type=volume", "--nfs-location=test:/test"}, expectedOutput: "Successfully created deployment with Celery executor. Deployment can be accessed at the following URLs", expectedError: ""},
 		{cmdArgs: []string{"create", "--label=new-deployment-name", "--executor=celery"}, expectedOutput: "Successfully created deployment with Celery executor. Deployment can be accessed at the following URLs", expectedError: ""},
-		{cmdArgs: []string{"create", "--label=new-deployment-name", "--executor=celery", "--dag-deployment-type=dummy"}, expectedOutput: "", expectedError: "please specify the correct DAG deployment type, one of the following: image, volume, git_sync"},
+		{cmdArgs: []string{"create", "--label=new-deployment-name", "--executor=celery", "--dag-deployment-type=dummy"}, expectedOutput: "", expectedError: "please specify the correct DAG deployment type, one of the following: image, volume, git_sync, dag_deploy"},
 	}
 	for _, tt := range myTests {
 		houstonClient = api
@@ -225,7 +231,7 @@ func TestDeploymentCreateCommandGitSyncEnabled(t *testing.T) {
 		{cmdArgs: []string{"create", "--label=new-deployment-name", "--executor=celery", "--dag-deployment-type=git_sync", "--git-repository-url=git@github.com:bote795/private-ariflow-dags-test.git", "--dag-directory-path=dagscopy/", "--git-branch-name=main", "--ssh-key=./testfiles/ssh_key", "--known-hosts=./testfiles/known_hosts"}, expectedOutput: "Successfully created deployment with Celery executor. Deployment can be accessed at the following URLs", expectedError: ""},
 		{cmdArgs: []string{"create", "--label=new-deployment-name", "--executor=celery", "--dag-deployment-type=git_sync", "--git-repository-url=git@github.com:neel-astro/private-airflow-dags-test.git", "--dag-directory-path=dagscopy/", "--git-branch-name=main", "--ssh-key=./testfiles/ssh_key", "--known-hosts=./testfiles/known_hosts"}, expectedOutput: "Successfully created deployment with Celery executor. Deployment can be accessed at the following URLs", expectedError: ""},
 		{cmdArgs: []string{"create", "--label=new-deployment-name", "--executor=celery", "--dag-deployment-type=git_sync", "--git-repository-url=git@github.com:neel-astro/private-airflow-dags-test.git", "--ssh-key=./testfiles/ssh_key", "--known-hosts=./testfiles/known_hosts"}, expectedOutput: "Successfully created deployment with Celery executor. Deployment can be accessed at the following URLs", expectedError: ""},
-		{cmdArgs: []string{"create", "--label=new-deployment-name", "--executor=celery", "--dag-deployment-type=dummy"}, expectedOutput: "", expectedError: "please specify the correct DAG deployment type, one of the following: image, volume, git_sync"},
+		{cmdArgs: []string{"create", "--label=new-deployment-name", "--executor=celery", "--dag-deployment-type=dummy"}, expectedOutput: "", expectedError: "please specify the correct DAG deployment type, one of the following: image, volume, git_sync, dag_deploy"},
 	}
 	for _, tt := range myTests {
 		houstonClient = api
@@ -325,7 +331,7 @@ func TestDeploymentUpdateCommand(t *testing.T) {
 		{cmdArgs: []string{"update", "cknrml96n02523xr97ygj95n5", "--label=test22222", "--dag-deployment-type=git_sync", "--git-repository-url=git@github.com:bote795/private-ariflow-dags-test.git", "--dag-directory-path=dagscopy/", "--git-branch-name=main", "--ssh-key=./testfiles/ssh_key", "--known-hosts=./testfiles/known_hosts"}, expectedOutput: "Successfully updated deployment", expectedError: ""},
 		{cmdArgs: []string{"update", "cknrml96n02523xr97ygj95n5", "--label=test22222", "--dag-deployment-type=git_sync", "--git-repository-url=git@github.com:neel-astro/private-airflow-dags-test.git", "--dag-directory-path=dagscopy/", "--git-branch-name=main", "--ssh-key=./testfiles/ssh_key", "--known-hosts=./testfiles/known_hosts"}, expectedOutput: "Successfully updated deployment", expectedError: ""},
 		{cmdArgs: []string{"update", "cknrml96n02523xr97ygj95n5", "--label=test22222", "--dag-deployment-type=git_sync", "--git-repository-url=git@github.com:neel-astro/private-airflow-dags-test.git", "--ssh-key=./testfiles/ssh_key", "--known-hosts=./testfiles/known_hosts"}, expectedOutput: "Successfully updated deployment", expectedError: ""},
-		{cmdArgs: []string{"update", "cknrml96n02523xr97ygj95n5", "--label=test22222", "--dag-deployment-type=wrong", "--nfs-location=test:/test"}, expectedOutput: "", expectedError: "please specify the correct DAG deployment type, one of the following: image, volume, git_sync"},
+		{cmdArgs: []string{"update", "cknrml96n02523xr97ygj95n5", "--label=test22222", "--dag-deployment-type=wrong", "--nfs-location=test:/test"}, expectedOutput: "", expectedError: "please specify the correct DAG deployment type, one of the following: image, volume, git_sync, dag_deploy"},
 		{cmdArgs: []string{"update", "cknrml96n02523xr97ygj95n5", "--label=test22222", "--executor=local"}, expectedOutput: "Successfully updated deployment", expectedError: ""},
 		{cmdArgs: []string{"update", "cknrml96n02523xr97ygj95n5", "--cloud-role=arn:aws:iam::1234567890:role/test_role4c2301381e"}, expectedOutput: "Successfully updated deployment", expectedError: ""},
 	}
@@ -463,6 +469,45 @@ func TestDeploymentList(t *testing.T) {
 	api.AssertExpectations(t)
 }
 
+func TestDeploymentListPaginated(t *testing.T) {
+	testUtil.InitTestConfig(testUtil.SoftwarePlatform)
+	ws, err := coalesceWorkspace()
+	assert.NoError(t, err)
+
+	api := new(mocks.ClientInterface)
+	api.On("PaginatedListDeployments", houston.PaginatedDeploymentsRequest{WorkspaceID: ws, Label: "test", CursorID: "cursor-id", Take: 1}).Return([]houston.Deployment{*mockDeployment}, nil)
+
+	houstonClient = api
+	output, err := execDeploymentCmd("list", "--label=test", "--page-size=1", "--cursor=cursor-id")
+	assert.NoError(t, err)
+	assert.Contains(t, output, mockDeployment.ID)
+	assert.Contains(t, output, "--cursor="+mockDeployment.ID)
+	api.AssertExpectations(t)
+
+	_, err = execDeploymentCmd("list", "--page-size=-1")
+	assert.ErrorIs(t, err, softwareUtils.ErrInvalidPageSize)
+
+	t.Run("every page of every workspace", func(t *testing.T) {
+		api := new(mocks.ClientInterface)
+		api.On("PaginatedListDeployments", houston.PaginatedDeploymentsRequest{Take: 1}).Return([]houston.Deployment{*mockDeployment}, nil).Once()
+		api.On("PaginatedListDeployments", houston.PaginatedDeploymentsRequest{CursorID: mockDeployment.ID, Take: 1}).Return([]houston.Deployment{}, nil).Once()
+
+		houstonClient = api
+		output, err := execDeploymentCmd("list", "--all", "--page-size=1", "--all-pages")
+		assert.NoError(t, err)
+		assert.Contains(t, output, mockDeployment.ID)
+		assert.NotContains(t, output, "--cursor=")
+		api.AssertExpectations(t)
+	})
+
+	t.Run("help explains --all-pages", func(t *testing.T) {
+		output, err := execDeploymentCmd("list", "--help")
+		assert.NoError(t, err)
+		assert.Contains(t, output, "Every page is listed with --all-pages, where the other list commands use --all, as --all lists the Deployments of every Workspace here")
+		assert.Contains(t, output, "Named --all on the other list commands")
+	})
+}
+
 func TestDeploymentDeleteHardResponseNo(t *testing.T) {
 	testUtil.InitTestConfig(testUtil.SoftwarePlatform)
 	appConfig = &houston.AppConfig{
@@ -611,6 +656,119 @@ func TestDeploymentRuntimeUpgradeCancelCommand(t *testing.T) {
 	api.AssertExpectations(t)
 }
 
+func TestDeploymentRuntimeUpgradeWaitCommand(t *testing.T) {
+	testUtil.InitTestConfig(testUtil.SoftwarePlatform)
+	appConfig = &houston.AppConfig{
+		Flags: houston.FeatureFlags{
+			AstroRuntimeEnabled: true,
+		},
+	}
+	defer func() {
+		EnsureProjectDir = utils.EnsureProjectDir
+		DeployAirflowImage = deploy.Airflow
+		setBaseImage = deploy.SetBaseImage
+		upgradeInterval = 10 * time.Second
+	}()
+	EnsureProjectDir = func(cmd *cobra.Command, args []string) error {
+		return nil
+	}
+	var baseImage, deployedID string
+	var restored bool
+	setBaseImage = func(path, image string) (func() error, error) {
+		baseImage = image
+		return func() error {
+			restored = true
+			return nil
+		}, nil
+	}
+	DeployAirflowImage = func(houstonClient houston.ClientInterface, path, deploymentID, wsID, byoRegistryDomain string, byoTagTemplates []string, ignoreCacheDeploy, byoRegistryEnabled, prompt bool) error {
+		deployedID = deploymentID
+		return nil
+	}
+	upgradeInterval = 0
+
+	mockDeploymentResponse := *mockDeployment
+	mockDeploymentResponse.AirflowVersion = ""
+	mockDeploymentResponse.DesiredAirflowVersion = ""
+	mockDeploymentResponse.RuntimeVersion = "4.2.4"
+	mockDeploymentResponse.RuntimeAirflowVersion = "2.2.5"
+	mockDeploymentResponse.DesiredRuntimeVersion = "4.2.5"
+	mockUpgradedResponse := mockDeploymentResponse
+	mockUpgradedResponse.RuntimeVersion = "4.2.5"
+
+	api := new(mocks.ClientInterface)
+	api.On("GetDeployment", mockDeploymentResponse.ID).Return(&mockDeploymentResponse, nil).Twice()
+	api.On("GetDeployment", mockDeploymentResponse.ID).Return(&mockUpgradedResponse, nil)
+	api.On("UpdateDeploymentRuntime", mock.Anything).Return(&mockDeploymentResponse, nil)
+	api.On("GetDeploymentStatus", mockDeploymentResponse.ID).Return(&houston.DeploymentStatus{Status: houston.HealthyStatus}, nil)
+
+	houstonClient = api
+	output, err := execDeploymentCmd(
+		"runtime",
+		"upgrade",
+		"--deployment-id="+mockDeploymentResponse.ID,
+		"--desired-runtime-version=4.2.5",
+		"--wait",
+	)
+	assert.NoError(t, err)
+	assert.Equal(t, "quay.io/astronomer/astro-runtime:4.2.5", baseImage)
+	assert.Equal(t, mockDeploymentResponse.ID, deployedID)
+	assert.True(t, restored)
+	assert.Contains(t, output, "is running quay.io/astronomer/astro-runtime:4.2.5 and all of its components are healthy")
+	api.AssertExpectations(t)
+}
+
+func TestDeploymentUpgradeLabelFilterCommand(t *testing.T) {
+	testUtil.InitTestConfig(testUtil.SoftwarePlatform)
+
+	mockDeploymentResponse := *mockDeployment
+	mockDeploymentResponse.AirflowVersion = "1.10.5"
+	mockUpgradingResponse := mockDeploymentResponse
+	mockUpgradingResponse.DesiredAirflowVersion = "1.10.10"
+	otherDeployment := *mockDeployment
+	otherDeployment.ID = "other-id"
+	otherDeployment.Label = "other"
+
+	api := new(mocks.ClientInterface)
+	api.On("ListDeployments", mock.Anything).Return([]houston.Deployment{mockDeploymentResponse, otherDeployment}, nil)
+	api.On("GetDeployment", mockDeploymentResponse.ID).Return(&mockDeploymentResponse, nil)
+	api.On("UpdateDeploymentAirflow", map[string]interface{}{"deploymentId": mockDeploymentResponse.ID, "desiredAirflowVersion": "1.10.10"}).Return(&mockUpgradingResponse, nil)
+
+	houstonClient = api
+	output, err := execDeploymentCmd(
+		"airflow",
+		"upgrade",
+		"--label-filter=te*",
+		"--desired-airflow-version=1.10.10",
+	)
+	assert.NoError(t, err)
+	assert.Contains(t, output, "Upgrading 1 deployments matching te*")
+	assert.Contains(t, output, "Successfully upgraded 1 deployments")
+	api.AssertExpectations(t)
+}
+
+func TestDeploymentUpgradeFlagsValidation(t *testing.T) {
+	testUtil.InitTestConfig(testUtil.SoftwarePlatform)
+	houstonClient = new(mocks.ClientInterface)
+
+	_, err := execDeploymentCmd("runtime", "migrate")
+	assert.ErrorIs(t, err, errUpgradeTarget)
+
+	_, err = execDeploymentCmd("runtime", "upgrade", "--deployment-id=test-id", "--label-filter=prod-*")
+	assert.ErrorIs(t, err, errUpgradeTarget)
+
+	_, err = execDeploymentCmd("airflow", "upgrade", "--cancel", "--label-filter=prod-*")
+	assert.ErrorIs(t, err, errUpgradeCancelFlags)
+
+	_, err = execDeploymentCmd("runtime", "upgrade", "--label-filter=prod-*", "--desired-runtime-version=4.2.5", "--wait")
+	assert.ErrorIs(t, err, errUpgradeWaitBatch)
+
+	houstonVersion = "0.33.0"
+	defer func() { houstonVersion = "" }()
+	_, err = execDeploymentCmd("runtime", "upgrade", "--deployment-id=test-id", "--desired-runtime-version=4.2.5", "--wait")
+	assert.ErrorIs(t, err, errUpgradeWaitVersion)
+}
+
 func TestDeploymentRuntimeMigrateCommand(t *testing.T) {
 	testUtil.InitTestConfig(testUtil.SoftwarePlatform)
 
@@ -685,3 +843,56 @@ func TestDeploymentRuntimeMigrateCancelCommand(t *testing.T) {
 	assert.Contains(t, output, expectedOut)
 	api.AssertExpectations(t)
 }
+
+func TestDeploymentInspect(t *testing.T) {
+	testUtil.InitTestConfig(testUtil.SoftwarePlatform)
+	api := new(mocks.ClientInterface)
+	api.On("GetDeployment", mockDeployment.ID).Return(mockDeployment, nil).Once()
+	api.On("ListDeploymentUsers", houston.ListDeploymentUsersRequest{DeploymentID: mockDeployment.ID}).Return([]houston.DeploymentUser{}, nil).Once()
+	api.On("ListDeploymentTeamsAndRoles", mockDeployment.ID).Return([]houston.Team{}, nil).Once()
+
+	houstonClient = api
+	t.Cleanup(func() { printutil.SetOutputFormat(printutil.OutputFormat{}) })
+	output, err := execDeploymentCmd("inspect", mockDeployment.ID, "-o", "json")
+	assert.NoError(t, err)
+	assert.Contains(t, output, `"name": "test"`)
+	assert.Contains(t, output, `"release_name": "accurate-radioactivity-8677"`)
+	api.AssertExpectations(t)
+
+	_, err = execDeploymentCmd("inspect", mockDeployment.ID, "--output", "table")
+	assert.EqualError(t, err, "invalid output format table, use one of: yaml or json")
+}
+
+func TestDeploymentCreateUpdateFromFile(t *testing.T) {
+	testUtil.InitTestConfig(testUtil.SoftwarePlatform)
+	filePath := filepath.Join(t.TempDir(), "deployment.yaml")
+	err := os.WriteFile(filePath, []byte("deployment:\n  configuration:\n    name: test\n    executor: CeleryExecutor\n"), 0o600)
+	assert.NoError(t, err)
+
+	api := new(mocks.ClientInterface)
+	api.On("GetAppConfig", nil).Return(mockAppConfig, nil)
+	api.On("GetDeploymentExecutorsConfig", nil).Return(&houston.DeploymentConfig{}, nil)
+	api.On("ListDeployments", mock.Anything).Return([]houston.Deployment{}, nil).Once()
+	api.On("CreateDeployment", mock.Anything).Return(mockDeployment, nil).Once()
+	api.On("GetDeployment", mockDeployment.ID).Return(mockDeployment, nil).Once()
+	api.On("ListDeploymentUsers", mock.Anything).Return([]houston.DeploymentUser{}, nil).Once()
+	api.On("ListDeploymentTeamsAndRoles", mockDeployment.ID).Return([]houston.Team{}, nil).Once()
+	houstonClient = api
+
+	output, err := execDeploymentCmd("create", "--deployment-file", filePath)
+	assert.NoError(t, err)
+	assert.Contains(t, output, "name: test")
+	api.AssertExpectations(t)
+
+	_, err = execDeploymentCmd("create", "--deployment-file", filePath, "--label", "test")
+	assert.ErrorIs(t, err, errDeploymentFileFlag)
+
+	_, err = execDeploymentCmd("create")
+	assert.ErrorIs(t, err, errLabelRequired)
+
+	_, err = execDeploymentCmd("update", mockDeployment.ID, "--deployment-file", filePath)
+	assert.ErrorIs(t, err, errDeploymentFileFlag)
+
+	_, err = execDeploymentCmd("update")
+	assert.EqualError(t, err, "accepts 1 arg(s), received 0")
+}
@@ -1,6 +1,7 @@
 package software
 
 import (
+	"errors"
 	"fmt"
 	"io"
 
@@ -14,10 +15,19 @@ var (
 	deploymentUserEmail    string
 	deploymentUserRole     string
 	deploymentUserFullname string
+	deploymentUserListRole string
+	deploymentUserSyncFile string
+	deploymentUserSyncDry  bool
+	deploymentUserSyncYes  bool
+
+	errUserListFilters = errors.New("--user-id and --name can not be used with --role or the pagination flags")
 	// examples
 	deploymentUserListExample = `
 # Search for deployment users
   $ astro deployment user list --deployment-id=<deployment-id> --email=EMAIL_ADDRESS --user-id=ID --name=NAME
+
+# List the deployment admins 50 at a time, run it again with the printed --cursor to get the next page
+  $ astro deployment user list --deployment-id=<deployment-id> --role=DEPLOYMENT_ADMIN --page-size=50
 `
 	deploymentUserCreateExample = `
 # Add a workspace user to a deployment with a particular role
@@ -30,6 +40,28 @@ var (
 	deploymentUserUpdateExample = `
 # Update a workspace user's deployment role
   $ astro deployment user update --deployment-id=xxxxx --role=DEPLOYMENT_ROLE <user-email-address>
+`
+	deploymentUserSyncExample = `
+# Add, update and remove the users and teams of a deployment to match the groups of an identity provider export
+  $ astro deployment user sync --deployment-id=xxxxx --from=groups.yaml
+
+# Show the changes without applying them
+  $ astro deployment user sync --deployment-id=xxxxx --from=groups.yaml --dry-run
+
+# Remove the users and teams missing from the groups without a confirmation
+  $ astro deployment user sync --deployment-id=xxxxx --from=groups.yaml --yes
+
+# groups.yaml
+groups:
+  - name: data-admins
+    role: DEPLOYMENT_ADMIN
+    members:
+      - admin@example.com
+  - name: data-engineers
+    role: DEPLOYMENT_EDITOR
+    team_id: <team-id>
+    members:
+      - engineer@example.com
 `
 )
 
@@ -45,6 +77,7 @@ func newDeploymentUserRootCmd(out io.Writer) *cobra.Command {
 		newDeploymentUserAddCmd(out),
 		newDeploymentUserRemoveCmd(out),
 		newDeploymentUserUpdateCmd(out),
+		newDeploymentUserSyncCmd(out),
 	)
 	return cmd
 }
@@ -63,6 +96,10 @@ func newDeploymentUserListCmd(out io.Writer) *cobra.Command {
 	cmd.Flags().StringVarP(&deploymentUserID, "user-id", "u", "", "ID of the user to search for")
 	cmd.Flags().StringVarP(&deploymentUserEmail, "email", "e", "", "Email of the user to search for")
 	cmd.Flags().StringVarP(&deploymentUserFullname, "name", "n", "", "Full name of the user to search for")
+	cmd.Flags().StringVarP(&deploymentUserListRole, "role", "r", "", "Only list the users with this role, one of: DEPLOYMENT_VIEWER, DEPLOYMENT_EDITOR, DEPLOYMENT_ADMIN")
+	cmd.Flags().IntVar(&listOptions.PageSize, "page-size", 0, pageSizeFlagUsage)
+	cmd.Flags().StringVar(&listOptions.Cursor, "cursor", "", cursorFlagUsage)
+	cmd.Flags().BoolVar(&listOptions.All, "all", false, allPagesFlagUsage)
 	_ = cmd.MarkFlagRequired("deployment-id")
 
 	return cmd
@@ -121,7 +158,44 @@ func newDeploymentUserUpdateCmd(out io.Writer) *cobra.Command { //nolint:dupl
 	return cmd
 }
 
+func newDeploymentUserSyncCmd(out io.Writer) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "sync",
+		Short:   "Sync the users and teams of a deployment with identity provider groups",
+		Long:    "Add, update and remove the users and teams of a deployment so that their roles match the groups of an identity provider export. A user or team in several groups gets the highest role of its groups. Users are only synced when a group has members, an empty members list removing them, and your own access is never removed.",
+		Args:    cobra.NoArgs,
+		Example: deploymentUserSyncExample,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return deploymentUserSync(cmd, out)
+		},
+	}
+	cmd.Flags().StringVar(&deploymentID, "deployment-id", "", "ID of the Deployment where you want to sync the users and teams")
+	cmd.Flags().StringVar(&deploymentUserSyncFile, "from", "", "Path to the yaml or json file with the groups to sync")
+	cmd.Flags().BoolVar(&deploymentUserSyncDry, "dry-run", false, "Print the changes without applying them")
+	cmd.Flags().BoolVarP(&deploymentUserSyncYes, "yes", "y", false, "Remove the users and teams missing from the groups without asking for a confirmation")
+	_ = cmd.MarkFlagRequired("deployment-id")
+	_ = cmd.MarkFlagRequired("from")
+	return cmd
+}
+
 func deploymentUserList(cmd *cobra.Command, out io.Writer) error {
+	if err := listOptions.Validate(); err != nil {
+		return err
+	}
+	if listOptions.Paginated() || deploymentUserListRole != "" {
+		if deploymentUserID != "" || deploymentUserFullname != "" {
+			return errUserListFilters
+		}
+		if deploymentUserListRole != "" {
+			if err := validateDeploymentRole(deploymentUserListRole); err != nil {
+				return fmt.Errorf("failed to find a valid role: %w", err)
+			}
+		}
+		// Silence Usage as we have now validated command input
+		cmd.SilenceUsage = true
+		return deployment.PaginatedUserList(deploymentID, deploymentUserEmail, deploymentUserListRole, listPages(listOptions), houstonClient, out)
+	}
+
 	// Silence Usage as we have now validated command input
 	cmd.SilenceUsage = true
 	return deployment.UserList(deploymentID, deploymentUserEmail, deploymentUserID, deploymentUserFullname, houstonClient, out)
@@ -152,3 +226,9 @@ func deploymentUserUpdate(cmd *cobra.Command, out io.Writer, args []string) erro
 	cmd.SilenceUsage = true
 	return deployment.UpdateUser(deploymentID, args[0], deploymentUserRole, houstonClient, out)
 }
+
+func deploymentUserSync(cmd *cobra.Command, out io.Writer) error {
+	// Silence Usage as we have now validated command input
+	cmd.SilenceUsage = true
+	return deployment.SyncRoles(deploymentID, deploymentUserSyncFile, deploymentUserSyncDry, deploymentUserSyncYes, houstonClient, out)
+}
@@ -1,11 +1,14 @@
 package software
 
 import (
+	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/astronomer/astro-cli/houston"
 	mocks "github.com/astronomer/astro-cli/houston/mocks"
 	testUtil "github.com/astronomer/astro-cli/pkg/testing"
+	softwareUtils "github.com/astronomer/astro-cli/software/utils"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -82,6 +85,33 @@ func TestDeploymentUserList(t *testing.T) {
 	api.AssertExpectations(t)
 }
 
+func TestDeploymentUserListPaginated(t *testing.T) {
+	testUtil.InitTestConfig(testUtil.SoftwarePlatform)
+	mockUser := []houston.DeploymentUser{
+		{
+			ID:           "test-user-id",
+			Username:     "test-email",
+			FullName:     "test-name",
+			RoleBindings: []houston.RoleBinding{{Role: houston.DeploymentAdminRole, Deployment: houston.Deployment{ID: "test-id"}}},
+		},
+	}
+	api := new(mocks.ClientInterface)
+	request := houston.PaginatedDeploymentUsersRequest{DeploymentID: "test-id", Role: houston.DeploymentAdminRole, Take: softwareUtils.DefaultPageSize}
+	api.On("PaginatedListDeploymentUsers", request).Return(mockUser, nil).Once()
+
+	houstonClient = api
+	output, err := execDeploymentCmd("user", "list", "--deployment-id", "test-id", "--role", houston.DeploymentAdminRole)
+	assert.NoError(t, err)
+	assert.Contains(t, output, "test-name")
+	api.AssertExpectations(t)
+
+	_, err = execDeploymentCmd("user", "list", "--deployment-id", "test-id", "--all", "-n", "test-name")
+	assert.ErrorIs(t, err, errUserListFilters)
+
+	_, err = execDeploymentCmd("user", "list", "--deployment-id", "test-id", "--role", "INVALID_ROLE")
+	assert.ErrorContains(t, err, "failed to find a valid role")
+}
+
 func TestDeploymentUserUpdateCommand(t *testing.T) {
 	testUtil.InitTestConfig(testUtil.SoftwarePlatform)
 	expectedNewRole := houston.DeploymentAdminRole
@@ -110,3 +140,23 @@ func TestDeploymentUserUpdateCommand(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Contains(t, output, expectedOut)
 }
+
+func TestDeploymentUserSyncCommand(t *testing.T) {
+	testUtil.InitTestConfig(testUtil.SoftwarePlatform)
+	groupsFile := filepath.Join(t.TempDir(), "groups.yaml")
+	err := os.WriteFile(groupsFile, []byte("groups:\n  - name: admins\n    role: DEPLOYMENT_ADMIN\n    members: [somebody@astronomer.io]\n"), os.FileMode(0o644))
+	assert.NoError(t, err)
+
+	api := new(mocks.ClientInterface)
+	api.On("ListDeploymentUsers", houston.ListDeploymentUsersRequest{DeploymentID: mockDeployment.ID}).Return([]houston.DeploymentUser{}, nil)
+	api.On("AddDeploymentUser", houston.UpdateDeploymentUserRequest{Email: "somebody@astronomer.io", Role: houston.DeploymentAdminRole, DeploymentID: mockDeployment.ID}).Return(&houston.RoleBinding{}, nil)
+	houstonClient = api
+
+	output, err := execDeploymentCmd("user", "sync", "--deployment-id="+mockDeployment.ID, "--from="+groupsFile)
+	assert.NoError(t, err)
+	assert.Contains(t, output, "Added user somebody@astronomer.io as a DEPLOYMENT_ADMIN")
+	api.AssertExpectations(t)
+
+	_, err = execDeploymentCmd("user", "sync", "--deployment-id="+mockDeployment.ID)
+	assert.EqualError(t, err, `required flag(s) "from" not set`)
+}
@@ -0,0 +1,168 @@
+package software
+
+import (
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/astronomer/astro-cli/software/deployment"
+	"github.com/spf13/cobra"
+)
+
+var (
+	errNoVariables = errors.New("no variables to set, pass key=value pairs or use --load to read them from an environment file")
+
+	variableKey     string
+	variableEnvFile string
+	variableLoad    bool
+	variableSecret  bool
+	// examples
+	deploymentVariableListExample = `
+# List the variables of a deployment, secret values are masked
+  $ astro deployment variable list --deployment-id=<deployment-id>
+`
+	deploymentVariableCreateExample = `
+# Create variables
+  $ astro deployment variable create --deployment-id=<deployment-id> KEY1=VAL1 KEY2=VAL2
+
+# Create secret variables from an environment file
+  $ astro deployment variable create --deployment-id=<deployment-id> --load --env=.env.prod --secret
+`
+	deploymentVariableUpdateExample = `
+# Update variables, variables that don't exist are created
+  $ astro deployment variable update --deployment-id=<deployment-id> KEY1=NEW_VAL1
+
+# Update variables from an environment file
+  $ astro deployment variable update --deployment-id=<deployment-id> --load --env=.env.prod
+`
+	deploymentVariableDeleteExample = `
+# Delete variables
+  $ astro deployment variable delete --deployment-id=<deployment-id> KEY1 KEY2
+`
+)
+
+func newDeploymentVariableRootCmd(out io.Writer) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "variable",
+		Aliases: []string{"var", "variables"},
+		Short:   "Manage Deployment environment variables",
+		Long:    "Manage environment variables for an Astronomer Deployment. These variables can be used in DAGs or to customize your Airflow environment",
+	}
+	cmd.AddCommand(
+		newDeploymentVariableListCmd(out),
+		newDeploymentVariableCreateCmd(out),
+		newDeploymentVariableUpdateCmd(out),
+		newDeploymentVariableDeleteCmd(out),
+	)
+	return cmd
+}
+
+func newDeploymentVariableListCmd(out io.Writer) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "list",
+		Aliases: []string{"ls"},
+		Short:   "List a Deployment's variables",
+		Long:    "List the keys and values of a Deployment's variables, the values of secret variables are masked",
+		Args:    cobra.NoArgs,
+		Example: deploymentVariableListExample,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return deploymentVariableList(cmd, out)
+		},
+	}
+	cmd.Flags().StringVarP(&deploymentID, "deployment-id", "d", "", "ID of the Deployment to list variables for")
+	cmd.Flags().StringVarP(&variableKey, "key", "k", "", "Key of a specific variable to show")
+	return cmd
+}
+
+func newDeploymentVariableCreateCmd(out io.Writer) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "create [key1=val1 key2=val2]",
+		Aliases: []string{"cr"},
+		Short:   "Create Deployment environment variables",
+		Long:    "Create Deployment environment variables by supplying key=value pairs or an environment file, existing variables are not changed",
+		Example: deploymentVariableCreateExample,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return deploymentVariableModify(cmd, args, false, out)
+		},
+	}
+	addDeploymentVariableModifyFlags(cmd)
+	return cmd
+}
+
+func newDeploymentVariableUpdateCmd(out io.Writer) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "update [key1=val1 key2=val2]",
+		Aliases: []string{"up"},
+		Short:   "Update Deployment environment variables",
+		Long:    "Update Deployment environment variables by supplying key=value pairs or an environment file, variables that don't already exist are created",
+		Example: deploymentVariableUpdateExample,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return deploymentVariableModify(cmd, args, true, out)
+		},
+	}
+	addDeploymentVariableModifyFlags(cmd)
+	return cmd
+}
+
+func newDeploymentVariableDeleteCmd(out io.Writer) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "delete [key1 key2]",
+		Aliases: []string{"de"},
+		Short:   "Delete Deployment environment variables",
+		Long:    "Delete Deployment environment variables by key",
+		Args:    cobra.MinimumNArgs(1),
+		Example: deploymentVariableDeleteExample,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return deploymentVariableDelete(cmd, args, out)
+		},
+	}
+	cmd.Flags().StringVarP(&deploymentID, "deployment-id", "d", "", "ID of the Deployment to delete variables from")
+	return cmd
+}
+
+func addDeploymentVariableModifyFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVarP(&deploymentID, "deployment-id", "d", "", "ID of the Deployment to set variables on")
+	cmd.Flags().BoolVarP(&variableLoad, "load", "l", false, "Load variables from an environment file")
+	cmd.Flags().StringVarP(&variableEnvFile, "env", "e", ".env", "Location of the environment file to load variables from")
+	cmd.Flags().BoolVarP(&variableSecret, "secret", "s", false, "Set the variables as secrets, their values can't be read back")
+}
+
+func deploymentVariableList(cmd *cobra.Command, out io.Writer) error {
+	ws, err := coalesceWorkspace()
+	if err != nil {
+		return fmt.Errorf("failed to find a valid workspace: %w", err)
+	}
+
+	// Silence Usage as we have now validated command input
+	cmd.SilenceUsage = true
+
+	return deployment.VariableList(ws, deploymentID, variableKey, houstonClient, out)
+}
+
+func deploymentVariableModify(cmd *cobra.Command, args []string, updateVars bool, out io.Writer) error {
+	ws, err := coalesceWorkspace()
+	if err != nil {
+		return fmt.Errorf("failed to find a valid workspace: %w", err)
+	}
+
+	if len(args) == 0 && !variableLoad {
+		return errNoVariables
+	}
+
+	// Silence Usage as we have now validated command input
+	cmd.SilenceUsage = true
+
+	return deployment.VariableModify(ws, deploymentID, variableEnvFile, args, variableLoad, variableSecret, updateVars, houstonClient, out)
+}
+
+func deploymentVariableDelete(cmd *cobra.Command, args []string, out io.Writer) error {
+	ws, err := coalesceWorkspace()
+	if err != nil {
+		return fmt.Errorf("failed to find a valid workspace: %w", err)
+	}
+
+	// Silence Usage as we have now validated command input
+	cmd.SilenceUsage = true
+
+	return deployment.VariableDelete(ws, deploymentID, args, houstonClient, out)
+}
@@ -0,0 +1,90 @@
+package software
+
+import (
+	"testing"
+
+	"github.com/astronomer/astro-cli/houston"
+	mocks "github.com/astronomer/astro-cli/houston/mocks"
+	testUtil "github.com/astronomer/astro-cli/pkg/testing"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDeploymentVariableCommands(t *testing.T) {
+	testUtil.InitTestConfig(testUtil.SoftwarePlatform)
+
+	deployments := []houston.Deployment{{ID: "test-id", ReleaseName: "test-release"}}
+	listRequest := houston.ListDeploymentVariablesRequest{DeploymentID: "test-id", ReleaseName: "test-release"}
+	variables := []houston.EnvironmentVariable{{Key: "FOO", Value: "bar"}, {Key: "TOKEN", IsSecret: true}}
+
+	t.Run("list", func(t *testing.T) {
+		api := new(mocks.ClientInterface)
+		api.On("ListDeployments", houston.ListDeploymentsRequest{WorkspaceID: "ck05r3bor07h40d02y2hw4n4v"}).Return(deployments, nil).Once()
+		api.On("ListDeploymentVariables", listRequest).Return(variables, nil).Once()
+
+		houstonClient = api
+		output, err := execDeploymentCmd("variable", "list", "--deployment-id", "test-id")
+		assert.NoError(t, err)
+		assert.Contains(t, output, "FOO")
+		assert.Contains(t, output, "****")
+		api.AssertExpectations(t)
+	})
+
+	t.Run("create", func(t *testing.T) {
+		expected := houston.UpdateDeploymentVariablesRequest{
+			DeploymentID:         "test-id",
+			ReleaseName:          "test-release",
+			EnvironmentVariables: append(append([]houston.EnvironmentVariable{}, variables...), houston.EnvironmentVariable{Key: "NEW", Value: "value", IsSecret: true}),
+		}
+		api := new(mocks.ClientInterface)
+		api.On("ListDeployments", houston.ListDeploymentsRequest{WorkspaceID: "ck05r3bor07h40d02y2hw4n4v"}).Return(deployments, nil).Once()
+		api.On("ListDeploymentVariables", listRequest).Return(variables, nil).Once()
+		api.On("UpdateDeploymentVariables", expected).Return(expected.EnvironmentVariables, nil).Once()
+
+		houstonClient = api
+		output, err := execDeploymentCmd("variable", "create", "--deployment-id", "test-id", "--secret", "NEW=value")
+		assert.NoError(t, err)
+		assert.Contains(t, output, "adding variable NEW")
+		api.AssertExpectations(t)
+	})
+
+	t.Run("update", func(t *testing.T) {
+		expected := houston.UpdateDeploymentVariablesRequest{
+			DeploymentID:         "test-id",
+			ReleaseName:          "test-release",
+			EnvironmentVariables: []houston.EnvironmentVariable{{Key: "FOO", Value: "baz"}, {Key: "TOKEN", IsSecret: true}},
+		}
+		api := new(mocks.ClientInterface)
+		api.On("ListDeployments", houston.ListDeploymentsRequest{WorkspaceID: "ck05r3bor07h40d02y2hw4n4v"}).Return(deployments, nil).Once()
+		api.On("ListDeploymentVariables", listRequest).Return(variables, nil).Once()
+		api.On("UpdateDeploymentVariables", expected).Return(expected.EnvironmentVariables, nil).Once()
+
+		houstonClient = api
+		output, err := execDeploymentCmd("variable", "update", "--deployment-id", "test-id", "--secret=false", "FOO=baz")
+		assert.NoError(t, err)
+		assert.Contains(t, output, "updating variable FOO")
+		api.AssertExpectations(t)
+	})
+
+	t.Run("update without variables", func(t *testing.T) {
+		_, err := execDeploymentCmd("variable", "update", "--deployment-id", "test-id", "--load=false")
+		assert.ErrorIs(t, err, errNoVariables)
+	})
+
+	t.Run("delete", func(t *testing.T) {
+		expected := houston.UpdateDeploymentVariablesRequest{
+			DeploymentID:         "test-id",
+			ReleaseName:          "test-release",
+			EnvironmentVariables: []houston.EnvironmentVariable{{Key: "TOKEN", IsSecret: true}},
+		}
+		api := new(mocks.ClientInterface)
+		api.On("ListDeployments", houston.ListDeploymentsRequest{WorkspaceID: "ck05r3bor07h40d02y2hw4n4v"}).Return(deployments, nil).Once()
+		api.On("ListDeploymentVariables", listRequest).Return(variables, nil).Once()
+		api.On("UpdateDeploymentVariables", expected).Return(expected.EnvironmentVariables, nil).Once()
+
+		houstonClient = api
+		output, err := execDeploymentCmd("variable", "delete", "--deployment-id", "test-id", "FOO")
+		assert.NoError(t, err)
+		assert.Contains(t, output, "deleting variable FOO")
+		api.AssertExpectations(t)
+	})
+}
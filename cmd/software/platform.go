@@ -0,0 +1,60 @@
+package software
+
+import (
+	"io"
+
+	"github.com/astronomer/astro-cli/houston"
+	"github.com/astronomer/astro-cli/pkg/printutil"
+	"github.com/astronomer/astro-cli/software/platform"
+	"github.com/spf13/cobra"
+)
+
+func newPlatformCmd(out io.Writer) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "platform",
+		Short: "Get information about the connected Astronomer Software platform",
+		Long:  "Get information about the connected Astronomer Software platform",
+	}
+	cmd.AddCommand(
+		newPlatformInfoCmd(out),
+	)
+	return cmd
+}
+
+func newPlatformInfoCmd(out io.Writer) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "info",
+		Short: "Show the platform version, feature flags and supported commands",
+		Long:  "Show the version and the feature flags of the connected platform, and which CLI commands are available, unavailable or degraded on it. With --output json or yaml, it is printed as a single document",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return platformInfo(cmd, out)
+		},
+	}
+	return cmd
+}
+
+func platformInfo(cmd *cobra.Command, out io.Writer) error {
+	outputFormat, err := printutil.DocumentFormat(printutil.TableFormat, printutil.TableFormat, printutil.JSONFormat, printutil.YAMLFormat)
+	if err != nil {
+		return err
+	}
+
+	version, err := houstonClient.GetPlatformVersion(nil)
+	if err != nil {
+		return err
+	}
+	config, err := houston.Call(houstonClient.GetAppConfig)(nil)
+	if err != nil {
+		return err
+	}
+
+	// Silence Usage as we have now validated command input
+	cmd.SilenceUsage = true
+
+	var flags houston.FeatureFlags
+	if config != nil {
+		flags = config.Flags
+	}
+	return platform.Info(version, config, commandsSupport(version, flags), outputFormat, out)
+}
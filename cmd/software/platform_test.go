@@ -0,0 +1,104 @@
+package software
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/astronomer/astro-cli/houston"
+	mocks "github.com/astronomer/astro-cli/houston/mocks"
+	"github.com/astronomer/astro-cli/pkg/printutil"
+	testUtil "github.com/astronomer/astro-cli/pkg/testing"
+	"github.com/astronomer/astro-cli/software/platform"
+	"github.com/stretchr/testify/assert"
+)
+
+func execPlatformCmd(args ...string) (string, error) {
+	buf := new(bytes.Buffer)
+	cmd := newPlatformCmd(buf)
+	cmd.SetOut(buf)
+	cmd.SetArgs(args)
+	testUtil.SetupOSArgsForGinkgo()
+	_, err := cmd.ExecuteC()
+	return buf.String(), err
+}
+
+func TestPlatformInfo(t *testing.T) {
+	testUtil.InitTestConfig(testUtil.SoftwarePlatform)
+
+	t.Run("success", func(t *testing.T) {
+		api := new(mocks.ClientInterface)
+		api.On("GetPlatformVersion", nil).Return("0.29.0", nil).Once()
+		api.On("GetAppConfig", nil).Return(&houston.AppConfig{BaseDomain: "local.astronomer.io", Flags: houston.FeatureFlags{TriggererEnabled: true, AstroRuntimeEnabled: true}}, nil).Once()
+
+		houstonClient = api
+		output, err := execPlatformCmd("info")
+		assert.NoError(t, err)
+		assert.Contains(t, output, "Astronomer Software Version: 0.29.0")
+		assert.Contains(t, output, "Base Domain: local.astronomer.io")
+		assert.Regexp(t, `triggererEnabled\s+true`, output)
+		assert.Regexp(t, `dagOnlyDeployment\s+false`, output)
+		assert.Regexp(t, `astro deployment inspect\s+unavailable\s+requires Astronomer Software >= 0.33.0`, output)
+		assert.Regexp(t, `astro deployment runtime\s+available`, output)
+		assert.Regexp(t, `astro deploy\s+degraded\s+no DAG-only deploys`, output)
+		api.AssertExpectations(t)
+	})
+
+	t.Run("single json document", func(t *testing.T) {
+		api := new(mocks.ClientInterface)
+		api.On("GetPlatformVersion", nil).Return("0.33.0", nil).Once()
+		api.On("GetAppConfig", nil).Return(&houston.AppConfig{BaseDomain: "local.astronomer.io", Flags: houston.FeatureFlags{DagOnlyDeployment: true}}, nil).Once()
+
+		houstonClient = api
+		format, err := printutil.ParseOutputFormat("json")
+		assert.NoError(t, err)
+		printutil.SetOutputFormat(format)
+		defer printutil.SetOutputFormat(printutil.OutputFormat{})
+		output, err := execPlatformCmd("info")
+		assert.NoError(t, err)
+
+		var info struct {
+			Version    string          `json:"version"`
+			BaseDomain string          `json:"base_domain"`
+			Flags      map[string]bool `json:"flags"`
+			Commands   []platform.Command
+		}
+		assert.NoError(t, json.Unmarshal([]byte(output), &info))
+		assert.Equal(t, "0.33.0", info.Version)
+		assert.Equal(t, "local.astronomer.io", info.BaseDomain)
+		assert.True(t, info.Flags["dagOnlyDeployment"])
+		assert.Contains(t, info.Commands, platform.Command{Name: "astro deploy", Status: platform.StatusAvailable})
+		assert.Contains(t, info.Commands, platform.Command{Name: "astro deployment list", Status: platform.StatusDegraded, Details: "no pagination and filters (--page-size, --cursor, --all-pages, --label), requires Astronomer Software >= 0.34.0"})
+		api.AssertExpectations(t)
+	})
+
+	t.Run("unsupported output format", func(t *testing.T) {
+		format, err := printutil.ParseOutputFormat("csv")
+		assert.NoError(t, err)
+		printutil.SetOutputFormat(format)
+		defer printutil.SetOutputFormat(printutil.OutputFormat{})
+		_, err = execPlatformCmd("info")
+		assert.EqualError(t, err, "invalid output format csv, use one of: table or json or yaml")
+	})
+
+	t.Run("platform version error", func(t *testing.T) {
+		api := new(mocks.ClientInterface)
+		api.On("GetPlatformVersion", nil).Return("", errMock).Once()
+
+		houstonClient = api
+		_, err := execPlatformCmd("info")
+		assert.ErrorIs(t, err, errMock)
+		api.AssertExpectations(t)
+	})
+
+	t.Run("app config error", func(t *testing.T) {
+		api := new(mocks.ClientInterface)
+		api.On("GetPlatformVersion", nil).Return("0.29.0", nil).Once()
+		api.On("GetAppConfig", nil).Return(nil, errMock).Once()
+
+		houstonClient = api
+		_, err := execPlatformCmd("info")
+		assert.ErrorIs(t, err, errMock)
+		api.AssertExpectations(t)
+	})
+}
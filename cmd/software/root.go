@@ -43,6 +43,7 @@ func AddCmds(client houston.ClientInterface, out io.Writer) []*cobra.Command {
 		NewDeployCmd(),
 		newUserCmd(out),
 		newTeamCmd(out),
+		newPlatformCmd(out),
 	}
 }
 
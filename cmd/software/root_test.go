@@ -26,7 +26,7 @@ func TestAddCmds(t *testing.T) {
 	buf := new(bytes.Buffer)
 	cmds := AddCmds(houstonMock, buf)
 	for cmdIdx := range cmds {
-		assert.Contains(t, []string{"deployment", "deploy [DEPLOYMENT ID]", "user", "workspace", "team"}, cmds[cmdIdx].Use)
+		assert.Contains(t, []string{"deployment", "deploy [DEPLOYMENT ID]", "user", "workspace", "team", "platform"}, cmds[cmdIdx].Use)
 	}
 	houstonMock.AssertExpectations(t)
 }
@@ -38,7 +38,7 @@ func TestAppConfigFailure(t *testing.T) {
 	buf := new(bytes.Buffer)
 	cmds := AddCmds(houstonMock, buf)
 	for cmdIdx := range cmds {
-		assert.Contains(t, []string{"deployment", "deploy [DEPLOYMENT ID]", "user", "workspace", "team"}, cmds[cmdIdx].Use)
+		assert.Contains(t, []string{"deployment", "deploy [DEPLOYMENT ID]", "user", "workspace", "team", "platform"}, cmds[cmdIdx].Use)
 	}
 	houstonMock.AssertExpectations(t)
 	assert.Contains(t, InitDebugLogs, fmt.Sprintf("Error checking feature flag: %s", errMock))
@@ -57,7 +57,7 @@ func TestPlatformVersionFailure(t *testing.T) {
 	buf := new(bytes.Buffer)
 	cmds := AddCmds(houstonMock, buf)
 	for cmdIdx := range cmds {
-		assert.Contains(t, []string{"deployment", "deploy [DEPLOYMENT ID]", "user", "workspace", "team"}, cmds[cmdIdx].Use)
+		assert.Contains(t, []string{"deployment", "deploy [DEPLOYMENT ID]", "user", "workspace", "team", "platform"}, cmds[cmdIdx].Use)
 	}
 	houstonMock.AssertExpectations(t)
 	assert.Contains(t, InitDebugLogs, fmt.Sprintf("Unable to get Houston version: %s", errMock))
@@ -2,9 +2,13 @@ package software
 
 import (
 	"fmt"
+	"sort"
 	"strings"
 
 	"github.com/astronomer/astro-cli/houston"
+	"github.com/astronomer/astro-cli/pkg/util"
+	"github.com/astronomer/astro-cli/software/platform"
+	softwareUtils "github.com/astronomer/astro-cli/software/utils"
 	"github.com/spf13/cobra"
 )
 
@@ -12,12 +16,92 @@ var cmdAvailabilityByVersion = map[string]houston.VersionRestrictions{
 	"astro team update": {GTE: "0.29.2"},
 
 	"astro deployment runtime": {GTE: "0.29.0"},
+	"astro deployment inspect": {GTE: "0.33.0"},
 
 	"astro deployment team": {GTE: "0.28.0"},
 	"astro workspace team":  {GTE: "0.28.0"},
 	"astro team":            {GTE: "0.28.0"},
 }
 
+// featureRestriction is a part of a command which only works when a feature flag is enabled on the platform
+type featureRestriction struct {
+	flag    string // name of the feature flag as reported by Houston
+	enabled func(houston.FeatureFlags) bool
+	limits  string // what is missing while the flag is disabled, empty when the whole command is missing
+}
+
+var (
+	nfsMountRestriction = featureRestriction{
+		flag:    "nfsMountDagDeployment",
+		enabled: func(f houston.FeatureFlags) bool { return f.NfsMountDagDeployment },
+		limits:  "volume DAG deployments (--nfs-location)",
+	}
+	gitSyncRestriction = featureRestriction{
+		flag:    "gitSyncDagDeployment",
+		enabled: func(f houston.FeatureFlags) bool { return f.GitSyncEnabled },
+		limits:  "git-sync DAG deployments (--git-repository-url)",
+	}
+	triggererRestriction = featureRestriction{
+		flag:    "triggererEnabled",
+		enabled: func(f houston.FeatureFlags) bool { return f.TriggererEnabled },
+		limits:  "triggerer (--triggerer-replicas)",
+	}
+	runtimeRestriction = featureRestriction{
+		flag:    "astroRuntimeEnabled",
+		enabled: func(f houston.FeatureFlags) bool { return f.AstroRuntimeEnabled },
+		limits:  "Astronomer Runtime (--runtime-version)",
+	}
+)
+
+// commands, or some of their flags, which are only added when a feature flag is enabled on the platform
+var cmdAvailabilityByFeature = map[string][]featureRestriction{
+	"astro deploy": {
+		{flag: "dagOnlyDeployment", enabled: func(f houston.FeatureFlags) bool { return f.DagOnlyDeployment }, limits: "DAG-only deploys (--dags)"},
+	},
+	"astro deployment create": {nfsMountRestriction, gitSyncRestriction, triggererRestriction, runtimeRestriction},
+	"astro deployment update": {nfsMountRestriction, gitSyncRestriction, triggererRestriction},
+	"astro deployment delete": {
+		{flag: "hardDeleteDeployment", enabled: func(f houston.FeatureFlags) bool { return f.HardDeleteDeployment }, limits: "hard deletes (--hard)"},
+	},
+	"astro deployment logs triggerer": {
+		{flag: "triggererEnabled", enabled: func(f houston.FeatureFlags) bool { return f.TriggererEnabled }},
+	},
+	"astro deployment runtime": {
+		{flag: "astroRuntimeEnabled", enabled: func(f houston.FeatureFlags) bool { return f.AstroRuntimeEnabled }},
+	},
+}
+
+// apiRestriction is a part of a command which calls a Houston API only available on some platform versions
+type apiRestriction struct {
+	api    string // name of the Houston API, as restricted by houston.APIRestriction
+	limits string // what is missing on the platform versions without the API
+}
+
+var upgradeWaitRestriction = apiRestriction{api: "GetDeploymentStatus", limits: "waiting for the deployment to be healthy (--wait)"}
+
+func paginationRestriction(api string) apiRestriction {
+	return apiRestriction{api: api, limits: "pagination and filters (--page-size, --cursor, --all, --label)"}
+}
+
+// commands, or some of their flags, which call a Houston API only available on some platform versions
+var cmdAvailabilityByAPI = map[string][]apiRestriction{
+	"astro deploy": {{api: "UploadDags", limits: "DAG-only deploys (--dags)"}},
+	"astro deployment list": {
+		{api: "PaginatedListDeployments", limits: "pagination and filters (--page-size, --cursor, --all-pages, --label)"},
+	},
+	"astro deployment user list": {
+		{api: "PaginatedListDeploymentUsers", limits: "pagination and filters (--page-size, --cursor, --all, --role)"},
+	},
+	"astro deployment service-account list": {paginationRestriction("PaginatedListDeploymentServiceAccounts")},
+	"astro workspace service-account list":  {paginationRestriction("PaginatedListWorkspaceServiceAccounts")},
+	"astro workspace user list": {
+		{api: "PaginatedListWorkspaceUsers", limits: "pagination and filters (--cursor, --all, --email, --role)"},
+	},
+	"astro deployment airflow upgrade": {upgradeWaitRestriction},
+	"astro deployment runtime upgrade": {upgradeWaitRestriction},
+	"astro deployment runtime migrate": {upgradeWaitRestriction},
+}
+
 func VersionMatchCmds(rootCmd *cobra.Command, parent []string) {
 	for _, cm := range rootCmd.Commands() {
 		cmdName := fmt.Sprintf("%s %s", strings.Join(parent, " "), cm.Name())
@@ -26,10 +110,123 @@ func VersionMatchCmds(rootCmd *cobra.Command, parent []string) {
 			removeCmd(cm)
 			continue // no need to check subcommands as that has been removed by removeCmd
 		}
+		limits := missingAPIs(cmdName, houstonVersion)
+		if appConfig != nil {
+			for _, limit := range disabledFeatures(cmdName, appConfig.Flags) {
+				if !util.Contains(limits, limit) {
+					limits = append(limits, limit)
+				}
+			}
+		}
+		if len(limits) > 0 {
+			annotateCmd(cm, limits)
+		}
 		VersionMatchCmds(cm, append(parent, cm.Name()))
 	}
 }
 
+// disabledFeatures returns what cmdName can't do because of feature flags disabled on the platform
+func disabledFeatures(cmdName string, flags houston.FeatureFlags) []string {
+	var limits []string
+	for _, restriction := range cmdAvailabilityByFeature[cmdName] {
+		if restriction.limits != "" && !restriction.enabled(flags) {
+			limits = append(limits, restriction.limits)
+		}
+	}
+	return limits
+}
+
+// missingAPIs returns what cmdName can't do because of Houston APIs the platform version doesn't have
+func missingAPIs(cmdName, version string) []string {
+	var limits []string
+	for _, restriction := range cmdAvailabilityByAPI[cmdName] {
+		if versions, ok := houston.APIRestriction(restriction.api); ok && !houston.VerifyVersionMatch(version, versions) {
+			limits = append(limits, restriction.limits)
+		}
+	}
+	return limits
+}
+
+// annotateCmd marks a command which is missing some of its features in the help output
+func annotateCmd(c *cobra.Command, limits []string) {
+	c.Short += " (limited on this platform)"
+	if c.Long != "" {
+		c.Long += "\n\n"
+	}
+	c.Long += fmt.Sprintf("Not supported by the connected platform: %s\nRun 'astro platform info' for more details", strings.Join(limits, ", "))
+}
+
+// commandsSupport returns the support of every command restricted by version, Houston API or feature flag on the
+// connected platform
+func commandsSupport(version string, flags houston.FeatureFlags) []platform.Command {
+	names := map[string]bool{}
+	for name := range cmdAvailabilityByVersion {
+		names[name] = true
+	}
+	for name := range cmdAvailabilityByAPI {
+		names[name] = true
+	}
+	for name := range cmdAvailabilityByFeature {
+		names[name] = true
+	}
+
+	support := make([]platform.Command, 0, len(names))
+	for name := range names {
+		support = append(support, commandSupport(name, version, flags))
+	}
+	sort.Slice(support, func(i, j int) bool { return support[i].Name < support[j].Name })
+	return support
+}
+
+// commandSupport returns the support of the command name, which is unavailable when the platform version is outside of
+// its restriction, or of the one of a parent command, or when a feature flag it needs is disabled, and degraded when
+// only some of its flags are
+func commandSupport(name, version string, flags houston.FeatureFlags) platform.Command {
+	if i := strings.LastIndex(name, " "); i > 0 {
+		if parent := commandSupport(name[:i], version, flags); parent.Status == platform.StatusUnavailable {
+			return platform.Command{Name: name, Status: platform.StatusUnavailable, Details: parent.Details}
+		}
+	}
+	if restriction, ok := cmdAvailabilityByVersion[name]; ok && !houston.VerifyVersionMatch(version, restriction) {
+		return platform.Command{Name: name, Status: platform.StatusUnavailable, Details: "requires Astronomer Software " + describeRestriction(restriction)}
+	}
+
+	var limits []string
+	for _, restriction := range cmdAvailabilityByAPI[name] {
+		versions, ok := houston.APIRestriction(restriction.api)
+		if ok && !houston.VerifyVersionMatch(version, versions) {
+			limits = append(limits, fmt.Sprintf("%s, requires Astronomer Software %s", restriction.limits, describeRestriction(versions)))
+		}
+	}
+	for _, restriction := range cmdAvailabilityByFeature[name] {
+		if restriction.enabled(flags) {
+			continue
+		}
+		if restriction.limits == "" {
+			return platform.Command{Name: name, Status: platform.StatusUnavailable, Details: fmt.Sprintf("feature flag %s is disabled", restriction.flag)}
+		}
+		limits = append(limits, fmt.Sprintf("%s, feature flag %s is disabled", restriction.limits, restriction.flag))
+	}
+	if len(limits) > 0 {
+		return platform.Command{Name: name, Status: platform.StatusDegraded, Details: "no " + strings.Join(limits, "; no ")}
+	}
+	return platform.Command{Name: name, Status: platform.StatusAvailable}
+}
+
+func describeRestriction(restriction houston.VersionRestrictions) string {
+	var parts []string
+	if len(restriction.EQ) > 0 {
+		parts = append(parts, "version "+strings.Join(restriction.EQ, " or "))
+	}
+	if restriction.GTE != "" {
+		parts = append(parts, ">= "+restriction.GTE)
+	}
+	if restriction.LT != "" {
+		parts = append(parts, "< "+restriction.LT)
+	}
+	return strings.Join(parts, " and ")
+}
+
 func removeCmd(c *cobra.Command) {
 	c.Hidden = true                                   // hide the command in help output
 	c.Run = func(cmd *cobra.Command, args []string) { // define the error response when the command is executed
@@ -38,3 +235,21 @@ func removeCmd(c *cobra.Command) {
 	c.ResetCommands()           // remove all the subcommands
 	c.DisableFlagParsing = true // to disable help flag
 }
+
+// listOptions are the pagination flags shared by the list commands
+var listOptions softwareUtils.ListOptions
+
+const (
+	pageSizeFlagUsage = "Number of results to list, the next page is listed with --cursor"
+	cursorFlagUsage   = "List the results after this cursor, printed at the end of the previous page"
+	allPagesFlagUsage = "List every result, fetching --page-size results at a time"
+)
+
+// listPages returns the pagination of a list command, every page is listed when neither --page-size nor --cursor is
+// set, for instance when only filters are
+func listPages(opts softwareUtils.ListOptions) softwareUtils.ListOptions {
+	if opts.PageSize == 0 && opts.Cursor == "" {
+		opts.All = true
+	}
+	return opts
+}
@@ -70,6 +70,92 @@ func TestVersionMatchCmds(t *testing.T) {
 	})
 }
 
+func TestVersionMatchCmdsAnnotatesDegradedCmds(t *testing.T) {
+	buf := new(bytes.Buffer)
+	mockAPI := new(houston_mocks.ClientInterface)
+	mockAPI.On("GetAppConfig", nil).Return(&houston.AppConfig{Version: "0.30.0", Flags: houston.FeatureFlags{HardDeleteDeployment: true}}, nil)
+	mockAPI.On("GetPlatformVersion", nil).Return("0.30.0", nil)
+	cmd := &cobra.Command{Use: "astro"}
+	cmd.AddCommand(AddCmds(mockAPI, buf)...)
+
+	VersionMatchCmds(cmd, []string{"astro"})
+
+	deployCmd, _, err := cmd.Find([]string{"deploy"})
+	assert.NoError(t, err)
+	assert.Contains(t, deployCmd.Short, "(limited on this platform)")
+	assert.Contains(t, deployCmd.Long, "Not supported by the connected platform: DAG-only deploys (--dags)")
+
+	listCmd, _, err := cmd.Find([]string{"deployment", "list"})
+	assert.NoError(t, err)
+	assert.Contains(t, listCmd.Long, "Not supported by the connected platform: pagination and filters (--page-size, --cursor, --all-pages, --label)")
+
+	deleteCmd, _, err := cmd.Find([]string{"deployment", "delete"})
+	assert.NoError(t, err)
+	assert.NotContains(t, deleteCmd.Short, "(limited on this platform)")
+}
+
+func TestCommandsSupport(t *testing.T) {
+	support := commandsSupport("0.28.0", houston.FeatureFlags{NfsMountDagDeployment: true, GitSyncEnabled: true, TriggererEnabled: true})
+	byName := map[string]string{}
+	for _, c := range support {
+		byName[c.Name] = c.Status
+	}
+	assert.Equal(t, "available", byName["astro team"])
+	assert.Equal(t, "unavailable", byName["astro deployment runtime"])
+	assert.Equal(t, "unavailable", byName["astro team update"])
+	assert.Equal(t, "available", byName["astro deployment update"])
+	assert.Equal(t, "degraded", byName["astro deployment create"])
+	assert.Equal(t, "available", byName["astro deployment logs triggerer"])
+	assert.Equal(t, "degraded", byName["astro deployment list"])
+	assert.Equal(t, "degraded", byName["astro deploy"])
+	assert.Equal(t, "unavailable", byName["astro deployment runtime upgrade"])
+
+	for i := 1; i < len(support); i++ {
+		assert.Less(t, support[i-1].Name, support[i].Name)
+	}
+}
+
+func TestCommandSupport(t *testing.T) {
+	t.Run("degraded by the platform version", func(t *testing.T) {
+		c := commandSupport("astro deploy", "0.32.0", houston.FeatureFlags{DagOnlyDeployment: true})
+		assert.Equal(t, "degraded", c.Status)
+		assert.Equal(t, "no DAG-only deploys (--dags), requires Astronomer Software >= 0.33.0", c.Details)
+	})
+
+	t.Run("degraded by the platform version and a feature flag", func(t *testing.T) {
+		c := commandSupport("astro deploy", "0.32.0", houston.FeatureFlags{})
+		assert.Equal(t, "degraded", c.Status)
+		assert.Equal(t, "no DAG-only deploys (--dags), requires Astronomer Software >= 0.33.0; no DAG-only deploys (--dags), feature flag dagOnlyDeployment is disabled", c.Details)
+	})
+
+	t.Run("available on a newer platform", func(t *testing.T) {
+		c := commandSupport("astro deployment user list", "0.34.0", houston.FeatureFlags{})
+		assert.Equal(t, "available", c.Status)
+		assert.Empty(t, c.Details)
+	})
+
+	t.Run("unavailable with its parent command", func(t *testing.T) {
+		c := commandSupport("astro deployment runtime upgrade", "0.30.0", houston.FeatureFlags{})
+		assert.Equal(t, "unavailable", c.Status)
+		assert.Equal(t, "feature flag astroRuntimeEnabled is disabled", c.Details)
+	})
+}
+
+func TestCmdAvailabilityByAPI(t *testing.T) {
+	for name, restrictions := range cmdAvailabilityByAPI {
+		for _, restriction := range restrictions {
+			_, ok := houston.APIRestriction(restriction.api)
+			assert.True(t, ok, "%s: %s is not restricted by version", name, restriction.api)
+		}
+	}
+}
+
+func TestDescribeRestriction(t *testing.T) {
+	assert.Equal(t, ">= 0.28.0", describeRestriction(houston.VersionRestrictions{GTE: "0.28.0"}))
+	assert.Equal(t, ">= 0.28.0 and < 0.30.0", describeRestriction(houston.VersionRestrictions{GTE: "0.28.0", LT: "0.30.0"}))
+	assert.Equal(t, "version 0.29.1 or 0.29.2", describeRestriction(houston.VersionRestrictions{EQ: []string{"0.29.1", "0.29.2"}}))
+}
+
 func TestRemoveCmd(t *testing.T) {
 	type args struct {
 		c *cobra.Command
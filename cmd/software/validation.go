@@ -13,7 +13,7 @@ import (
 )
 
 var (
-	errInvalidDAGDeploymentType = errors.New("please specify the correct DAG deployment type, one of the following: image, volume, git_sync")
+	errInvalidDAGDeploymentType = errors.New("please specify the correct DAG deployment type, one of the following: image, volume, git_sync, dag_deploy")
 	errNFSLocationNotFound      = errors.New("please specify the nfs location via --nfs-location flag")
 	errGitRepoNotFound          = errors.New("please specify a valid git repository URL via --git-repository-url")
 	errInvalidExecutorType      = errors.New("please specify correct executor, one of: local, celery, kubernetes, k8s")
@@ -87,6 +87,7 @@ func validateDagDeploymentArgs(dagDeploymentType, nfsLocation, gitRepoURL string
 	if dagDeploymentType != houston.ImageDeploymentType &&
 		dagDeploymentType != houston.VolumeDeploymentType &&
 		dagDeploymentType != houston.GitSyncDeploymentType &&
+		dagDeploymentType != houston.DagOnlyDeploymentType &&
 		dagDeploymentType != "" {
 		return errInvalidDAGDeploymentType
 	}
@@ -10,10 +10,13 @@ import (
 )
 
 var (
-	workspaceSAUserID   string
-	workspaceSACategory string
-	workspaceSALabel    string
-	workspaceSARole     string
+	workspaceSAUserID    string
+	workspaceSACategory  string
+	workspaceSALabel     string
+	workspaceSARole      string
+	workspaceSAListLabel string
+
+	workspaceSARotateOptions sa.RotateOptions
 
 	workspaceSaCreateExample = `
   # Create service-account
@@ -21,6 +24,19 @@ var (
 `
 	workspaceSaListExample = `
 $ astro workspace service-account list --workspace-id=<workspace-id>
+
+# Get the first 50 workspace service-accounts, run it again with the printed --cursor to get the next page
+$ astro workspace service-account list --workspace-id=<workspace-id> --page-size=50
+`
+	workspaceSaRotateExample = `
+  # Replace a service-account and write the new API key to a file
+  $ astro workspace service-account rotate <service-account-id> --workspace-id=<workspace-id> --key-file=api-key.txt
+
+  # Replace a service-account and delete the old one right away
+  $ astro workspace service-account rotate <service-account-id> --workspace-id=<workspace-id> --delete-old
+
+  # Replace a service-account and print the command deleting the old one, to run once the new API key is rolled out
+  $ astro workspace service-account rotate <service-account-id> --workspace-id=<workspace-id> --delete-old --grace-period=1h
 `
 )
 
@@ -35,6 +51,7 @@ func newWorkspaceSaRootCmd(out io.Writer) *cobra.Command {
 		newWorkspaceSaCreateCmd(out),
 		newWorkspaceSaListCmd(out),
 		newWorkspaceSaDeleteCmd(out),
+		newWorkspaceSaRotateCmd(out),
 	)
 
 	return cmd
@@ -72,6 +89,10 @@ func newWorkspaceSaListCmd(out io.Writer) *cobra.Command {
 		},
 	}
 	cmd.Flags().StringVarP(&workspaceID, "workspace-id", "w", "", "ID of the workspace, you can leave it empty if you want to use your current context's workspace ID")
+	cmd.Flags().StringVarP(&workspaceSAListLabel, "label", "l", "", "Only list the Service Accounts with this label")
+	cmd.Flags().IntVar(&listOptions.PageSize, "page-size", 0, pageSizeFlagUsage)
+	cmd.Flags().StringVar(&listOptions.Cursor, "cursor", "", cursorFlagUsage)
+	cmd.Flags().BoolVar(&listOptions.All, "all", false, allPagesFlagUsage)
 	return cmd
 }
 
@@ -91,6 +112,26 @@ func newWorkspaceSaDeleteCmd(out io.Writer) *cobra.Command {
 	return cmd
 }
 
+func newWorkspaceSaRotateCmd(out io.Writer) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "rotate [service-account id]",
+		Aliases: []string{"ro"},
+		Short:   "Rotate the API key of a Service Account in the Astronomer Software platform",
+		Long:    "Replace a Service Account with a new one with the same label, category and role, and optionally delete the old one. With --delete-old the old Service Account is deleted right away. With --grace-period it is kept, and the command prints when and how to delete it: deleting it once the grace period is over is left to you",
+		Args:    cobra.ExactArgs(1),
+		Example: workspaceSaRotateExample,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return workspaceSaRotate(cmd, out, args)
+		},
+	}
+	cmd.Flags().StringVarP(&workspaceID, "workspace-id", "w", "", "ID of the workspace, you can leave it empty if you want to use your current context's workspace ID")
+	cmd.Flags().StringVarP(&workspaceSARotateOptions.Role, "role", "r", "", "Role of the new service account, the role of the rotated service account is kept by default")
+	cmd.Flags().StringVar(&workspaceSARotateOptions.KeyFile, "key-file", "", "Write the new API key to this file instead of printing it")
+	cmd.Flags().BoolVar(&workspaceSARotateOptions.DeleteOld, "delete-old", false, "Delete the rotated service account right away, or with --grace-period print the command deleting it once the grace period has passed")
+	cmd.Flags().DurationVar(&workspaceSARotateOptions.GracePeriod, "grace-period", 0, "Time the rotated service account is kept for before it can be deleted, used with --delete-old")
+	return cmd
+}
+
 func workspaceSaCreate(cmd *cobra.Command, out io.Writer) error {
 	ws, err := coalesceWorkspace()
 	if err != nil {
@@ -110,10 +151,16 @@ func workspaceSaList(cmd *cobra.Command, out io.Writer) error {
 	if err != nil {
 		return err
 	}
+	if err := listOptions.Validate(); err != nil {
+		return err
+	}
 
 	// Silence Usage as we have now validated command input
 	cmd.SilenceUsage = true
 
+	if listOptions.Paginated() || workspaceSAListLabel != "" {
+		return sa.PaginatedWorkspaceServiceAccounts(ws, workspaceSAListLabel, listPages(listOptions), houstonClient, out)
+	}
 	return sa.GetWorkspaceServiceAccounts(ws, houstonClient, out)
 }
 
@@ -128,3 +175,23 @@ func workspaceSaDelete(cmd *cobra.Command, out io.Writer, args []string) error {
 
 	return sa.DeleteUsingWorkspaceUUID(args[0], ws, houstonClient, out)
 }
+
+func workspaceSaRotate(cmd *cobra.Command, out io.Writer, args []string) error {
+	ws, err := coalesceWorkspace()
+	if err != nil {
+		return err
+	}
+
+	if workspaceSARotateOptions.Role != "" {
+		if err := validateWorkspaceRole(workspaceSARotateOptions.Role); err != nil {
+			return fmt.Errorf("failed to find a valid role: %w", err)
+		}
+	}
+	if workspaceSARotateOptions.GracePeriod != 0 && !workspaceSARotateOptions.DeleteOld {
+		return errGracePeriodWithoutDelete
+	}
+	// Silence Usage as we have now validated command input
+	cmd.SilenceUsage = true
+
+	return sa.RotateWorkspaceServiceAccount(ws, args[0], workspaceSARotateOptions, houstonClient, out)
+}
@@ -42,6 +42,22 @@ func TestWorkspaceSAListCommand(t *testing.T) {
 	assert.Contains(t, output, expectedOut)
 }
 
+func TestWorkspaceSAListPaginatedCommand(t *testing.T) {
+	testUtil.InitTestConfig(testUtil.SoftwarePlatform)
+	mockSA := houston.ServiceAccount{ID: "sa-id", APIKey: "sa-api-key", Label: "ci"}
+
+	api := new(mocks.ClientInterface)
+	api.On("PaginatedListWorkspaceServiceAccounts", houston.PaginatedServiceAccountsRequest{WorkspaceID: mockWorkspace.ID, Label: "ci", Take: 1}).Return([]houston.ServiceAccount{mockSA}, nil).Once()
+	api.On("PaginatedListWorkspaceServiceAccounts", houston.PaginatedServiceAccountsRequest{WorkspaceID: mockWorkspace.ID, Label: "ci", CursorID: "sa-id", Take: 1}).Return([]houston.ServiceAccount{}, nil).Once()
+	houstonClient = api
+
+	output, err := execWorkspaceCmd("sa", "list", "--workspace-id="+mockWorkspace.ID, "--label=ci", "--page-size=1", "--all")
+	assert.NoError(t, err)
+	assert.Contains(t, output, "sa-api-key")
+	assert.NotContains(t, output, "--cursor")
+	api.AssertExpectations(t)
+}
+
 func TestWorkspaceSaCreate(t *testing.T) {
 	testUtil.InitTestConfig(testUtil.SoftwarePlatform)
 	buf := new(bytes.Buffer)
@@ -80,3 +96,21 @@ func TestWorkspaceSaDelete(t *testing.T) {
 
 	houstonMock.AssertExpectations(t)
 }
+
+func TestWorkspaceSaRotateCommand(t *testing.T) {
+	testUtil.InitTestConfig(testUtil.SoftwarePlatform)
+	oldSA := houston.ServiceAccount{ID: "old-sa-id", Label: "ci", Category: "default", LastUsedAt: "2022-03-01T00:00:00.000Z"}
+
+	api := new(mocks.ClientInterface)
+	api.On("ListWorkspaceServiceAccounts", mockWorkspace.ID).Return([]houston.ServiceAccount{oldSA}, nil)
+	api.On("CreateWorkspaceServiceAccount", &houston.CreateServiceAccountRequest{WorkspaceID: mockWorkspace.ID, Label: "ci", Category: "default", Role: houston.WorkspaceAdminRole}).
+		Return(&houston.WorkspaceServiceAccount{ID: "new-sa-id", APIKey: "new-api-key", Label: "ci", Category: "default"}, nil)
+	houstonClient = api
+
+	output, err := execWorkspaceCmd("sa", "rotate", "old-sa-id", "--workspace-id="+mockWorkspace.ID, "--role="+houston.WorkspaceAdminRole)
+	assert.NoError(t, err)
+	assert.Contains(t, output, "last used 2022-03-01T00:00:00.000Z")
+	assert.Contains(t, output, "new-api-key")
+	assert.Contains(t, output, "The old service account old-sa-id is still active")
+	api.AssertExpectations(t)
+}
@@ -16,6 +16,8 @@ var (
 	workspaceUserCreateEmail string
 	paginated                bool
 	pageSize                 int
+	workspaceUserListEmail   string
+	workspaceUserListRole    string
 )
 
 const defaultWorkspaceUserPageSize = 100
@@ -94,7 +96,11 @@ func newWorkspaceUserListCmd(out io.Writer) *cobra.Command {
 	}
 	if houston.VerifyVersionMatch(houstonVersion, houston.VersionRestrictions{GTE: "0.30.0"}) {
 		cmd.Flags().BoolVarP(&paginated, "paginated", "p", false, "Paginated workspace user list")
-		cmd.Flags().IntVarP(&pageSize, "page-size", "s", 0, "Page size of the workspace user list if paginated is set to true")
+		cmd.Flags().IntVarP(&pageSize, "page-size", "s", 0, "Page size of the workspace user list. Without --paginated, only this many users are listed, the next page is listed with --cursor")
+		cmd.Flags().StringVar(&listOptions.Cursor, "cursor", "", cursorFlagUsage)
+		cmd.Flags().BoolVar(&listOptions.All, "all", false, allPagesFlagUsage)
+		cmd.Flags().StringVarP(&workspaceUserListEmail, "email", "e", "", "Only list the user with this email")
+		cmd.Flags().StringVarP(&workspaceUserListRole, "role", "r", "", "Only list the users with this role, one of: WORKSPACE_VIEWER, WORKSPACE_EDITOR, WORKSPACE_ADMIN")
 	}
 	return cmd
 }
@@ -152,9 +158,26 @@ func workspaceUserList(_ *cobra.Command, out io.Writer) error {
 		return fmt.Errorf("failed to find a valid workspace: %w", err)
 	}
 	configPageSize := config.CFG.PageSize.GetInt()
+	interactive := config.CFG.Interactive.GetBool() || paginated
+
+	// listing a page, or every page, without prompting when a cursor, --all or a filter is set, or a page size without
+	// the interactive pager
+	opts := listOptions
+	opts.PageSize = pageSize
+	if opts.Cursor != "" || opts.All || workspaceUserListEmail != "" || workspaceUserListRole != "" || (pageSize != 0 && !interactive) {
+		if err := opts.Validate(); err != nil {
+			return err
+		}
+		if workspaceUserListRole != "" {
+			if err := validateWorkspaceRole(workspaceUserListRole); err != nil {
+				return fmt.Errorf("failed to find a valid role: %w", err)
+			}
+		}
+		return workspace.FilteredListRoles(ws, workspaceUserListEmail, workspaceUserListRole, listPages(opts), houstonClient, out)
+	}
 
 	// not calling paginated workspace roles if houston version is before 0.30.0, since that doesn't support pagination
-	if (config.CFG.Interactive.GetBool() || paginated) && houston.VerifyVersionMatch(houstonVersion, houston.VersionRestrictions{GTE: "0.30.0"}) {
+	if interactive && houston.VerifyVersionMatch(houstonVersion, houston.VersionRestrictions{GTE: "0.30.0"}) {
 		if pageSize <= 0 && configPageSize > 0 {
 			pageSize = configPageSize
 		}
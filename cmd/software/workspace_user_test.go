@@ -11,6 +11,7 @@ import (
 	"github.com/astronomer/astro-cli/houston"
 	mocks "github.com/astronomer/astro-cli/houston/mocks"
 	testUtil "github.com/astronomer/astro-cli/pkg/testing"
+	softwareUtils "github.com/astronomer/astro-cli/software/utils"
 	"github.com/spf13/cobra"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
@@ -169,6 +170,41 @@ func TestWorkspaceUserList(t *testing.T) {
 	houstonMock.AssertExpectations(t)
 }
 
+func TestWorkspaceUserListFiltered(t *testing.T) {
+	testUtil.InitTestConfig(testUtil.SoftwarePlatform)
+	ws, err := coalesceWorkspace()
+	assert.NoError(t, err)
+	mockResponse := []houston.WorkspaceUserRoleBindings{
+		{
+			ID:           "test-id-username",
+			Username:     "test@astronomer.io",
+			RoleBindings: []houston.RoleBinding{{Role: houston.WorkspaceAdminRole, Workspace: houston.Workspace{ID: ws}}},
+		},
+	}
+
+	houstonMock := new(mocks.ClientInterface)
+	request := houston.PaginatedWorkspaceUsersRequest{WorkspaceID: ws, Take: softwareUtils.DefaultPageSize, Role: houston.WorkspaceAdminRole}
+	houstonMock.On("PaginatedListWorkspaceUsers", request).Return(mockResponse, nil)
+
+	currentClient := houstonClient
+	houstonClient = houstonMock
+	defer func() {
+		houstonClient = currentClient
+		workspaceUserListRole = ""
+	}()
+
+	buf := new(bytes.Buffer)
+	workspaceUserListRole = houston.WorkspaceAdminRole
+	err = workspaceUserList(&cobra.Command{}, buf)
+	assert.NoError(t, err)
+	assert.Contains(t, buf.String(), mockResponse[0].Username)
+	houstonMock.AssertExpectations(t)
+
+	workspaceUserListRole = "INVALID_ROLE"
+	err = workspaceUserList(&cobra.Command{}, buf)
+	assert.ErrorContains(t, err, "failed to find a valid role")
+}
+
 func TestWorkspaceUserListPaginated(t *testing.T) {
 	t.Run("with default page size", func(t *testing.T) {
 		testUtil.InitTestConfig(testUtil.SoftwarePlatform)
@@ -77,6 +77,11 @@ var (
 		Verbosity:             newCfg("verbosity", "warning"),
 		HoustonDialTimeout:    newCfg("houston.dial_timeout", "10"),
 		HoustonSkipVerifyTLS:  newCfg("houston.skip_verify_tls", "false"),
+		HTTPMaxRetries:        newCfg("http.max_retries", "3"),
+		HTTPCacheTTL:          newCfg("http.cache_ttl", "0"),
+		TLSCABundle:           newCfg("tls.ca_bundle", ""),
+		TLSClientCert:         newCfg("tls.client_cert", ""),
+		TLSClientKey:          newCfg("tls.client_key", ""),
 		DuplicateImageVolumes: newCfg("duplicate_volumes", "true"),
 		SkipParse:             newCfg("skip_parse", "false"),
 		Interactive:           newCfg("interactive", "false"),
@@ -229,6 +234,11 @@ func IsProjectDir(path string) (bool, error) {
 	return fileutil.Exists(configFile, nil)
 }
 
+// HTTPCacheDir returns the directory cached API responses are stored in
+func HTTPCacheDir() string {
+	return filepath.Join(HomeConfigPath, "cache", "http")
+}
+
 // saveConfig will save the config to a file
 func saveConfig(v *viper.Viper, file string) error {
 	err := v.WriteConfigAs(file)
@@ -34,6 +34,8 @@ type Context struct {
 	Token                 string `mapstructure:"token"`
 	RefreshToken          string `mapstructure:"refreshtoken"`
 	UserEmail             string `mapstructure:"user_email"`
+	Proxy                 string `mapstructure:"proxy"`
+	NoProxy               string `mapstructure:"no_proxy"`
 }
 
 // GetCurrentContext looks up current context and gets corresponding Context struct
@@ -124,6 +126,8 @@ func (c *Context) SetContext() error {
 		"last_used_workspace":     c.Workspace,
 		"refreshtoken":            c.RefreshToken,
 		"user_email":              c.UserEmail,
+		"proxy":                   c.Proxy,
+		"no_proxy":                c.NoProxy,
 	}
 
 	viperHome.Set("contexts"+"."+key, context)
@@ -4,6 +4,7 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"net/url"
 
 	"github.com/astronomer/astro-cli/pkg/printutil"
 )
@@ -79,6 +80,16 @@ func (c *Context) GetSoftwareAppURL() string {
 	)
 }
 
+// GetSoftwareDagsUploadURL returns the DAG upload Url of a deployment for the provided Context, using the protocol of
+// its Houston API
+func (c *Context) GetSoftwareDagsUploadURL(releaseName string) string {
+	protocol := CFG.CloudAPIProtocol.GetString()
+	if u, err := url.Parse(c.GetSoftwareAPIURL()); err == nil && u.Scheme != "" {
+		protocol = u.Scheme
+	}
+	return fmt.Sprintf("%s://deployments.%s/%s/dags/upload", protocol, c.Domain, releaseName)
+}
+
 // GetSoftwareWebsocketURL returns full Houston websocket Url for the provided Context
 func (c *Context) GetSoftwareWebsocketURL() string {
 	if c.Domain == localhostDomain || c.Domain == houstonDomain {
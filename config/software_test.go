@@ -78,6 +78,42 @@ func TestContextGetSoftwareAppURL(t *testing.T) {
 	}
 }
 
+func TestContextGetSoftwareDagsUploadURL(t *testing.T) {
+	initTestConfig()
+	CFG.LocalHouston.SetHomeString("http://localhost/v1")
+	CFG.CloudAPIProtocol.SetHomeString("https")
+	CFG.CloudAPIPort.SetHomeString("8080")
+	type fields struct {
+		Domain string
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		want   string
+	}{
+		{
+			name:   "basic localhost case",
+			fields: fields{Domain: "localhost"},
+			want:   "http://deployments.localhost/test-release/dags/upload",
+		},
+		{
+			name:   "basic cloud case",
+			fields: fields{Domain: "dev.astro.io"},
+			want:   "https://deployments.dev.astro.io/test-release/dags/upload",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &Context{
+				Domain: tt.fields.Domain,
+			}
+			if got := c.GetSoftwareDagsUploadURL("test-release"); got != tt.want {
+				t.Errorf("Context.GetSoftwareDagsUploadURL() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
 func TestContextGetSoftwareWebsocketURL(t *testing.T) {
 	initTestConfig()
 	CFG.LocalHouston.SetHomeString("http://localhost/v1")
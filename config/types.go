@@ -38,6 +38,11 @@ type cfgs struct {
 	Verbosity             cfg
 	HoustonDialTimeout    cfg
 	HoustonSkipVerifyTLS  cfg
+	HTTPMaxRetries        cfg
+	HTTPCacheTTL          cfg
+	TLSCABundle           cfg
+	TLSClientCert         cfg
+	TLSClientKey          cfg
 	SkipParse             cfg
 	Interactive           cfg
 	PageSize              cfg
@@ -9,6 +9,7 @@ import (
 
 	"github.com/astronomer/astro-cli/config"
 	"github.com/astronomer/astro-cli/pkg/domainutil"
+	"github.com/astronomer/astro-cli/pkg/httputil"
 	"github.com/astronomer/astro-cli/pkg/input"
 	"github.com/astronomer/astro-cli/pkg/printutil"
 	"github.com/spf13/cobra"
@@ -24,6 +25,8 @@ var (
 	cancelCtxDeleteMsg   = "Canceling context delete..."
 	failCtxDeleteMsg     = "Error deleting context %s: "
 	successCtxDeleteMsg  = "Successfully deleted context: %s"
+	successCtxProxyMsg   = "Successfully set the proxy of context %s to %s"
+	successCtxNoProxyMsg = "Successfully removed the proxy of context %s"
 )
 
 var tab = printutil.Table{
@@ -201,3 +204,45 @@ func IsCloudDomain(domain string) bool {
 
 	return false
 }
+
+// TransportOptions returns the network settings for API calls: the proxy of the current context
+// and the CA bundle and client certificate from the global config
+func TransportOptions() httputil.TransportOptions {
+	opts := httputil.TransportOptions{
+		CABundle:   config.CFG.TLSCABundle.GetString(),
+		ClientCert: config.CFG.TLSClientCert.GetString(),
+		ClientKey:  config.CFG.TLSClientKey.GetString(),
+	}
+	if currCtx, err := GetCurrentContext(); err == nil {
+		opts.ProxyURL = currCtx.Proxy
+		opts.NoProxy = currCtx.NoProxy
+	}
+	return opts
+}
+
+// SetProxy sets the proxy used to reach the current context, an empty proxyURL removes it
+func SetProxy(proxyURL, noProxy string, out io.Writer) error {
+	c, err := GetCurrentContext()
+	if err != nil {
+		return err
+	}
+	if proxyURL != "" {
+		if _, err := (httputil.TransportOptions{ProxyURL: proxyURL}).Proxy(); err != nil {
+			return err
+		}
+	} else {
+		noProxy = ""
+	}
+	if err := c.SetContextKey("proxy", proxyURL); err != nil {
+		return err
+	}
+	if err := c.SetContextKey("no_proxy", noProxy); err != nil {
+		return err
+	}
+	if proxyURL == "" {
+		fmt.Fprintln(out, fmt.Sprintf(successCtxNoProxyMsg, c.Domain))
+		return nil
+	}
+	fmt.Fprintln(out, fmt.Sprintf(successCtxProxyMsg, c.Domain, proxyURL))
+	return nil
+}
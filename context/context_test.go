@@ -172,3 +172,36 @@ func TestIsCloudDomain(t *testing.T) {
 		}
 	})
 }
+
+func TestSetProxy(t *testing.T) {
+	testUtil.InitTestConfig(testUtil.LocalPlatform)
+	out := new(bytes.Buffer)
+
+	err := SetProxy("http://proxy.example.com:3128", "internal.example.com", out)
+	assert.NoError(t, err)
+	assert.Contains(t, out.String(), "Successfully set the proxy of context localhost to http://proxy.example.com:3128")
+
+	opts := TransportOptions()
+	assert.Equal(t, "http://proxy.example.com:3128", opts.ProxyURL)
+	assert.Equal(t, "internal.example.com", opts.NoProxy)
+
+	out.Reset()
+	err = SetProxy("", "internal.example.com", out)
+	assert.NoError(t, err)
+	assert.Contains(t, out.String(), "Successfully removed the proxy of context localhost")
+	opts = TransportOptions()
+	assert.Equal(t, "", opts.ProxyURL)
+	assert.Equal(t, "", opts.NoProxy)
+}
+
+func TestTransportOptions(t *testing.T) {
+	testUtil.InitTestConfig(testUtil.LocalPlatform)
+	assert.NoError(t, config.CFG.TLSCABundle.SetHomeString("/etc/ssl/corp-ca.pem"))
+	assert.NoError(t, config.CFG.TLSClientCert.SetHomeString("/etc/ssl/client.pem"))
+	assert.NoError(t, config.CFG.TLSClientKey.SetHomeString("/etc/ssl/client-key.pem"))
+
+	opts := TransportOptions()
+	assert.Equal(t, "/etc/ssl/corp-ca.pem", opts.CABundle)
+	assert.Equal(t, "/etc/ssl/client.pem", opts.ClientCert)
+	assert.Equal(t, "/etc/ssl/client-key.pem", opts.ClientKey)
+}
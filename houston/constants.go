@@ -25,4 +25,8 @@ const (
 	GitSyncDeploymentType = "git_sync"
 	VolumeDeploymentType  = "volume"
 	ImageDeploymentType   = "image"
+	DagOnlyDeploymentType = "dag_deploy"
+
+	// Deployment and component health
+	HealthyStatus = "HEALTHY"
 )
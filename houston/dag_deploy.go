@@ -0,0 +1,75 @@
+package houston
+
+import (
+	"io"
+	"mime/multipart"
+	"net/http"
+	"os"
+	"path/filepath"
+
+	"github.com/astronomer/astro-cli/context"
+	"github.com/astronomer/astro-cli/pkg/httputil"
+)
+
+// UploadDagsRequest is the tarball of the dags folder uploaded to a deployment using DAG-only deploys
+type UploadDagsRequest struct {
+	ReleaseName string
+	DagsFile    string
+}
+
+// UploadDagsResponse is the result of a DAG-only deploy
+type UploadDagsResponse struct {
+	// Size is the number of bytes of the uploaded tarball
+	Size int64
+}
+
+// UploadDags - upload a tarball of the dags folder to the DAG server of a deployment. The tarball is streamed, so it
+// is never held in memory.
+func (h ClientImplementation) UploadDags(req UploadDagsRequest) (*UploadDagsResponse, error) {
+	c, err := context.GetCurrentContext()
+	if err != nil {
+		return nil, err
+	}
+
+	dagsFile, err := os.Open(req.DagsFile)
+	if err != nil {
+		return nil, err
+	}
+	defer dagsFile.Close()
+
+	body, bodyWriter := io.Pipe()
+	writer := multipart.NewWriter(bodyWriter)
+	written := make(chan int64, 1)
+	go func() {
+		var size int64
+		part, err := writer.CreateFormFile("file", filepath.Base(req.DagsFile))
+		if err == nil {
+			size, err = io.Copy(part, dagsFile)
+		}
+		if err == nil {
+			err = writer.Close()
+		}
+		bodyWriter.CloseWithError(err)
+		written <- size
+	}()
+	// unblocks the writer when the request ends before the whole body is read
+	defer body.Close()
+
+	doOpts := &httputil.DoOptions{
+		Method: http.MethodPost,
+		Path:   c.GetSoftwareDagsUploadURL(req.ReleaseName),
+		Body:   body,
+		Headers: map[string]string{
+			"Content-Type":  writer.FormDataContentType(),
+			"authorization": c.Token,
+		},
+	}
+	resp, err := h.client.HTTPClient.Do(doOpts)
+	if err != nil {
+		return nil, handleAPIErr(err)
+	}
+	defer resp.Body.Close()
+
+	body.Close()
+	return &UploadDagsResponse{Size: <-written}, nil
+}
@@ -0,0 +1,62 @@
+package houston
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+
+	testUtil "github.com/astronomer/astro-cli/pkg/testing"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUploadDags(t *testing.T) {
+	testUtil.InitTestConfig("software")
+
+	dagsFile := filepath.Join(t.TempDir(), "dags.tar")
+	err := os.WriteFile(dagsFile, []byte("dags"), 0o600)
+	assert.NoError(t, err)
+
+	t.Run("success", func(t *testing.T) {
+		client := testUtil.NewTestClient(func(req *http.Request) *http.Response {
+			assert.Equal(t, http.MethodPost, req.Method)
+			assert.Contains(t, req.URL.String(), "/testRelease/dags/upload")
+			assert.Contains(t, req.Header.Get("Content-Type"), "multipart/form-data")
+			body, _ := io.ReadAll(req.Body)
+			assert.Contains(t, string(body), "dags.tar")
+			return &http.Response{
+				StatusCode: 200,
+				Body:       io.NopCloser(bytes.NewBufferString("")),
+				Header:     make(http.Header),
+			}
+		})
+		api := NewClient(client)
+
+		resp, err := api.UploadDags(UploadDagsRequest{ReleaseName: "testRelease", DagsFile: dagsFile})
+		assert.NoError(t, err)
+		assert.Equal(t, &UploadDagsResponse{Size: 4}, resp)
+	})
+
+	t.Run("error", func(t *testing.T) {
+		client := testUtil.NewTestClient(func(req *http.Request) *http.Response {
+			return &http.Response{
+				StatusCode: 500,
+				Body:       io.NopCloser(bytes.NewBufferString("Internal Server Error")),
+				Header:     make(http.Header),
+			}
+		})
+		api := NewClient(client)
+
+		_, err := api.UploadDags(UploadDagsRequest{ReleaseName: "testRelease", DagsFile: dagsFile})
+		assert.Contains(t, err.Error(), "Internal Server Error")
+	})
+
+	t.Run("missing file", func(t *testing.T) {
+		api := NewClient(testUtil.NewTestClient(nil))
+
+		_, err := api.UploadDags(UploadDagsRequest{ReleaseName: "testRelease", DagsFile: filepath.Join(t.TempDir(), "missing.tar")})
+		assert.ErrorIs(t, err, os.ErrNotExist)
+	})
+}
@@ -28,6 +28,15 @@ type VersionRestrictions struct {
 
 // APIs availability based on the version they were added/removed in Houston
 var houstonAPIAvailabilityByVersion = map[string]VersionRestrictions{
+	"PaginatedListDeployments":               {GTE: "0.34.0"},
+	"PaginatedListDeploymentUsers":           {GTE: "0.34.0"},
+	"PaginatedListDeploymentServiceAccounts": {GTE: "0.34.0"},
+	"PaginatedListWorkspaceServiceAccounts":  {GTE: "0.34.0"},
+	"PaginatedListWorkspaceUsers":            {GTE: "0.34.0"},
+	"GetDeploymentStatus":                    {GTE: "0.34.0"},
+
+	"UploadDags": {GTE: "0.33.0"},
+
 	"WorkspacesPaginatedGetRequest":     {GTE: "0.30.0"},
 	"WorkspacePaginatedGetUsersRequest": {GTE: "0.30.0"},
 
@@ -48,6 +57,13 @@ var houstonAPIAvailabilityByVersion = map[string]VersionRestrictions{
 	"GetWorkspaceTeamRole":       {GTE: "0.28.0"},
 }
 
+// APIRestriction returns the platform versions the Houston API name is available on, ok is false when it is available
+// on every version
+func APIRestriction(name string) (restriction VersionRestrictions, ok bool) {
+	restriction, ok = houstonAPIAvailabilityByVersion[name]
+	return restriction, ok
+}
+
 func Call[fReq any, fResp any, fType func(any) (any, error)](houstonFunc func(fReq) (fResp, error)) func(fReq) (fResp, error) {
 	return func(r fReq) (fResp, error) {
 		if !ApplyDecoratorForTests && isCalledFromUnitTestFile() { // bypassing this decorator for unit tests
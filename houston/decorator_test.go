@@ -193,3 +193,12 @@ func TestGetVersion(t *testing.T) {
 		assert.Equal(t, version, resp)
 	})
 }
+
+func TestAPIRestriction(t *testing.T) {
+	restriction, ok := APIRestriction("UploadDags")
+	assert.True(t, ok)
+	assert.Equal(t, VersionRestrictions{GTE: "0.33.0"}, restriction)
+
+	_, ok = APIRestriction("ListDeployments")
+	assert.False(t, ok)
+}
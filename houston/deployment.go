@@ -8,6 +8,14 @@ type ListDeploymentsRequest struct {
 	ReleaseName string `json:"releaseName"`
 }
 
+// PaginatedDeploymentsRequest - filters to list a page of deployments, from every workspace when WorkspaceID is empty
+type PaginatedDeploymentsRequest struct {
+	WorkspaceID string `json:"workspaceUuid,omitempty"`
+	Label       string `json:"label,omitempty"`
+	CursorID    string `json:"cursorUuid,omitempty"`
+	Take        int    `json:"take"`
+}
+
 // ListDeploymentLogsRequest - filters to list logs from a deployment
 type ListDeploymentLogsRequest struct {
 	DeploymentID string    `json:"deploymentId"`
@@ -233,6 +241,38 @@ var (
 		},
 	}
 
+	DeploymentsPaginatedGetRequest = `
+	query paginatedDeployments(
+		$workspaceUuid: Uuid
+		$label: String
+		$cursorUuid: Uuid
+		$take: Int
+	){
+		paginatedDeployments(
+			workspaceUuid: $workspaceUuid
+			label: $label
+			cursor: $cursorUuid
+			take: $take
+		){
+			id
+			type
+			label
+			releaseName
+			workspace {
+				id
+			}
+			deployInfo {
+				nextCli
+				current
+			}
+			version
+			airflowVersion
+			runtimeVersion
+			createdAt
+			updatedAt
+		}
+	}`
+
 	DeploymentUpdateRequest = queryList{
 		{
 			version: "0.25.0",
@@ -306,6 +346,46 @@ var (
 				}
 			}`,
 		},
+		{
+			version: "0.33.0",
+			query: `
+			mutation UpdateDeployment(
+				$deploymentId: Uuid!,
+				$payload: JSON!,
+				$config: JSON,
+				$executor: ExecutorType,
+				$cloudRole: String,
+				$dagDeployment: DagDeployment,
+				$triggererReplicas: Int
+			){
+				updateDeployment(
+					deploymentUuid: $deploymentId,
+					payload: $payload,
+					config: $config,
+					executor: $executor,
+					cloudRole: $cloudRole,
+					dagDeployment: $dagDeployment,
+					triggerer:{ replicas: $triggererReplicas }
+				){
+					id
+					type
+					label
+					description
+					releaseName
+					version
+					airflowVersion
+					runtimeVersion
+					workspace {
+						id
+					}
+					deployInfo {
+						current
+					}
+					createdAt
+					updatedAt
+				}
+			}`,
+		},
 	}
 
 	DeploymentGetRequest = queryList{
@@ -350,6 +430,50 @@ var (
 				}
 			}`,
 		},
+		{
+			version: "0.33.0",
+			query: `
+			query GetDeployment(
+				$id: String!
+			){
+				deployment(
+					where: {id: $id}
+				){
+					id
+					label
+					description
+					releaseName
+					version
+					airflowVersion
+					desiredAirflowVersion
+					runtimeVersion
+					desiredRuntimeVersion
+					runtimeAirflowVersion
+					config
+					workspace {
+						id
+					}
+					deployInfo {
+						current
+					}
+					dagDeployment {
+						type
+						nfsLocation
+						repositoryUrl
+						branchName
+						rev
+						dagDirectoryLocation
+						syncInterval
+					}
+					urls {
+						type
+						url
+					}
+					createdAt
+					updatedAt
+				}
+			}`,
+		},
 	}
 
 	DeploymentDeleteRequest = `
@@ -399,6 +523,40 @@ var (
 		}
 	}`
 
+	// DeploymentExecutorsInfoRequest is the deployment config along with the executors enabled on the platform, which
+	// older platforms don't report
+	DeploymentExecutorsInfoRequest = queryList{
+		{
+			version: "0.25.0",
+			query: `
+			query DeploymentInfo {
+				deploymentConfig {
+					airflowImages {
+						version
+						tag
+					}
+					airflowVersions
+					defaultAirflowImageTag
+				}
+			}`,
+		},
+		{
+			version: "0.34.0",
+			query: `
+			query DeploymentInfo {
+				deploymentConfig {
+					airflowImages {
+						version
+						tag
+					}
+					airflowVersions
+					defaultAirflowImageTag
+					executors
+				}
+			}`,
+		},
+	}
+
 	DeploymentLogsGetRequest = `
 	query GetLogs(
 		$deploymentId: Uuid!
@@ -522,6 +680,21 @@ func (h ClientImplementation) ListDeployments(filters ListDeploymentsRequest) ([
 	return res.Data.GetDeployments, nil
 }
 
+// PaginatedListDeployments - list a page of deployments
+func (h ClientImplementation) PaginatedListDeployments(request PaginatedDeploymentsRequest) ([]Deployment, error) {
+	req := Request{
+		Query:     DeploymentsPaginatedGetRequest,
+		Variables: request,
+	}
+
+	res, err := req.DoWithClient(h.client)
+	if err != nil {
+		return nil, handleAPIErr(err)
+	}
+
+	return res.Data.PaginatedDeployments, nil
+}
+
 // UpdateDeployment - update a deployment
 func (h ClientImplementation) UpdateDeployment(variables map[string]interface{}) (*Deployment, error) {
 	reqQuery := DeploymentUpdateRequest.GreatestLowerBound(version)
@@ -544,6 +717,7 @@ func (h ClientImplementation) GetDeployment(deploymentID string) (*Deployment, e
 	req := Request{
 		Query:     reqQuery,
 		Variables: map[string]interface{}{"id": deploymentID},
+		NoCache:   true,
 	}
 
 	res, err := req.DoWithClient(h.client)
@@ -583,6 +757,20 @@ func (h ClientImplementation) GetDeploymentConfig(_ interface{}) (*DeploymentCon
 	return &resp.Data.DeploymentConfig, nil
 }
 
+// GetDeploymentExecutorsConfig - get a deployment configuration, with the executors enabled on the platform when it reports them
+func (h ClientImplementation) GetDeploymentExecutorsConfig(_ interface{}) (*DeploymentConfig, error) {
+	dReq := Request{
+		Query: DeploymentExecutorsInfoRequest.GreatestLowerBound(version),
+	}
+
+	resp, err := dReq.DoWithClient(h.client)
+	if err != nil {
+		return nil, handleAPIErr(err)
+	}
+
+	return &resp.Data.DeploymentConfig, nil
+}
+
 // ListDeploymentLogs - list logs from a deployment
 func (h ClientImplementation) ListDeploymentLogs(filters ListDeploymentLogsRequest) ([]DeploymentLog, error) {
 	req := Request{
@@ -0,0 +1,33 @@
+package houston
+
+// DeploymentStatusGetRequest returns the health of a deployment and of each of its Airflow components
+var DeploymentStatusGetRequest = `
+	query deploymentStatus(
+		$deploymentUuid: Uuid!
+	){
+		deploymentStatus(
+			deploymentUuid: $deploymentUuid
+		){
+			status
+			components {
+				name
+				status
+			}
+		}
+	}`
+
+// GetDeploymentStatus - get the health of a deployment and of its components
+func (h ClientImplementation) GetDeploymentStatus(deploymentID string) (*DeploymentStatus, error) {
+	req := Request{
+		Query:     DeploymentStatusGetRequest,
+		Variables: map[string]interface{}{"deploymentUuid": deploymentID},
+		NoCache:   true,
+	}
+
+	r, err := req.DoWithClient(h.client)
+	if err != nil {
+		return nil, handleAPIErr(err)
+	}
+
+	return r.Data.DeploymentStatus, nil
+}
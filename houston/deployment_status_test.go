@@ -0,0 +1,60 @@
+package houston
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"testing"
+
+	testUtil "github.com/astronomer/astro-cli/pkg/testing"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetDeploymentStatus(t *testing.T) {
+	testUtil.InitTestConfig("software")
+
+	mockResponse := &Response{
+		Data: ResponseData{
+			DeploymentStatus: &DeploymentStatus{
+				Status: HealthyStatus,
+				Components: []ComponentStatus{
+					{Name: "scheduler", Status: HealthyStatus},
+					{Name: "webserver", Status: HealthyStatus},
+				},
+			},
+		},
+	}
+	jsonResponse, err := json.Marshal(mockResponse)
+	assert.NoError(t, err)
+
+	t.Run("success", func(t *testing.T) {
+		client := testUtil.NewTestClient(func(req *http.Request) *http.Response {
+			assert.Equal(t, "no-cache", req.Header.Get("Cache-Control"))
+			return &http.Response{
+				StatusCode: 200,
+				Body:       io.NopCloser(bytes.NewBuffer(jsonResponse)),
+				Header:     make(http.Header),
+			}
+		})
+		api := NewClient(client)
+
+		response, err := api.GetDeploymentStatus("deployment-id")
+		assert.NoError(t, err)
+		assert.Equal(t, mockResponse.Data.DeploymentStatus, response)
+	})
+
+	t.Run("error", func(t *testing.T) {
+		client := testUtil.NewTestClient(func(req *http.Request) *http.Response {
+			return &http.Response{
+				StatusCode: 500,
+				Body:       io.NopCloser(bytes.NewBufferString("Internal Server Error")),
+				Header:     make(http.Header),
+			}
+		})
+		api := NewClient(client)
+
+		_, err := api.GetDeploymentStatus("deployment-id")
+		assert.Contains(t, err.Error(), "Internal Server Error")
+	})
+}
@@ -421,6 +421,49 @@ func TestGetDeploymentConfig(t *testing.T) {
 	})
 }
 
+func TestGetDeploymentExecutorsConfig(t *testing.T) {
+	testUtil.InitTestConfig("software")
+
+	mockDeploymentConfig := &Response{
+		Data: ResponseData{
+			DeploymentConfig: DeploymentConfig{
+				AirflowVersions: []string{"2.5.1"},
+				Executors:       []Executor{{Name: CeleryExecutorType, Enabled: true}},
+			},
+		},
+	}
+	jsonResponse, err := json.Marshal(mockDeploymentConfig)
+	assert.NoError(t, err)
+
+	var query string
+	client := testUtil.NewTestClient(func(req *http.Request) *http.Response {
+		body, _ := io.ReadAll(req.Body)
+		query = string(body)
+		return &http.Response{
+			StatusCode: 200,
+			Body:       io.NopCloser(bytes.NewBuffer(jsonResponse)),
+			Header:     make(http.Header),
+		}
+	})
+	api := NewClient(client)
+	defer func() { version = "" }()
+
+	t.Run("platform reporting executors", func(t *testing.T) {
+		version = "0.34.0"
+		deploymentConfig, err := api.GetDeploymentExecutorsConfig(nil)
+		assert.NoError(t, err)
+		assert.Equal(t, *deploymentConfig, mockDeploymentConfig.Data.DeploymentConfig)
+		assert.Contains(t, query, "executors")
+	})
+
+	t.Run("older platform", func(t *testing.T) {
+		version = "0.32.0"
+		_, err := api.GetDeploymentExecutorsConfig(nil)
+		assert.NoError(t, err)
+		assert.NotContains(t, query, "executors")
+	})
+}
+
 func TestListDeploymentLogs(t *testing.T) {
 	testUtil.InitTestConfig("software")
 
@@ -620,3 +663,46 @@ func TestUpdateDeploymentImage(t *testing.T) {
 		assert.Contains(t, err.Error(), "Internal Server Error")
 	})
 }
+
+func TestPaginatedListDeployments(t *testing.T) {
+	testUtil.InitTestConfig("software")
+
+	mockResponse := &Response{
+		Data: ResponseData{
+			PaginatedDeployments: []Deployment{
+				{ID: "deployment-id", Label: "prod", ReleaseName: "prod-release"},
+			},
+		},
+	}
+	jsonResponse, err := json.Marshal(mockResponse)
+	assert.NoError(t, err)
+
+	t.Run("success", func(t *testing.T) {
+		client := testUtil.NewTestClient(func(req *http.Request) *http.Response {
+			return &http.Response{
+				StatusCode: 200,
+				Body:       io.NopCloser(bytes.NewBuffer(jsonResponse)),
+				Header:     make(http.Header),
+			}
+		})
+		api := NewClient(client)
+
+		response, err := api.PaginatedListDeployments(PaginatedDeploymentsRequest{WorkspaceID: "workspace-id", Label: "prod", Take: 10})
+		assert.NoError(t, err)
+		assert.Equal(t, response, mockResponse.Data.PaginatedDeployments)
+	})
+
+	t.Run("error", func(t *testing.T) {
+		client := testUtil.NewTestClient(func(req *http.Request) *http.Response {
+			return &http.Response{
+				StatusCode: 500,
+				Body:       io.NopCloser(bytes.NewBufferString("Internal Server Error")),
+				Header:     make(http.Header),
+			}
+		})
+		api := NewClient(client)
+
+		_, err := api.PaginatedListDeployments(PaginatedDeploymentsRequest{WorkspaceID: "workspace-id", Label: "prod", Take: 10})
+		assert.Contains(t, err.Error(), "Internal Server Error")
+	})
+}
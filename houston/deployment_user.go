@@ -8,6 +8,15 @@ type ListDeploymentUsersRequest struct {
 	DeploymentID string `json:"deploymentId"`
 }
 
+// PaginatedDeploymentUsersRequest - filters to list a page of the users of a deployment
+type PaginatedDeploymentUsersRequest struct {
+	DeploymentID string `json:"deploymentId"`
+	Email        string `json:"email,omitempty"`
+	Role         string `json:"role,omitempty"`
+	CursorID     string `json:"cursorUuid,omitempty"`
+	Take         int    `json:"take"`
+}
+
 // UpdateDeploymentUserRequest - properties to create a user in a deployment
 type UpdateDeploymentUserRequest struct {
 	Email        string `json:"email"`
@@ -43,6 +52,34 @@ var (
 		}
 	}`
 
+	// DeploymentUsersPaginatedGetRequest return a page of the users of a deployment
+	DeploymentUsersPaginatedGetRequest = `
+	query paginatedDeploymentUsers(
+		$deploymentId: Id!
+		$email: String
+		$role: Role
+		$cursorUuid: Uuid
+		$take: Int
+	){
+		paginatedDeploymentUsers(
+			deploymentId: $deploymentId
+			email: $email
+			role: $role
+			cursor: $cursorUuid
+			take: $take
+		){
+			id
+			fullName
+			username
+			roleBindings {
+				role
+				deployment {
+					id
+				}
+			}
+		}
+	}`
+
 	// DeploymentUserAddRequest Mutation for AddDeploymentUser
 	DeploymentUserAddRequest = `
 	mutation AddDeploymentUser(
@@ -137,6 +174,21 @@ func (h ClientImplementation) ListDeploymentUsers(filters ListDeploymentUsersReq
 	return r.Data.DeploymentUserList, nil
 }
 
+// PaginatedListDeploymentUsers - list a page of the users with deployment access
+func (h ClientImplementation) PaginatedListDeploymentUsers(request PaginatedDeploymentUsersRequest) ([]DeploymentUser, error) {
+	req := Request{
+		Query:     DeploymentUsersPaginatedGetRequest,
+		Variables: request,
+	}
+
+	r, err := req.DoWithClient(h.client)
+	if err != nil {
+		return []DeploymentUser{}, handleAPIErr(err)
+	}
+
+	return r.Data.PaginatedDeploymentUsers, nil
+}
+
 // AddUserToDeployment - Add a user to a deployment with specified role
 func (h ClientImplementation) AddDeploymentUser(variables UpdateDeploymentUserRequest) (*RoleBinding, error) {
 	req := Request{
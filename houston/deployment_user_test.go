@@ -225,3 +225,46 @@ func TestDeleteDeploymentUser(t *testing.T) {
 		assert.Contains(t, err.Error(), "Internal Server Error")
 	})
 }
+
+func TestPaginatedListDeploymentUsers(t *testing.T) {
+	testUtil.InitTestConfig("software")
+
+	mockResponse := &Response{
+		Data: ResponseData{
+			PaginatedDeploymentUsers: []DeploymentUser{
+				{ID: "user-id", FullName: "Some Person", Username: "somebody@astronomer.io"},
+			},
+		},
+	}
+	jsonResponse, err := json.Marshal(mockResponse)
+	assert.NoError(t, err)
+
+	t.Run("success", func(t *testing.T) {
+		client := testUtil.NewTestClient(func(req *http.Request) *http.Response {
+			return &http.Response{
+				StatusCode: 200,
+				Body:       io.NopCloser(bytes.NewBuffer(jsonResponse)),
+				Header:     make(http.Header),
+			}
+		})
+		api := NewClient(client)
+
+		response, err := api.PaginatedListDeploymentUsers(PaginatedDeploymentUsersRequest{DeploymentID: "deployment-id", Role: DeploymentAdminRole, Take: 10})
+		assert.NoError(t, err)
+		assert.Equal(t, response, mockResponse.Data.PaginatedDeploymentUsers)
+	})
+
+	t.Run("error", func(t *testing.T) {
+		client := testUtil.NewTestClient(func(req *http.Request) *http.Response {
+			return &http.Response{
+				StatusCode: 500,
+				Body:       io.NopCloser(bytes.NewBufferString("Internal Server Error")),
+				Header:     make(http.Header),
+			}
+		})
+		api := NewClient(client)
+
+		_, err := api.PaginatedListDeploymentUsers(PaginatedDeploymentUsersRequest{DeploymentID: "deployment-id", Role: DeploymentAdminRole, Take: 10})
+		assert.Contains(t, err.Error(), "Internal Server Error")
+	})
+}
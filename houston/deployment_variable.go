@@ -0,0 +1,81 @@
+package houston
+
+// ListDeploymentVariablesRequest - properties to list the environment variables of a deployment
+type ListDeploymentVariablesRequest struct {
+	DeploymentID string `json:"deploymentId"`
+	ReleaseName  string `json:"releaseName"`
+}
+
+// UpdateDeploymentVariablesRequest - properties to set the environment variables of a deployment, the variables
+// replace the existing ones. Secret variables sent without a value keep their stored value.
+type UpdateDeploymentVariablesRequest struct {
+	DeploymentID         string                `json:"deploymentId"`
+	ReleaseName          string                `json:"releaseName"`
+	EnvironmentVariables []EnvironmentVariable `json:"environmentVariables"`
+}
+
+var (
+	// DeploymentVariablesListRequest return the environment variables of a deployment, secret values are not returned
+	DeploymentVariablesListRequest = `
+	query deploymentVariables(
+		$deploymentId: Uuid!
+		$releaseName: String!
+	){
+		deploymentVariables(
+			deploymentUuid: $deploymentId
+			releaseName: $releaseName
+		){
+			key
+			value
+			isSecret
+		}
+	}`
+
+	// DeploymentVariablesUpdateRequest Mutation for UpdateDeploymentVariables
+	DeploymentVariablesUpdateRequest = `
+	mutation UpdateDeploymentVariables(
+		$deploymentId: Uuid!
+		$releaseName: String!
+		$environmentVariables: [InputEnvironmentVariable!]!
+	){
+		updateDeploymentVariables(
+			deploymentUuid: $deploymentId
+			releaseName: $releaseName
+			environmentVariables: $environmentVariables
+		){
+			key
+			value
+			isSecret
+		}
+	}`
+)
+
+// ListDeploymentVariables - list the environment variables of a deployment
+func (h ClientImplementation) ListDeploymentVariables(request ListDeploymentVariablesRequest) ([]EnvironmentVariable, error) {
+	req := Request{
+		Query:     DeploymentVariablesListRequest,
+		Variables: request,
+	}
+
+	r, err := req.DoWithClient(h.client)
+	if err != nil {
+		return nil, handleAPIErr(err)
+	}
+
+	return r.Data.DeploymentVariables, nil
+}
+
+// UpdateDeploymentVariables - set the environment variables of a deployment
+func (h ClientImplementation) UpdateDeploymentVariables(request UpdateDeploymentVariablesRequest) ([]EnvironmentVariable, error) {
+	req := Request{
+		Query:     DeploymentVariablesUpdateRequest,
+		Variables: request,
+	}
+
+	r, err := req.DoWithClient(h.client)
+	if err != nil {
+		return nil, handleAPIErr(err)
+	}
+
+	return r.Data.UpdateDeploymentVariables, nil
+}
@@ -0,0 +1,99 @@
+package houston
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"testing"
+
+	testUtil "github.com/astronomer/astro-cli/pkg/testing"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestListDeploymentVariables(t *testing.T) {
+	testUtil.InitTestConfig("software")
+
+	mockResponse := &Response{
+		Data: ResponseData{
+			DeploymentVariables: []EnvironmentVariable{
+				{Key: "FOO", Value: "bar"},
+				{Key: "SECRET", IsSecret: true},
+			},
+		},
+	}
+	jsonResponse, err := json.Marshal(mockResponse)
+	assert.NoError(t, err)
+
+	t.Run("success", func(t *testing.T) {
+		client := testUtil.NewTestClient(func(req *http.Request) *http.Response {
+			return &http.Response{
+				StatusCode: 200,
+				Body:       io.NopCloser(bytes.NewBuffer(jsonResponse)),
+				Header:     make(http.Header),
+			}
+		})
+		api := NewClient(client)
+
+		response, err := api.ListDeploymentVariables(ListDeploymentVariablesRequest{DeploymentID: "deployment-id", ReleaseName: "release-name"})
+		assert.NoError(t, err)
+		assert.Equal(t, mockResponse.Data.DeploymentVariables, response)
+	})
+
+	t.Run("error", func(t *testing.T) {
+		client := testUtil.NewTestClient(func(req *http.Request) *http.Response {
+			return &http.Response{
+				StatusCode: 500,
+				Body:       io.NopCloser(bytes.NewBufferString("Internal Server Error")),
+				Header:     make(http.Header),
+			}
+		})
+		api := NewClient(client)
+
+		_, err := api.ListDeploymentVariables(ListDeploymentVariablesRequest{DeploymentID: "deployment-id", ReleaseName: "release-name"})
+		assert.Contains(t, err.Error(), "Internal Server Error")
+	})
+}
+
+func TestUpdateDeploymentVariables(t *testing.T) {
+	testUtil.InitTestConfig("software")
+
+	mockResponse := &Response{
+		Data: ResponseData{
+			UpdateDeploymentVariables: []EnvironmentVariable{
+				{Key: "FOO", Value: "baz"},
+			},
+		},
+	}
+	jsonResponse, err := json.Marshal(mockResponse)
+	assert.NoError(t, err)
+
+	t.Run("success", func(t *testing.T) {
+		client := testUtil.NewTestClient(func(req *http.Request) *http.Response {
+			return &http.Response{
+				StatusCode: 200,
+				Body:       io.NopCloser(bytes.NewBuffer(jsonResponse)),
+				Header:     make(http.Header),
+			}
+		})
+		api := NewClient(client)
+
+		response, err := api.UpdateDeploymentVariables(UpdateDeploymentVariablesRequest{DeploymentID: "deployment-id", ReleaseName: "release-name", EnvironmentVariables: []EnvironmentVariable{{Key: "FOO", Value: "baz"}}})
+		assert.NoError(t, err)
+		assert.Equal(t, mockResponse.Data.UpdateDeploymentVariables, response)
+	})
+
+	t.Run("error", func(t *testing.T) {
+		client := testUtil.NewTestClient(func(req *http.Request) *http.Response {
+			return &http.Response{
+				StatusCode: 500,
+				Body:       io.NopCloser(bytes.NewBufferString("Internal Server Error")),
+				Header:     make(http.Header),
+			}
+		})
+		api := NewClient(client)
+
+		_, err := api.UpdateDeploymentVariables(UpdateDeploymentVariablesRequest{DeploymentID: "deployment-id", ReleaseName: "release-name"})
+		assert.Contains(t, err.Error(), "Internal Server Error")
+	})
+}
@@ -1,12 +1,11 @@
 package houston
 
 import (
-	"crypto/tls"
+	httpContext "context"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
-	"net"
 	"net/http"
 	"strings"
 	"time"
@@ -45,6 +44,7 @@ type ClientInterface interface {
 	DeleteWorkspaceUser(req DeleteWorkspaceUserRequest) (*Workspace, error)
 	ListWorkspaceUserAndRoles(workspaceID string) ([]WorkspaceUserRoleBindings, error)
 	ListWorkspacePaginatedUserAndRoles(req PaginatedWorkspaceUserRolesRequest) ([]WorkspaceUserRoleBindings, error)
+	PaginatedListWorkspaceUsers(req PaginatedWorkspaceUsersRequest) ([]WorkspaceUserRoleBindings, error)
 	UpdateWorkspaceUserRole(req UpdateWorkspaceUserRoleRequest) (string, error)
 	GetWorkspaceUserRole(req GetWorkspaceUserRoleRequest) (WorkspaceUserRoleBindings, error)
 	// auth
@@ -54,16 +54,23 @@ type ClientInterface interface {
 	CreateDeployment(vars map[string]interface{}) (*Deployment, error)
 	DeleteDeployment(req DeleteDeploymentRequest) (*Deployment, error)
 	ListDeployments(filters ListDeploymentsRequest) ([]Deployment, error)
+	PaginatedListDeployments(req PaginatedDeploymentsRequest) ([]Deployment, error)
 	UpdateDeployment(variables map[string]interface{}) (*Deployment, error)
 	GetDeployment(deploymentID string) (*Deployment, error)
+	GetDeploymentStatus(deploymentID string) (*DeploymentStatus, error)
 	UpdateDeploymentAirflow(variables map[string]interface{}) (*Deployment, error)
 	UpdateDeploymentRuntime(variables map[string]interface{}) (*Deployment, error)
 	CancelUpdateDeploymentRuntime(variables map[string]interface{}) (*Deployment, error)
 	GetDeploymentConfig(interface{}) (*DeploymentConfig, error)
+	GetDeploymentExecutorsConfig(interface{}) (*DeploymentConfig, error)
 	ListDeploymentLogs(filters ListDeploymentLogsRequest) ([]DeploymentLog, error)
 	UpdateDeploymentImage(req UpdateDeploymentImageRequest) (interface{}, error)
+	UploadDags(req UploadDagsRequest) (*UploadDagsResponse, error)
+	ListDeploymentVariables(req ListDeploymentVariablesRequest) ([]EnvironmentVariable, error)
+	UpdateDeploymentVariables(req UpdateDeploymentVariablesRequest) ([]EnvironmentVariable, error)
 	// deployment users
 	ListDeploymentUsers(filters ListDeploymentUsersRequest) ([]DeploymentUser, error)
+	PaginatedListDeploymentUsers(req PaginatedDeploymentUsersRequest) ([]DeploymentUser, error)
 	AddDeploymentUser(variables UpdateDeploymentUserRequest) (*RoleBinding, error)
 	UpdateDeploymentUser(variables UpdateDeploymentUserRequest) (*RoleBinding, error)
 	DeleteDeploymentUser(req DeleteDeploymentUserRequest) (*RoleBinding, error)
@@ -71,9 +78,11 @@ type ClientInterface interface {
 	CreateDeploymentServiceAccount(variables *CreateServiceAccountRequest) (*DeploymentServiceAccount, error)
 	DeleteDeploymentServiceAccount(req DeleteServiceAccountRequest) (*ServiceAccount, error)
 	ListDeploymentServiceAccounts(deploymentID string) ([]ServiceAccount, error)
+	PaginatedListDeploymentServiceAccounts(req PaginatedServiceAccountsRequest) ([]ServiceAccount, error)
 	CreateWorkspaceServiceAccount(variables *CreateServiceAccountRequest) (*WorkspaceServiceAccount, error)
 	DeleteWorkspaceServiceAccount(req DeleteServiceAccountRequest) (*ServiceAccount, error)
 	ListWorkspaceServiceAccounts(workspaceID string) ([]ServiceAccount, error)
+	PaginatedListWorkspaceServiceAccounts(req PaginatedServiceAccountsRequest) ([]ServiceAccount, error)
 	// app
 	GetAppConfig(interface{}) (*AppConfig, error)
 	GetAvailableNamespaces(interface{}) ([]Namespace, error)
@@ -122,14 +131,17 @@ func NewHTTPClient() *httputil.HTTPClient {
 	httpClient := httputil.NewHTTPClient()
 	// configure http transport
 	dialTimeout := config.CFG.HoustonDialTimeout.GetInt()
-	// #nosec
-	httpClient.HTTPClient.Transport = &http.Transport{
-		Dial: (&net.Dialer{
-			Timeout: time.Duration(dialTimeout) * time.Second,
-		}).Dial,
-		TLSHandshakeTimeout: time.Duration(dialTimeout) * time.Second,
-		TLSClientConfig:     &tls.Config{InsecureSkipVerify: config.CFG.HoustonSkipVerifyTLS.GetBool()},
+	opts := context.TransportOptions()
+	opts.DialTimeout = time.Duration(dialTimeout) * time.Second
+	opts.InsecureSkipVerify = config.CFG.HoustonSkipVerifyTLS.GetBool()
+	transport, err := httputil.NewTransport(opts)
+	if err != nil {
+		newLogger.Debugf("Unable to configure the Houston transport: %s", err.Error())
+		httpClient.HTTPClient.Transport = httputil.ErrorTransport{Err: err}
+		return httpClient
 	}
+	httpClient.HTTPClient.Transport = httputil.NewRetryTransport(httputil.NewTraceTransport(transport), config.CFG.HTTPMaxRetries.GetInt())
+	httpClient.EnableCache(config.HTTPCacheDir(), time.Duration(config.CFG.HTTPCacheTTL.GetInt())*time.Second)
 	return httpClient
 }
 
@@ -143,6 +155,8 @@ func newInternalClient(c *httputil.HTTPClient) *Client {
 type Request struct {
 	Query     string      `json:"query"`
 	Variables interface{} `json:"variables"`
+	// NoCache always sends the request to Houston, for the state of deployments which is polled
+	NoCache bool `json:"-"`
 }
 
 // Do (request) is a wrapper to more easily pass variables to a Client.Do request
@@ -157,6 +171,12 @@ func (r *Request) DoWithClient(api *Client) (*Response, error) {
 			"Accept": "application/json",
 		},
 	}
+	if isQuery(r.Query) {
+		doOpts.Context = httputil.GraphQLQueryContext(httpContext.Background())
+	}
+	if r.NoCache {
+		doOpts.Headers["Cache-Control"] = "no-cache"
+	}
 
 	return api.Do(doOpts)
 }
@@ -658,6 +658,58 @@ func (_m *ClientInterface) GetDeploymentConfig(_a0 interface{}) (*houston.Deploy
 	return r0, r1
 }
 
+// GetDeploymentExecutorsConfig provides a mock function with given fields: _a0
+func (_m *ClientInterface) GetDeploymentExecutorsConfig(_a0 interface{}) (*houston.DeploymentConfig, error) {
+	ret := _m.Called(_a0)
+
+	var r0 *houston.DeploymentConfig
+	var r1 error
+	if rf, ok := ret.Get(0).(func(interface{}) (*houston.DeploymentConfig, error)); ok {
+		return rf(_a0)
+	}
+	if rf, ok := ret.Get(0).(func(interface{}) *houston.DeploymentConfig); ok {
+		r0 = rf(_a0)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*houston.DeploymentConfig)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(interface{}) error); ok {
+		r1 = rf(_a0)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetDeploymentStatus provides a mock function with given fields: deploymentID
+func (_m *ClientInterface) GetDeploymentStatus(deploymentID string) (*houston.DeploymentStatus, error) {
+	ret := _m.Called(deploymentID)
+
+	var r0 *houston.DeploymentStatus
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string) (*houston.DeploymentStatus, error)); ok {
+		return rf(deploymentID)
+	}
+	if rf, ok := ret.Get(0).(func(string) *houston.DeploymentStatus); ok {
+		r0 = rf(deploymentID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*houston.DeploymentStatus)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(deploymentID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetPlatformVersion provides a mock function with given fields: _a0
 func (_m *ClientInterface) GetPlatformVersion(_a0 interface{}) (string, error) {
 	ret := _m.Called(_a0)
@@ -940,6 +992,32 @@ func (_m *ClientInterface) ListDeploymentUsers(filters houston.ListDeploymentUse
 	return r0, r1
 }
 
+// ListDeploymentVariables provides a mock function with given fields: req
+func (_m *ClientInterface) ListDeploymentVariables(req houston.ListDeploymentVariablesRequest) ([]houston.EnvironmentVariable, error) {
+	ret := _m.Called(req)
+
+	var r0 []houston.EnvironmentVariable
+	var r1 error
+	if rf, ok := ret.Get(0).(func(houston.ListDeploymentVariablesRequest) ([]houston.EnvironmentVariable, error)); ok {
+		return rf(req)
+	}
+	if rf, ok := ret.Get(0).(func(houston.ListDeploymentVariablesRequest) []houston.EnvironmentVariable); ok {
+		r0 = rf(req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]houston.EnvironmentVariable)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(houston.ListDeploymentVariablesRequest) error); ok {
+		r1 = rf(req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // ListDeployments provides a mock function with given fields: filters
 func (_m *ClientInterface) ListDeployments(filters houston.ListDeploymentsRequest) ([]houston.Deployment, error) {
 	ret := _m.Called(filters)
@@ -1120,6 +1198,136 @@ func (_m *ClientInterface) ListWorkspaces(_a0 interface{}) ([]houston.Workspace,
 	return r0, r1
 }
 
+// PaginatedListDeploymentServiceAccounts provides a mock function with given fields: req
+func (_m *ClientInterface) PaginatedListDeploymentServiceAccounts(req houston.PaginatedServiceAccountsRequest) ([]houston.ServiceAccount, error) {
+	ret := _m.Called(req)
+
+	var r0 []houston.ServiceAccount
+	var r1 error
+	if rf, ok := ret.Get(0).(func(houston.PaginatedServiceAccountsRequest) ([]houston.ServiceAccount, error)); ok {
+		return rf(req)
+	}
+	if rf, ok := ret.Get(0).(func(houston.PaginatedServiceAccountsRequest) []houston.ServiceAccount); ok {
+		r0 = rf(req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]houston.ServiceAccount)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(houston.PaginatedServiceAccountsRequest) error); ok {
+		r1 = rf(req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// PaginatedListDeploymentUsers provides a mock function with given fields: req
+func (_m *ClientInterface) PaginatedListDeploymentUsers(req houston.PaginatedDeploymentUsersRequest) ([]houston.DeploymentUser, error) {
+	ret := _m.Called(req)
+
+	var r0 []houston.DeploymentUser
+	var r1 error
+	if rf, ok := ret.Get(0).(func(houston.PaginatedDeploymentUsersRequest) ([]houston.DeploymentUser, error)); ok {
+		return rf(req)
+	}
+	if rf, ok := ret.Get(0).(func(houston.PaginatedDeploymentUsersRequest) []houston.DeploymentUser); ok {
+		r0 = rf(req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]houston.DeploymentUser)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(houston.PaginatedDeploymentUsersRequest) error); ok {
+		r1 = rf(req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// PaginatedListDeployments provides a mock function with given fields: req
+func (_m *ClientInterface) PaginatedListDeployments(req houston.PaginatedDeploymentsRequest) ([]houston.Deployment, error) {
+	ret := _m.Called(req)
+
+	var r0 []houston.Deployment
+	var r1 error
+	if rf, ok := ret.Get(0).(func(houston.PaginatedDeploymentsRequest) ([]houston.Deployment, error)); ok {
+		return rf(req)
+	}
+	if rf, ok := ret.Get(0).(func(houston.PaginatedDeploymentsRequest) []houston.Deployment); ok {
+		r0 = rf(req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]houston.Deployment)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(houston.PaginatedDeploymentsRequest) error); ok {
+		r1 = rf(req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// PaginatedListWorkspaceServiceAccounts provides a mock function with given fields: req
+func (_m *ClientInterface) PaginatedListWorkspaceServiceAccounts(req houston.PaginatedServiceAccountsRequest) ([]houston.ServiceAccount, error) {
+	ret := _m.Called(req)
+
+	var r0 []houston.ServiceAccount
+	var r1 error
+	if rf, ok := ret.Get(0).(func(houston.PaginatedServiceAccountsRequest) ([]houston.ServiceAccount, error)); ok {
+		return rf(req)
+	}
+	if rf, ok := ret.Get(0).(func(houston.PaginatedServiceAccountsRequest) []houston.ServiceAccount); ok {
+		r0 = rf(req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]houston.ServiceAccount)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(houston.PaginatedServiceAccountsRequest) error); ok {
+		r1 = rf(req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// PaginatedListWorkspaceUsers provides a mock function with given fields: req
+func (_m *ClientInterface) PaginatedListWorkspaceUsers(req houston.PaginatedWorkspaceUsersRequest) ([]houston.WorkspaceUserRoleBindings, error) {
+	ret := _m.Called(req)
+
+	var r0 []houston.WorkspaceUserRoleBindings
+	var r1 error
+	if rf, ok := ret.Get(0).(func(houston.PaginatedWorkspaceUsersRequest) ([]houston.WorkspaceUserRoleBindings, error)); ok {
+		return rf(req)
+	}
+	if rf, ok := ret.Get(0).(func(houston.PaginatedWorkspaceUsersRequest) []houston.WorkspaceUserRoleBindings); ok {
+		r0 = rf(req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]houston.WorkspaceUserRoleBindings)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(houston.PaginatedWorkspaceUsersRequest) error); ok {
+		r1 = rf(req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // PaginatedListWorkspaces provides a mock function with given fields: req
 func (_m *ClientInterface) PaginatedListWorkspaces(req houston.PaginatedListWorkspaceRequest) ([]houston.Workspace, error) {
 	ret := _m.Called(req)
@@ -1328,6 +1536,32 @@ func (_m *ClientInterface) UpdateDeploymentUser(variables houston.UpdateDeployme
 	return r0, r1
 }
 
+// UpdateDeploymentVariables provides a mock function with given fields: req
+func (_m *ClientInterface) UpdateDeploymentVariables(req houston.UpdateDeploymentVariablesRequest) ([]houston.EnvironmentVariable, error) {
+	ret := _m.Called(req)
+
+	var r0 []houston.EnvironmentVariable
+	var r1 error
+	if rf, ok := ret.Get(0).(func(houston.UpdateDeploymentVariablesRequest) ([]houston.EnvironmentVariable, error)); ok {
+		return rf(req)
+	}
+	if rf, ok := ret.Get(0).(func(houston.UpdateDeploymentVariablesRequest) []houston.EnvironmentVariable); ok {
+		r0 = rf(req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]houston.EnvironmentVariable)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(houston.UpdateDeploymentVariablesRequest) error); ok {
+		r1 = rf(req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // UpdateWorkspace provides a mock function with given fields: req
 func (_m *ClientInterface) UpdateWorkspace(req houston.UpdateWorkspaceRequest) (*houston.Workspace, error) {
 	ret := _m.Called(req)
@@ -1402,6 +1636,32 @@ func (_m *ClientInterface) UpdateWorkspaceUserRole(req houston.UpdateWorkspaceUs
 	return r0, r1
 }
 
+// UploadDags provides a mock function with given fields: req
+func (_m *ClientInterface) UploadDags(req houston.UploadDagsRequest) (*houston.UploadDagsResponse, error) {
+	ret := _m.Called(req)
+
+	var r0 *houston.UploadDagsResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(houston.UploadDagsRequest) (*houston.UploadDagsResponse, error)); ok {
+		return rf(req)
+	}
+	if rf, ok := ret.Get(0).(func(houston.UploadDagsRequest) *houston.UploadDagsResponse); ok {
+		r0 = rf(req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*houston.UploadDagsResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(houston.UploadDagsRequest) error); ok {
+		r1 = rf(req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // ValidateWorkspaceID provides a mock function with given fields: workspaceID
 func (_m *ClientInterface) ValidateWorkspaceID(workspaceID string) (*houston.Workspace, error) {
 	ret := _m.Called(workspaceID)
@@ -16,6 +16,15 @@ type DeleteServiceAccountRequest struct {
 	ServiceAccountID string `json:"serviceAccountUuid"`
 }
 
+// PaginatedServiceAccountsRequest - filters to list a page of the service accounts of a workspace or a deployment
+type PaginatedServiceAccountsRequest struct {
+	WorkspaceID  string `json:"workspaceUuid,omitempty"`
+	DeploymentID string `json:"deploymentUuid,omitempty"`
+	Label        string `json:"label,omitempty"`
+	CursorID     string `json:"cursorUuid,omitempty"`
+	Take         int    `json:"take"`
+}
+
 var (
 	CreateDeploymentServiceAccountRequest = `
 	mutation createDeploymentServiceAccount(
@@ -130,6 +139,9 @@ var (
 			createdAt
 			updatedAt
 			lastUsedAt
+			roleBinding {
+				role
+			}
 		}
   	}`
 
@@ -150,6 +162,67 @@ var (
 			createdAt
 			updatedAt
 			lastUsedAt
+			roleBinding {
+				role
+			}
+		}
+	}`
+
+	DeploymentServiceAccountsPaginatedGetRequest = `
+	query paginatedDeploymentServiceAccounts(
+		$deploymentUuid: Uuid!
+		$label: String
+		$cursorUuid: Uuid
+		$take: Int
+	){
+		paginatedDeploymentServiceAccounts(
+			deploymentUuid: $deploymentUuid
+			label: $label
+			cursor: $cursorUuid
+			take: $take
+		){
+			id
+			apiKey
+			label
+			category
+			entityType
+			entityUuid
+			active
+			createdAt
+			updatedAt
+			lastUsedAt
+			roleBinding {
+				role
+			}
+		}
+	}`
+
+	WorkspaceServiceAccountsPaginatedGetRequest = `
+	query paginatedWorkspaceServiceAccounts(
+		$workspaceUuid: Uuid!
+		$label: String
+		$cursorUuid: Uuid
+		$take: Int
+	){
+		paginatedWorkspaceServiceAccounts(
+			workspaceUuid: $workspaceUuid
+			label: $label
+			cursor: $cursorUuid
+			take: $take
+		){
+			id
+			apiKey
+			label
+			category
+			entityType
+			entityUuid
+			active
+			createdAt
+			updatedAt
+			lastUsedAt
+			roleBinding {
+				role
+			}
 		}
 	}`
 )
@@ -241,3 +314,33 @@ func (h ClientImplementation) ListWorkspaceServiceAccounts(workspaceID string) (
 
 	return resp.Data.GetWorkspaceServiceAccounts, nil
 }
+
+// PaginatedListDeploymentServiceAccounts - list a page of the service accounts of a deployment
+func (h ClientImplementation) PaginatedListDeploymentServiceAccounts(request PaginatedServiceAccountsRequest) ([]ServiceAccount, error) {
+	req := Request{
+		Query:     DeploymentServiceAccountsPaginatedGetRequest,
+		Variables: request,
+	}
+
+	resp, err := req.DoWithClient(h.client)
+	if err != nil {
+		return nil, handleAPIErr(err)
+	}
+
+	return resp.Data.PaginatedDeploymentSAs, nil
+}
+
+// PaginatedListWorkspaceServiceAccounts - list a page of the service accounts of a workspace
+func (h ClientImplementation) PaginatedListWorkspaceServiceAccounts(request PaginatedServiceAccountsRequest) ([]ServiceAccount, error) {
+	req := Request{
+		Query:     WorkspaceServiceAccountsPaginatedGetRequest,
+		Variables: request,
+	}
+
+	resp, err := req.DoWithClient(h.client)
+	if err != nil {
+		return nil, handleAPIErr(err)
+	}
+
+	return resp.Data.PaginatedWorkspaceSAs, nil
+}
@@ -338,3 +338,89 @@ func TestListWorkspaceServiceAccounts(t *testing.T) {
 		assert.Contains(t, err.Error(), "Internal Server Error")
 	})
 }
+
+func TestPaginatedListDeploymentServiceAccounts(t *testing.T) {
+	testUtil.InitTestConfig("software")
+
+	mockResponse := &Response{
+		Data: ResponseData{
+			PaginatedDeploymentSAs: []ServiceAccount{
+				{ID: "id", APIKey: "apikey", Label: "ci", Category: "default"},
+			},
+		},
+	}
+	jsonResponse, err := json.Marshal(mockResponse)
+	assert.NoError(t, err)
+
+	t.Run("success", func(t *testing.T) {
+		client := testUtil.NewTestClient(func(req *http.Request) *http.Response {
+			return &http.Response{
+				StatusCode: 200,
+				Body:       io.NopCloser(bytes.NewBuffer(jsonResponse)),
+				Header:     make(http.Header),
+			}
+		})
+		api := NewClient(client)
+
+		response, err := api.PaginatedListDeploymentServiceAccounts(PaginatedServiceAccountsRequest{DeploymentID: "deployment-id", Label: "ci", Take: 10})
+		assert.NoError(t, err)
+		assert.Equal(t, response, mockResponse.Data.PaginatedDeploymentSAs)
+	})
+
+	t.Run("error", func(t *testing.T) {
+		client := testUtil.NewTestClient(func(req *http.Request) *http.Response {
+			return &http.Response{
+				StatusCode: 500,
+				Body:       io.NopCloser(bytes.NewBufferString("Internal Server Error")),
+				Header:     make(http.Header),
+			}
+		})
+		api := NewClient(client)
+
+		_, err := api.PaginatedListDeploymentServiceAccounts(PaginatedServiceAccountsRequest{DeploymentID: "deployment-id", Label: "ci", Take: 10})
+		assert.Contains(t, err.Error(), "Internal Server Error")
+	})
+}
+
+func TestPaginatedListWorkspaceServiceAccounts(t *testing.T) {
+	testUtil.InitTestConfig("software")
+
+	mockResponse := &Response{
+		Data: ResponseData{
+			PaginatedWorkspaceSAs: []ServiceAccount{
+				{ID: "id", APIKey: "apikey", Label: "ci", Category: "default"},
+			},
+		},
+	}
+	jsonResponse, err := json.Marshal(mockResponse)
+	assert.NoError(t, err)
+
+	t.Run("success", func(t *testing.T) {
+		client := testUtil.NewTestClient(func(req *http.Request) *http.Response {
+			return &http.Response{
+				StatusCode: 200,
+				Body:       io.NopCloser(bytes.NewBuffer(jsonResponse)),
+				Header:     make(http.Header),
+			}
+		})
+		api := NewClient(client)
+
+		response, err := api.PaginatedListWorkspaceServiceAccounts(PaginatedServiceAccountsRequest{WorkspaceID: "workspace-id", CursorID: "cursor-id", Take: 10})
+		assert.NoError(t, err)
+		assert.Equal(t, response, mockResponse.Data.PaginatedWorkspaceSAs)
+	})
+
+	t.Run("error", func(t *testing.T) {
+		client := testUtil.NewTestClient(func(req *http.Request) *http.Response {
+			return &http.Response{
+				StatusCode: 500,
+				Body:       io.NopCloser(bytes.NewBufferString("Internal Server Error")),
+				Header:     make(http.Header),
+			}
+		})
+		api := NewClient(client)
+
+		_, err := api.PaginatedListWorkspaceServiceAccounts(PaginatedServiceAccountsRequest{WorkspaceID: "workspace-id", CursorID: "cursor-id", Take: 10})
+		assert.Contains(t, err.Error(), "Internal Server Error")
+	})
+}
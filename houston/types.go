@@ -18,6 +18,10 @@ type ResponseData struct {
 	DeleteDeploymentUser           *RoleBinding                `json:"deploymentRemoveUserRole,omitempty"`
 	UpdateDeploymentUser           *RoleBinding                `json:"deploymentUpdateUserRole,omitempty"`
 	DeploymentUserList             []DeploymentUser            `json:"deploymentUsers,omitempty"`
+	PaginatedDeploymentUsers       []DeploymentUser            `json:"paginatedDeploymentUsers,omitempty"`
+	DeploymentVariables            []EnvironmentVariable       `json:"deploymentVariables,omitempty"`
+	DeploymentStatus               *DeploymentStatus           `json:"deploymentStatus,omitempty"`
+	UpdateDeploymentVariables      []EnvironmentVariable       `json:"updateDeploymentVariables,omitempty"`
 	AddWorkspaceUser               *Workspace                  `json:"workspaceAddUser,omitempty"`
 	RemoveWorkspaceUser            *Workspace                  `json:"workspaceRemoveUser,omitempty"`
 	CreateDeployment               *Deployment                 `json:"createDeployment,omitempty"`
@@ -32,10 +36,13 @@ type ResponseData struct {
 	DeleteWorkspace                *Workspace                  `json:"deleteWorkspace,omitempty"`
 	GetDeployment                  Deployment                  `json:"deployment,omitempty"`
 	GetDeployments                 []Deployment                `json:"workspaceDeployments,omitempty"`
+	PaginatedDeployments           []Deployment                `json:"paginatedDeployments,omitempty"`
 	GetAuthConfig                  *AuthConfig                 `json:"authConfig,omitempty"`
 	GetAppConfig                   *AppConfig                  `json:"appConfig,omitempty"`
 	GetDeploymentServiceAccounts   []ServiceAccount            `json:"deploymentServiceAccounts,omitempty"`
 	GetWorkspaceServiceAccounts    []ServiceAccount            `json:"workspaceServiceAccounts,omitempty"`
+	PaginatedDeploymentSAs         []ServiceAccount            `json:"paginatedDeploymentServiceAccounts,omitempty"`
+	PaginatedWorkspaceSAs          []ServiceAccount            `json:"paginatedWorkspaceServiceAccounts,omitempty"`
 	GetUsers                       []User                      `json:"users,omitempty"`
 	GetWorkspaces                  []Workspace                 `json:"workspaces,omitempty"`
 	GetPaginatedWorkspaces         []Workspace                 `json:"paginatedWorkspaces,omitempty"`
@@ -113,18 +120,66 @@ type Deployment struct {
 	RuntimeAirflowVersion string          `json:"runtimeAirflowVersion"`
 	DesiredRuntimeVersion string          `json:"desiredRuntimeVersion"`
 	DeploymentInfo        DeploymentInfo  `json:"deployInfo"`
+	Description           string          `json:"description"`
+	Config                AirflowConfig   `json:"config"`
+	DagDeployment         DagDeployment   `json:"dagDeployment"`
 	Workspace             Workspace       `json:"workspace"`
 	Urls                  []DeploymentURL `json:"urls"`
 	CreatedAt             time.Time       `json:"createdAt"`
 	UpdatedAt             time.Time       `json:"updatedAt"`
 }
 
+// EnvironmentVariable is an environment variable of a deployment, the value of secret variables is never returned
+type EnvironmentVariable struct {
+	Key      string `json:"key"`
+	Value    string `json:"value"`
+	IsSecret bool   `json:"isSecret"`
+}
+
 // DeploymentURL defines structure of a houston response DeploymentURL object
 type DeploymentURL struct {
 	Type string `json:"type"`
 	URL  string `json:"url"`
 }
 
+// DagDeployment contains the DAG deployment mechanism of a deployment
+type DagDeployment struct {
+	Type                 string `json:"type"`
+	NfsLocation          string `json:"nfsLocation"`
+	RepositoryURL        string `json:"repositoryUrl"`
+	BranchName           string `json:"branchName"`
+	Rev                  string `json:"rev"`
+	DagDirectoryLocation string `json:"dagDirectoryLocation"`
+	SyncInterval         int    `json:"syncInterval"`
+}
+
+// AirflowConfig contains the executor and the resources of the Airflow components of a deployment
+type AirflowConfig struct {
+	Executor  string          `json:"executor"`
+	Scheduler ComponentConfig `json:"scheduler"`
+	Webserver ComponentConfig `json:"webserver"`
+	Workers   ComponentConfig `json:"workers"`
+	Triggerer ComponentConfig `json:"triggerer"`
+}
+
+// ComponentConfig contains the replicas and resources of an Airflow component
+type ComponentConfig struct {
+	Replicas  int                `json:"replicas"`
+	Resources ComponentResources `json:"resources"`
+}
+
+// ComponentResources contains the kubernetes resources of an Airflow component
+type ComponentResources struct {
+	Limits   ResourceQuantities `json:"limits"`
+	Requests ResourceQuantities `json:"requests"`
+}
+
+// ResourceQuantities contains kubernetes cpu and memory quantities, e.g. 500m and 1920Mi
+type ResourceQuantities struct {
+	CPU    string `json:"cpu"`
+	Memory string `json:"memory"`
+}
+
 // DeploymentInfo contains registry related information for a deployment
 type DeploymentInfo struct {
 	NextCli string `json:"NextCli"`
@@ -162,14 +217,15 @@ type Status struct {
 
 // ServiceAccount defines a structure of a ServiceAccountResponse object
 type ServiceAccount struct {
-	ID         string `json:"id"`
-	APIKey     string `json:"apiKey"`
-	Label      string `json:"label"`
-	Category   string `json:"category"`
-	LastUsedAt string `json:"lastUsedAt"`
-	CreatedAt  string `json:"createdAt"`
-	UpdatedAt  string `json:"updatedAt"`
-	Active     bool   `json:"active"`
+	ID          string       `json:"id"`
+	APIKey      string       `json:"apiKey"`
+	Label       string       `json:"label"`
+	Category    string       `json:"category"`
+	LastUsedAt  string       `json:"lastUsedAt"`
+	CreatedAt   string       `json:"createdAt"`
+	UpdatedAt   string       `json:"updatedAt"`
+	Active      bool         `json:"active"`
+	RoleBinding *RoleBinding `json:"roleBinding,omitempty"`
 }
 
 // WorkspaceServiceAccount defines a structure of a WorkspaceServiceAccountResponse object
@@ -283,6 +339,18 @@ type Workspace struct {
 	RoleBindings []RoleBinding `json:"roleBindings"`
 }
 
+// DeploymentStatus is the health of a deployment and of each of its Airflow components
+type DeploymentStatus struct {
+	Status     string            `json:"status"`
+	Components []ComponentStatus `json:"components"`
+}
+
+// ComponentStatus is the health of an Airflow component of a deployment
+type ComponentStatus struct {
+	Name   string `json:"name"`
+	Status string `json:"status"`
+}
+
 // DeploymentLog contains all log related to deployment components
 type DeploymentLog struct {
 	ID        string `json:"id"`
@@ -308,6 +376,13 @@ type DeploymentConfig struct {
 	AirflowImages          []AirflowImage `json:"airflowImages"`
 	DefaultAirflowImageTag string         `json:"defaultAirflowImageTag"`
 	AirflowVersions        []string       `json:"airflowVersions"`
+	Executors              []Executor     `json:"executors"`
+}
+
+// Executor is an executor of the platform, only enabled executors can be used by deployments
+type Executor struct {
+	Name    string `json:"name"`
+	Enabled bool   `json:"enabled"`
 }
 
 func (config *DeploymentConfig) GetValidTags(tag string) (tags []string) {
@@ -364,6 +439,7 @@ type FeatureFlags struct {
 	NamespaceFreeFormEntry bool `json:"namespaceFreeFormEntry"`
 	BYORegistryEnabled     bool `json:"byoUpdateRegistryEnabled"`
 	AstroRuntimeEnabled    bool `json:"astroRuntimeEnabled"`
+	DagOnlyDeployment      bool `json:"dagOnlyDeployment"`
 }
 
 // coerce a string into SemVer if possible
@@ -2,6 +2,7 @@ package houston
 
 import (
 	"sort"
+	"strings"
 
 	"github.com/sirupsen/logrus"
 	"golang.org/x/mod/semver"
@@ -44,3 +45,9 @@ func (s queryList) GreatestLowerBound(v string) string {
 	logrus.Debugf("GraphQL query not defined for the given Platform version: %s, falling back to latest query", v)
 	return s[len(s)-1].query
 }
+
+// isQuery reports whether the given GraphQL document is a query, as opposed to a mutation or subscription
+func isQuery(document string) bool {
+	document = strings.TrimSpace(document)
+	return strings.HasPrefix(document, "query") || strings.HasPrefix(document, "{")
+}
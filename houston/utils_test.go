@@ -51,3 +51,20 @@ func TestQueryListGreatestLowerBound(t *testing.T) {
 		})
 	}
 }
+
+func TestIsQuery(t *testing.T) {
+	tests := []struct {
+		document string
+		want     bool
+	}{
+		{document: DeploymentInfoRequest, want: true},
+		{document: DeploymentDeleteRequest, want: false},
+		{document: "{ appConfig { version } }", want: true},
+		{document: "subscription log { log }", want: false},
+	}
+	for _, tt := range tests {
+		if got := isQuery(tt.document); got != tt.want {
+			t.Errorf("isQuery(%q) = %v, want %v", tt.document, got, tt.want)
+		}
+	}
+}
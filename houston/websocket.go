@@ -2,13 +2,18 @@ package houston
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
 	"log"
 	"net/http"
 	"os"
 	"os/signal"
 	"time"
 
+	"github.com/astronomer/astro-cli/config"
+	"github.com/astronomer/astro-cli/context"
+
 	"github.com/gorilla/websocket"
 )
 
@@ -71,76 +76,201 @@ func BuildDeploymentLogsSubscribeRequest(deploymentID, component, search string,
 	return string(b), nil
 }
 
-func Subscribe(jwtToken, url, queryMessage string) error {
+// newWebsocketDialer returns a websocket dialer honouring the same proxy and TLS settings as the Houston client
+func newWebsocketDialer() (*websocket.Dialer, error) {
+	opts := context.TransportOptions()
+	opts.InsecureSkipVerify = config.CFG.HoustonSkipVerifyTLS.GetBool()
+	tlsConfig, err := opts.TLSConfig()
+	if err != nil {
+		return nil, err
+	}
+	proxy, err := opts.Proxy()
+	if err != nil {
+		return nil, err
+	}
+	return &websocket.Dialer{
+		Proxy:            proxy,
+		TLSClientConfig:  tlsConfig,
+		HandshakeTimeout: websocket.DefaultDialer.HandshakeTimeout,
+	}, nil
+}
+
+// logResumeWindow is how many log IDs a subscription remembers to skip the logs sent again after a reconnect
+const logResumeWindow = 1000
+
+var (
+	// LogSubscriptionRetries is how many times in a row a dropped log subscription is reconnected before giving up
+	LogSubscriptionRetries    = 5
+	logSubscriptionRetryDelay = 2 * time.Second
+
+	errLogSubscriptionClosed = errors.New("the log subscription was closed by the platform, your token may have expired, please log in again")
+	errLogSubscriptionLost   = errors.New("lost the connection to the log subscription")
+	errLogSubscription       = errors.New("log subscription error")
+	errLogSubscriptionAuth   = errors.New("the platform refused the log subscription, your token may have expired, please log in again")
+)
+
+// logResume tracks the logs handled by a subscription, so it can be resumed from the last one after a reconnect
+// without handling the same logs twice
+type logResume struct {
+	last     time.Time
+	seen     map[string]bool
+	order    []string
+	received bool
+}
+
+// handled reports whether log was already handled, and records it otherwise
+func (r *logResume) handled(deploymentLog DeploymentLog) bool {
+	if deploymentLog.ID != "" {
+		if r.seen[deploymentLog.ID] {
+			return true
+		}
+		r.seen[deploymentLog.ID] = true
+		r.order = append(r.order, deploymentLog.ID)
+		if len(r.order) > logResumeWindow {
+			delete(r.seen, r.order[0])
+			r.order = r.order[1:]
+		}
+	}
+	if ts, err := time.Parse(time.RFC3339Nano, deploymentLog.CreatedAt); err == nil && ts.After(r.last) {
+		r.last = ts
+	}
+	r.received = true
+	return false
+}
+
+// SubscribeDeploymentLogs passes the logs matching request to handler as they arrive, until interrupted. When the
+// connection drops it reconnects and resumes from the timestamp of the last log received, logs already handled are
+// skipped. Connection status messages are written to status.
+func SubscribeDeploymentLogs(jwtToken, url string, request ListDeploymentLogsRequest, handler func(DeploymentLog) error, status io.Writer) error {
 	interrupt := make(chan os.Signal, 1)
 	signal.Notify(interrupt, os.Interrupt)
-	h := http.Header{"Sec-WebSocket-Protocol": []string{"graphql-ws"}}
-	ws, resp, err := websocket.DefaultDialer.Dial(url, h)
+	defer signal.Stop(interrupt)
+
+	resume := &logResume{seen: map[string]bool{}}
+	retries := 0
+	for {
+		retry, err := streamDeploymentLogs(jwtToken, url, request, resume, handler, interrupt, status)
+		if !retry {
+			return err
+		}
+		// only failed attempts in a row count against the retries
+		if resume.received {
+			retries = 0
+			resume.received = false
+		}
+		if retries >= LogSubscriptionRetries {
+			return fmt.Errorf("%w after %d attempts: %s", errLogSubscriptionLost, retries+1, err.Error())
+		}
+		retries++
+		fmt.Fprintf(status, "Lost the connection to the logs (%s), reconnecting %d/%d...\n", err.Error(), retries, LogSubscriptionRetries)
+		select {
+		case <-interrupt:
+			return nil
+		case <-time.After(logSubscriptionRetryDelay):
+		}
+		if !resume.last.IsZero() {
+			request.Timestamp = resume.last
+		}
+	}
+}
+
+type streamResult struct {
+	retry bool
+	err   error
+}
+
+// streamDeploymentLogs runs a single log subscription, it returns whether the subscription should be retried
+func streamDeploymentLogs(jwtToken, url string, request ListDeploymentLogsRequest, resume *logResume, handler func(DeploymentLog) error, interrupt <-chan os.Signal, status io.Writer) (bool, error) {
+	dialer, err := newWebsocketDialer()
 	if err != nil {
-		log.Fatal("dial:", err)
+		return false, err
 	}
-	defer func() {
-		ws.Close()
-		if resp != nil {
-			resp.Body.Close()
+	h := http.Header{"Sec-WebSocket-Protocol": []string{"graphql-ws"}}
+	ws, resp, err := dialer.Dial(url, h)
+	if resp != nil {
+		resp.Body.Close()
+	}
+	if err != nil {
+		// retrying can't fix the credentials
+		if resp != nil && (resp.StatusCode == http.StatusUnauthorized || resp.StatusCode == http.StatusForbidden) {
+			return false, fmt.Errorf("%w: %s", errLogSubscriptionAuth, resp.Status)
 		}
-	}()
+		return true, err
+	}
+	defer ws.Close()
 
 	initSubscription := InitSubscription{Type: "connection_init", Payload: AuthPayload{Authorization: jwtToken}}
 	js, _ := json.Marshal(&initSubscription)
-
-	err = ws.WriteMessage(websocket.TextMessage, js)
-	if err != nil {
-		fmt.Printf("Could not init connection: %s", err.Error())
+	if err := ws.WriteMessage(websocket.TextMessage, js); err != nil {
+		return true, fmt.Errorf("could not init connection: %w", err)
 	}
 
-	err = ws.WriteMessage(websocket.TextMessage, []byte(queryMessage))
-	if err != nil {
-		fmt.Printf("Could not subscribe to logs: %s", err.Error())
+	queryMessage, _ := BuildDeploymentLogsSubscribeRequest(request.DeploymentID, request.Component, request.Search, request.Timestamp)
+	if err := ws.WriteMessage(websocket.TextMessage, []byte(queryMessage)); err != nil {
+		return true, fmt.Errorf("could not subscribe to logs: %w", err)
 	}
 
-	fmt.Println("Waiting for logs...")
-	done := make(chan struct{})
+	fmt.Fprintln(status, "Waiting for logs...")
+	done := make(chan streamResult, 1)
 
 	go func() {
-		defer close(done)
 		for {
 			_, message, err := ws.ReadMessage()
 			if err != nil {
-				switch err.(type) {
-				case *websocket.CloseError:
-					fmt.Println("Your token has expired. Please log in again.")
+				var closeErr *websocket.CloseError
+				if errors.As(err, &closeErr) && closeErr.Code != websocket.CloseAbnormalClosure && closeErr.Code != websocket.CloseGoingAway {
+					done <- streamResult{err: errLogSubscriptionClosed}
 					return
-				default:
-					log.Fatal(err)
 				}
+				done <- streamResult{retry: true, err: err}
+				return
 			}
 			var resp WSResponse
 			if err = json.Unmarshal(message, &resp); err != nil {
-				log.Fatal(err)
+				done <- streamResult{err: err}
+				return
+			}
+			switch resp.Type {
+			case "data":
+				deploymentLog := DeploymentLog{
+					ID:        resp.Payload.Data.Log.ID,
+					Component: request.Component,
+					CreatedAt: resp.Payload.Data.Log.CreatedAt,
+					Log:       resp.Payload.Data.Log.Log,
+				}
+				if resume.handled(deploymentLog) {
+					continue
+				}
+				if err := handler(deploymentLog); err != nil {
+					done <- streamResult{err: err}
+					return
+				}
+			case "error", "connection_error":
+				done <- streamResult{err: fmt.Errorf("%w: %s", errLogSubscription, message)}
+				return
+			case "complete":
+				done <- streamResult{}
+				return
 			}
-			fmt.Print(resp.Payload.Data.Log.Log)
 		}
 	}()
 
-	for {
+	select {
+	case result := <-done:
+		return result.retry, result.err
+	case <-interrupt:
+		log.Println("Bye bye ...")
+		// Cleanly close the connection by sending a close message and then
+		// waiting (with timeout) for the server to close the connection.
+		err := ws.WriteMessage(websocket.CloseMessage, websocket.FormatCloseMessage(websocket.CloseNormalClosure, `{"id":"1","type":"stop"}`))
+		if err != nil {
+			fmt.Fprintln(status, "Close connection...")
+			return false, err
+		}
 		select {
 		case <-done:
-			return nil
-		case <-interrupt:
-			log.Println("Bye bye ...")
-			// Cleanly close the connection by sending a close message and then
-			// waiting (with timeout) for the server to close the connection.
-			err := ws.WriteMessage(websocket.CloseMessage, websocket.FormatCloseMessage(websocket.CloseNormalClosure, `{"id":"1","type":"stop"}`))
-			if err != nil {
-				fmt.Println("Close connection...")
-				return err
-			}
-			select {
-			case <-done:
-			case <-time.After(time.Second):
-			}
-			return nil
+		case <-time.After(time.Second):
 		}
+		return false, nil
 	}
 }
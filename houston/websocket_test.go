@@ -1,43 +1,79 @@
 package houston
 
 import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
 	"net/http"
 	"net/http/httptest"
 	"strings"
+	"sync"
 	"testing"
 	"time"
 
+	testUtil "github.com/astronomer/astro-cli/pkg/testing"
 	"github.com/gorilla/websocket"
 	"github.com/stretchr/testify/assert"
 )
 
-var (
-	upgrader = websocket.Upgrader{}
+var upgrader = websocket.Upgrader{}
 
-	websocketServerRequestCount = 1
-)
+func logMessage(id, createdAt, log string) string {
+	return fmt.Sprintf(`{"id":"1","type":"data","payload":{"data":{"log":{"id":%q,"createdAt":%q,"log":%q}}}}`, id, createdAt, log)
+}
 
-func websocketHandler(w http.ResponseWriter, r *http.Request) {
-	c, err := upgrader.Upgrade(w, r, nil)
-	if err != nil {
-		return
-	}
-	defer c.Close()
-	currCount := 0
-	for {
-		mt, message, err := c.ReadMessage()
+// startRecorder records the start messages received by logsHandler
+type startRecorder struct {
+	mu     sync.Mutex
+	starts []string
+}
+
+func (r *startRecorder) add(start string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.starts = append(r.starts, start)
+}
+
+func (r *startRecorder) get() []string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]string{}, r.starts...)
+}
+
+// logsHandler serves a graphql-ws log subscription, every connection sends the messages of the next entry of
+// connections and records the start message it received. A connection is dropped after its messages unless its
+// last message is a complete message.
+func logsHandler(connections [][]string, starts *startRecorder) http.HandlerFunc {
+	var mu sync.Mutex
+	count := 0
+	return func(w http.ResponseWriter, r *http.Request) {
+		c, err := upgrader.Upgrade(w, r, nil)
 		if err != nil {
-			break
+			return
 		}
-		// simply write back what was read from the connection
-		err = c.WriteMessage(mt, message)
-		if err != nil {
-			break
+		defer c.Close()
+
+		mu.Lock()
+		messages := connections[count%len(connections)]
+		count++
+		mu.Unlock()
+
+		// connection_init and start
+		for i := 0; i < 2; i++ {
+			_, message, err := c.ReadMessage()
+			if err != nil {
+				return
+			}
+			if i == 1 {
+				starts.add(string(message))
+			}
 		}
-		if currCount >= websocketServerRequestCount {
-			break
+		for _, message := range messages {
+			if err := c.WriteMessage(websocket.TextMessage, []byte(message)); err != nil {
+				return
+			}
 		}
-		currCount++
 	}
 }
 
@@ -49,16 +85,121 @@ func TestBuildDeploymentLogsSubscribeRequest(t *testing.T) {
 	assert.Contains(t, resp, "test")
 }
 
-func TestSubscribe(t *testing.T) {
-	// Create test server with the echo handler.
-	s := httptest.NewServer(http.HandlerFunc(websocketHandler))
-	defer s.Close()
+func TestSubscribeDeploymentLogs(t *testing.T) {
+	testUtil.InitTestConfig(testUtil.SoftwarePlatform)
+	logSubscriptionRetryDelay = time.Millisecond
+	request := ListDeploymentLogsRequest{DeploymentID: "test-id", Component: "scheduler", Search: "test"}
 
 	t.Run("success", func(t *testing.T) {
-		// Convert http://127.0.0.1 to ws://127.0.0.
+		starts := &startRecorder{}
+		s := httptest.NewServer(logsHandler([][]string{{
+			`{"type":"connection_ack"}`,
+			logMessage("1", "2023-06-05T10:00:00Z", "first\n"),
+			logMessage("2", "2023-06-05T10:00:01Z", "second\n"),
+			`{"id":"1","type":"complete"}`,
+		}}, starts))
+		defer s.Close()
+		url := "ws" + strings.TrimPrefix(s.URL, "http")
+
+		var logs []DeploymentLog
+		status := new(bytes.Buffer)
+		err := SubscribeDeploymentLogs("test-token", url, request, func(log DeploymentLog) error {
+			logs = append(logs, log)
+			return nil
+		}, status)
+		assert.NoError(t, err)
+		assert.Equal(t, []DeploymentLog{
+			{ID: "1", Component: "scheduler", CreatedAt: "2023-06-05T10:00:00Z", Log: "first\n"},
+			{ID: "2", Component: "scheduler", CreatedAt: "2023-06-05T10:00:01Z", Log: "second\n"},
+		}, logs)
+		assert.Contains(t, status.String(), "Waiting for logs...")
+		assert.Len(t, starts.get(), 1)
+		assert.Contains(t, starts.get()[0], "test-id")
+	})
+
+	t.Run("reconnects and resumes after the connection drops", func(t *testing.T) {
+		starts := &startRecorder{}
+		s := httptest.NewServer(logsHandler([][]string{
+			{
+				logMessage("1", "2023-06-05T10:00:00Z", "first"),
+				logMessage("2", "2023-06-05T10:00:01Z", "second"),
+			},
+			{
+				logMessage("2", "2023-06-05T10:00:01Z", "second"),
+				logMessage("3", "2023-06-05T10:00:02Z", "third"),
+				`{"id":"1","type":"complete"}`,
+			},
+		}, starts))
+		defer s.Close()
 		url := "ws" + strings.TrimPrefix(s.URL, "http")
 
-		err := Subscribe("test-token", url, `{"type": "test", "payload": {"data": {"log": {"log": "test"}}}}`)
+		var logs []string
+		status := new(bytes.Buffer)
+		err := SubscribeDeploymentLogs("test-token", url, request, func(log DeploymentLog) error {
+			logs = append(logs, log.Log)
+			return nil
+		}, status)
 		assert.NoError(t, err)
+		assert.Equal(t, []string{"first", "second", "third"}, logs)
+		assert.Contains(t, status.String(), "reconnecting 1/5")
+		assert.Len(t, starts.get(), 2)
+		assert.Contains(t, starts.get()[1], "2023-06-05T10:00:01Z")
+	})
+
+	t.Run("gives up after the retries", func(t *testing.T) {
+		starts := &startRecorder{}
+		s := httptest.NewServer(logsHandler([][]string{{}}, starts))
+		defer s.Close()
+		url := "ws" + strings.TrimPrefix(s.URL, "http")
+
+		err := SubscribeDeploymentLogs("test-token", url, request, func(log DeploymentLog) error {
+			return nil
+		}, io.Discard)
+		assert.ErrorIs(t, err, errLogSubscriptionLost)
+		assert.Len(t, starts.get(), LogSubscriptionRetries+1)
+	})
+
+	t.Run("subscription error", func(t *testing.T) {
+		starts := &startRecorder{}
+		s := httptest.NewServer(logsHandler([][]string{{`{"id":"1","type":"error","payload":{"message":"not authorized"}}`}}, starts))
+		defer s.Close()
+		url := "ws" + strings.TrimPrefix(s.URL, "http")
+
+		err := SubscribeDeploymentLogs("test-token", url, request, func(log DeploymentLog) error {
+			return nil
+		}, io.Discard)
+		assert.ErrorIs(t, err, errLogSubscription)
+		assert.Contains(t, err.Error(), "not authorized")
+		assert.Len(t, starts.get(), 1)
+	})
+
+	t.Run("authentication error", func(t *testing.T) {
+		dials := 0
+		s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			dials++
+			w.WriteHeader(http.StatusUnauthorized)
+		}))
+		defer s.Close()
+		url := "ws" + strings.TrimPrefix(s.URL, "http")
+
+		err := SubscribeDeploymentLogs("test-token", url, request, func(log DeploymentLog) error {
+			return nil
+		}, io.Discard)
+		assert.ErrorIs(t, err, errLogSubscriptionAuth)
+		assert.Equal(t, 1, dials)
+	})
+
+	t.Run("handler error", func(t *testing.T) {
+		starts := &startRecorder{}
+		s := httptest.NewServer(logsHandler([][]string{{logMessage("1", "2023-06-05T10:00:00Z", "first")}}, starts))
+		defer s.Close()
+		url := "ws" + strings.TrimPrefix(s.URL, "http")
+
+		errHandler := errors.New("handler error")
+		err := SubscribeDeploymentLogs("test-token", url, request, func(log DeploymentLog) error {
+			return errHandler
+		}, io.Discard)
+		assert.ErrorIs(t, err, errHandler)
+		assert.Len(t, starts.get(), 1)
 	})
 }
@@ -33,6 +33,15 @@ type PaginatedWorkspaceUserRolesRequest struct {
 	Take        float64 `json:"take"`
 }
 
+// PaginatedWorkspaceUsersRequest - filters to list a page of the users of a workspace
+type PaginatedWorkspaceUsersRequest struct {
+	WorkspaceID string `json:"workspaceUuid"`
+	Email       string `json:"email,omitempty"`
+	Role        string `json:"role,omitempty"`
+	CursorID    string `json:"cursorUuid,omitempty"`
+	Take        int    `json:"take"`
+}
+
 var (
 	WorkspaceUserAddRequest = `
 	mutation AddWorkspaceUser(
@@ -127,6 +136,37 @@ var (
 		}
 	}`
 
+	// WorkspaceUsersFilteredGetRequest return a page of the users of a workspace, filtered by email and role
+	WorkspaceUsersFilteredGetRequest = `
+	query paginatedWorkspaceUsers(
+		$workspaceUuid: Uuid!
+		$email: String
+		$role: Role
+		$cursorUuid: Uuid
+		$take: Int
+	){
+		paginatedWorkspaceUsers(
+			workspaceUuid: $workspaceUuid
+			email: $email
+			role: $role
+			cursor: $cursorUuid
+			take: $take
+		){
+			id
+			username
+			fullName
+			emails {
+				address
+			}
+			roleBindings {
+				workspace{
+					id
+				}
+				role
+			}
+		}
+	}`
+
 	WorkspaceUserUpdateRequest = `
 	mutation workspaceUpsertUserRole(
 		$workspaceUuid: Uuid!,
@@ -221,6 +261,21 @@ func (h ClientImplementation) ListWorkspacePaginatedUserAndRoles(request Paginat
 	return r.Data.WorkspacePaginatedGetUsers, nil
 }
 
+// PaginatedListWorkspaceUsers - list a page of the users of a workspace, filtered by email and role
+func (h ClientImplementation) PaginatedListWorkspaceUsers(request PaginatedWorkspaceUsersRequest) ([]WorkspaceUserRoleBindings, error) {
+	req := Request{
+		Query:     WorkspaceUsersFilteredGetRequest,
+		Variables: request,
+	}
+
+	r, err := req.DoWithClient(h.client)
+	if err != nil {
+		return []WorkspaceUserRoleBindings{}, handleAPIErr(err)
+	}
+
+	return r.Data.WorkspacePaginatedGetUsers, nil
+}
+
 // UpdateUserRoleInWorkspace - update a user role in a workspace
 func (h ClientImplementation) UpdateWorkspaceUserRole(request UpdateWorkspaceUserRoleRequest) (string, error) {
 	req := Request{
@@ -209,7 +209,7 @@ func TestListWorkspacePaginatedUserAndRoles(t *testing.T) {
 		})
 		api := NewClient(client)
 
-		response, err := api.ListWorkspacePaginatedUserAndRoles(PaginatedWorkspaceUserRolesRequest{"workspace-id", "cursor-id", 100})
+		response, err := api.ListWorkspacePaginatedUserAndRoles(PaginatedWorkspaceUserRolesRequest{WorkspaceID: "workspace-id", CursorID: "cursor-id", Take: 100})
 		assert.NoError(t, err)
 		assert.Equal(t, response, mockResponse.Data.WorkspacePaginatedGetUsers)
 	})
@@ -224,7 +224,54 @@ func TestListWorkspacePaginatedUserAndRoles(t *testing.T) {
 		})
 		api := NewClient(client)
 
-		_, err := api.ListWorkspacePaginatedUserAndRoles(PaginatedWorkspaceUserRolesRequest{"workspace-id", "cursor-id", 100})
+		_, err := api.ListWorkspacePaginatedUserAndRoles(PaginatedWorkspaceUserRolesRequest{WorkspaceID: "workspace-id", CursorID: "cursor-id", Take: 100})
+		assert.Contains(t, err.Error(), "Internal Server Error")
+	})
+}
+
+func TestPaginatedListWorkspaceUsers(t *testing.T) {
+	testUtil.InitTestConfig("software")
+
+	mockResponse := &Response{
+		Data: ResponseData{
+			WorkspacePaginatedGetUsers: []WorkspaceUserRoleBindings{
+				{ID: "user-id", Username: "test@astronomer.com", RoleBindings: []RoleBinding{{Role: WorkspaceAdminRole}}},
+			},
+		},
+	}
+	jsonResponse, err := json.Marshal(mockResponse)
+	assert.NoError(t, err)
+
+	t.Run("success", func(t *testing.T) {
+		client := testUtil.NewTestClient(func(req *http.Request) *http.Response {
+			body, err := io.ReadAll(req.Body)
+			assert.NoError(t, err)
+			assert.Contains(t, string(body), `"role":"WORKSPACE_ADMIN"`)
+			assert.NotContains(t, string(body), `"email"`)
+			return &http.Response{
+				StatusCode: 200,
+				Body:       io.NopCloser(bytes.NewBuffer(jsonResponse)),
+				Header:     make(http.Header),
+			}
+		})
+		api := NewClient(client)
+
+		response, err := api.PaginatedListWorkspaceUsers(PaginatedWorkspaceUsersRequest{WorkspaceID: "workspace-id", Role: WorkspaceAdminRole, Take: 10})
+		assert.NoError(t, err)
+		assert.Equal(t, response, mockResponse.Data.WorkspacePaginatedGetUsers)
+	})
+
+	t.Run("error", func(t *testing.T) {
+		client := testUtil.NewTestClient(func(req *http.Request) *http.Response {
+			return &http.Response{
+				StatusCode: 500,
+				Body:       io.NopCloser(bytes.NewBufferString("Internal Server Error")),
+				Header:     make(http.Header),
+			}
+		})
+		api := NewClient(client)
+
+		_, err := api.PaginatedListWorkspaceUsers(PaginatedWorkspaceUsersRequest{WorkspaceID: "workspace-id", Take: 10})
 		assert.Contains(t, err.Error(), "Internal Server Error")
 	})
 }
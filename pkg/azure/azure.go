@@ -3,8 +3,11 @@ package azure
 import (
 	"context"
 	"io"
+	"net/http"
 
 	"github.com/Azure/azure-sdk-for-go/sdk/storage/azblob"
+
+	"github.com/astronomer/astro-cli/pkg/httputil"
 )
 
 var azureUploader = Upload
@@ -18,7 +21,11 @@ func azureUpload(sasLink string, dagFileReader io.Reader) (string, error) {
 }
 
 func Upload(sasLink string, dagFileReader io.Reader) (string, error) {
-	blobClient, err := azblob.NewBlockBlobClientWithNoCredential(sasLink, nil)
+	// go through the shared transport, so that the configured proxy and CA bundle apply to uploads too
+	options := &azblob.ClientOptions{
+		Transport: &http.Client{Transport: httputil.NewTraceTransport(httputil.SharedTransport())},
+	}
+	blobClient, err := azblob.NewBlockBlobClientWithNoCredential(sasLink, options)
 	if err != nil {
 		return "", err
 	}
@@ -0,0 +1,85 @@
+package fileutil
+
+import (
+	"errors"
+	"fmt"
+	"os"
+)
+
+const rotatePermissions = 0o644
+
+var errNoRotatedFiles = errors.New("at least one rotated file must be kept, otherwise the rotation would discard what was written")
+
+// RotatingFile is a file writer which moves the file to <path>.1 once it reaches a maximum size, <path>.1 to
+// <path>.2 and so on, keeping at most a given number of rotated files
+type RotatingFile struct {
+	path     string
+	maxSize  int64
+	maxFiles int
+	file     *os.File
+	size     int64
+}
+
+// NewRotatingFile creates or truncates the file at path. With maxSize 0 the file is never rotated, otherwise maxFiles
+// has to be at least 1.
+func NewRotatingFile(path string, maxSize int64, maxFiles int) (*RotatingFile, error) {
+	if maxSize > 0 && maxFiles < 1 {
+		return nil, errNoRotatedFiles
+	}
+	r := &RotatingFile{path: path, maxSize: maxSize, maxFiles: maxFiles}
+	if err := r.open(); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// Write writes p to the file, rotating it first when p would make it larger than the maximum size. Writes are never
+// split between files.
+func (r *RotatingFile) Write(p []byte) (int, error) {
+	if r.maxSize > 0 && r.size > 0 && r.size+int64(len(p)) > r.maxSize {
+		if err := r.rotate(); err != nil {
+			return 0, err
+		}
+	}
+	n, err := r.file.Write(p)
+	r.size += int64(n)
+	return n, err
+}
+
+// Close closes the current file
+func (r *RotatingFile) Close() error {
+	return r.file.Close()
+}
+
+func (r *RotatingFile) open() error {
+	file, err := os.OpenFile(r.path, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, rotatePermissions)
+	if err != nil {
+		return err
+	}
+	r.file = file
+	r.size = 0
+	return nil
+}
+
+func (r *RotatingFile) rotate() error {
+	if err := r.file.Close(); err != nil {
+		return err
+	}
+	// drop the oldest file and shift the others up
+	if err := os.Remove(rotatedName(r.path, r.maxFiles)); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	for i := r.maxFiles - 1; i > 0; i-- {
+		if err := os.Rename(rotatedName(r.path, i), rotatedName(r.path, i+1)); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+	}
+	if err := os.Rename(r.path, rotatedName(r.path, 1)); err != nil {
+		return err
+	}
+	return r.open()
+}
+
+func rotatedName(path string, index int) string {
+	return fmt.Sprintf("%s.%d", path, index)
+}
@@ -0,0 +1,62 @@
+package fileutil
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRotatingFile(t *testing.T) {
+	t.Run("rotates and keeps max files", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "test.log")
+		file, err := NewRotatingFile(path, 10, 2)
+		assert.NoError(t, err)
+
+		for _, line := range []string{"first\n", "second\n", "third\n", "fourth\n"} {
+			_, err = file.Write([]byte(line))
+			assert.NoError(t, err)
+		}
+		assert.NoError(t, file.Close())
+
+		for name, expected := range map[string]string{path: "fourth\n", path + ".1": "third\n", path + ".2": "second\n"} {
+			content, err := os.ReadFile(name)
+			assert.NoError(t, err)
+			assert.Equal(t, expected, string(content))
+		}
+		_, err = os.Stat(path + ".3")
+		assert.True(t, os.IsNotExist(err))
+	})
+
+	t.Run("never rotates without max size", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "test.log")
+		file, err := NewRotatingFile(path, 0, 2)
+		assert.NoError(t, err)
+
+		for _, line := range []string{"first\n", "second\n"} {
+			_, err = file.Write([]byte(line))
+			assert.NoError(t, err)
+		}
+		assert.NoError(t, file.Close())
+
+		content, err := os.ReadFile(path)
+		assert.NoError(t, err)
+		assert.Equal(t, "first\nsecond\n", string(content))
+		_, err = os.Stat(path + ".1")
+		assert.True(t, os.IsNotExist(err))
+	})
+
+	t.Run("no rotated files to keep", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "test.log")
+		_, err := NewRotatingFile(path, 10, 0)
+		assert.ErrorIs(t, err, errNoRotatedFiles)
+		_, err = os.Stat(path)
+		assert.True(t, os.IsNotExist(err))
+	})
+
+	t.Run("invalid path", func(t *testing.T) {
+		_, err := NewRotatingFile(filepath.Join(t.TempDir(), "missing", "test.log"), 10, 2)
+		assert.Error(t, err)
+	})
+}
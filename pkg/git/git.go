@@ -2,6 +2,7 @@ package git
 
 import (
 	"os/exec"
+	"strings"
 )
 
 // IsGitRepository checks if current directory is a git repository
@@ -23,3 +24,21 @@ func HasUncommittedChanges() bool {
 
 	return false
 }
+
+// GetCommitSHA returns the short SHA of the commit checked out in the current directory
+func GetCommitSHA() (string, error) {
+	out, err := exec.Command("git", "rev-parse", "--short", "HEAD").Output()
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(string(out)), nil
+}
+
+// GetTag returns the tag of the commit checked out in the current directory
+func GetTag() (string, error) {
+	out, err := exec.Command("git", "describe", "--tags", "--exact-match", "HEAD").Output()
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(string(out)), nil
+}
@@ -22,3 +22,13 @@ func TestIsGitRepository(t *testing.T) {
 		})
 	}
 }
+
+func TestGetCommitSHA(t *testing.T) {
+	sha, err := GetCommitSHA()
+	if err != nil {
+		t.Fatalf("GetCommitSHA() error = %v", err)
+	}
+	if sha == "" {
+		t.Errorf("GetCommitSHA() returned an empty SHA")
+	}
+}
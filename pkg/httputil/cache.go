@@ -0,0 +1,249 @@
+package httputil
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+)
+
+const cacheFileExt = ".json"
+
+var cacheDirPerm os.FileMode = 0o700
+
+// cachedSensitiveKeyRegex matches the keys of the response fields that are never written to the cache, on top of the
+// keys redacted from traces, as they carry environment variable values
+var cachedSensitiveKeyRegex = regexp.MustCompile(`(?i)(environment_?variables|env_?vars|deployment_?variables|is_?secret)`)
+
+type graphQLQueryKey struct{}
+
+// GraphQLQueryContext marks a request as a side-effect free GraphQL query. Such requests are sent as POST
+// but are cached like GET requests, keyed on their body, unless the response carries GraphQL errors.
+func GraphQLQueryContext(ctx context.Context) context.Context {
+	return context.WithValue(ctx, graphQLQueryKey{}, true)
+}
+
+func isGraphQLQuery(req *http.Request) bool {
+	marked, _ := req.Context().Value(graphQLQueryKey{}).(bool)
+	return marked && req.Method == http.MethodPost
+}
+
+// CacheTransport is an http.RoundTripper that keeps successful GET responses on disk for a fixed TTL.
+// Any successful non-GET request clears the cache, so that a command never reads back stale data
+// it has just modified itself. Responses carrying secrets, like tokens and environment variables, are
+// never stored, and requests sent with a Cache-Control: no-cache header always reach the server.
+type CacheTransport struct {
+	Base http.RoundTripper
+	Dir  string
+	TTL  time.Duration
+}
+
+type cacheEntry struct {
+	StoredAt   time.Time   `json:"stored_at"`
+	StatusCode int         `json:"status_code"`
+	Header     http.Header `json:"header"`
+	Body       []byte      `json:"body"`
+}
+
+// NewCacheTransport returns a CacheTransport storing responses in dir for ttl
+func NewCacheTransport(base http.RoundTripper, dir string, ttl time.Duration) *CacheTransport {
+	return &CacheTransport{
+		Base: base,
+		Dir:  dir,
+		TTL:  ttl,
+	}
+}
+
+// RoundTrip implements http.RoundTripper
+func (t *CacheTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	base := t.Base
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	if t.Dir == "" || t.TTL <= 0 {
+		return base.RoundTrip(req)
+	}
+
+	graphQL := isGraphQLQuery(req)
+	if req.Method != http.MethodGet && !graphQL {
+		resp, err := base.RoundTrip(req)
+		if err == nil && req.Method != http.MethodHead && resp.StatusCode < http.StatusBadRequest {
+			t.Purge()
+		}
+		return resp, err
+	}
+
+	if req.Header.Get("Range") != "" || strings.Contains(req.Header.Get("Cache-Control"), "no-cache") {
+		return base.RoundTrip(req)
+	}
+
+	key, err := cacheKey(req)
+	if err != nil {
+		return base.RoundTrip(req)
+	}
+	path := filepath.Join(t.Dir, key+cacheFileExt)
+	if resp, ok := t.load(path, req); ok {
+		return resp, nil
+	}
+
+	resp, err := base.RoundTrip(req)
+	if err != nil || resp.StatusCode != http.StatusOK {
+		return resp, err
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+	if graphQL && hasGraphQLErrors(body) {
+		return resp, nil
+	}
+	if hasSensitiveData(body) {
+		return resp, nil
+	}
+	t.store(path, &cacheEntry{
+		StoredAt:   time.Now(),
+		StatusCode: resp.StatusCode,
+		Header:     resp.Header,
+		Body:       body,
+	})
+	return resp, nil
+}
+
+// Purge removes every cached response
+func (t *CacheTransport) Purge() {
+	files, err := filepath.Glob(filepath.Join(t.Dir, "*"+cacheFileExt))
+	if err != nil {
+		return
+	}
+	for _, file := range files {
+		os.Remove(file)
+	}
+}
+
+func (t *CacheTransport) load(path string, req *http.Request) (*http.Response, bool) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, false
+	}
+	var entry cacheEntry
+	if err := json.Unmarshal(data, &entry); err != nil {
+		return nil, false
+	}
+	if time.Since(entry.StoredAt) > t.TTL {
+		os.Remove(path)
+		return nil, false
+	}
+	return &http.Response{
+		Status:        http.StatusText(entry.StatusCode),
+		StatusCode:    entry.StatusCode,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        entry.Header,
+		Body:          io.NopCloser(bytes.NewReader(entry.Body)),
+		ContentLength: int64(len(entry.Body)),
+		Request:       req,
+	}, true
+}
+
+// store writes the entry through a temporary file (created with 0600 permissions) so that
+// concurrent invocations never read a partial entry
+func (t *CacheTransport) store(path string, entry *cacheEntry) {
+	if err := os.MkdirAll(t.Dir, cacheDirPerm); err != nil {
+		return
+	}
+	tmp, err := os.CreateTemp(t.Dir, "entry-*.tmp")
+	if err != nil {
+		return
+	}
+	defer os.Remove(tmp.Name())
+
+	w := bufio.NewWriter(tmp)
+	err = json.NewEncoder(w).Encode(entry)
+	if err == nil {
+		err = w.Flush()
+	}
+	if closeErr := tmp.Close(); err != nil || closeErr != nil {
+		return
+	}
+	_ = os.Rename(tmp.Name(), path)
+}
+
+// cacheKey identifies a request by its method, URL, headers and body, so that responses are never
+// shared between different tokens, organizations or queries
+func cacheKey(req *http.Request) (string, error) {
+	h := sha256.New()
+	h.Write([]byte(req.Method + " " + req.URL.String()))
+	keys := make([]string, 0, len(req.Header))
+	for k := range req.Header {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		h.Write([]byte{0})
+		h.Write([]byte(k + ":" + strings.Join(req.Header[k], ",")))
+	}
+	if req.GetBody != nil {
+		body, err := req.GetBody()
+		if err != nil {
+			return "", err
+		}
+		defer body.Close()
+		h.Write([]byte{0})
+		if _, err := io.Copy(h, body); err != nil {
+			return "", err
+		}
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+func hasGraphQLErrors(body []byte) bool {
+	var decode struct {
+		Errors json.RawMessage `json:"errors"`
+	}
+	if err := json.Unmarshal(body, &decode); err != nil {
+		return true
+	}
+	return len(decode.Errors) > 0 && string(decode.Errors) != "null"
+}
+
+// hasSensitiveData returns true if the JSON body has a field holding a secret. Bodies that can't be parsed are
+// considered sensitive.
+func hasSensitiveData(body []byte) bool {
+	var doc interface{}
+	if err := json.Unmarshal(body, &doc); err != nil {
+		return true
+	}
+	return hasSensitiveKey(doc)
+}
+
+func hasSensitiveKey(doc interface{}) bool {
+	switch v := doc.(type) {
+	case map[string]interface{}:
+		for key, value := range v {
+			if sensitiveKeyRegex.MatchString(key) || cachedSensitiveKeyRegex.MatchString(key) || hasSensitiveKey(value) {
+				return true
+			}
+		}
+	case []interface{}:
+		for i := range v {
+			if hasSensitiveKey(v[i]) {
+				return true
+			}
+		}
+	}
+	return false
+}
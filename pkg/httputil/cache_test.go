@@ -0,0 +1,141 @@
+package httputil
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func readBody(t *testing.T, resp *http.Response) string {
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	assert.NoError(t, err)
+	return string(body)
+}
+
+func TestCacheTransport(t *testing.T) {
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		body, _ := io.ReadAll(r.Body)
+		if string(body) == "errors" {
+			w.Write([]byte(`{"errors":[{"message":"boom"}]}`))
+			return
+		}
+		w.Write([]byte(`{"data":"` + r.Header.Get("authorization") + `"}`))
+	}))
+	defer server.Close()
+
+	client := NewHTTPClient()
+	client.EnableCache(t.TempDir(), time.Minute)
+
+	get := func(token string) string {
+		resp, err := client.Do(&DoOptions{Method: http.MethodGet, Path: server.URL, Headers: map[string]string{"authorization": token}})
+		assert.NoError(t, err)
+		return readBody(t, resp)
+	}
+
+	t.Run("GET responses are cached per token", func(t *testing.T) {
+		atomic.StoreInt32(&calls, 0)
+		assert.Equal(t, `{"data":"a"}`, get("a"))
+		assert.Equal(t, `{"data":"a"}`, get("a"))
+		assert.Equal(t, `{"data":"b"}`, get("b"))
+		assert.Equal(t, int32(2), atomic.LoadInt32(&calls))
+	})
+
+	t.Run("mutations purge the cache", func(t *testing.T) {
+		atomic.StoreInt32(&calls, 0)
+		resp, err := client.Do(&DoOptions{Method: http.MethodPost, Path: server.URL, Data: []byte("{}")})
+		assert.NoError(t, err)
+		readBody(t, resp)
+		get("a")
+		assert.Equal(t, int32(2), atomic.LoadInt32(&calls))
+	})
+
+	t.Run("GraphQL queries are cached unless they return errors", func(t *testing.T) {
+		atomic.StoreInt32(&calls, 0)
+		ctx := GraphQLQueryContext(context.Background())
+		for i := 0; i < 2; i++ {
+			resp, err := client.Do(&DoOptions{Method: http.MethodPost, Path: server.URL, Data: []byte("query"), Context: ctx})
+			assert.NoError(t, err)
+			readBody(t, resp)
+			resp, err = client.Do(&DoOptions{Method: http.MethodPost, Path: server.URL, Data: []byte("errors"), Context: ctx})
+			assert.NoError(t, err)
+			readBody(t, resp)
+		}
+		assert.Equal(t, int32(3), atomic.LoadInt32(&calls))
+	})
+}
+
+func TestCacheTransportSkipsSecretsAndNoCache(t *testing.T) {
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		switch r.URL.Path {
+		case "/token":
+			w.Write([]byte(`{"data":{"createToken":{"token":"secret"}}}`))
+		case "/variables":
+			w.Write([]byte(`{"data":{"environmentVariables":[{"key":"AWS_SECRET","value":"secret"}]}}`))
+		default:
+			w.Write([]byte(`{"data":"ok"}`))
+		}
+	}))
+	defer server.Close()
+
+	client := NewHTTPClient()
+	client.EnableCache(t.TempDir(), time.Minute)
+	for _, test := range []struct {
+		path    string
+		headers map[string]string
+	}{
+		{path: "/token"},
+		{path: "/variables"},
+		{path: "/status", headers: map[string]string{"Cache-Control": "no-cache"}},
+	} {
+		atomic.StoreInt32(&calls, 0)
+		for i := 0; i < 2; i++ {
+			resp, err := client.Do(&DoOptions{Method: http.MethodGet, Path: server.URL + test.path, Headers: test.headers})
+			assert.NoError(t, err)
+			readBody(t, resp)
+		}
+		assert.Equal(t, int32(2), atomic.LoadInt32(&calls), test.path)
+	}
+}
+
+func TestCacheTransportExpiry(t *testing.T) {
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		w.Write([]byte(`{"data":"ok"}`))
+	}))
+	defer server.Close()
+
+	client := NewHTTPClient()
+	client.EnableCache(t.TempDir(), time.Nanosecond)
+	for i := 0; i < 2; i++ {
+		resp, err := client.Do(&DoOptions{Method: http.MethodGet, Path: server.URL})
+		assert.NoError(t, err)
+		assert.Equal(t, `{"data":"ok"}`, readBody(t, resp))
+		time.Sleep(time.Millisecond)
+	}
+	assert.Equal(t, int32(2), atomic.LoadInt32(&calls))
+}
+
+func TestEnableCacheDisabled(t *testing.T) {
+	client := NewHTTPClient()
+	client.EnableCache(t.TempDir(), 0)
+	_, ok := client.HTTPClient.Transport.(*CacheTransport)
+	assert.False(t, ok)
+
+	client.EnableCache(t.TempDir(), time.Minute)
+	client.SetMaxRetries(1)
+	retry, ok := client.retryTransport()
+	assert.True(t, ok)
+	assert.Equal(t, 1, retry.MaxRetries)
+}
@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"time"
 
 	"github.com/sirupsen/logrus"
 
@@ -31,23 +32,57 @@ type HTTPResponse struct {
 
 // DoOptions are options passed to the HTTPClient.Do function
 type DoOptions struct {
-	Data    []byte
+	Data []byte
+	// Body is streamed as the request body instead of Data, requests with a Body are not retried
+	Body    io.Reader
 	Context context.Context
 	Headers map[string]string
 	Method  string
 	Path    string
 }
 
-// NewHTTPClient returns a new HTTP Client
+// NewHTTPClient returns a new HTTP Client using the shared transport, which retries transient failures
+// and reports requests to the installed Tracer
 func NewHTTPClient() *HTTPClient {
 	return &HTTPClient{
-		HTTPClient: &http.Client{},
+		HTTPClient: &http.Client{
+			Transport: NewRetryTransport(NewTraceTransport(SharedTransport()), DefaultMaxRetries),
+		},
+	}
+}
+
+// SetMaxRetries changes the number of retries for transient failures, 0 disables retries
+func (c *HTTPClient) SetMaxRetries(maxRetries int) {
+	if retry, ok := c.retryTransport(); ok {
+		retry.MaxRetries = maxRetries
+		return
+	}
+	c.HTTPClient.Transport = NewRetryTransport(c.HTTPClient.Transport, maxRetries)
+}
+
+// EnableCache caches successful GET responses in dir for ttl, a non-positive ttl leaves the client untouched
+func (c *HTTPClient) EnableCache(dir string, ttl time.Duration) {
+	if dir == "" || ttl <= 0 {
+		return
+	}
+	if _, ok := c.HTTPClient.Transport.(*CacheTransport); ok {
+		return
+	}
+	c.HTTPClient.Transport = NewCacheTransport(c.HTTPClient.Transport, dir, ttl)
+}
+
+func (c *HTTPClient) retryTransport() (*RetryTransport, bool) {
+	transport := c.HTTPClient.Transport
+	if cache, ok := transport.(*CacheTransport); ok {
+		transport = cache.Base
 	}
+	retry, ok := transport.(*RetryTransport)
+	return retry, ok
 }
 
 // Do executes the given HTTP request and returns the HTTP Response
 func (c *HTTPClient) Do(doOptions *DoOptions) (*http.Response, error) {
-	var body io.Reader
+	body := doOptions.Body
 	if len(doOptions.Data) > 0 {
 		body = bytes.NewBuffer(doOptions.Data)
 	}
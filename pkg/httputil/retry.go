@@ -0,0 +1,171 @@
+package httputil
+
+import (
+	"crypto/tls"
+	"errors"
+	"io"
+	"math/rand"
+	"net"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+const (
+	// DefaultMaxRetries is the number of times a failed request is retried before giving up
+	DefaultMaxRetries = 3
+	// DefaultRetryBaseDelay is the delay before the first retry, doubled for every subsequent attempt
+	DefaultRetryBaseDelay = 500 * time.Millisecond
+	// DefaultRetryMaxDelay caps the delay between two attempts, including delays requested through Retry-After
+	DefaultRetryMaxDelay = 30 * time.Second
+)
+
+// RetryTransport is an http.RoundTripper that retries requests which failed with a transient error.
+// GET requests and GraphQL queries are retried on network errors, 429 and 5xx responses. Other requests,
+// like GraphQL mutations and uploads, may have been applied even when they failed, so they are only retried
+// on 429 and 503 responses carrying a Retry-After header.
+type RetryTransport struct {
+	Base       http.RoundTripper
+	MaxRetries int
+	BaseDelay  time.Duration
+	MaxDelay   time.Duration
+}
+
+// NewRetryTransport returns a RetryTransport wrapping base with the default backoff settings
+func NewRetryTransport(base http.RoundTripper, maxRetries int) *RetryTransport {
+	return &RetryTransport{
+		Base:       base,
+		MaxRetries: maxRetries,
+		BaseDelay:  DefaultRetryBaseDelay,
+		MaxDelay:   DefaultRetryMaxDelay,
+	}
+}
+
+// RoundTrip implements http.RoundTripper
+func (t *RetryTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	base := t.Base
+	if base == nil {
+		base = http.DefaultTransport
+	}
+
+	for attempt := 0; ; attempt++ {
+		resp, err := base.RoundTrip(req)
+		if attempt >= t.MaxRetries || !t.shouldRetry(req, resp, err) {
+			return resp, err
+		}
+
+		// the request body has been consumed by the previous attempt, rewind it if we can
+		if req.Body != nil && req.Body != http.NoBody {
+			if req.GetBody == nil {
+				return resp, err
+			}
+			body, bodyErr := req.GetBody()
+			if bodyErr != nil {
+				return resp, err
+			}
+			req = req.Clone(req.Context())
+			req.Body = body
+		}
+
+		delay := t.backoff(attempt, resp)
+		if resp != nil {
+			// drain the body so the underlying connection can be reused
+			_, _ = io.Copy(io.Discard, resp.Body)
+			resp.Body.Close()
+		}
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-req.Context().Done():
+			timer.Stop()
+			return nil, req.Context().Err()
+		case <-timer.C:
+		}
+	}
+}
+
+func (t *RetryTransport) shouldRetry(req *http.Request, resp *http.Response, err error) bool {
+	if req.Context().Err() != nil {
+		return false
+	}
+	safe := isSafeMethod(req.Method) || isGraphQLQuery(req)
+	if err != nil {
+		return safe && !isPermanentNetworkError(err)
+	}
+	if safe {
+		return resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= http.StatusInternalServerError
+	}
+	switch resp.StatusCode {
+	case http.StatusTooManyRequests, http.StatusServiceUnavailable:
+		return resp.Header.Get("Retry-After") != ""
+	}
+	return false
+}
+
+// backoff returns the delay before the next attempt, honouring the Retry-After header when the server sent one
+func (t *RetryTransport) backoff(attempt int, resp *http.Response) time.Duration {
+	maxDelay := t.MaxDelay
+	if maxDelay <= 0 {
+		maxDelay = DefaultRetryMaxDelay
+	}
+	if resp != nil {
+		if delay, ok := parseRetryAfter(resp.Header.Get("Retry-After")); ok {
+			if delay > maxDelay {
+				return maxDelay
+			}
+			return delay
+		}
+	}
+	delay := t.BaseDelay << attempt
+	if delay <= 0 || delay > maxDelay {
+		delay = maxDelay
+	}
+	// add up to 10% jitter so that parallel CLI invocations do not retry in lockstep
+	if jitter := int64(delay / 10); jitter > 0 {
+		delay += time.Duration(rand.Int63n(jitter)) //nolint:gosec
+	}
+	return delay
+}
+
+// parseRetryAfter parses a Retry-After header value, which is either a number of seconds or an HTTP date
+func parseRetryAfter(value string) (time.Duration, bool) {
+	if value == "" {
+		return 0, false
+	}
+	if seconds, err := strconv.Atoi(value); err == nil {
+		if seconds < 0 {
+			return 0, false
+		}
+		return time.Duration(seconds) * time.Second, true
+	}
+	if date, err := http.ParseTime(value); err == nil {
+		delay := time.Until(date)
+		if delay < 0 {
+			delay = 0
+		}
+		return delay, true
+	}
+	return 0, false
+}
+
+// isPermanentNetworkError reports errors that will not go away by retrying, like an unknown host when offline
+func isPermanentNetworkError(err error) bool {
+	if errors.Is(err, ErrTransportConfig) {
+		return true
+	}
+	var dnsErr *net.DNSError
+	if errors.As(err, &dnsErr) && dnsErr.IsNotFound {
+		return true
+	}
+	var certErr *tls.CertificateVerificationError
+	return errors.As(err, &certErr)
+}
+
+// isSafeMethod returns true for the methods that never change the state of the server
+func isSafeMethod(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions:
+		return true
+	}
+	return false
+}
@@ -0,0 +1,188 @@
+package httputil
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestRetryClient(maxRetries int) *HTTPClient {
+	client := NewHTTPClient()
+	client.SetMaxRetries(maxRetries)
+	retry, _ := client.retryTransport()
+	retry.BaseDelay = time.Millisecond
+	return client
+}
+
+func TestRetryTransportRetriesTransientErrors(t *testing.T) {
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		assert.Equal(t, "payload", string(body))
+		if atomic.AddInt32(&calls, 1) < 3 {
+			w.WriteHeader(http.StatusBadGateway)
+			return
+		}
+		w.Write([]byte("ok"))
+	}))
+	defer server.Close()
+
+	client := newTestRetryClient(3)
+	resp, err := client.Do(&DoOptions{Method: http.MethodPost, Path: server.URL, Data: []byte("payload"), Context: GraphQLQueryContext(context.Background())})
+	assert.NoError(t, err)
+	defer resp.Body.Close()
+	body, _ := io.ReadAll(resp.Body)
+	assert.Equal(t, "ok", string(body))
+	assert.Equal(t, int32(3), atomic.LoadInt32(&calls))
+}
+
+func TestRetryTransportGivesUp(t *testing.T) {
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		w.WriteHeader(http.StatusServiceUnavailable)
+		w.Write([]byte("unavailable"))
+	}))
+	defer server.Close()
+
+	client := newTestRetryClient(2)
+	_, err := client.Do(&DoOptions{Method: http.MethodGet, Path: server.URL})
+	assert.EqualError(t, err, "API error (503): unavailable")
+	assert.Equal(t, int32(3), atomic.LoadInt32(&calls))
+}
+
+func TestRetryTransportDoesNotRetryNonIdempotentServerErrors(t *testing.T) {
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	client := newTestRetryClient(3)
+	_, err := client.Do(&DoOptions{Method: http.MethodPost, Path: server.URL, Data: []byte("{}")})
+	assert.Error(t, err)
+	assert.Equal(t, int32(1), atomic.LoadInt32(&calls))
+
+	_, err = client.Do(&DoOptions{Method: http.MethodGet, Path: server.URL})
+	assert.Error(t, err)
+	assert.Equal(t, int32(5), atomic.LoadInt32(&calls))
+}
+
+func TestRetryTransportMutations(t *testing.T) {
+	var calls int32
+	var retryAfter string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&calls, 1) > 1 {
+			w.Write([]byte("ok"))
+			return
+		}
+		if retryAfter != "" {
+			w.Header().Set("Retry-After", retryAfter)
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer server.Close()
+	client := newTestRetryClient(3)
+
+	t.Run("gateway errors are not retried", func(t *testing.T) {
+		atomic.StoreInt32(&calls, 0)
+		_, err := client.Do(&DoOptions{Method: http.MethodPost, Path: server.URL, Data: []byte("mutation")})
+		assert.Error(t, err)
+		assert.Equal(t, int32(1), atomic.LoadInt32(&calls))
+	})
+
+	t.Run("503 with Retry-After is retried", func(t *testing.T) {
+		atomic.StoreInt32(&calls, 0)
+		retryAfter = "0"
+		resp, err := client.Do(&DoOptions{Method: http.MethodPost, Path: server.URL, Data: []byte("mutation")})
+		assert.NoError(t, err)
+		resp.Body.Close()
+		assert.Equal(t, int32(2), atomic.LoadInt32(&calls))
+	})
+}
+
+func TestRetryTransportNoRetryOnClientErrors(t *testing.T) {
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer server.Close()
+
+	client := newTestRetryClient(3)
+	_, err := client.Do(&DoOptions{Method: http.MethodGet, Path: server.URL})
+	assert.Error(t, err)
+	assert.Equal(t, int32(1), atomic.LoadInt32(&calls))
+}
+
+func TestRetryTransportBackoff(t *testing.T) {
+	transport := &RetryTransport{BaseDelay: time.Second, MaxDelay: 5 * time.Second}
+
+	t.Run("exponential", func(t *testing.T) {
+		delay := transport.backoff(1, nil)
+		assert.GreaterOrEqual(t, delay, 2*time.Second)
+		assert.Less(t, delay, 2200*time.Millisecond)
+	})
+
+	t.Run("capped", func(t *testing.T) {
+		delay := transport.backoff(10, nil)
+		assert.GreaterOrEqual(t, delay, 5*time.Second)
+		assert.Less(t, delay, 5500*time.Millisecond)
+	})
+
+	t.Run("retry-after seconds", func(t *testing.T) {
+		resp := &http.Response{Header: http.Header{"Retry-After": []string{"2"}}, Body: io.NopCloser(&bytes.Buffer{})}
+		assert.Equal(t, 2*time.Second, transport.backoff(0, resp))
+	})
+
+	t.Run("retry-after capped", func(t *testing.T) {
+		resp := &http.Response{Header: http.Header{"Retry-After": []string{"120"}}, Body: io.NopCloser(&bytes.Buffer{})}
+		assert.Equal(t, 5*time.Second, transport.backoff(0, resp))
+	})
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	delay, ok := parseRetryAfter("3")
+	assert.True(t, ok)
+	assert.Equal(t, 3*time.Second, delay)
+
+	delay, ok = parseRetryAfter(time.Now().Add(-time.Minute).UTC().Format(http.TimeFormat))
+	assert.True(t, ok)
+	assert.Equal(t, time.Duration(0), delay)
+
+	_, ok = parseRetryAfter("")
+	assert.False(t, ok)
+
+	_, ok = parseRetryAfter("soon")
+	assert.False(t, ok)
+}
+
+func TestRetryTransportUnknownHost(t *testing.T) {
+	var calls int32
+	transport := NewRetryTransport(roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		atomic.AddInt32(&calls, 1)
+		return nil, &net.DNSError{Err: "no such host", Name: req.URL.Host, IsNotFound: true}
+	}), 3)
+	client := &HTTPClient{HTTPClient: &http.Client{Transport: transport}}
+
+	_, err := client.Do(&DoOptions{Method: http.MethodGet, Path: "https://unknown.invalid"})
+	assert.Error(t, err)
+	assert.Equal(t, int32(1), atomic.LoadInt32(&calls))
+}
+
+type roundTripFunc func(req *http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
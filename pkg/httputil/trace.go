@@ -0,0 +1,504 @@
+package httputil
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	redactedValue = "REDACTED"
+	// maxTracedBodySize is the number of body bytes kept in a trace, longer bodies are truncated
+	maxTracedBodySize = 64 * 1024
+)
+
+var (
+	sensitiveKeyRegex = regexp.MustCompile(`(?i)(token|password|secret|authorization|api_?key|cookie|credential|signature)`)
+	// secretFlagRegex matches the keys flagging the value of their object as a secret, e.g. {"value": "...", "isSecret": true}
+	secretFlagRegex = regexp.MustCompile(`(?i)^is_?secret$`)
+	secretValueKey  = "value"
+
+	tracerMu sync.RWMutex
+	tracer   Tracer
+)
+
+// Tracer receives a TraceEntry for every HTTP request sent through a TraceTransport
+type Tracer interface {
+	Trace(entry *TraceEntry)
+}
+
+// TraceEntry describes a single HTTP exchange, with sensitive headers and body fields already redacted
+type TraceEntry struct {
+	StartedAt      time.Time
+	Duration       time.Duration
+	Method         string
+	URL            string
+	Proto          string
+	RequestHeader  http.Header
+	RequestBody    string
+	StatusCode     int
+	Status         string
+	ResponseHeader http.Header
+	ResponseBody   string
+	Err            error
+}
+
+// SetTracer installs the tracer used by every TraceTransport, nil disables tracing
+func SetTracer(t Tracer) {
+	tracerMu.Lock()
+	defer tracerMu.Unlock()
+	tracer = t
+}
+
+// CloseTracer flushes and closes the installed tracer, when it buffers the exchanges it receives
+func CloseTracer() error {
+	if c, ok := currentTracer().(io.Closer); ok {
+		return c.Close()
+	}
+	return nil
+}
+
+func currentTracer() Tracer {
+	tracerMu.RLock()
+	defer tracerMu.RUnlock()
+	return tracer
+}
+
+// TraceTransport is an http.RoundTripper reporting every request to the tracer installed through SetTracer
+type TraceTransport struct {
+	Base http.RoundTripper
+}
+
+// NewTraceTransport returns a TraceTransport wrapping base
+func NewTraceTransport(base http.RoundTripper) *TraceTransport {
+	return &TraceTransport{Base: base}
+}
+
+// RoundTrip implements http.RoundTripper
+func (t *TraceTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	base := t.Base
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	tr := currentTracer()
+	if tr == nil {
+		return base.RoundTrip(req)
+	}
+
+	entry := &TraceEntry{
+		StartedAt:     time.Now(),
+		Method:        req.Method,
+		URL:           redactURL(req.URL),
+		Proto:         req.Proto,
+		RequestHeader: redactHeader(req.Header),
+	}
+	if req.GetBody != nil {
+		if body, err := req.GetBody(); err == nil {
+			data, _ := io.ReadAll(io.LimitReader(body, maxTracedBodySize+1))
+			body.Close()
+			entry.RequestBody = redactBody(data, req.Header.Get("Content-Type"))
+		}
+	}
+
+	resp, err := base.RoundTrip(req)
+	entry.Duration = time.Since(entry.StartedAt)
+	if err != nil {
+		entry.Err = err
+		tr.Trace(entry)
+		return resp, err
+	}
+
+	entry.StatusCode = resp.StatusCode
+	entry.Status = resp.Status
+	entry.ResponseHeader = redactHeader(resp.Header)
+	// only buffer the head of the body, so streamed responses are not held in memory
+	data, readErr := io.ReadAll(io.LimitReader(resp.Body, maxTracedBodySize+1))
+	resp.Body = readCloser{Reader: io.MultiReader(bytes.NewReader(data), resp.Body), Closer: resp.Body}
+	if readErr == nil {
+		entry.ResponseBody = redactBody(data, resp.Header.Get("Content-Type"))
+	}
+	tr.Trace(entry)
+	return resp, nil
+}
+
+type readCloser struct {
+	io.Reader
+	io.Closer
+}
+
+func redactHeader(header http.Header) http.Header {
+	redacted := make(http.Header, len(header))
+	for k, v := range header {
+		if sensitiveKeyRegex.MatchString(k) {
+			redacted[k] = []string{redactedValue}
+			continue
+		}
+		redacted[k] = v
+	}
+	return redacted
+}
+
+func redactURL(u *url.URL) string {
+	redacted := *u
+	if redacted.User != nil {
+		redacted.User = url.User(redactedValue)
+	}
+	query := redacted.Query()
+	for k := range query {
+		if sensitiveKeyRegex.MatchString(k) {
+			query.Set(k, redactedValue)
+		}
+	}
+	if len(query) > 0 {
+		redacted.RawQuery = query.Encode()
+	}
+	return redacted.String()
+}
+
+func redactBody(data []byte, contentType string) string {
+	if !isTextContent(contentType) {
+		return fmt.Sprintf("<%d bytes of %s>", len(data), contentType)
+	}
+	truncated := len(data) > maxTracedBodySize
+	if truncated {
+		data = data[:maxTracedBodySize]
+	}
+	var body string
+	switch {
+	case truncated:
+		// a partial document can't be parsed, so only keep it when it is plain text
+		if strings.Contains(contentType, "json") || strings.Contains(contentType, "form") {
+			body = fmt.Sprintf("<%d bytes not shown>", len(data))
+		} else {
+			body = string(data)
+		}
+	case strings.Contains(contentType, "application/x-www-form-urlencoded"):
+		values, err := url.ParseQuery(string(data))
+		if err != nil {
+			return "<unparseable form body>"
+		}
+		for k := range values {
+			if sensitiveKeyRegex.MatchString(k) {
+				values.Set(k, redactedValue)
+			}
+		}
+		body = values.Encode()
+	default:
+		var doc interface{}
+		if err := json.Unmarshal(data, &doc); err != nil {
+			body = string(data)
+			break
+		}
+		redacted, err := json.Marshal(redactJSON(doc, false))
+		if err != nil {
+			body = string(data)
+			break
+		}
+		body = string(redacted)
+	}
+	if truncated {
+		body += " [truncated]"
+	}
+	return body
+}
+
+func isTextContent(contentType string) bool {
+	if contentType == "" {
+		return true
+	}
+	for _, text := range []string{"json", "text", "form", "xml", "graphql"} {
+		if strings.Contains(contentType, text) {
+			return true
+		}
+	}
+	return false
+}
+
+// redactJSON replaces every string stored under a sensitive key, including strings nested in objects
+// stored under such a key, e.g. {"token": {"value": "..."}}, and the value of objects flagged as secret,
+// e.g. an environment variable {"key": "...", "value": "...", "isSecret": true}
+func redactJSON(doc interface{}, sensitive bool) interface{} {
+	switch v := doc.(type) {
+	case map[string]interface{}:
+		secret := isFlaggedSecret(v)
+		for key, value := range v {
+			v[key] = redactJSON(value, sensitive || sensitiveKeyRegex.MatchString(key) || (secret && strings.EqualFold(key, secretValueKey)))
+		}
+		return v
+	case []interface{}:
+		for i := range v {
+			v[i] = redactJSON(v[i], sensitive)
+		}
+		return v
+	case string:
+		if sensitive {
+			return redactedValue
+		}
+		return v
+	default:
+		return v
+	}
+}
+
+// isFlaggedSecret returns whether an object has a secret flag set, as a boolean or a string
+func isFlaggedSecret(object map[string]interface{}) bool {
+	for key, value := range object {
+		if !secretFlagRegex.MatchString(key) {
+			continue
+		}
+		switch flag := value.(type) {
+		case bool:
+			return flag
+		case string:
+			return strings.EqualFold(flag, "true")
+		}
+	}
+	return false
+}
+
+// TextTracer writes a human readable dump of every exchange, in the style of curl -v
+type TextTracer struct {
+	mu  sync.Mutex
+	out io.Writer
+}
+
+// NewTextTracer returns a TextTracer writing to out
+func NewTextTracer(out io.Writer) *TextTracer {
+	return &TextTracer{out: out}
+}
+
+// Trace implements Tracer
+func (t *TextTracer) Trace(entry *TraceEntry) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "> %s %s\n", entry.Method, entry.URL)
+	writeHeader(&b, "> ", entry.RequestHeader)
+	if entry.RequestBody != "" {
+		fmt.Fprintf(&b, ">\n> %s\n", entry.RequestBody)
+	}
+	if entry.Err != nil {
+		fmt.Fprintf(&b, "< error after %s: %s\n\n", entry.Duration.Round(time.Millisecond), entry.Err.Error())
+		fmt.Fprint(t.out, b.String())
+		return
+	}
+	fmt.Fprintf(&b, "< %s (%s)\n", entry.Status, entry.Duration.Round(time.Millisecond))
+	writeHeader(&b, "< ", entry.ResponseHeader)
+	if entry.ResponseBody != "" {
+		fmt.Fprintf(&b, "<\n< %s\n", entry.ResponseBody)
+	}
+	b.WriteString("\n")
+	fmt.Fprint(t.out, b.String())
+}
+
+func writeHeader(b *strings.Builder, prefix string, header http.Header) {
+	keys := make([]string, 0, len(header))
+	for k := range header {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		fmt.Fprintf(b, "%s%s: %s\n", prefix, k, strings.Join(header[k], ", "))
+	}
+}
+
+// HARTracer records every exchange in a HTTP Archive (HAR 1.2) file. The exchanges are buffered and the
+// file is written once, when the tracer is closed.
+type HARTracer struct {
+	mu      sync.Mutex
+	path    string
+	creator harCreator
+	entries []harEntry
+}
+
+type harLog struct {
+	Log struct {
+		Version string     `json:"version"`
+		Creator harCreator `json:"creator"`
+		Entries []harEntry `json:"entries"`
+	} `json:"log"`
+}
+
+type harCreator struct {
+	Name    string `json:"name"`
+	Version string `json:"version"`
+}
+
+type harEntry struct {
+	StartedDateTime string      `json:"startedDateTime"`
+	Time            float64     `json:"time"`
+	Request         harRequest  `json:"request"`
+	Response        harResponse `json:"response"`
+	Cache           struct{}    `json:"cache"`
+	Timings         harTimings  `json:"timings"`
+	Comment         string      `json:"comment,omitempty"`
+}
+
+type harRequest struct {
+	Method      string         `json:"method"`
+	URL         string         `json:"url"`
+	HTTPVersion string         `json:"httpVersion"`
+	Headers     []harNameValue `json:"headers"`
+	QueryString []harNameValue `json:"queryString"`
+	Cookies     []harNameValue `json:"cookies"`
+	PostData    *harPostData   `json:"postData,omitempty"`
+	HeadersSize int            `json:"headersSize"`
+	BodySize    int            `json:"bodySize"`
+}
+
+type harResponse struct {
+	Status      int            `json:"status"`
+	StatusText  string         `json:"statusText"`
+	HTTPVersion string         `json:"httpVersion"`
+	Headers     []harNameValue `json:"headers"`
+	Cookies     []harNameValue `json:"cookies"`
+	Content     harContent     `json:"content"`
+	RedirectURL string         `json:"redirectURL"`
+	HeadersSize int            `json:"headersSize"`
+	BodySize    int            `json:"bodySize"`
+}
+
+type harNameValue struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+type harPostData struct {
+	MimeType string `json:"mimeType"`
+	Text     string `json:"text"`
+}
+
+type harContent struct {
+	Size     int    `json:"size"`
+	MimeType string `json:"mimeType"`
+	Text     string `json:"text"`
+}
+
+type harTimings struct {
+	Send    float64 `json:"send"`
+	Wait    float64 `json:"wait"`
+	Receive float64 `json:"receive"`
+}
+
+// NewHARTracer returns a HARTracer writing to path, creatorVersion is recorded as the astro CLI version
+func NewHARTracer(path, creatorVersion string) *HARTracer {
+	return &HARTracer{
+		path:    path,
+		creator: harCreator{Name: "astro-cli", Version: creatorVersion},
+	}
+}
+
+// Trace implements Tracer
+func (t *HARTracer) Trace(entry *TraceEntry) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	ms := float64(entry.Duration) / float64(time.Millisecond)
+	e := harEntry{
+		StartedDateTime: entry.StartedAt.Format(time.RFC3339Nano),
+		Time:            ms,
+		Request: harRequest{
+			Method:      entry.Method,
+			URL:         entry.URL,
+			HTTPVersion: protoOrDefault(entry.Proto),
+			Headers:     harHeaders(entry.RequestHeader),
+			QueryString: harQuery(entry.URL),
+			Cookies:     []harNameValue{},
+			HeadersSize: -1,
+			BodySize:    len(entry.RequestBody),
+		},
+		Response: harResponse{
+			Status:      entry.StatusCode,
+			StatusText:  http.StatusText(entry.StatusCode),
+			HTTPVersion: protoOrDefault(entry.Proto),
+			Headers:     harHeaders(entry.ResponseHeader),
+			Cookies:     []harNameValue{},
+			Content: harContent{
+				Size:     len(entry.ResponseBody),
+				MimeType: entry.ResponseHeader.Get("Content-Type"),
+				Text:     entry.ResponseBody,
+			},
+			HeadersSize: -1,
+			BodySize:    len(entry.ResponseBody),
+		},
+		Timings: harTimings{Send: 0, Wait: ms, Receive: 0},
+	}
+	if entry.RequestBody != "" {
+		e.Request.PostData = &harPostData{MimeType: entry.RequestHeader.Get("Content-Type"), Text: entry.RequestBody}
+	}
+	if entry.Err != nil {
+		e.Comment = entry.Err.Error()
+	}
+	t.entries = append(t.entries, e)
+}
+
+// Close writes the recorded exchanges to the HAR file
+func (t *HARTracer) Close() error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.entries == nil {
+		t.entries = []harEntry{}
+	}
+	var har harLog
+	har.Log.Version = "1.2"
+	har.Log.Creator = t.creator
+	har.Log.Entries = t.entries
+	data, err := json.MarshalIndent(har, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(t.path, data, 0o600) //nolint:gomnd
+}
+
+func protoOrDefault(proto string) string {
+	if proto == "" {
+		return "HTTP/1.1"
+	}
+	return proto
+}
+
+func harHeaders(header http.Header) []harNameValue {
+	values := []harNameValue{}
+	keys := make([]string, 0, len(header))
+	for k := range header {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		for _, v := range header[k] {
+			values = append(values, harNameValue{Name: k, Value: v})
+		}
+	}
+	return values
+}
+
+func harQuery(rawURL string) []harNameValue {
+	values := []harNameValue{}
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return values
+	}
+	query := u.Query()
+	keys := make([]string, 0, len(query))
+	for k := range query {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		for _, v := range query[k] {
+			values = append(values, harNameValue{Name: k, Value: v})
+		}
+	}
+	return values
+}
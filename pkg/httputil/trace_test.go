@@ -0,0 +1,148 @@
+package httputil
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type recordingTracer struct {
+	entries []*TraceEntry
+}
+
+func (r *recordingTracer) Trace(entry *TraceEntry) {
+	r.entries = append(r.entries, entry)
+}
+
+func TestTraceTransport(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Set-Cookie", "session=abc")
+		w.Write([]byte(`{"data":{"createToken":{"token":{"value":"secret-token"}},"id":"ck123"}}`))
+	}))
+	defer server.Close()
+
+	recorder := &recordingTracer{}
+	SetTracer(recorder)
+	defer SetTracer(nil)
+
+	client := NewHTTPClient()
+	resp, err := client.Do(&DoOptions{
+		Method:  http.MethodPost,
+		Path:    server.URL + "?api_key=123&page=2",
+		Data:    []byte(`{"variables":{"password":"hunter2","identity":"me@example.com"}}`),
+		Headers: map[string]string{"authorization": "Bearer abc"},
+	})
+	assert.NoError(t, err)
+	body, _ := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	assert.Contains(t, string(body), "secret-token", "the caller still receives the unredacted body")
+
+	assert.Len(t, recorder.entries, 1)
+	entry := recorder.entries[0]
+	assert.Equal(t, http.MethodPost, entry.Method)
+	assert.Contains(t, entry.URL, "api_key=REDACTED")
+	assert.Contains(t, entry.URL, "page=2")
+	assert.Equal(t, "REDACTED", entry.RequestHeader.Get("Authorization"))
+	assert.JSONEq(t, `{"variables":{"password":"REDACTED","identity":"me@example.com"}}`, entry.RequestBody)
+	assert.Equal(t, http.StatusOK, entry.StatusCode)
+	assert.Equal(t, "REDACTED", entry.ResponseHeader.Get("Set-Cookie"))
+	assert.JSONEq(t, `{"data":{"createToken":{"token":{"value":"REDACTED"}},"id":"ck123"}}`, entry.ResponseBody)
+}
+
+func TestTraceTransportDisabled(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("ok"))
+	}))
+	defer server.Close()
+
+	SetTracer(nil)
+	client := NewHTTPClient()
+	resp, err := client.Do(&DoOptions{Method: http.MethodGet, Path: server.URL})
+	assert.NoError(t, err)
+	resp.Body.Close()
+}
+
+func TestRedactBody(t *testing.T) {
+	assert.Equal(t, "grant_type=password&password=REDACTED", redactBody([]byte("grant_type=password&password=x"), "application/x-www-form-urlencoded"))
+	assert.Equal(t, "plain text", redactBody([]byte("plain text"), "text/plain"))
+	assert.Equal(t, "<3 bytes of application/gzip>", redactBody([]byte{1, 2, 3}, "application/gzip"))
+
+	large := bytes.Repeat([]byte("a"), maxTracedBodySize+1)
+	assert.Equal(t, "<65536 bytes not shown> [truncated]", redactBody(large, "application/json"))
+}
+
+func TestRedactSecretResponses(t *testing.T) {
+	envVars := `{"data":{"updateDeploymentVariables":[{"key":"A","value":"secret-a","isSecret":true},{"key":"B","value":"plain-b","isSecret":false}]}}`
+	assert.Equal(t, `{"data":{"updateDeploymentVariables":[{"isSecret":true,"key":"A","value":"REDACTED"},{"isSecret":false,"key":"B","value":"plain-b"}]}}`,
+		redactBody([]byte(envVars), "application/json"))
+
+	serviceAccount := `{"data":{"createDeploymentServiceAccount":{"id":"sa-id","apiKey":"new-api-key"}}}`
+	assert.Equal(t, `{"data":{"createDeploymentServiceAccount":{"apiKey":"REDACTED","id":"sa-id"}}}`, redactBody([]byte(serviceAccount), "application/json"))
+
+	rotatedToken := `{"id":"token-id","name":"ci","token":"new-token"}`
+	assert.Equal(t, `{"id":"token-id","name":"ci","token":"REDACTED"}`, redactBody([]byte(rotatedToken), "application/json"))
+
+	apiKeyValue := `{"apiKey":{"id":"key-id","value":"new-key"}}`
+	assert.Equal(t, `{"apiKey":{"id":"REDACTED","value":"REDACTED"}}`, redactBody([]byte(apiKeyValue), "application/json"))
+}
+
+func TestTextTracer(t *testing.T) {
+	out := new(bytes.Buffer)
+	tracer := NewTextTracer(out)
+	tracer.Trace(&TraceEntry{
+		Method:         http.MethodGet,
+		URL:            "https://api.astronomer.io/v1/organizations",
+		RequestHeader:  http.Header{"Authorization": []string{"REDACTED"}},
+		StatusCode:     http.StatusOK,
+		Status:         "200 OK",
+		ResponseHeader: http.Header{"Content-Type": []string{"application/json"}},
+		ResponseBody:   `{"organizations":[]}`,
+	})
+	assert.Equal(t, `> GET https://api.astronomer.io/v1/organizations
+> Authorization: REDACTED
+< 200 OK (0s)
+< Content-Type: application/json
+<
+< {"organizations":[]}
+
+`, out.String())
+}
+
+func TestHARTracer(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "trace.har")
+	tracer := NewHARTracer(path, "1.2.3")
+	tracer.Trace(&TraceEntry{
+		Method:         http.MethodPost,
+		URL:            "https://houston.example.com/v1?a=b",
+		RequestHeader:  http.Header{"Content-Type": []string{"application/json"}},
+		RequestBody:    `{"query":"{ appConfig }"}`,
+		StatusCode:     http.StatusBadGateway,
+		ResponseHeader: http.Header{},
+	})
+	// the exchanges are only written once the tracer is closed
+	_, err := os.Stat(path)
+	assert.True(t, os.IsNotExist(err))
+	assert.NoError(t, tracer.Close())
+
+	data, err := os.ReadFile(path)
+	assert.NoError(t, err)
+	var har harLog
+	assert.NoError(t, json.Unmarshal(data, &har))
+	assert.Equal(t, "1.2", har.Log.Version)
+	assert.Equal(t, harCreator{Name: "astro-cli", Version: "1.2.3"}, har.Log.Creator)
+	assert.Len(t, har.Log.Entries, 1)
+	entry := har.Log.Entries[0]
+	assert.Equal(t, "https://houston.example.com/v1?a=b", entry.Request.URL)
+	assert.Equal(t, []harNameValue{{Name: "a", Value: "b"}}, entry.Request.QueryString)
+	assert.Equal(t, `{"query":"{ appConfig }"}`, entry.Request.PostData.Text)
+	assert.Equal(t, http.StatusBadGateway, entry.Response.Status)
+	assert.Equal(t, "Bad Gateway", entry.Response.StatusText)
+}
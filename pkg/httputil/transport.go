@@ -0,0 +1,183 @@
+package httputil
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/net/http/httpproxy"
+)
+
+var (
+	// ErrTransportConfig is returned by requests sent through a transport whose options are invalid
+	ErrTransportConfig = errors.New("invalid network configuration")
+
+	errClientCertWithoutKey = errors.New("a client certificate and a client key must be configured together")
+	errNoCertificatesFound  = errors.New("no PEM certificates found")
+	errInvalidProxyURL      = errors.New("a proxy URL needs an http, https or socks5 scheme and a host, like http://proxy.example.com:3128")
+
+	proxySchemes = map[string]bool{"http": true, "https": true, "socks5": true}
+
+	transportMu      sync.RWMutex
+	transportOptions TransportOptions
+	sharedTransport  http.RoundTripper = http.DefaultTransport
+	sharedErr        error
+)
+
+// TransportOptions are the network settings applied to every outgoing connection
+type TransportOptions struct {
+	// CABundle is the path to a PEM file with certificates trusted in addition to the system roots
+	CABundle string
+	// ClientCert and ClientKey are paths to a PEM certificate and key presented for mutual TLS
+	ClientCert string
+	ClientKey  string
+	// ProxyURL and NoProxy override the HTTPS_PROXY and NO_PROXY environment variables when set
+	ProxyURL string
+	NoProxy  string
+	// InsecureSkipVerify disables certificate verification altogether
+	InsecureSkipVerify bool
+	// DialTimeout limits the time spent on connecting and on the TLS handshake, 0 keeps the defaults
+	DialTimeout time.Duration
+}
+
+// TLSConfig returns the TLS configuration described by the options
+func (o TransportOptions) TLSConfig() (*tls.Config, error) {
+	tlsConfig := &tls.Config{InsecureSkipVerify: o.InsecureSkipVerify} //nolint:gosec
+	if o.CABundle != "" {
+		pool, err := x509.SystemCertPool()
+		if err != nil || pool == nil {
+			pool = x509.NewCertPool()
+		}
+		pem, err := os.ReadFile(o.CABundle)
+		if err != nil {
+			return nil, fmt.Errorf("error reading CA bundle %s: %w", o.CABundle, err)
+		}
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("error reading CA bundle %s: %w", o.CABundle, errNoCertificatesFound)
+		}
+		tlsConfig.RootCAs = pool
+	}
+	if o.ClientCert != "" || o.ClientKey != "" {
+		if o.ClientCert == "" || o.ClientKey == "" {
+			return nil, errClientCertWithoutKey
+		}
+		cert, err := tls.LoadX509KeyPair(o.ClientCert, o.ClientKey)
+		if err != nil {
+			return nil, fmt.Errorf("error loading client certificate %s: %w", o.ClientCert, err)
+		}
+		tlsConfig.Certificates = []tls.Certificate{cert}
+	}
+	return tlsConfig, nil
+}
+
+// Proxy returns the function choosing the proxy of a request, in the format of http.Transport.Proxy
+func (o TransportOptions) Proxy() (func(*http.Request) (*url.URL, error), error) {
+	if o.ProxyURL == "" {
+		if o.NoProxy == "" {
+			return http.ProxyFromEnvironment, nil
+		}
+		env := httpproxy.FromEnvironment()
+		env.NoProxy = o.NoProxy
+		proxyFunc := env.ProxyFunc()
+		return func(req *http.Request) (*url.URL, error) { return proxyFunc(req.URL) }, nil
+	}
+	if u, err := url.Parse(o.ProxyURL); err != nil || !proxySchemes[u.Scheme] || u.Hostname() == "" {
+		return nil, fmt.Errorf("invalid proxy URL %s: %w", o.ProxyURL, errInvalidProxyURL)
+	}
+	proxyFunc := (&httpproxy.Config{
+		HTTPProxy:  o.ProxyURL,
+		HTTPSProxy: o.ProxyURL,
+		NoProxy:    o.NoProxy,
+	}).ProxyFunc()
+	return func(req *http.Request) (*url.URL, error) { return proxyFunc(req.URL) }, nil
+}
+
+// NewTransport returns an http.Transport, based on http.DefaultTransport, with the options applied
+func NewTransport(o TransportOptions) (*http.Transport, error) {
+	tlsConfig, err := o.TLSConfig()
+	if err != nil {
+		return nil, err
+	}
+	proxy, err := o.Proxy()
+	if err != nil {
+		return nil, err
+	}
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.TLSClientConfig = tlsConfig
+	transport.Proxy = proxy
+	if o.DialTimeout > 0 {
+		transport.DialContext = (&net.Dialer{Timeout: o.DialTimeout, KeepAlive: 30 * time.Second}).DialContext //nolint:gomnd
+		transport.TLSHandshakeTimeout = o.DialTimeout
+	}
+	return transport, nil
+}
+
+// SetTransportOptions configures the transport shared by every client created through NewHTTPClient.
+// An invalid configuration is returned, and also reported by every request until it is fixed.
+func SetTransportOptions(o TransportOptions) error {
+	transport, err := NewTransport(o)
+
+	transportMu.Lock()
+	defer transportMu.Unlock()
+	transportOptions = o
+	sharedErr = err
+	if err == nil {
+		sharedTransport = transport
+	}
+	return err
+}
+
+// CurrentTransportOptions returns the options last passed to SetTransportOptions
+func CurrentTransportOptions() TransportOptions {
+	transportMu.RLock()
+	defer transportMu.RUnlock()
+	return transportOptions
+}
+
+// SharedTransport returns an http.RoundTripper sending requests through the transport configured with
+// SetTransportOptions, including when the options change after the RoundTripper was created
+func SharedTransport() http.RoundTripper {
+	return sharedRoundTripper{}
+}
+
+type sharedRoundTripper struct{}
+
+func (sharedRoundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	transportMu.RLock()
+	transport, err := sharedTransport, sharedErr
+	transportMu.RUnlock()
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrTransportConfig, err.Error())
+	}
+	return transport.RoundTrip(req)
+}
+
+// ErrorTransport is an http.RoundTripper failing every request, used when a transport could not be configured
+type ErrorTransport struct {
+	Err error
+}
+
+// RoundTrip implements http.RoundTripper
+func (t ErrorTransport) RoundTrip(*http.Request) (*http.Response, error) {
+	return nil, fmt.Errorf("%w: %s", ErrTransportConfig, t.Err.Error())
+}
+
+// IsCertificateError reports whether err was caused by a certificate which could not be verified,
+// typically because an intercepting proxy or a private CA is in use
+func IsCertificateError(err error) bool {
+	var certErr *tls.CertificateVerificationError
+	var unknownAuthority x509.UnknownAuthorityError
+	if errors.As(err, &certErr) || errors.As(err, &unknownAuthority) {
+		return true
+	}
+	// errors coming from the Docker daemon are only available as text
+	return strings.Contains(err.Error(), "x509: ")
+}
@@ -0,0 +1,100 @@
+package httputil
+
+import (
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func writeCABundle(t *testing.T, server *httptest.Server) string {
+	path := filepath.Join(t.TempDir(), "ca.pem")
+	data := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw})
+	assert.NoError(t, os.WriteFile(path, data, 0o600))
+	return path
+}
+
+func TestNewTransportCABundle(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("ok"))
+	}))
+	defer server.Close()
+
+	t.Run("untrusted without the bundle", func(t *testing.T) {
+		transport, err := NewTransport(TransportOptions{})
+		assert.NoError(t, err)
+		client := &http.Client{Transport: transport}
+		_, err = client.Get(server.URL) //nolint:noctx
+		assert.Error(t, err)
+		assert.True(t, IsCertificateError(err))
+	})
+
+	t.Run("trusted with the bundle", func(t *testing.T) {
+		transport, err := NewTransport(TransportOptions{CABundle: writeCABundle(t, server)})
+		assert.NoError(t, err)
+		client := &http.Client{Transport: transport}
+		resp, err := client.Get(server.URL) //nolint:noctx
+		assert.NoError(t, err)
+		resp.Body.Close()
+	})
+}
+
+func TestTransportOptionsErrors(t *testing.T) {
+	_, err := NewTransport(TransportOptions{CABundle: filepath.Join(t.TempDir(), "missing.pem")})
+	assert.ErrorContains(t, err, "error reading CA bundle")
+
+	empty := filepath.Join(t.TempDir(), "empty.pem")
+	assert.NoError(t, os.WriteFile(empty, []byte("not a certificate"), 0o600))
+	_, err = NewTransport(TransportOptions{CABundle: empty})
+	assert.ErrorIs(t, err, errNoCertificatesFound)
+
+	_, err = NewTransport(TransportOptions{ClientCert: "cert.pem"})
+	assert.ErrorIs(t, err, errClientCertWithoutKey)
+}
+
+func TestTransportOptionsProxy(t *testing.T) {
+	proxy, err := TransportOptions{ProxyURL: "http://proxy.example.com:3128", NoProxy: "internal.example.com"}.Proxy()
+	assert.NoError(t, err)
+
+	req, _ := http.NewRequest(http.MethodGet, "https://api.astronomer.io/v1", http.NoBody) //nolint:noctx
+	proxyURL, err := proxy(req)
+	assert.NoError(t, err)
+	assert.Equal(t, "http://proxy.example.com:3128", proxyURL.String())
+
+	req, _ = http.NewRequest(http.MethodGet, "https://internal.example.com/v1", http.NoBody) //nolint:noctx
+	proxyURL, err = proxy(req)
+	assert.NoError(t, err)
+	assert.Nil(t, proxyURL)
+
+	for _, invalid := range []string{"proxy.example.com:3128", "proxy", "ftp://proxy.example.com", "http://", "http://:3128"} {
+		_, err = TransportOptions{ProxyURL: invalid}.Proxy()
+		assert.ErrorIs(t, err, errInvalidProxyURL, invalid)
+	}
+}
+
+func TestSetTransportOptions(t *testing.T) {
+	defer SetTransportOptions(TransportOptions{}) //nolint:errcheck
+
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("ok"))
+	}))
+	defer server.Close()
+	client := NewHTTPClient()
+
+	caBundle := writeCABundle(t, server)
+	err := SetTransportOptions(TransportOptions{CABundle: caBundle})
+	assert.NoError(t, err)
+	assert.Equal(t, caBundle, CurrentTransportOptions().CABundle)
+	resp, err := client.Do(&DoOptions{Method: http.MethodGet, Path: server.URL})
+	assert.NoError(t, err)
+	resp.Body.Close()
+
+	err = SetTransportOptions(TransportOptions{ClientKey: "key.pem"})
+	assert.ErrorIs(t, err, errClientCertWithoutKey)
+	_, err = client.Do(&DoOptions{Method: http.MethodGet, Path: server.URL})
+	assert.ErrorIs(t, err, ErrTransportConfig)
+}
@@ -0,0 +1,284 @@
+package printutil
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"regexp"
+	"strings"
+	"text/template"
+	"unicode"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Output formats supported by Table
+const (
+	TableFormat    = "table"
+	JSONFormat     = "json"
+	YAMLFormat     = "yaml"
+	CSVFormat      = "csv"
+	TemplateFormat = "template"
+)
+
+var (
+	errInvalidOutputFormat     = errors.New("invalid output format, expected one of: table, json, yaml, csv or template='<go template>'")
+	errUnsupportedOutputFormat = errors.New("invalid output format")
+
+	outputFormat = OutputFormat{Name: TableFormat}
+
+	// messagesOut receives the messages printed along with tables in structured output
+	messagesOut io.Writer = os.Stderr
+
+	ansiEscape = regexp.MustCompile("\x1b\\[[0-9;]*m")
+
+	// initialisms are kept upper case in field names, like Go identifiers
+	initialisms = map[string]bool{
+		"API": true, "AWS": true, "CD": true, "CI": true, "CPU": true, "DAG": true, "DAGS": true, "GCP": true,
+		"ID": true, "IDS": true, "IP": true, "SSO": true, "UI": true, "URL": true, "UUID": true,
+	}
+)
+
+// OutputFormat describes how tables are rendered
+type OutputFormat struct {
+	Name     string
+	Template *template.Template
+	value    string
+}
+
+// ParseOutputFormat parses an output format, one of table, json, yaml, csv or template=<go template>
+func ParseOutputFormat(value string) (OutputFormat, error) {
+	name, tmpl, hasTemplate := strings.Cut(value, "=")
+	name = strings.ToLower(strings.TrimSpace(name))
+	switch {
+	case name == TemplateFormat && hasTemplate:
+		t, err := template.New(TemplateFormat).Option("missingkey=zero").Parse(tmpl)
+		if err != nil {
+			return OutputFormat{}, fmt.Errorf("invalid output template: %w", err)
+		}
+		return OutputFormat{Name: TemplateFormat, Template: t, value: value}, nil
+	case hasTemplate:
+		return OutputFormat{}, errInvalidOutputFormat
+	case name == "" || name == TableFormat:
+		return OutputFormat{Name: TableFormat, value: TableFormat}, nil
+	case name == JSONFormat || name == YAMLFormat || name == CSVFormat:
+		return OutputFormat{Name: name, value: name}, nil
+	}
+	return OutputFormat{}, errInvalidOutputFormat
+}
+
+// SetOutputFormat sets the format used by every table printed afterwards
+func SetOutputFormat(f OutputFormat) {
+	if f.Name == "" {
+		f.Name = TableFormat
+	}
+	outputFormat = f
+}
+
+// CurrentOutputFormat returns the format set with SetOutputFormat
+func CurrentOutputFormat() OutputFormat {
+	return outputFormat
+}
+
+// IsStructuredOutput reports whether tables are printed in a machine readable format, in which case commands
+// should not print anything else on stdout
+func IsStructuredOutput() bool {
+	return outputFormat.Name != TableFormat
+}
+
+// MessageOut returns the writer for messages printed along with tables, like "No Deployments found" or the hint to
+// fetch the next page. In structured output they go to stderr, so that stdout can be parsed.
+func MessageOut(out io.Writer) io.Writer {
+	if IsStructuredOutput() {
+		return messagesOut
+	}
+	return out
+}
+
+// DocumentFormat returns the output format of commands printing a document instead of tables, like deployment
+// inspect. It is defaultFormat unless --output was set, in which case it must be one of supported.
+func DocumentFormat(defaultFormat string, supported ...string) (string, error) {
+	if outputFormat.value == "" {
+		return defaultFormat, nil
+	}
+	for _, name := range supported {
+		if outputFormat.Name == name && outputFormat.Template == nil {
+			return name, nil
+		}
+	}
+	return "", fmt.Errorf("%w %s, use one of: %s", errUnsupportedOutputFormat, outputFormat.value, strings.Join(supported, " or "))
+}
+
+// OutputFlag is a pflag.Value setting the output format of every table. Commands printing a document instead of tables
+// redefine the flag with the Default they use, see DocumentFormat.
+type OutputFlag struct {
+	Default string
+}
+
+// String implements pflag.Value
+func (f OutputFlag) String() string {
+	if outputFormat.value == "" {
+		if f.Default != "" {
+			return f.Default
+		}
+		return TableFormat
+	}
+	return outputFormat.value
+}
+
+// Set implements pflag.Value
+func (OutputFlag) Set(value string) error {
+	f, err := ParseOutputFormat(value)
+	if err != nil {
+		return err
+	}
+	SetOutputFormat(f)
+	return nil
+}
+
+// Type implements pflag.Value
+func (OutputFlag) Type() string {
+	return "format"
+}
+
+// FieldName converts a column header to the field name used in structured output, e.g. "DEPLOYMENT ID" becomes "DeploymentID"
+func FieldName(header string) string {
+	words := strings.FieldsFunc(header, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	var b strings.Builder
+	for _, word := range words {
+		upper := strings.ToUpper(word)
+		if initialisms[upper] {
+			if upper == "IDS" || upper == "DAGS" {
+				upper = upper[:len(upper)-1] + "s"
+			}
+			b.WriteString(upper)
+			continue
+		}
+		b.WriteString(upper[:1] + strings.ToLower(word[1:]))
+	}
+	return b.String()
+}
+
+// fieldNames returns the field names of the table columns
+func (t *Table) fieldNames() []string {
+	names := make([]string, len(t.Header))
+	for i, header := range t.Header {
+		if i < len(t.FieldNames) && t.FieldNames[i] != "" {
+			names[i] = t.FieldNames[i]
+		} else {
+			names[i] = FieldName(header)
+		}
+	}
+	return names
+}
+
+// records returns the rows of the table as a list of values matching fieldNames, without any color codes
+func (t *Table) records() [][]string {
+	records := make([][]string, 0, len(t.Rows))
+	for _, row := range t.Rows {
+		record := make([]string, len(t.Header))
+		for i := range record {
+			if i < len(row.Raw) {
+				record[i] = ansiEscape.ReplaceAllString(row.Raw[i], "")
+			}
+		}
+		records = append(records, record)
+	}
+	return records
+}
+
+// printStructured prints the table rows in a machine readable format
+func (t *Table) printStructured(f OutputFormat, out io.Writer) error {
+	names := t.fieldNames()
+	records := t.records()
+	switch f.Name {
+	case JSONFormat:
+		return printJSON(names, records, out)
+	case YAMLFormat:
+		return printYAML(names, records, out)
+	case CSVFormat:
+		w := csv.NewWriter(out)
+		if err := w.Write(names); err != nil {
+			return err
+		}
+		if err := w.WriteAll(records); err != nil {
+			return err
+		}
+		return w.Error()
+	case TemplateFormat:
+		for _, record := range records {
+			fields := make(map[string]string, len(names))
+			for i, name := range names {
+				fields[name] = record[i]
+			}
+			if err := f.Template.Execute(out, fields); err != nil {
+				return err
+			}
+			fmt.Fprintln(out)
+		}
+		return nil
+	}
+	return errInvalidOutputFormat
+}
+
+// printJSON prints the records as a list of objects, keeping the fields in the column order
+func printJSON(names []string, records [][]string, out io.Writer) error {
+	var buf bytes.Buffer
+	buf.WriteByte('[')
+	for i, record := range records {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		buf.WriteByte('{')
+		for j, name := range names {
+			if j > 0 {
+				buf.WriteByte(',')
+			}
+			key, _ := json.Marshal(name)
+			value, _ := json.Marshal(record[j])
+			buf.Write(key)
+			buf.WriteByte(':')
+			buf.Write(value)
+		}
+		buf.WriteByte('}')
+	}
+	buf.WriteByte(']')
+
+	var indented bytes.Buffer
+	if err := json.Indent(&indented, buf.Bytes(), "", "    "); err != nil {
+		return err
+	}
+	indented.WriteByte('\n')
+	_, err := indented.WriteTo(out)
+	return err
+}
+
+// printYAML prints the records as a list of mappings, keeping the fields in the column order
+func printYAML(names []string, records [][]string, out io.Writer) error {
+	list := &yaml.Node{Kind: yaml.SequenceNode}
+	for _, record := range records {
+		mapping := &yaml.Node{Kind: yaml.MappingNode}
+		for i, name := range names {
+			mapping.Content = append(mapping.Content,
+				&yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: name},
+				&yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: record[i]},
+			)
+		}
+		list.Content = append(list.Content, mapping)
+	}
+	if len(list.Content) == 0 {
+		list.Style = yaml.FlowStyle
+	}
+	enc := yaml.NewEncoder(out)
+	enc.SetIndent(2) //nolint:gomnd
+	if err := enc.Encode(list); err != nil {
+		return err
+	}
+	return enc.Close()
+}
@@ -0,0 +1,185 @@
+package printutil
+
+import (
+	"bytes"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func newFormatTestTable() *Table {
+	tab := &Table{
+		Padding:      []int{10, 10, 10},
+		Header:       []string{"NAME", "DEPLOYMENT ID", "CI-CD ENFORCEMENT"},
+		NoResultsMsg: "no rows present",
+		SuccessMsg:   "done",
+	}
+	tab.AddRow([]string{"first", "id-1", "true"}, false)
+	tab.AddRow([]string{"\033[1msecond\033[0m", "id-2"}, true)
+	return tab
+}
+
+func useOutputFormat(t *testing.T, value string) {
+	t.Helper()
+	f, err := ParseOutputFormat(value)
+	assert.NoError(t, err)
+	SetOutputFormat(f)
+	t.Cleanup(func() { SetOutputFormat(OutputFormat{}) })
+}
+
+func useMessagesOut(t *testing.T) *bytes.Buffer {
+	t.Helper()
+	buf := new(bytes.Buffer)
+	messagesOut = buf
+	t.Cleanup(func() { messagesOut = os.Stderr })
+	return buf
+}
+
+func TestParseOutputFormat(t *testing.T) {
+	for _, value := range []string{"", "table", "JSON", "yaml", "csv"} {
+		_, err := ParseOutputFormat(value)
+		assert.NoError(t, err, value)
+	}
+
+	f, err := ParseOutputFormat("template={{.ID}}")
+	assert.NoError(t, err)
+	assert.Equal(t, TemplateFormat, f.Name)
+	assert.NotNil(t, f.Template)
+
+	_, err = ParseOutputFormat("xml")
+	assert.ErrorIs(t, err, errInvalidOutputFormat)
+	_, err = ParseOutputFormat("json={{.ID}}")
+	assert.ErrorIs(t, err, errInvalidOutputFormat)
+	_, err = ParseOutputFormat("template={{.ID")
+	assert.ErrorContains(t, err, "invalid output template")
+}
+
+func TestOutputFlag(t *testing.T) {
+	defer SetOutputFormat(OutputFormat{})
+	flag := OutputFlag{}
+	assert.Equal(t, TableFormat, flag.String())
+	assert.False(t, IsStructuredOutput())
+
+	assert.NoError(t, flag.Set("yaml"))
+	assert.Equal(t, "yaml", flag.String())
+	assert.True(t, IsStructuredOutput())
+
+	assert.Error(t, flag.Set("xml"))
+	assert.Equal(t, "yaml", flag.String())
+
+	SetOutputFormat(OutputFormat{})
+	assert.Equal(t, YAMLFormat, OutputFlag{Default: YAMLFormat}.String())
+}
+
+func TestMessageOut(t *testing.T) {
+	messages := useMessagesOut(t)
+	out := new(bytes.Buffer)
+	assert.Equal(t, out, MessageOut(out))
+
+	useOutputFormat(t, "csv")
+	assert.Equal(t, messages, MessageOut(out))
+}
+
+func TestDocumentFormat(t *testing.T) {
+	defer SetOutputFormat(OutputFormat{})
+	format, err := DocumentFormat(YAMLFormat, YAMLFormat, JSONFormat)
+	assert.NoError(t, err)
+	assert.Equal(t, YAMLFormat, format)
+
+	assert.NoError(t, OutputFlag{}.Set("json"))
+	format, err = DocumentFormat(YAMLFormat, YAMLFormat, JSONFormat)
+	assert.NoError(t, err)
+	assert.Equal(t, JSONFormat, format)
+
+	assert.NoError(t, OutputFlag{}.Set("table"))
+	_, err = DocumentFormat(YAMLFormat, YAMLFormat, JSONFormat)
+	assert.EqualError(t, err, "invalid output format table, use one of: yaml or json")
+}
+
+func TestFieldName(t *testing.T) {
+	assert.Equal(t, "Name", FieldName("NAME"))
+	assert.Equal(t, "ID", FieldName("ID"))
+	assert.Equal(t, "DeploymentID", FieldName("DEPLOYMENT ID"))
+	assert.Equal(t, "CICDEnforcement", FieldName("CI-CD ENFORCEMENT"))
+	assert.Equal(t, "CreateDate", FieldName("Create Date"))
+	assert.Equal(t, "WorkspaceIDs", FieldName("WORKSPACE IDS"))
+}
+
+func TestTablePrintStructured(t *testing.T) {
+	t.Run("json", func(t *testing.T) {
+		useOutputFormat(t, "json")
+		messages := useMessagesOut(t)
+		buf := new(bytes.Buffer)
+		assert.NoError(t, newFormatTestTable().Print(buf))
+		assert.Equal(t, `[
+    {
+        "Name": "first",
+        "DeploymentID": "id-1",
+        "CICDEnforcement": "true"
+    },
+    {
+        "Name": "second",
+        "DeploymentID": "id-2",
+        "CICDEnforcement": ""
+    }
+]
+`, buf.String())
+		assert.Equal(t, "done\n", messages.String())
+	})
+
+	t.Run("json without rows", func(t *testing.T) {
+		useOutputFormat(t, "json")
+		messages := useMessagesOut(t)
+		buf := new(bytes.Buffer)
+		assert.NoError(t, (&Table{Header: []string{"NAME"}, NoResultsMsg: "no rows present", SuccessMsg: "done"}).Print(buf))
+		assert.Equal(t, "[]\n", buf.String())
+		assert.Equal(t, "no rows present\n", messages.String())
+	})
+
+	t.Run("yaml", func(t *testing.T) {
+		useOutputFormat(t, "yaml")
+		buf := new(bytes.Buffer)
+		assert.NoError(t, newFormatTestTable().Print(buf))
+		assert.Equal(t, `- Name: first
+  DeploymentID: id-1
+  CICDEnforcement: "true"
+- Name: second
+  DeploymentID: id-2
+  CICDEnforcement: ""
+`, buf.String())
+	})
+
+	t.Run("yaml without rows", func(t *testing.T) {
+		useOutputFormat(t, "yaml")
+		buf := new(bytes.Buffer)
+		assert.NoError(t, (&Table{Header: []string{"NAME"}}).Print(buf))
+		assert.Equal(t, "[]\n", buf.String())
+	})
+
+	t.Run("csv with field names", func(t *testing.T) {
+		useOutputFormat(t, "csv")
+		tab := newFormatTestTable()
+		tab.FieldNames = []string{"", "ID"}
+		buf := new(bytes.Buffer)
+		assert.NoError(t, tab.PrintWithPageNumber(0, buf))
+		assert.Equal(t, "Name,ID,CICDEnforcement\nfirst,id-1,true\nsecond,id-2,\n", buf.String())
+	})
+
+	t.Run("template", func(t *testing.T) {
+		useOutputFormat(t, "template={{.DeploymentID}} {{.Name}} {{.Missing}}")
+		buf := new(bytes.Buffer)
+		assert.NoError(t, newFormatTestTable().Print(buf))
+		assert.Equal(t, "id-1 first \nid-2 second \n", buf.String())
+	})
+
+	t.Run("selection tables are printed as text", func(t *testing.T) {
+		useOutputFormat(t, "json")
+		tab := newFormatTestTable()
+		tab.GetUserInput = true
+		buf := new(bytes.Buffer)
+		assert.NoError(t, tab.Print(buf))
+		assert.Contains(t, buf.String(), "DEPLOYMENT ID")
+		assert.Contains(t, buf.String(), "done")
+	})
+}
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"io"
 	"strconv"
+	"strings"
 )
 
 // Table represents a table to be printed
@@ -16,6 +17,9 @@ type Table struct {
 	Header         []string
 	RenderedHeader string
 
+	// Optional field names used instead of the headers in structured output, see FieldName
+	FieldNames []string
+
 	// Truncate rows if they exceed padding length
 	Truncate bool
 
@@ -67,6 +71,11 @@ func (t *Table) AddRow(values []string, color bool) {
 
 // Print header __as well as__ rows
 func (t *Table) Print(out io.Writer) error {
+	// tables used to select a row are always printed as text
+	if f := CurrentOutputFormat(); f.Name != TableFormat && !t.GetUserInput {
+		return t.printStructuredWithMessages(f, out)
+	}
+
 	if len(t.Rows) == 0 && t.NoResultsMsg != "" {
 		fmt.Fprintln(out, t.NoResultsMsg)
 		return nil
@@ -83,6 +92,11 @@ func (t *Table) Print(out io.Writer) error {
 
 // Print header __as well as__ rows
 func (t *Table) PrintWithPageNumber(pageNumber int, out io.Writer) error {
+	// tables used to select a row are always printed as text
+	if f := CurrentOutputFormat(); f.Name != TableFormat && !t.GetUserInput {
+		return t.printStructuredWithMessages(f, out)
+	}
+
 	if len(t.Rows) == 0 && t.NoResultsMsg != "" {
 		fmt.Fprintln(out, t.NoResultsMsg)
 		return nil
@@ -97,6 +111,19 @@ func (t *Table) PrintWithPageNumber(pageNumber int, out io.Writer) error {
 	return nil
 }
 
+// printStructuredWithMessages prints the table in a machine readable format to out and its messages to MessageOut
+func (t *Table) printStructuredWithMessages(f OutputFormat, out io.Writer) error {
+	if err := t.printStructured(f, out); err != nil {
+		return err
+	}
+	if len(t.Rows) == 0 && t.NoResultsMsg != "" {
+		fmt.Fprintln(MessageOut(out), t.NoResultsMsg)
+	} else if t.SuccessMsg != "" {
+		fmt.Fprintln(MessageOut(out), strings.TrimLeft(t.SuccessMsg, "\n"))
+	}
+	return nil
+}
+
 // PrintHeader prints header
 func (t *Table) PrintHeader(out io.Writer) {
 	if t.DynamicPadding {
@@ -4,7 +4,9 @@ import (
 	b64 "encoding/base64"
 	"fmt"
 	"os"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/Masterminds/semver"
 	"github.com/golang-jwt/jwt/v4"
@@ -90,6 +92,41 @@ func CheckEnvBool(envBool string) bool {
 	return false
 }
 
+// ParseDuration parses a duration like time.ParseDuration does, with support for days (7d) and weeks (2w)
+func ParseDuration(value string) (time.Duration, error) {
+	value = strings.TrimSpace(value)
+	unit := time.Duration(0)
+	switch {
+	case strings.HasSuffix(value, "d"):
+		unit = 24 * time.Hour //nolint:gomnd
+	case strings.HasSuffix(value, "w"):
+		unit = 7 * 24 * time.Hour //nolint:gomnd
+	}
+	if unit == 0 {
+		return time.ParseDuration(value)
+	}
+	n, err := strconv.ParseFloat(value[:len(value)-1], 64) //nolint:gomnd
+	if err != nil {
+		return 0, errors.Errorf("invalid duration %q", value)
+	}
+	return time.Duration(n * float64(unit)), nil
+}
+
+// ParseTime parses either an RFC 3339 timestamp, a date (2006-01-02) or a duration before now, like 24h or 7d
+func ParseTime(value string, now time.Time) (time.Time, error) {
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t, nil
+	}
+	if t, err := time.Parse("2006-01-02", value); err == nil {
+		return t, nil
+	}
+	d, err := ParseDuration(value)
+	if err != nil {
+		return time.Time{}, errors.Errorf("invalid time %q, expected an RFC 3339 timestamp, a date like 2006-01-02 or a duration like 24h or 7d", value)
+	}
+	return now.Add(-d), nil
+}
+
 // IsM1 returns true if running on M1 architecture
 // returns false if not running on M1 architecture
 // We use this to setup longerHealthCheck
@@ -2,6 +2,7 @@ package util
 
 import (
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -228,3 +229,41 @@ func TestIsM1(t *testing.T) {
 		assert.False(t, IsM1("windows", "amd64"))
 	})
 }
+
+func TestParseDuration(t *testing.T) {
+	d, err := ParseDuration("36h")
+	assert.NoError(t, err)
+	assert.Equal(t, 36*time.Hour, d)
+
+	d, err = ParseDuration("7d")
+	assert.NoError(t, err)
+	assert.Equal(t, 7*24*time.Hour, d)
+
+	d, err = ParseDuration("2w")
+	assert.NoError(t, err)
+	assert.Equal(t, 14*24*time.Hour, d)
+
+	_, err = ParseDuration("xd")
+	assert.Error(t, err)
+	_, err = ParseDuration("7")
+	assert.Error(t, err)
+}
+
+func TestParseTime(t *testing.T) {
+	now := time.Date(2023, 5, 10, 12, 0, 0, 0, time.UTC)
+
+	parsed, err := ParseTime("2023-05-01T10:00:00Z", now)
+	assert.NoError(t, err)
+	assert.Equal(t, time.Date(2023, 5, 1, 10, 0, 0, 0, time.UTC), parsed)
+
+	parsed, err = ParseTime("2023-05-01", now)
+	assert.NoError(t, err)
+	assert.Equal(t, time.Date(2023, 5, 1, 0, 0, 0, 0, time.UTC), parsed)
+
+	parsed, err = ParseTime("2d", now)
+	assert.NoError(t, err)
+	assert.Equal(t, time.Date(2023, 5, 8, 12, 0, 0, 0, time.UTC), parsed)
+
+	_, err = ParseTime("yesterday", now)
+	assert.Error(t, err)
+}
@@ -7,15 +7,16 @@ import (
 	"path/filepath"
 	"strconv"
 	"strings"
-	"time"
 
 	"github.com/astronomer/astro-cli/airflow"
 	"github.com/astronomer/astro-cli/airflow/types"
 	"github.com/astronomer/astro-cli/config"
 	"github.com/astronomer/astro-cli/docker"
 	"github.com/astronomer/astro-cli/houston"
+	"github.com/astronomer/astro-cli/pkg/fileutil"
 	"github.com/astronomer/astro-cli/pkg/input"
 	"github.com/astronomer/astro-cli/pkg/printutil"
+	"github.com/sirupsen/logrus"
 )
 
 var (
@@ -33,6 +34,10 @@ var (
 	errInvalidDeploymentID       = errors.New("please specify a valid deployment ID")
 	errDeploymentNotFound        = errors.New("no airflow deployments found")
 	errInvalidDeploymentSelected = errors.New("invalid deployment selection\n") //nolint
+	errDagOnlyDeployDisabled     = errors.New("DAG-only deploys are not enabled on this Astronomer Software installation")
+	errDagOnlyDeployNotEnabled   = errors.New("the deployment does not use DAG-only deploys. Run astro deployment update with --dag-deployment-type=dag_deploy to use them")
+	errNoDagsFolder              = errors.New("no dags folder found in the project")
+	errNoBaseImage               = errors.New("no FROM instruction found in the dockerfile")
 )
 
 const (
@@ -57,15 +62,112 @@ var tab = printutil.Table{
 	Header:         []string{"#", "LABEL", "DEPLOYMENT NAME", "WORKSPACE", "DEPLOYMENT ID"},
 }
 
-func Airflow(houstonClient houston.ClientInterface, path, deploymentID, wsID, byoRegistryDomain string, ignoreCacheDeploy, byoRegistryEnabled, prompt bool) error {
+// Airflow builds the project image and pushes it to the registry of the deployment. With a BYO registry the image is
+// pushed with the tags of byoTagTemplates, or of DefaultImageTagTemplate, and the deployment is updated to its digest.
+func Airflow(houstonClient houston.ClientInterface, path, deploymentID, wsID, byoRegistryDomain string, byoTagTemplates []string, ignoreCacheDeploy, byoRegistryEnabled, prompt bool) error {
+	deploymentID, deployments, err := getDeploymentID(houstonClient, wsID, deploymentID, prompt)
+	if err != nil {
+		return err
+	}
+
+	c, err := config.GetCurrentContext()
+	if err != nil {
+		return err
+	}
+	cloudDomain := c.Domain
+
+	nextTag := ""
+	releaseName := ""
+	for i := range deployments {
+		deployment := deployments[i]
+		if deployment.ID == deploymentID {
+			nextTag = deployment.DeploymentInfo.NextCli
+			releaseName = deployment.ReleaseName
+		}
+	}
+
+	deploymentInfo, err := houston.Call(houstonClient.GetDeployment)(deploymentID)
+	if err != nil {
+		return fmt.Errorf("failed to get deployment info: %w", err)
+	}
+
+	fmt.Printf(houstonDeploymentPrompt, releaseName)
+
+	// Build the image to deploy
+	err = buildPushDockerImage(houstonClient, &c, deploymentInfo, releaseName, path, nextTag, cloudDomain, byoRegistryDomain, byoTagTemplates, ignoreCacheDeploy, byoRegistryEnabled)
+	if err != nil {
+		return err
+	}
+
+	deploymentLink := getAirflowUILink(deploymentID, deploymentInfo.Urls)
+	fmt.Printf("Successfully pushed Docker image to Astronomer registry, it can take a few minutes to update the deployment with the new image. Navigate to the Astronomer UI to confirm the state of your deployment (%s).\n", deploymentLink)
+
+	return nil
+}
+
+// DagsOnlyDeploy uploads the dags folder of the project to a deployment using DAG-only deploys, without building an image
+func DagsOnlyDeploy(houstonClient houston.ClientInterface, appConfig *houston.AppConfig, path, deploymentID, wsID string, prompt bool) error {
+	if appConfig == nil || !appConfig.Flags.DagOnlyDeployment {
+		return errDagOnlyDeployDisabled
+	}
+
+	deploymentID, deployments, err := getDeploymentID(houstonClient, wsID, deploymentID, prompt)
+	if err != nil {
+		return err
+	}
+
+	releaseName := ""
+	for i := range deployments {
+		if deployments[i].ID == deploymentID {
+			releaseName = deployments[i].ReleaseName
+		}
+	}
+
+	deploymentInfo, err := houston.Call(houstonClient.GetDeployment)(deploymentID)
+	if err != nil {
+		return fmt.Errorf("failed to get deployment info: %w", err)
+	}
+	if deploymentInfo.DagDeployment.Type != houston.DagOnlyDeploymentType {
+		return errDagOnlyDeployNotEnabled
+	}
+
+	dagsPath := filepath.Join(path, "dags")
+	if info, err := os.Stat(dagsPath); err != nil || !info.IsDir() {
+		return errNoDagsFolder
+	}
+
+	fmt.Printf(houstonDeploymentPrompt, releaseName)
+
+	// Generate the dags tar
+	if err = fileutil.Tar(dagsPath, path); err != nil {
+		return err
+	}
+	dagsFile := filepath.Join(path, "dags.tar")
+	defer os.Remove(dagsFile)
+
+	upload, err := houston.Call(houstonClient.UploadDags)(houston.UploadDagsRequest{ReleaseName: releaseName, DagsFile: dagsFile})
+	if err != nil {
+		return err
+	}
+	logrus.Debugf("Uploaded %d bytes of DAGs to %s", upload.Size, releaseName)
+
+	deploymentLink := getAirflowUILink(deploymentID, deploymentInfo.Urls)
+	fmt.Printf("Successfully uploaded DAGs to the deployment, it can take a few minutes for Airflow to pick them up. Navigate to the Airflow UI to confirm the state of your DAGs (%s).\n", deploymentLink)
+
+	return nil
+}
+
+// getDeploymentID returns the ID of the deployment to deploy to and the deployments of workspace wsID. The user selects the
+// deployment when no ID is passed or set in the project config, or when prompt is set.
+func getDeploymentID(houstonClient houston.ClientInterface, wsID, deploymentID string, prompt bool) (string, []houston.Deployment, error) {
 	if wsID == "" {
-		return errNoWorkspaceID
+		return "", nil, errNoWorkspaceID
 	}
 
 	// Validate workspace
 	currentWorkspace, err := houston.Call(houstonClient.GetWorkspace)(wsID)
 	if err != nil {
-		return err
+		return "", nil, err
 	}
 
 	// Get Deployments from workspace ID
@@ -74,17 +176,17 @@ func Airflow(houstonClient houston.ClientInterface, path, deploymentID, wsID, by
 	}
 	deployments, err := houston.Call(houstonClient.ListDeployments)(request)
 	if err != nil {
-		return err
+		return "", nil, err
 	}
 
 	c, err := config.GetCurrentContext()
 	if err != nil {
-		return err
+		return "", nil, err
 	}
 
 	cloudDomain := c.Domain
 	if cloudDomain == "" {
-		return errNoDomainSet
+		return "", nil, errNoDomainSet
 	}
 
 	// Use config deployment if provided
@@ -93,13 +195,13 @@ func Airflow(houstonClient houston.ClientInterface, path, deploymentID, wsID, by
 	}
 
 	if deploymentID != "" && !deploymentExists(deploymentID, deployments) {
-		return errInvalidDeploymentID
+		return "", nil, errInvalidDeploymentID
 	}
 
 	// Prompt user for deployment if no deployment passed in
 	if deploymentID == "" || prompt {
 		if len(deployments) == 0 {
-			return errDeploymentNotFound
+			return "", nil, errDeploymentNotFound
 		}
 
 		fmt.Printf(houstonDeploymentHeader, cloudDomain)
@@ -118,42 +220,11 @@ func Airflow(houstonClient houston.ClientInterface, path, deploymentID, wsID, by
 		choice := input.Text("\n> ")
 		selected, ok := deployMap[choice]
 		if !ok {
-			return errInvalidDeploymentSelected
+			return "", nil, errInvalidDeploymentSelected
 		}
 		deploymentID = selected.ID
 	}
-
-	nextTag := ""
-	releaseName := ""
-	for i := range deployments {
-		deployment := deployments[i]
-		if deployment.ID == deploymentID {
-			nextTag = deployment.DeploymentInfo.NextCli
-			releaseName = deployment.ReleaseName
-		}
-	}
-
-	if byoRegistryEnabled {
-		nextTag = "deploy-" + time.Now().UTC().Format("2006-01-02T15-04") // updating nextTag logic for private registry, since houston won't maintain next tag in case of BYO registry
-	}
-
-	deploymentInfo, err := houston.Call(houstonClient.GetDeployment)(deploymentID)
-	if err != nil {
-		return fmt.Errorf("failed to get deployment info: %w", err)
-	}
-
-	fmt.Printf(houstonDeploymentPrompt, releaseName)
-
-	// Build the image to deploy
-	err = buildPushDockerImage(houstonClient, &c, deploymentInfo, releaseName, path, nextTag, cloudDomain, byoRegistryDomain, ignoreCacheDeploy, byoRegistryEnabled)
-	if err != nil {
-		return err
-	}
-
-	deploymentLink := getAirflowUILink(deploymentID, deploymentInfo.Urls)
-	fmt.Printf("Successfully pushed Docker image to Astronomer registry, it can take a few minutes to update the deployment with the new image. Navigate to the Astronomer UI to confirm the state of your deployment (%s).\n", deploymentLink)
-
-	return nil
+	return deploymentID, deployments, nil
 }
 
 // Find deployment ID in deployments slice
@@ -167,7 +238,7 @@ func deploymentExists(deploymentID string, deployments []houston.Deployment) boo
 	return false
 }
 
-func buildPushDockerImage(houstonClient houston.ClientInterface, c *config.Context, deploymentInfo *houston.Deployment, name, path, nextTag, cloudDomain, byoRegistryDomain string, ignoreCacheDeploy, byoRegistryEnabled bool) error {
+func buildPushDockerImage(houstonClient houston.ClientInterface, c *config.Context, deploymentInfo *houston.Deployment, name, path, nextTag, cloudDomain, byoRegistryDomain string, byoTagTemplates []string, ignoreCacheDeploy, byoRegistryEnabled bool) error {
 	// Build our image
 	fmt.Println(imageBuildingPrompt)
 
@@ -228,30 +299,14 @@ func buildPushDockerImage(houstonClient houston.ClientInterface, c *config.Conte
 		return err
 	}
 
-	var registry, remoteImage, token string
+	// houston doesn't maintain the next tag of deployments using a BYO registry
 	if byoRegistryEnabled {
-		registry = byoRegistryDomain
-		remoteImage = fmt.Sprintf("%s:%s", registry, fmt.Sprintf("%s-%s", name, nextTag))
-	} else {
-		registry = registryDomainPrefix + cloudDomain
-		remoteImage = fmt.Sprintf("%s/%s", registry, airflow.ImageName(name, nextTag))
-		token = c.Token
-	}
-
-	err = imageHandler.Push(registry, "", token, remoteImage)
-	if err != nil {
-		return err
+		return pushBYORegistryImage(houstonClient, imageHandler, name, byoRegistryDomain, byoTagTemplates)
 	}
 
-	if byoRegistryEnabled {
-		runtimeVersion, _ := imageHandler.GetLabel("", runtimeImageLabel)
-		airflowVersion, _ := imageHandler.GetLabel("", airflowImageLabel)
-		req := houston.UpdateDeploymentImageRequest{ReleaseName: name, Image: remoteImage, AirflowVersion: airflowVersion, RuntimeVersion: runtimeVersion}
-		_, err := houston.Call(houstonClient.UpdateDeploymentImage)(req)
-		return err
-	}
-
-	return nil
+	registry := registryDomainPrefix + cloudDomain
+	remoteImage := fmt.Sprintf("%s/%s", registry, airflow.ImageName(name, nextTag))
+	return imageHandler.Push(registry, "", c.Token, remoteImage)
 }
 
 func validAirflowImageRepo(image string) bool {
@@ -289,3 +344,40 @@ func getAirflowUILink(deploymentID string, deploymentURLs []houston.DeploymentUR
 	}
 	return ""
 }
+
+// SetBaseImage replaces the base image of the Dockerfile in the project at path, the first FROM instruction, with image.
+// The returned function restores the original Dockerfile.
+func SetBaseImage(path, image string) (restore func() error, err error) {
+	dockerfilePath := filepath.Join(path, dockerfile)
+	info, err := os.Stat(dockerfilePath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read dockerfile: %s: %w", dockerfilePath, err)
+	}
+	content, err := os.ReadFile(dockerfilePath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read dockerfile: %s: %w", dockerfilePath, err)
+	}
+
+	lines := strings.Split(string(content), "\n")
+	for i, line := range lines {
+		fields := strings.Fields(line)
+		if len(fields) < 2 || !strings.EqualFold(fields[0], "FROM") {
+			continue
+		}
+		// keep any flag before the image, like --platform, and the stage name after it
+		for j := 1; j < len(fields); j++ {
+			if !strings.HasPrefix(fields[j], "--") {
+				fields[j] = image
+				break
+			}
+		}
+		lines[i] = strings.Join(fields, " ")
+		if err := os.WriteFile(dockerfilePath, []byte(strings.Join(lines, "\n")), info.Mode().Perm()); err != nil {
+			return nil, err
+		}
+		return func() error {
+			return os.WriteFile(dockerfilePath, content, info.Mode().Perm())
+		}, nil
+	}
+	return nil, fmt.Errorf("%w: %s", errNoBaseImage, dockerfilePath)
+}
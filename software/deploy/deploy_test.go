@@ -2,6 +2,8 @@ package deploy
 
 import (
 	"errors"
+	"os"
+	"path/filepath"
 	"testing"
 	"time"
 
@@ -11,6 +13,7 @@ import (
 	"github.com/astronomer/astro-cli/context"
 	"github.com/astronomer/astro-cli/houston"
 	houston_mocks "github.com/astronomer/astro-cli/houston/mocks"
+	"github.com/astronomer/astro-cli/pkg/git"
 	testUtil "github.com/astronomer/astro-cli/pkg/testing"
 
 	"github.com/spf13/afero"
@@ -114,7 +117,7 @@ func TestBuildPushDockerImageSuccessWithTagWarning(t *testing.T) {
 	houstonMock.On("GetRuntimeReleases", "").Return(houston.RuntimeReleases{}, nil)
 	houstonMock.On("GetDeploymentConfig", nil).Return(mockedDeploymentConfig, nil)
 
-	err := buildPushDockerImage(houstonMock, &config.Context{}, mockDeployment, "test", "./testfiles/", "test", "test", "", false, false)
+	err := buildPushDockerImage(houstonMock, &config.Context{}, mockDeployment, "test", "./testfiles/", "test", "test", "", nil, false, false)
 	assert.NoError(t, err)
 	mockImageHandler.AssertExpectations(t)
 	houstonMock.AssertExpectations(t)
@@ -145,7 +148,7 @@ func TestBuildPushDockerImageSuccessWithImageRepoWarning(t *testing.T) {
 	houstonMock.On("GetDeploymentConfig", nil).Return(mockedDeploymentConfig, nil)
 	houstonMock.On("GetRuntimeReleases", "").Return(houston.RuntimeReleases{}, nil)
 
-	err := buildPushDockerImage(houstonMock, &config.Context{}, mockDeployment, "test", "./testfiles/", "test", "test", "", false, false)
+	err := buildPushDockerImage(houstonMock, &config.Context{}, mockDeployment, "test", "./testfiles/", "test", "test", "", nil, false, false)
 	assert.NoError(t, err)
 	mockImageHandler.AssertExpectations(t)
 	houstonMock.AssertExpectations(t)
@@ -159,13 +162,19 @@ func TestBuildPushDockerImageSuccessWithBYORegistry(t *testing.T) {
 
 	dockerfile = "Dockerfile"
 	defer func() { dockerfile = "Dockerfile" }()
+	gitCommitSHA = func() (string, error) { return "abc1234", nil }
+	defer func() { gitCommitSHA = git.GetCommitSHA }()
 
 	mockImageHandler := new(mocks.ImageHandler)
 	imageHandlerInit = func(image string) airflow.ImageHandler {
 		mockImageHandler.On("Build", mock.Anything, mock.Anything).Return(nil)
-		mockImageHandler.On("Push", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
 		mockImageHandler.On("GetLabel", "", runtimeImageLabel).Return("", nil).Once()
 		mockImageHandler.On("GetLabel", "", airflowImageLabel).Return("1.10.12", nil).Once()
+		mockImageHandler.On("DoesImageExist", "test.registry.io:test-abc1234").Return(airflow.ErrImageNotFound).Once()
+		mockImageHandler.On("DoesImageExist", "test.registry.io:1.10.12").Return(airflow.ErrImageNotFound).Once()
+		mockImageHandler.On("Push", "test.registry.io", "", "", "test.registry.io:test-abc1234").Return(nil).Once()
+		mockImageHandler.On("Push", "test.registry.io", "", "", "test.registry.io:1.10.12").Return(nil).Once()
+		mockImageHandler.On("GetRemoteDigest", "test.registry.io:test-abc1234").Return("sha256:1234", nil).Once()
 		return mockImageHandler
 	}
 
@@ -175,18 +184,76 @@ func TestBuildPushDockerImageSuccessWithBYORegistry(t *testing.T) {
 	houstonMock := new(houston_mocks.ClientInterface)
 	houstonMock.On("GetDeploymentConfig", nil).Return(mockedDeploymentConfig, nil)
 	houstonMock.On("GetRuntimeReleases", "").Return(houston.RuntimeReleases{}, nil)
-	houstonMock.On("UpdateDeploymentImage", houston.UpdateDeploymentImageRequest{ReleaseName: "test", Image: "test.registry.io:test-test", AirflowVersion: "1.10.12", RuntimeVersion: ""}).Return(nil, nil)
+	houstonMock.On("UpdateDeploymentImage", houston.UpdateDeploymentImageRequest{ReleaseName: "test", Image: "test.registry.io@sha256:1234", AirflowVersion: "1.10.12", RuntimeVersion: ""}).Return(nil, nil)
 
-	err := buildPushDockerImage(houstonMock, &config.Context{}, mockDeployment, "test", "./testfiles/", "test", "test", "test.registry.io", false, true)
+	tagTemplates := []string{"{{.ReleaseName}}-{{.GitSHA}}", "{{.AirflowVersion}}"}
+	err := buildPushDockerImage(houstonMock, &config.Context{}, mockDeployment, "test", "./testfiles/", "test", "test", "test.registry.io", tagTemplates, false, true)
 	assert.NoError(t, err)
 	mockImageHandler.AssertExpectations(t)
 	houstonMock.AssertExpectations(t)
 }
 
+func TestBuildPushDockerImageBYORegistryExistingTag(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	configYaml := testUtil.NewTestConfig("docker")
+	afero.WriteFile(fs, config.HomeConfigFile, configYaml, 0o777)
+	config.InitConfig(fs)
+
+	now = func() time.Time { return time.Date(2023, 1, 2, 3, 4, 5, 0, time.UTC) }
+	defer func() { now = time.Now }()
+
+	mockImageHandler := new(mocks.ImageHandler)
+	imageHandlerInit = func(image string) airflow.ImageHandler {
+		mockImageHandler.On("Build", mock.Anything, mock.Anything).Return(nil)
+		mockImageHandler.On("GetLabel", "", runtimeImageLabel).Return("", nil).Once()
+		mockImageHandler.On("GetLabel", "", airflowImageLabel).Return("1.10.12", nil).Once()
+		mockImageHandler.On("DoesImageExist", "test.registry.io:test-deploy-2023-01-02T03-04-05").Return(nil).Once()
+		return mockImageHandler
+	}
+
+	houstonMock := new(houston_mocks.ClientInterface)
+	houstonMock.On("GetDeploymentConfig", nil).Return(&houston.DeploymentConfig{AirflowImages: mockAirflowImageList}, nil)
+	houstonMock.On("GetRuntimeReleases", "").Return(houston.RuntimeReleases{}, nil)
+
+	err := buildPushDockerImage(houstonMock, &config.Context{}, mockDeployment, "test", "./testfiles/", "test", "test", "test.registry.io", nil, false, true)
+	assert.ErrorIs(t, err, errImageTagExists)
+	mockImageHandler.AssertExpectations(t)
+	houstonMock.AssertExpectations(t)
+}
+
+func TestBuildPushDockerImageBYORegistryCheckError(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	configYaml := testUtil.NewTestConfig("docker")
+	afero.WriteFile(fs, config.HomeConfigFile, configYaml, 0o777)
+	config.InitConfig(fs)
+
+	now = func() time.Time { return time.Date(2023, 1, 2, 3, 4, 5, 0, time.UTC) }
+	defer func() { now = time.Now }()
+
+	mockImageHandler := new(mocks.ImageHandler)
+	imageHandlerInit = func(image string) airflow.ImageHandler {
+		mockImageHandler.On("Build", mock.Anything, mock.Anything).Return(nil)
+		mockImageHandler.On("GetLabel", "", runtimeImageLabel).Return("", nil).Once()
+		mockImageHandler.On("GetLabel", "", airflowImageLabel).Return("1.10.12", nil).Once()
+		mockImageHandler.On("DoesImageExist", "test.registry.io:test-deploy-2023-01-02T03-04-05").Return(errSomeContainerIssue).Once()
+		return mockImageHandler
+	}
+
+	houstonMock := new(houston_mocks.ClientInterface)
+	houstonMock.On("GetDeploymentConfig", nil).Return(&houston.DeploymentConfig{AirflowImages: mockAirflowImageList}, nil)
+	houstonMock.On("GetRuntimeReleases", "").Return(houston.RuntimeReleases{}, nil)
+
+	err := buildPushDockerImage(houstonMock, &config.Context{}, mockDeployment, "test", "./testfiles/", "test", "test", "test.registry.io", nil, false, true)
+	assert.ErrorIs(t, err, errSomeContainerIssue)
+	assert.ErrorContains(t, err, "failed to check whether test.registry.io:test-deploy-2023-01-02T03-04-05 exists")
+	mockImageHandler.AssertExpectations(t)
+	houstonMock.AssertExpectations(t)
+}
+
 func TestBuildPushDockerImageFailure(t *testing.T) {
 	// invalid dockerfile test
 	dockerfile = "Dockerfile.invalid"
-	err := buildPushDockerImage(nil, &config.Context{}, mockDeployment, "test", "./testfiles/", "test", "test", "", false, false)
+	err := buildPushDockerImage(nil, &config.Context{}, mockDeployment, "test", "./testfiles/", "test", "test", "", nil, false, false)
 	assert.EqualError(t, err, "failed to parse dockerfile: testfiles/Dockerfile.invalid: when using JSON array syntax, arrays must be comprised of strings only")
 	dockerfile = "Dockerfile"
 
@@ -202,7 +269,7 @@ func TestBuildPushDockerImageFailure(t *testing.T) {
 	houstonMock.On("GetDeploymentConfig", nil).Return(nil, errMockHouston).Once()
 	houstonMock.On("GetRuntimeReleases", "").Return(houston.RuntimeReleases{}, nil)
 	// houston GetDeploymentConfig call failure
-	err = buildPushDockerImage(houstonMock, &config.Context{}, mockDeployment, "test", "./testfiles/", "test", "test", "", false, false)
+	err = buildPushDockerImage(houstonMock, &config.Context{}, mockDeployment, "test", "./testfiles/", "test", "test", "", nil, false, false)
 	assert.Error(t, err, errMockHouston)
 
 	houstonMock.On("GetDeploymentConfig", nil).Return(mockedDeploymentConfig, nil).Twice()
@@ -214,7 +281,7 @@ func TestBuildPushDockerImageFailure(t *testing.T) {
 	}
 
 	// build error test case
-	err = buildPushDockerImage(houstonMock, &config.Context{}, mockDeployment, "test", "./testfiles/", "test", "test", "", false, false)
+	err = buildPushDockerImage(houstonMock, &config.Context{}, mockDeployment, "test", "./testfiles/", "test", "test", "", nil, false, false)
 	assert.Error(t, err, errSomeContainerIssue.Error())
 	mockImageHandler.AssertExpectations(t)
 
@@ -226,7 +293,7 @@ func TestBuildPushDockerImageFailure(t *testing.T) {
 	}
 
 	// push error test case
-	err = buildPushDockerImage(houstonMock, &config.Context{}, mockDeployment, "test", "./testfiles/", "test", "test", "", false, false)
+	err = buildPushDockerImage(houstonMock, &config.Context{}, mockDeployment, "test", "./testfiles/", "test", "test", "", nil, false, false)
 	assert.Error(t, err, errSomeContainerIssue.Error())
 	mockImageHandler.AssertExpectations(t)
 	houstonMock.AssertExpectations(t)
@@ -263,14 +330,14 @@ func TestGetAirflowUILinkFailure(t *testing.T) {
 
 func TestAirflowFailure(t *testing.T) {
 	// No workspace ID test case
-	err := Airflow(nil, "", "", "", "", false, false, false)
+	err := Airflow(nil, "", "", "", "", nil, false, false, false)
 	assert.ErrorIs(t, err, errNoWorkspaceID)
 
 	// houston GetWorkspace failure case
 	houstonMock := new(houston_mocks.ClientInterface)
 	houstonMock.On("GetWorkspace", mock.Anything).Return(nil, errMockHouston).Once()
 
-	err = Airflow(houstonMock, "", "", "test-workspace-id", "", false, false, false)
+	err = Airflow(houstonMock, "", "", "test-workspace-id", "", nil, false, false, false)
 	assert.ErrorIs(t, err, errMockHouston)
 	houstonMock.AssertExpectations(t)
 
@@ -278,7 +345,7 @@ func TestAirflowFailure(t *testing.T) {
 	houstonMock.On("GetWorkspace", mock.Anything).Return(&houston.Workspace{}, nil)
 	houstonMock.On("ListDeployments", mock.Anything).Return(nil, errMockHouston).Once()
 
-	err = Airflow(houstonMock, "", "", "test-workspace-id", "", false, false, false)
+	err = Airflow(houstonMock, "", "", "test-workspace-id", "", nil, false, false, false)
 	assert.ErrorIs(t, err, errMockHouston)
 	houstonMock.AssertExpectations(t)
 
@@ -292,29 +359,29 @@ func TestAirflowFailure(t *testing.T) {
 	// config GetCurrentContext failure case
 	config.ResetCurrentContext()
 
-	err = Airflow(houstonMock, "", "", "test-workspace-id", "", false, false, false)
+	err = Airflow(houstonMock, "", "", "test-workspace-id", "", nil, false, false, false)
 	assert.EqualError(t, err, "no context set, have you authenticated to Astro or Astronomer Software? Run astro login and try again")
 
 	context.Switch("localhost")
 
 	// Invalid deployment name case
-	err = Airflow(houstonMock, "", "test-deployment-id", "test-workspace-id", "", false, false, false)
+	err = Airflow(houstonMock, "", "test-deployment-id", "test-workspace-id", "", nil, false, false, false)
 	assert.ErrorIs(t, err, errInvalidDeploymentID)
 
 	// No deployment in the current workspace case
-	err = Airflow(houstonMock, "", "", "test-workspace-id", "", false, false, false)
+	err = Airflow(houstonMock, "", "", "test-workspace-id", "", nil, false, false, false)
 	assert.ErrorIs(t, err, errDeploymentNotFound)
 	houstonMock.AssertExpectations(t)
 
 	// Invalid deployment selection case
 	houstonMock.On("ListDeployments", mock.Anything).Return([]houston.Deployment{{ID: "test-deployment-id"}}, nil)
-	err = Airflow(houstonMock, "", "", "test-workspace-id", "", false, false, false)
+	err = Airflow(houstonMock, "", "", "test-workspace-id", "", nil, false, false, false)
 	assert.ErrorIs(t, err, errInvalidDeploymentSelected)
 
 	// buildPushDockerImage failure case
 	houstonMock.On("GetDeployment", "test-deployment-id").Return(&houston.Deployment{}, nil)
 	dockerfile = "Dockerfile.invalid"
-	err = Airflow(houstonMock, "./testfiles/", "test-deployment-id", "test-workspace-id", "", false, false, false)
+	err = Airflow(houstonMock, "./testfiles/", "test-deployment-id", "test-workspace-id", "", nil, false, false, false)
 	dockerfile = "Dockerfile"
 	assert.Error(t, err)
 	assert.Contains(t, err.Error(), "failed to parse dockerfile")
@@ -347,7 +414,112 @@ func TestAirflowSuccess(t *testing.T) {
 	houstonMock.On("GetDeployment", mock.Anything).Return(&houston.Deployment{}, nil).Once()
 	houstonMock.On("GetRuntimeReleases", "").Return(mockRuntimeReleases, nil)
 
-	err := Airflow(houstonMock, "./testfiles/", "test-deployment-id", "test-workspace-id", "", false, false, false)
+	err := Airflow(houstonMock, "./testfiles/", "test-deployment-id", "test-workspace-id", "", nil, false, false, false)
 	assert.Nil(t, err)
 	houstonMock.AssertExpectations(t)
 }
+
+func TestDagsOnlyDeploy(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	configYaml := testUtil.NewTestConfig("localhost")
+	afero.WriteFile(fs, config.HomeConfigFile, configYaml, 0o777)
+	config.InitConfig(fs)
+
+	appConfig := &houston.AppConfig{Flags: houston.FeatureFlags{DagOnlyDeployment: true}}
+	deployments := []houston.Deployment{{ID: "test-deployment-id", ReleaseName: "testDeploymentName"}}
+
+	t.Run("feature flag disabled", func(t *testing.T) {
+		err := DagsOnlyDeploy(nil, &houston.AppConfig{}, "./testfiles/", "test-deployment-id", "test-workspace-id", false)
+		assert.ErrorIs(t, err, errDagOnlyDeployDisabled)
+	})
+
+	t.Run("deployment not using dag deploys", func(t *testing.T) {
+		houstonMock := new(houston_mocks.ClientInterface)
+		houstonMock.On("GetWorkspace", mock.Anything).Return(&houston.Workspace{}, nil).Once()
+		houstonMock.On("ListDeployments", mock.Anything).Return(deployments, nil).Once()
+		houstonMock.On("GetDeployment", "test-deployment-id").Return(&houston.Deployment{DagDeployment: houston.DagDeployment{Type: "image"}}, nil).Once()
+
+		err := DagsOnlyDeploy(houstonMock, appConfig, "./testfiles/", "test-deployment-id", "test-workspace-id", false)
+		assert.ErrorIs(t, err, errDagOnlyDeployNotEnabled)
+		houstonMock.AssertExpectations(t)
+	})
+
+	t.Run("no dags folder", func(t *testing.T) {
+		houstonMock := new(houston_mocks.ClientInterface)
+		houstonMock.On("GetWorkspace", mock.Anything).Return(&houston.Workspace{}, nil).Once()
+		houstonMock.On("ListDeployments", mock.Anything).Return(deployments, nil).Once()
+		houstonMock.On("GetDeployment", "test-deployment-id").Return(&houston.Deployment{DagDeployment: houston.DagDeployment{Type: houston.DagOnlyDeploymentType}}, nil).Once()
+
+		err := DagsOnlyDeploy(houstonMock, appConfig, t.TempDir(), "test-deployment-id", "test-workspace-id", false)
+		assert.ErrorIs(t, err, errNoDagsFolder)
+		houstonMock.AssertExpectations(t)
+	})
+
+	t.Run("success", func(t *testing.T) {
+		path := t.TempDir()
+		err := os.Mkdir(filepath.Join(path, "dags"), 0o755)
+		assert.NoError(t, err)
+		err = os.WriteFile(filepath.Join(path, "dags", "dag.py"), []byte("# dag"), 0o600)
+		assert.NoError(t, err)
+
+		houstonMock := new(houston_mocks.ClientInterface)
+		houstonMock.On("GetWorkspace", mock.Anything).Return(&houston.Workspace{}, nil).Once()
+		houstonMock.On("ListDeployments", mock.Anything).Return(deployments, nil).Once()
+		houstonMock.On("GetDeployment", "test-deployment-id").Return(&houston.Deployment{DagDeployment: houston.DagDeployment{Type: houston.DagOnlyDeploymentType}}, nil).Once()
+		houstonMock.On("UploadDags", houston.UploadDagsRequest{ReleaseName: "testDeploymentName", DagsFile: filepath.Join(path, "dags.tar")}).Return(&houston.UploadDagsResponse{Size: 1024}, nil).Once()
+
+		err = DagsOnlyDeploy(houstonMock, appConfig, path, "test-deployment-id", "test-workspace-id", false)
+		assert.NoError(t, err)
+		assert.NoFileExists(t, filepath.Join(path, "dags.tar"))
+		houstonMock.AssertExpectations(t)
+	})
+
+	t.Run("upload failure", func(t *testing.T) {
+		path := t.TempDir()
+		err := os.Mkdir(filepath.Join(path, "dags"), 0o755)
+		assert.NoError(t, err)
+
+		houstonMock := new(houston_mocks.ClientInterface)
+		houstonMock.On("GetWorkspace", mock.Anything).Return(&houston.Workspace{}, nil).Once()
+		houstonMock.On("ListDeployments", mock.Anything).Return(deployments, nil).Once()
+		houstonMock.On("GetDeployment", "test-deployment-id").Return(&houston.Deployment{DagDeployment: houston.DagDeployment{Type: houston.DagOnlyDeploymentType}}, nil).Once()
+		houstonMock.On("UploadDags", mock.Anything).Return(nil, errMockHouston).Once()
+
+		err = DagsOnlyDeploy(houstonMock, appConfig, path, "test-deployment-id", "test-workspace-id", false)
+		assert.ErrorIs(t, err, errMockHouston)
+		houstonMock.AssertExpectations(t)
+	})
+}
+
+func TestSetBaseImage(t *testing.T) {
+	t.Run("replaces the first base image", func(t *testing.T) {
+		dir := t.TempDir()
+		content := "# comment\nFROM --platform=linux/amd64 quay.io/astronomer/astro-runtime:6.0.0 AS base\nRUN echo hi\nFROM base\n"
+		assert.NoError(t, os.WriteFile(filepath.Join(dir, "Dockerfile"), []byte(content), 0o600))
+
+		restore, err := SetBaseImage(dir, "quay.io/astronomer/astro-runtime:7.0.0")
+		assert.NoError(t, err)
+
+		updated, err := os.ReadFile(filepath.Join(dir, "Dockerfile"))
+		assert.NoError(t, err)
+		assert.Equal(t, "# comment\nFROM --platform=linux/amd64 quay.io/astronomer/astro-runtime:7.0.0 AS base\nRUN echo hi\nFROM base\n", string(updated))
+
+		assert.NoError(t, restore())
+		restored, err := os.ReadFile(filepath.Join(dir, "Dockerfile"))
+		assert.NoError(t, err)
+		assert.Equal(t, content, string(restored))
+	})
+
+	t.Run("no base image", func(t *testing.T) {
+		dir := t.TempDir()
+		assert.NoError(t, os.WriteFile(filepath.Join(dir, "Dockerfile"), []byte("RUN echo hi\n"), 0o600))
+
+		_, err := SetBaseImage(dir, "quay.io/astronomer/astro-runtime:7.0.0")
+		assert.ErrorIs(t, err, errNoBaseImage)
+	})
+
+	t.Run("no dockerfile", func(t *testing.T) {
+		_, err := SetBaseImage(t.TempDir(), "quay.io/astronomer/astro-runtime:7.0.0")
+		assert.ErrorIs(t, err, os.ErrNotExist)
+	})
+}
@@ -0,0 +1,139 @@
+package deploy
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"regexp"
+	"text/template"
+	"time"
+
+	"github.com/astronomer/astro-cli/airflow"
+	"github.com/astronomer/astro-cli/houston"
+	"github.com/astronomer/astro-cli/pkg/git"
+)
+
+// DefaultImageTagTemplate is the tag of the images pushed to a BYO registry when no template is set
+const DefaultImageTagTemplate = "{{.ReleaseName}}-deploy-{{.Timestamp}}"
+
+var (
+	errInvalidImageTag = errors.New("is not a valid image tag")
+	errImageTagExists  = errors.New("already exists in the registry, image tags can not be overwritten")
+	errGitValue        = errors.New("could not be resolved from the git repository of the project")
+
+	imageTagRegex = regexp.MustCompile(`^[A-Za-z0-9_][A-Za-z0-9_.-]{0,127}$`)
+
+	// these are used to monkey patch the functions in order to write unit test cases
+	gitCommitSHA = git.GetCommitSHA
+	gitTag       = git.GetTag
+	now          = time.Now
+)
+
+// imageTagData is the data available to the image tag templates
+type imageTagData struct {
+	ReleaseName    string
+	Timestamp      string
+	RuntimeVersion string
+	AirflowVersion string
+}
+
+// GitSHA returns the short SHA of the project commit, it is only resolved by the templates that use it
+func (d *imageTagData) GitSHA() (string, error) {
+	sha, err := gitCommitSHA()
+	if err != nil {
+		return "", fmt.Errorf("commit SHA %w: %s", errGitValue, err.Error())
+	}
+	return sha, nil
+}
+
+// GitTag returns the tag of the project commit, it is only resolved by the templates that use it
+func (d *imageTagData) GitTag() (string, error) {
+	tag, err := gitTag()
+	if err != nil {
+		return "", fmt.Errorf("tag %w, is the commit tagged? %s", errGitValue, err.Error())
+	}
+	return tag, nil
+}
+
+// renderImageTags returns the tags of the tagTemplates, or of DefaultImageTagTemplate when there are none
+func renderImageTags(tagTemplates []string, data *imageTagData) ([]string, error) {
+	if len(tagTemplates) == 0 {
+		tagTemplates = []string{DefaultImageTagTemplate}
+	}
+	tags := make([]string, 0, len(tagTemplates))
+	for _, tagTemplate := range tagTemplates {
+		tmpl, err := template.New("tag").Option("missingkey=error").Parse(tagTemplate)
+		if err != nil {
+			return nil, fmt.Errorf("invalid image tag template %s: %w", tagTemplate, err)
+		}
+		buf := new(bytes.Buffer)
+		if err := tmpl.Execute(buf, data); err != nil {
+			return nil, fmt.Errorf("invalid image tag template %s: %w", tagTemplate, err)
+		}
+		tag := buf.String()
+		if !imageTagRegex.MatchString(tag) {
+			return nil, fmt.Errorf("%q from the template %s %w", tag, tagTemplate, errInvalidImageTag)
+		}
+		if !contains(tags, tag) {
+			tags = append(tags, tag)
+		}
+	}
+	return tags, nil
+}
+
+// pushBYORegistryImage pushes the image with every tag of tagTemplates to the BYO registry, without overwriting
+// existing tags, and updates the deployment to the digest of the pushed image
+func pushBYORegistryImage(houstonClient houston.ClientInterface, imageHandler airflow.ImageHandler, name, registry string, tagTemplates []string) error {
+	runtimeVersion, _ := imageHandler.GetLabel("", runtimeImageLabel)
+	airflowVersion, _ := imageHandler.GetLabel("", airflowImageLabel)
+
+	data := &imageTagData{
+		ReleaseName:    name,
+		Timestamp:      now().UTC().Format("2006-01-02T15-04-05"),
+		RuntimeVersion: runtimeVersion,
+		AirflowVersion: airflowVersion,
+	}
+
+	tags, err := renderImageTags(tagTemplates, data)
+	if err != nil {
+		return err
+	}
+	remoteImages := make([]string, 0, len(tags))
+	for _, tag := range tags {
+		remoteImage := fmt.Sprintf("%s:%s", registry, tag)
+		// only a tag missing from the registry is free, any other failure can't tell whether it would be overwritten
+		switch err := imageHandler.DoesImageExist(remoteImage); {
+		case err == nil:
+			return fmt.Errorf("%s %w", remoteImage, errImageTagExists)
+		case !errors.Is(err, airflow.ErrImageNotFound):
+			return fmt.Errorf("failed to check whether %s exists in the registry: %w", remoteImage, err)
+		}
+		remoteImages = append(remoteImages, remoteImage)
+	}
+
+	for _, remoteImage := range remoteImages {
+		if err := imageHandler.Push(registry, "", "", remoteImage); err != nil {
+			return err
+		}
+	}
+
+	digest, err := imageHandler.GetRemoteDigest(remoteImages[0])
+	if err != nil {
+		return fmt.Errorf("failed to get the digest of %s: %w", remoteImages[0], err)
+	}
+	image := fmt.Sprintf("%s@%s", registry, digest)
+	fmt.Printf("Updating the deployment to the image %s\n", image)
+
+	req := houston.UpdateDeploymentImageRequest{ReleaseName: name, Image: image, AirflowVersion: airflowVersion, RuntimeVersion: runtimeVersion}
+	_, err = houston.Call(houstonClient.UpdateDeploymentImage)(req)
+	return err
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
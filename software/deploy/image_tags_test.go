@@ -0,0 +1,58 @@
+package deploy
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/astronomer/astro-cli/pkg/git"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRenderImageTags(t *testing.T) {
+	data := &imageTagData{ReleaseName: "test", Timestamp: "2023-01-02T03-04-05", RuntimeVersion: "7.0.0"}
+	gitCommitSHA = func() (string, error) { return "abc1234", nil }
+	gitTag = func() (string, error) { return "v1.2.0", nil }
+	defer func() {
+		gitCommitSHA = git.GetCommitSHA
+		gitTag = git.GetTag
+	}()
+
+	t.Run("default template", func(t *testing.T) {
+		tags, err := renderImageTags(nil, data)
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"test-deploy-2023-01-02T03-04-05"}, tags)
+	})
+
+	t.Run("multiple templates", func(t *testing.T) {
+		tags, err := renderImageTags([]string{"{{.GitSHA}}", "{{.GitTag}}", "runtime-{{.RuntimeVersion}}", "{{.GitSHA}}"}, data)
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"abc1234", "v1.2.0", "runtime-7.0.0"}, tags)
+	})
+
+	t.Run("empty value", func(t *testing.T) {
+		_, err := renderImageTags([]string{"{{.AirflowVersion}}"}, data)
+		assert.ErrorIs(t, err, errInvalidImageTag)
+	})
+
+	t.Run("invalid tag", func(t *testing.T) {
+		_, err := renderImageTags([]string{"{{.ReleaseName}}:latest"}, data)
+		assert.ErrorIs(t, err, errInvalidImageTag)
+	})
+
+	t.Run("git value not resolved", func(t *testing.T) {
+		gitTag = func() (string, error) { return "", errors.New("no tag exactly matches") }
+		defer func() { gitTag = func() (string, error) { return "v1.2.0", nil } }()
+
+		_, err := renderImageTags([]string{"{{.GitTag}}"}, data)
+		assert.ErrorIs(t, err, errGitValue)
+
+		tags, err := renderImageTags([]string{"{{.GitSHA}}"}, data)
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"abc1234"}, tags)
+	})
+
+	t.Run("unknown field", func(t *testing.T) {
+		_, err := renderImageTags([]string{"{{.Branch}}"}, data)
+		assert.ErrorContains(t, err, "invalid image tag template {{.Branch}}")
+	})
+}
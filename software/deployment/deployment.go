@@ -16,6 +16,7 @@ import (
 	"github.com/astronomer/astro-cli/pkg/input"
 	"github.com/astronomer/astro-cli/pkg/printutil"
 	"github.com/astronomer/astro-cli/settings"
+	"github.com/astronomer/astro-cli/software/utils"
 
 	semver "github.com/Masterminds/semver/v3"
 	"github.com/fatih/camelcase"
@@ -148,10 +149,8 @@ func addTriggererReplicasArg(vars map[string]interface{}, client houston.ClientI
 	}
 }
 
-// Create airflow deployment
-func Create(req *CreateDeploymentRequest, client houston.ClientInterface, out io.Writer) error {
-	vars := map[string]interface{}{"label": req.Label, "workspaceId": req.WS, "executor": req.Executor, "cloudRole": req.CloudRole}
-
+// addNamespaceArg asks the user for the kubernetes namespace of a new deployment when the platform requires it
+func addNamespaceArg(vars map[string]interface{}, client houston.ClientInterface, out io.Writer) error {
 	if CheckPreCreateNamespaceDeployment(client) {
 		namespace, err := getDeploymentSelectionNamespaces(client, out)
 		if err != nil {
@@ -167,6 +166,16 @@ func Create(req *CreateDeploymentRequest, client houston.ClientInterface, out io
 		}
 		vars["namespace"] = namespace
 	}
+	return nil
+}
+
+// Create airflow deployment
+func Create(req *CreateDeploymentRequest, client houston.ClientInterface, out io.Writer) error {
+	vars := map[string]interface{}{"label": req.Label, "workspaceId": req.WS, "executor": req.Executor, "cloudRole": req.CloudRole}
+
+	if err := addNamespaceArg(vars, client, out); err != nil {
+		return err
+	}
 
 	if req.ReleaseName != "" && checkManualReleaseNames(client) {
 		vars["releaseName"] = req.ReleaseName
@@ -310,24 +319,45 @@ func List(ws string, all bool, client houston.ClientInterface, out io.Writer) er
 
 	// Build rows
 	for i := range deployments {
-		d := deployments[i]
-
-		currentTag := d.DeploymentInfo.Current
-		if currentTag == "" {
-			currentTag = "?"
-		}
-		var resp []string
-		if d.RuntimeVersion != "" {
-			resp = []string{d.Label, d.ReleaseName, "v" + d.Version, d.ID, currentTag, fmt.Sprintf("%s-%s", runtimeImageType, d.RuntimeVersion)}
-		} else {
-			resp = []string{d.Label, d.ReleaseName, "v" + d.Version, d.ID, currentTag, fmt.Sprintf("%s-%s", certifiedImageType, d.AirflowVersion)}
-		}
-		tab.AddRow(resp, false)
+		tab.AddRow(deploymentRow(&deployments[i]), false)
 	}
 
 	return tab.Print(out)
 }
 
+// PaginatedList prints the page of deployments after the cursor of opts, or every deployment after it with opts.All.
+// Deployments are listed from every workspace when ws is empty, and only those with label when it is set.
+func PaginatedList(ws, label string, opts utils.ListOptions, client houston.ClientInterface, out io.Writer) error {
+	fetch := func(cursor string, take int) ([]houston.Deployment, error) {
+		return houston.Call(client.PaginatedListDeployments)(houston.PaginatedDeploymentsRequest{WorkspaceID: ws, Label: label, CursorID: cursor, Take: take})
+	}
+	deployments, next, err := utils.FetchPages(opts, fetch, func(d *houston.Deployment) string { return d.ID })
+	if err != nil {
+		return err
+	}
+
+	tab := newTableOut()
+	for i := range deployments {
+		tab.AddRow(deploymentRow(&deployments[i]), false)
+	}
+	if err := tab.Print(out); err != nil {
+		return err
+	}
+	utils.PrintNextCursor(next, out)
+	return nil
+}
+
+func deploymentRow(d *houston.Deployment) []string {
+	currentTag := d.DeploymentInfo.Current
+	if currentTag == "" {
+		currentTag = "?"
+	}
+	if d.RuntimeVersion != "" {
+		return []string{d.Label, d.ReleaseName, "v" + d.Version, d.ID, currentTag, fmt.Sprintf("%s-%s", runtimeImageType, d.RuntimeVersion)}
+	}
+	return []string{d.Label, d.ReleaseName, "v" + d.Version, d.ID, currentTag, fmt.Sprintf("%s-%s", certifiedImageType, d.AirflowVersion)}
+}
+
 // Update an airflow deployment
 func Update(id, cloudRole string, args map[string]string, dagDeploymentType, nfsLocation, gitRepoURL, gitRevision, gitBranchName, gitDAGDir, sshKey, knownHosts, executor string, gitSyncInterval, triggererReplicas int, client houston.ClientInterface, out io.Writer) error {
 	vars := map[string]interface{}{"deploymentId": id, "payload": args, "cloudRole": cloudRole}
@@ -11,6 +11,7 @@ import (
 
 	semver "github.com/Masterminds/semver/v3"
 	testUtil "github.com/astronomer/astro-cli/pkg/testing"
+	"github.com/astronomer/astro-cli/software/utils"
 
 	"github.com/astronomer/astro-cli/houston"
 	mocks "github.com/astronomer/astro-cli/houston/mocks"
@@ -25,6 +26,46 @@ var (
 	errRegMock              = errors.New("error")
 )
 
+func TestPaginatedList(t *testing.T) {
+	testUtil.InitTestConfig(testUtil.SoftwarePlatform)
+	mockDeployments := []houston.Deployment{
+		{ID: "dep-1", Label: "prod-1", ReleaseName: "release-1", Version: "0.15.6", AirflowVersion: "2.4.1"},
+		{ID: "dep-2", Label: "prod-2", ReleaseName: "release-2", Version: "0.15.6", RuntimeVersion: "7.0.0"},
+	}
+
+	t.Run("page of deployments with a label", func(t *testing.T) {
+		api := new(mocks.ClientInterface)
+		api.On("PaginatedListDeployments", houston.PaginatedDeploymentsRequest{WorkspaceID: "ws-id", Label: "prod", Take: 2}).Return(mockDeployments, nil)
+
+		buf := new(bytes.Buffer)
+		err := PaginatedList("ws-id", "prod", utils.ListOptions{PageSize: 2}, api, buf)
+		assert.NoError(t, err)
+		assert.Contains(t, buf.String(), "release-1")
+		assert.Contains(t, buf.String(), "Runtime-7.0.0")
+		assert.Contains(t, buf.String(), "--cursor=dep-2")
+		api.AssertExpectations(t)
+	})
+
+	t.Run("every deployment of every workspace", func(t *testing.T) {
+		api := new(mocks.ClientInterface)
+		api.On("PaginatedListDeployments", houston.PaginatedDeploymentsRequest{Take: utils.DefaultPageSize}).Return(mockDeployments, nil)
+
+		buf := new(bytes.Buffer)
+		err := PaginatedList("", "", utils.ListOptions{All: true}, api, buf)
+		assert.NoError(t, err)
+		assert.Contains(t, buf.String(), "release-2")
+		assert.NotContains(t, buf.String(), "--cursor")
+	})
+
+	t.Run("error", func(t *testing.T) {
+		api := new(mocks.ClientInterface)
+		api.On("PaginatedListDeployments", mock.Anything).Return(nil, errMock)
+
+		err := PaginatedList("ws-id", "", utils.ListOptions{All: true}, api, new(bytes.Buffer))
+		assert.ErrorIs(t, err, errMock)
+	})
+}
+
 func TestGetDeployments(t *testing.T) {
 	// Create a mock Houston client
 	mockClient := &mocks.ClientInterface{}
@@ -0,0 +1,409 @@
+package deployment
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"regexp"
+
+	"github.com/astronomer/astro-cli/houston"
+	"github.com/ghodss/yaml"
+	"github.com/sirupsen/logrus"
+)
+
+var (
+	errEmptyFile                      = errors.New("has no content")
+	errRequiredField                  = errors.New("missing required field")
+	errInvalidValue                   = errors.New("is not valid")
+	errCannotUpdateExistingDeployment = errors.New("already exists")
+	errNotFound                       = errors.New("does not exist")
+	errAirflowAndRuntimeVersion       = errors.New("only one of deployment.configuration.airflow_version and deployment.configuration.runtime_version can be set")
+
+	// kubernetes quantities as used by the Astronomer platform, e.g. 500m, 1.5 or 1920Mi
+	quantityRegex = regexp.MustCompile(`^[0-9]+(\.[0-9]+)?(m|k|Ki|M|Mi|G|Gi|T|Ti)?$`)
+)
+
+const (
+	CreateAction = "create"
+	UpdateAction = "update"
+
+	defaultGitSyncInterval = 60
+)
+
+// CreateOrUpdateFromFile creates or updates a deployment with the configuration of inputFile, in the format printed
+// by Inspect. inputFile can be in yaml or json format. The deployment is found by name in the workspace of the file,
+// or in ws when the file has none. Users and teams of the file are added to the deployment or have their role
+// updated, other users and teams of the deployment are left as they are.
+func CreateOrUpdateFromFile(inputFile, action, ws string, client houston.ClientInterface, out io.Writer) error {
+	dataBytes, err := os.ReadFile(inputFile)
+	if err != nil {
+		return err
+	}
+	if len(dataBytes) == 0 {
+		return fmt.Errorf("%s %w", inputFile, errEmptyFile)
+	}
+
+	var formatted FormattedDeployment
+	if err = yaml.Unmarshal(dataBytes, &formatted); err != nil {
+		return err
+	}
+	spec := &formatted.Deployment
+
+	if err = checkRequiredFields(spec, action); err != nil {
+		return err
+	}
+	if err = validateDeploymentSpec(spec, client); err != nil {
+		return err
+	}
+
+	if spec.Configuration.WorkspaceID != "" {
+		ws = spec.Configuration.WorkspaceID
+	}
+	deployments, err := GetDeployments(ws, client)
+	if err != nil {
+		return err
+	}
+	existing, exists := deploymentFromLabel(deployments, spec.Configuration.Name)
+
+	var d *houston.Deployment
+	switch action {
+	case CreateAction:
+		if exists {
+			return fmt.Errorf("deployment: %s %w: use deployment update --deployment-file %s instead", spec.Configuration.Name, errCannotUpdateExistingDeployment, inputFile)
+		}
+		vars, err := getCreateVars(spec, ws, client, out)
+		if err != nil {
+			return err
+		}
+		d, err = houston.Call(client.CreateDeployment)(vars)
+		if err != nil {
+			return err
+		}
+	case UpdateAction:
+		if !exists {
+			return fmt.Errorf("deployment: %s %w: use deployment create --deployment-file %s instead", spec.Configuration.Name, errNotFound, inputFile)
+		}
+		if (spec.Configuration.AirflowVersion != "" && spec.Configuration.AirflowVersion != existing.AirflowVersion) ||
+			(spec.Configuration.RuntimeVersion != "" && spec.Configuration.RuntimeVersion != existing.RuntimeVersion) {
+			fmt.Fprintln(out, "The Airflow and Runtime versions of the file are only used on create, use astro deployment airflow upgrade or astro deployment runtime upgrade to change them")
+		}
+		vars, err := getUpdateVars(spec, existing.ID, client)
+		if err != nil {
+			return err
+		}
+		d, err = houston.Call(client.UpdateDeployment)(vars)
+		if err != nil {
+			return err
+		}
+	}
+
+	if err = applyRoles(d.ID, spec, client, out); err != nil {
+		return err
+	}
+
+	var outputFormat string
+	if isJSON(dataBytes) {
+		outputFormat = jsonFormat
+	}
+	return Inspect(d.ID, outputFormat, false, client, out)
+}
+
+func getCreateVars(spec *DeploymentSpec, ws string, client houston.ClientInterface, out io.Writer) (map[string]interface{}, error) {
+	c := &spec.Configuration
+	vars := map[string]interface{}{"label": c.Name, "workspaceId": ws, "executor": c.Executor}
+	if c.CloudRole != "" {
+		vars["cloudRole"] = c.CloudRole
+	}
+
+	if c.Namespace != "" {
+		vars["namespace"] = c.Namespace
+	} else if err := addNamespaceArg(vars, client, out); err != nil {
+		return nil, err
+	}
+
+	if c.ReleaseName != "" && checkManualReleaseNames(client) {
+		vars["releaseName"] = c.ReleaseName
+	}
+
+	if c.AirflowVersion != "" {
+		vars["airflowVersion"] = c.AirflowVersion
+	} else if c.RuntimeVersion != "" {
+		vars["runtimeVersion"] = c.RuntimeVersion
+	}
+
+	if err := addAirflowConfigArgs(vars, spec, client); err != nil {
+		return nil, err
+	}
+	return vars, nil
+}
+
+func getUpdateVars(spec *DeploymentSpec, deploymentID string, client houston.ClientInterface) (map[string]interface{}, error) {
+	c := &spec.Configuration
+	payload := map[string]string{"label": c.Name}
+	if c.Description != "" {
+		payload["description"] = c.Description
+	}
+	vars := map[string]interface{}{"deploymentId": deploymentID, "payload": payload}
+	if c.Executor != "" {
+		vars["executor"] = c.Executor
+	}
+	// sync with commander only when we have cloudRole
+	if c.CloudRole != "" {
+		vars["cloudRole"] = c.CloudRole
+		vars["sync"] = true
+	}
+
+	if err := addAirflowConfigArgs(vars, spec, client); err != nil {
+		return nil, err
+	}
+	return vars, nil
+}
+
+// addAirflowConfigArgs adds the resources, the triggerer replicas and the dag deployment of the file to the houston request map
+func addAirflowConfigArgs(vars map[string]interface{}, spec *DeploymentSpec, client houston.ClientInterface) error {
+	components := map[string]*ComponentResources{
+		"scheduler": spec.Resources.Scheduler,
+		"webserver": spec.Resources.Webserver,
+		"workers":   spec.Resources.Workers,
+		"triggerer": spec.Resources.Triggerer,
+	}
+	airflowConfig := map[string]interface{}{}
+	for name, resources := range components {
+		if resources == nil {
+			continue
+		}
+		component := map[string]interface{}{}
+		quantities := map[string]string{}
+		if resources.CPU != "" {
+			quantities["cpu"] = resources.CPU
+		}
+		if resources.Memory != "" {
+			quantities["memory"] = resources.Memory
+		}
+		if len(quantities) > 0 {
+			component["resources"] = map[string]interface{}{"limits": quantities, "requests": quantities}
+		}
+		// the triggerer replicas have their own argument
+		if resources.Replicas != nil && name != "triggerer" {
+			component["replicas"] = *resources.Replicas
+		}
+		if len(component) > 0 {
+			airflowConfig[name] = component
+		}
+	}
+	if len(airflowConfig) > 0 {
+		vars["config"] = airflowConfig
+	}
+
+	if spec.Resources.Triggerer != nil && spec.Resources.Triggerer.Replicas != nil {
+		addTriggererReplicasArg(vars, client, *spec.Resources.Triggerer.Replicas)
+	}
+
+	if dagDeployment := spec.DagDeployment; dagDeployment != nil {
+		syncInterval := dagDeployment.SyncInterval
+		if syncInterval == 0 {
+			syncInterval = defaultGitSyncInterval
+		}
+		return addDagDeploymentArgs(vars, dagDeployment.Type, dagDeployment.NFSLocation, dagDeployment.SSHKey, dagDeployment.KnownHosts,
+			dagDeployment.RepositoryURL, dagDeployment.Revision, dagDeployment.BranchName, dagDeployment.DagDirectory, syncInterval)
+	}
+	return nil
+}
+
+// applyRoles adds the users and teams of the file to the deployment, or updates their role when it is different
+func applyRoles(deploymentID string, spec *DeploymentSpec, client houston.ClientInterface, out io.Writer) error {
+	if len(spec.Users) > 0 {
+		users, err := houston.Call(client.ListDeploymentUsers)(houston.ListDeploymentUsersRequest{DeploymentID: deploymentID})
+		if err != nil {
+			return err
+		}
+		roles := map[string]string{}
+		for i := range users {
+			roles[users[i].Username] = getDeploymentLevelRole(users[i].RoleBindings, deploymentID)
+		}
+		for _, user := range spec.Users {
+			req := houston.UpdateDeploymentUserRequest{Email: user.Email, Role: user.Role, DeploymentID: deploymentID}
+			switch role, ok := roles[user.Email]; {
+			case !ok || role == houston.NoneRole:
+				if _, err := houston.Call(client.AddDeploymentUser)(req); err != nil {
+					return fmt.Errorf("failed to add user %s: %w", user.Email, err)
+				}
+				fmt.Fprintf(out, "Added user %s as a %s\n", user.Email, user.Role)
+			case role != user.Role:
+				if _, err := houston.Call(client.UpdateDeploymentUser)(req); err != nil {
+					return fmt.Errorf("failed to update user %s: %w", user.Email, err)
+				}
+				fmt.Fprintf(out, "Updated user %s to a %s\n", user.Email, user.Role)
+			}
+		}
+	}
+
+	if len(spec.Teams) > 0 {
+		teams, err := houston.Call(client.ListDeploymentTeamsAndRoles)(deploymentID)
+		if err != nil {
+			return err
+		}
+		roles := map[string]string{}
+		for i := range teams {
+			roles[teams[i].ID] = getDeploymentLevelRole(teams[i].RoleBindings, deploymentID)
+		}
+		for _, team := range spec.Teams {
+			switch role, ok := roles[team.ID]; {
+			case !ok || role == houston.NoneRole:
+				if _, err := houston.Call(client.AddDeploymentTeam)(houston.AddDeploymentTeamRequest{TeamID: team.ID, DeploymentID: deploymentID, Role: team.Role}); err != nil {
+					return fmt.Errorf("failed to add team %s: %w", team.ID, err)
+				}
+				fmt.Fprintf(out, "Added team %s as a %s\n", team.ID, team.Role)
+			case role != team.Role:
+				if _, err := houston.Call(client.UpdateDeploymentTeamRole)(houston.UpdateDeploymentTeamRequest{TeamID: team.ID, DeploymentID: deploymentID, Role: team.Role}); err != nil {
+					return fmt.Errorf("failed to update team %s: %w", team.ID, err)
+				}
+				fmt.Fprintf(out, "Updated team %s to a %s\n", team.ID, team.Role)
+			}
+		}
+	}
+	return nil
+}
+
+// checkRequiredFields returns an error if a field needed for action is missing from the file
+func checkRequiredFields(spec *DeploymentSpec, action string) error {
+	if spec.Configuration.Name == "" {
+		return fmt.Errorf("%w: %s", errRequiredField, "deployment.configuration.name")
+	}
+	if action == CreateAction && spec.Configuration.Executor == "" {
+		return fmt.Errorf("%w: %s", errRequiredField, "deployment.configuration.executor")
+	}
+	for i, user := range spec.Users {
+		if user.Email == "" {
+			return fmt.Errorf("%w: %s", errRequiredField, fmt.Sprintf("deployment.users[%d].email", i))
+		}
+	}
+	for i, team := range spec.Teams {
+		if team.ID == "" {
+			return fmt.Errorf("%w: %s", errRequiredField, fmt.Sprintf("deployment.teams[%d].id", i))
+		}
+	}
+	if spec.DagDeployment != nil {
+		switch spec.DagDeployment.Type {
+		case houston.VolumeDeploymentType:
+			if spec.DagDeployment.NFSLocation == "" {
+				return fmt.Errorf("%w: %s", errRequiredField, "deployment.dag_deployment.nfs_location")
+			}
+		case houston.GitSyncDeploymentType:
+			if spec.DagDeployment.RepositoryURL == "" {
+				return fmt.Errorf("%w: %s", errRequiredField, "deployment.dag_deployment.repository_url")
+			}
+		}
+	}
+	return nil
+}
+
+// validateDeploymentSpec validates the values of the file against the deployment config of the platform
+func validateDeploymentSpec(spec *DeploymentSpec, client houston.ClientInterface) error {
+	c := &spec.Configuration
+	if c.AirflowVersion != "" && c.RuntimeVersion != "" {
+		return errAirflowAndRuntimeVersion
+	}
+
+	deploymentConfig, err := houston.Call(client.GetDeploymentExecutorsConfig)(nil)
+	if err != nil {
+		// the platform may not know the executors field, validate the file without it
+		logrus.Debugf("unable to get the executors of the platform: %s", err.Error())
+		deploymentConfig, err = houston.Call(client.GetDeploymentConfig)(nil)
+		if err != nil {
+			return err
+		}
+	}
+
+	if c.Executor != "" && !isEnabledExecutor(c.Executor, deploymentConfig.Executors) {
+		return fmt.Errorf("deployment.configuration.executor: %s %w", c.Executor, errInvalidValue)
+	}
+	if c.AirflowVersion != "" && len(deploymentConfig.AirflowVersions) > 0 && !contains(deploymentConfig.AirflowVersions, c.AirflowVersion) {
+		return fmt.Errorf("deployment.configuration.airflow_version: %s %w, available versions: %v", c.AirflowVersion, errInvalidValue, deploymentConfig.AirflowVersions)
+	}
+
+	components := []struct {
+		name      string
+		resources *ComponentResources
+	}{
+		{"scheduler", spec.Resources.Scheduler},
+		{"webserver", spec.Resources.Webserver},
+		{"workers", spec.Resources.Workers},
+		{"triggerer", spec.Resources.Triggerer},
+	}
+	for _, component := range components {
+		if component.resources == nil {
+			continue
+		}
+		if component.resources.CPU != "" && !quantityRegex.MatchString(component.resources.CPU) {
+			return fmt.Errorf("deployment.resources.%s.cpu: %s %w", component.name, component.resources.CPU, errInvalidValue)
+		}
+		if component.resources.Memory != "" && !quantityRegex.MatchString(component.resources.Memory) {
+			return fmt.Errorf("deployment.resources.%s.memory: %s %w", component.name, component.resources.Memory, errInvalidValue)
+		}
+		if component.resources.Replicas != nil && *component.resources.Replicas < 0 {
+			return fmt.Errorf("deployment.resources.%s.replicas: %d %w", component.name, *component.resources.Replicas, errInvalidValue)
+		}
+	}
+
+	if spec.DagDeployment != nil {
+		switch spec.DagDeployment.Type {
+		case houston.ImageDeploymentType, houston.VolumeDeploymentType, houston.GitSyncDeploymentType, houston.DagOnlyDeploymentType:
+		default:
+			return fmt.Errorf("deployment.dag_deployment.type: %s %w", spec.DagDeployment.Type, errInvalidValue)
+		}
+	}
+
+	for _, roles := range [][]DeploymentRole{spec.Users, spec.Teams} {
+		for _, r := range roles {
+			if r.Role == houston.NoneRole || !IsValidDeploymentLevelRole(r.Role) {
+				return fmt.Errorf("role %s %w, use one of %s, %s or %s", r.Role, errInvalidValue, houston.DeploymentAdminRole, houston.DeploymentEditorRole, houston.DeploymentViewerRole)
+			}
+		}
+	}
+	return nil
+}
+
+// isEnabledExecutor returns true if executor is enabled on the platform. Platforms that don't report their executors
+// allow all of them.
+func isEnabledExecutor(executor string, executors []houston.Executor) bool {
+	if len(executors) == 0 {
+		switch executor {
+		case houston.CeleryExecutorType, houston.LocalExecutorType, houston.KubernetesExecutorType:
+			return true
+		}
+		return false
+	}
+	for _, e := range executors {
+		if e.Name == executor && e.Enabled {
+			return true
+		}
+	}
+	return false
+}
+
+func deploymentFromLabel(deployments []houston.Deployment, label string) (houston.Deployment, bool) {
+	for i := range deployments {
+		if deployments[i].Label == label {
+			return deployments[i], true
+		}
+	}
+	return houston.Deployment{}, false
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+// isJSON returns true if data is in JSON format.
+func isJSON(data []byte) bool {
+	var js interface{}
+	return json.Unmarshal(data, &js) == nil
+}
@@ -0,0 +1,185 @@
+package deployment
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/astronomer/astro-cli/houston"
+	mocks "github.com/astronomer/astro-cli/houston/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+const deploymentFileContent = `deployment:
+  configuration:
+    name: prod
+    description: production
+    workspace_id: ck05r3bor07h40d02y2hw4n4v
+    executor: CeleryExecutor
+    runtime_version: 7.2.0
+  resources:
+    scheduler:
+      cpu: 500m
+      memory: 1920Mi
+      replicas: 2
+    triggerer:
+      replicas: 1
+  dag_deployment:
+    type: volume
+    nfs_location: test:/test
+  users:
+    - email: admin@astronomer.io
+      role: DEPLOYMENT_ADMIN
+    - email: new@astronomer.io
+      role: DEPLOYMENT_VIEWER
+  teams:
+    - id: team-id
+      role: DEPLOYMENT_ADMIN
+`
+
+var fromFileDeploymentConfig = &houston.DeploymentConfig{
+	AirflowVersions: []string{"2.5.1"},
+	Executors:       []houston.Executor{{Name: houston.CeleryExecutorType, Enabled: true}, {Name: houston.LocalExecutorType}},
+}
+
+func writeDeploymentFile(t *testing.T, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "deployment.yaml")
+	assert.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+	return path
+}
+
+func TestCreateOrUpdateFromFile(t *testing.T) {
+	t.Run("create", func(t *testing.T) {
+		path := writeDeploymentFile(t, deploymentFileContent)
+		api := new(mocks.ClientInterface)
+		api.On("GetDeploymentExecutorsConfig", nil).Return(fromFileDeploymentConfig, nil).Once()
+		api.On("ListDeployments", houston.ListDeploymentsRequest{WorkspaceID: "ck05r3bor07h40d02y2hw4n4v"}).Return([]houston.Deployment{{ID: "other", Label: "dev"}}, nil).Once()
+		api.On("GetAppConfig", nil).Return(&houston.AppConfig{Flags: houston.FeatureFlags{TriggererEnabled: true}}, nil)
+		var vars map[string]interface{}
+		api.On("CreateDeployment", mock.MatchedBy(func(v map[string]interface{}) bool { vars = v; return true })).Return(&houston.Deployment{ID: inspectDeployment.ID}, nil).Once()
+		api.On("ListDeploymentUsers", houston.ListDeploymentUsersRequest{DeploymentID: inspectDeployment.ID}).Return(inspectUsers, nil).Once()
+		api.On("AddDeploymentUser", houston.UpdateDeploymentUserRequest{Email: "new@astronomer.io", Role: houston.DeploymentViewerRole, DeploymentID: inspectDeployment.ID}).Return(&houston.RoleBinding{}, nil).Once()
+		api.On("ListDeploymentTeamsAndRoles", inspectDeployment.ID).Return(inspectTeams, nil).Once()
+		api.On("UpdateDeploymentTeamRole", houston.UpdateDeploymentTeamRequest{TeamID: "team-id", DeploymentID: inspectDeployment.ID, Role: houston.DeploymentAdminRole}).Return(&houston.RoleBinding{}, nil).Once()
+		mockInspect(api)
+
+		out := new(bytes.Buffer)
+		err := CreateOrUpdateFromFile(path, CreateAction, "", api, out)
+		assert.NoError(t, err)
+		assert.Equal(t, "prod", vars["label"])
+		assert.Equal(t, "ck05r3bor07h40d02y2hw4n4v", vars["workspaceId"])
+		assert.Equal(t, houston.CeleryExecutorType, vars["executor"])
+		assert.Equal(t, "7.2.0", vars["runtimeVersion"])
+		assert.Equal(t, 1, vars["triggererReplicas"])
+		assert.Equal(t, map[string]interface{}{"type": houston.VolumeDeploymentType, "nfsLocation": "test:/test"}, vars["dagDeployment"])
+		quantities := map[string]string{"cpu": "500m", "memory": "1920Mi"}
+		assert.Equal(t, map[string]interface{}{
+			"scheduler": map[string]interface{}{"replicas": 2, "resources": map[string]interface{}{"limits": quantities, "requests": quantities}},
+		}, vars["config"])
+		assert.Contains(t, out.String(), "Added user new@astronomer.io as a DEPLOYMENT_VIEWER")
+		assert.NotContains(t, out.String(), "admin@astronomer.io as a")
+		assert.Contains(t, out.String(), "Updated team team-id to a DEPLOYMENT_ADMIN")
+		assert.Contains(t, out.String(), "name: prod")
+		api.AssertExpectations(t)
+	})
+
+	t.Run("create an existing deployment", func(t *testing.T) {
+		path := writeDeploymentFile(t, deploymentFileContent)
+		api := new(mocks.ClientInterface)
+		api.On("GetDeploymentExecutorsConfig", nil).Return(fromFileDeploymentConfig, nil).Once()
+		api.On("ListDeployments", mock.Anything).Return([]houston.Deployment{{ID: inspectDeployment.ID, Label: "prod"}}, nil).Once()
+
+		err := CreateOrUpdateFromFile(path, CreateAction, "", api, new(bytes.Buffer))
+		assert.ErrorIs(t, err, errCannotUpdateExistingDeployment)
+		api.AssertExpectations(t)
+	})
+
+	t.Run("update", func(t *testing.T) {
+		path := writeDeploymentFile(t, `{"deployment": {"configuration": {"name": "prod", "description": "new description"}, "resources": {"workers": {"replicas": 5}}}}`)
+		api := new(mocks.ClientInterface)
+		api.On("GetDeploymentExecutorsConfig", nil).Return(fromFileDeploymentConfig, nil).Once()
+		api.On("ListDeployments", houston.ListDeploymentsRequest{WorkspaceID: "ws-id"}).Return([]houston.Deployment{{ID: inspectDeployment.ID, Label: "prod"}}, nil).Once()
+		api.On("UpdateDeployment", map[string]interface{}{
+			"deploymentId": inspectDeployment.ID,
+			"payload":      map[string]string{"label": "prod", "description": "new description"},
+			"config":       map[string]interface{}{"workers": map[string]interface{}{"replicas": 5}},
+		}).Return(&houston.Deployment{ID: inspectDeployment.ID}, nil).Once()
+		mockInspect(api)
+
+		out := new(bytes.Buffer)
+		err := CreateOrUpdateFromFile(path, UpdateAction, "ws-id", api, out)
+		assert.NoError(t, err)
+		assert.Contains(t, out.String(), `"name": "prod"`)
+		api.AssertExpectations(t)
+	})
+
+	t.Run("platform without executors", func(t *testing.T) {
+		path := writeDeploymentFile(t, "deployment:\n  configuration:\n    name: prod\n    executor: KubernetesExecutor\n")
+		api := new(mocks.ClientInterface)
+		api.On("GetDeploymentExecutorsConfig", nil).Return(nil, errMock).Once()
+		api.On("GetDeploymentConfig", nil).Return(&houston.DeploymentConfig{AirflowVersions: []string{"2.5.1"}}, nil).Once()
+		api.On("ListDeployments", mock.Anything).Return([]houston.Deployment{}, nil).Once()
+
+		err := CreateOrUpdateFromFile(path, UpdateAction, "ws-id", api, new(bytes.Buffer))
+		assert.ErrorIs(t, err, errNotFound)
+		api.AssertExpectations(t)
+	})
+
+	t.Run("update a missing deployment", func(t *testing.T) {
+		path := writeDeploymentFile(t, "deployment:\n  configuration:\n    name: prod\n")
+		api := new(mocks.ClientInterface)
+		api.On("GetDeploymentExecutorsConfig", nil).Return(fromFileDeploymentConfig, nil).Once()
+		api.On("ListDeployments", mock.Anything).Return([]houston.Deployment{}, nil).Once()
+
+		err := CreateOrUpdateFromFile(path, UpdateAction, "ws-id", api, new(bytes.Buffer))
+		assert.ErrorIs(t, err, errNotFound)
+		api.AssertExpectations(t)
+	})
+
+	t.Run("empty file", func(t *testing.T) {
+		err := CreateOrUpdateFromFile(writeDeploymentFile(t, ""), CreateAction, "ws-id", nil, new(bytes.Buffer))
+		assert.ErrorIs(t, err, errEmptyFile)
+	})
+
+	t.Run("missing executor", func(t *testing.T) {
+		err := CreateOrUpdateFromFile(writeDeploymentFile(t, "deployment:\n  configuration:\n    name: prod\n"), CreateAction, "ws-id", nil, new(bytes.Buffer))
+		assert.ErrorIs(t, err, errRequiredField)
+		assert.Contains(t, err.Error(), "deployment.configuration.executor")
+	})
+}
+
+func TestValidateDeploymentSpec(t *testing.T) {
+	replicas := -1
+	tests := []struct {
+		name  string
+		spec  DeploymentSpec
+		field string
+	}{
+		{"disabled executor", DeploymentSpec{Configuration: DeploymentConfiguration{Executor: houston.LocalExecutorType}}, "executor"},
+		{"unknown airflow version", DeploymentSpec{Configuration: DeploymentConfiguration{AirflowVersion: "1.10.5"}}, "airflow_version"},
+		{"invalid cpu", DeploymentSpec{Resources: DeploymentResources{Scheduler: &ComponentResources{CPU: "half"}}}, "scheduler.cpu"},
+		{"invalid memory", DeploymentSpec{Resources: DeploymentResources{Workers: &ComponentResources{Memory: "2 GB"}}}, "workers.memory"},
+		{"negative replicas", DeploymentSpec{Resources: DeploymentResources{Webserver: &ComponentResources{Replicas: &replicas}}}, "webserver.replicas"},
+		{"invalid dag deployment", DeploymentSpec{DagDeployment: &DagDeploymentConfig{Type: "s3"}}, "dag_deployment.type"},
+		{"invalid role", DeploymentSpec{Users: []DeploymentRole{{Email: "user@astronomer.io", Role: houston.WorkspaceAdminRole}}}, "role"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			api := new(mocks.ClientInterface)
+			api.On("GetDeploymentExecutorsConfig", nil).Return(fromFileDeploymentConfig, nil).Once()
+			err := validateDeploymentSpec(&tt.spec, api)
+			assert.ErrorIs(t, err, errInvalidValue)
+			assert.Contains(t, err.Error(), tt.field)
+		})
+	}
+
+	err := validateDeploymentSpec(&DeploymentSpec{Configuration: DeploymentConfiguration{AirflowVersion: "2.5.1", RuntimeVersion: "7.2.0"}}, nil)
+	assert.ErrorIs(t, err, errAirflowAndRuntimeVersion)
+
+	// platforms that don't report executors allow all of them
+	assert.True(t, isEnabledExecutor(houston.KubernetesExecutorType, nil))
+	assert.False(t, isEnabledExecutor("SequentialExecutor", nil))
+}
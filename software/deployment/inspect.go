@@ -0,0 +1,218 @@
+package deployment
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/astronomer/astro-cli/houston"
+	"gopkg.in/yaml.v3"
+)
+
+const jsonFormat = "json"
+
+// FormattedDeployment is a deployment as exported by inspect and read by CreateOrUpdateFromFile
+type FormattedDeployment struct {
+	Deployment DeploymentSpec `yaml:"deployment" json:"deployment"`
+}
+
+// DeploymentSpec contains the configuration of a deployment in a deployment file
+type DeploymentSpec struct {
+	Configuration DeploymentConfiguration `yaml:"configuration" json:"configuration"`
+	Resources     DeploymentResources     `yaml:"resources" json:"resources"`
+	DagDeployment *DagDeploymentConfig    `yaml:"dag_deployment,omitempty" json:"dag_deployment,omitempty"`
+	Users         []DeploymentRole        `yaml:"users,omitempty" json:"users,omitempty"`
+	Teams         []DeploymentRole        `yaml:"teams,omitempty" json:"teams,omitempty"`
+	Metadata      *DeploymentMetadata     `yaml:"metadata,omitempty" json:"metadata,omitempty"`
+}
+
+type DeploymentConfiguration struct {
+	Name           string `yaml:"name" json:"name"`
+	Description    string `yaml:"description" json:"description"`
+	WorkspaceID    string `yaml:"workspace_id,omitempty" json:"workspace_id,omitempty"`
+	ReleaseName    string `yaml:"release_name,omitempty" json:"release_name,omitempty"`
+	Namespace      string `yaml:"namespace,omitempty" json:"namespace,omitempty"`
+	CloudRole      string `yaml:"cloud_role,omitempty" json:"cloud_role,omitempty"`
+	Executor       string `yaml:"executor" json:"executor"`
+	AirflowVersion string `yaml:"airflow_version,omitempty" json:"airflow_version,omitempty"`
+	RuntimeVersion string `yaml:"runtime_version,omitempty" json:"runtime_version,omitempty"`
+}
+
+type DeploymentResources struct {
+	Scheduler *ComponentResources `yaml:"scheduler,omitempty" json:"scheduler,omitempty"`
+	Webserver *ComponentResources `yaml:"webserver,omitempty" json:"webserver,omitempty"`
+	Workers   *ComponentResources `yaml:"workers,omitempty" json:"workers,omitempty"`
+	Triggerer *ComponentResources `yaml:"triggerer,omitempty" json:"triggerer,omitempty"`
+}
+
+// ComponentResources are the resources of one Airflow component, cpu and memory are kubernetes quantities like 500m and 1920Mi
+type ComponentResources struct {
+	CPU      string `yaml:"cpu,omitempty" json:"cpu,omitempty"`
+	Memory   string `yaml:"memory,omitempty" json:"memory,omitempty"`
+	Replicas *int   `yaml:"replicas,omitempty" json:"replicas,omitempty"`
+}
+
+// DagDeploymentConfig is how DAGs get to the deployment. The ssh key and known hosts are paths to local files, they
+// are never exported
+type DagDeploymentConfig struct {
+	Type          string `yaml:"type" json:"type"`
+	NFSLocation   string `yaml:"nfs_location,omitempty" json:"nfs_location,omitempty"`
+	RepositoryURL string `yaml:"repository_url,omitempty" json:"repository_url,omitempty"`
+	BranchName    string `yaml:"branch_name,omitempty" json:"branch_name,omitempty"`
+	Revision      string `yaml:"revision,omitempty" json:"revision,omitempty"`
+	DagDirectory  string `yaml:"dag_directory,omitempty" json:"dag_directory,omitempty"`
+	SyncInterval  int    `yaml:"sync_interval,omitempty" json:"sync_interval,omitempty"`
+	SSHKey        string `yaml:"ssh_key,omitempty" json:"ssh_key,omitempty"`
+	KnownHosts    string `yaml:"known_hosts,omitempty" json:"known_hosts,omitempty"`
+}
+
+// DeploymentRole is the deployment role of a user, identified by email, or of a team, identified by ID
+type DeploymentRole struct {
+	Email string `yaml:"email,omitempty" json:"email,omitempty"`
+	ID    string `yaml:"id,omitempty" json:"id,omitempty"`
+	Name  string `yaml:"name,omitempty" json:"name,omitempty"`
+	Role  string `yaml:"role" json:"role"`
+}
+
+type DeploymentMetadata struct {
+	DeploymentID string    `yaml:"deployment_id" json:"deployment_id"`
+	WorkspaceID  string    `yaml:"workspace_id" json:"workspace_id"`
+	ReleaseName  string    `yaml:"release_name" json:"release_name"`
+	Version      string    `yaml:"version" json:"version"`
+	CurrentTag   string    `yaml:"current_tag" json:"current_tag"`
+	AirflowURL   string    `yaml:"airflow_url" json:"airflow_url"`
+	CreatedAt    time.Time `yaml:"created_at" json:"created_at"`
+	UpdatedAt    time.Time `yaml:"updated_at" json:"updated_at"`
+}
+
+var (
+	jsonMarshal = json.MarshalIndent
+	yamlMarshal = yaml.Marshal
+)
+
+// Inspect prints the deployment in yaml or json. With template set, the name and every field that identifies the
+// deployment are left out so the output can be used to create a new deployment.
+func Inspect(deploymentID, outputFormat string, template bool, client houston.ClientInterface, out io.Writer) error {
+	formatted, err := getFormattedDeployment(deploymentID, client)
+	if err != nil {
+		return err
+	}
+	if template {
+		formatted = getTemplate(formatted)
+	}
+
+	var infoToPrint []byte
+	switch outputFormat {
+	case jsonFormat:
+		infoToPrint, err = jsonMarshal(formatted, "", "    ")
+	default:
+		// always yaml by default
+		infoToPrint, err = yamlMarshal(formatted)
+	}
+	if err != nil {
+		return err
+	}
+	fmt.Fprintln(out, string(infoToPrint))
+	return nil
+}
+
+func getFormattedDeployment(deploymentID string, client houston.ClientInterface) (FormattedDeployment, error) {
+	d, err := houston.Call(client.GetDeployment)(deploymentID)
+	if err != nil {
+		return FormattedDeployment{}, err
+	}
+
+	users, err := houston.Call(client.ListDeploymentUsers)(houston.ListDeploymentUsersRequest{DeploymentID: deploymentID})
+	if err != nil {
+		return FormattedDeployment{}, err
+	}
+
+	teams, err := houston.Call(client.ListDeploymentTeamsAndRoles)(deploymentID)
+	if err != nil {
+		return FormattedDeployment{}, err
+	}
+
+	var airflowURL string
+	for _, url := range d.Urls {
+		if url.Type == "airflow" {
+			airflowURL = url.URL
+		}
+	}
+
+	spec := DeploymentSpec{
+		Configuration: DeploymentConfiguration{
+			Name:           d.Label,
+			Description:    d.Description,
+			WorkspaceID:    d.Workspace.ID,
+			ReleaseName:    d.ReleaseName,
+			Executor:       d.Config.Executor,
+			AirflowVersion: d.AirflowVersion,
+			RuntimeVersion: d.RuntimeVersion,
+		},
+		Resources: DeploymentResources{
+			Scheduler: getComponentResources(&d.Config.Scheduler),
+			Webserver: getComponentResources(&d.Config.Webserver),
+			Workers:   getComponentResources(&d.Config.Workers),
+			Triggerer: getComponentResources(&d.Config.Triggerer),
+		},
+		Metadata: &DeploymentMetadata{
+			DeploymentID: d.ID,
+			WorkspaceID:  d.Workspace.ID,
+			ReleaseName:  d.ReleaseName,
+			Version:      d.Version,
+			CurrentTag:   d.DeploymentInfo.Current,
+			AirflowURL:   airflowURL,
+			CreatedAt:    d.CreatedAt,
+			UpdatedAt:    d.UpdatedAt,
+		},
+	}
+	// runtime deployments report the airflow version of the runtime image, it can't be set on its own
+	if d.RuntimeVersion != "" {
+		spec.Configuration.AirflowVersion = ""
+	}
+	if d.DagDeployment.Type != "" {
+		spec.DagDeployment = &DagDeploymentConfig{
+			Type:          d.DagDeployment.Type,
+			NFSLocation:   d.DagDeployment.NfsLocation,
+			RepositoryURL: d.DagDeployment.RepositoryURL,
+			BranchName:    d.DagDeployment.BranchName,
+			Revision:      d.DagDeployment.Rev,
+			DagDirectory:  d.DagDeployment.DagDirectoryLocation,
+			SyncInterval:  d.DagDeployment.SyncInterval,
+		}
+	}
+	for i := range users {
+		role := getDeploymentLevelRole(users[i].RoleBindings, deploymentID)
+		if role != houston.NoneRole {
+			spec.Users = append(spec.Users, DeploymentRole{Email: users[i].Username, Role: role})
+		}
+	}
+	for i := range teams {
+		role := getDeploymentLevelRole(teams[i].RoleBindings, deploymentID)
+		if role != houston.NoneRole {
+			spec.Teams = append(spec.Teams, DeploymentRole{ID: teams[i].ID, Name: teams[i].Name, Role: role})
+		}
+	}
+
+	return FormattedDeployment{Deployment: spec}, nil
+}
+
+// getComponentResources returns nil for components the deployment does not run, like the triggerer on Airflow 1
+func getComponentResources(component *houston.ComponentConfig) *ComponentResources {
+	limits := component.Resources.Limits
+	if limits.CPU == "" && limits.Memory == "" && component.Replicas == 0 {
+		return nil
+	}
+	replicas := component.Replicas
+	return &ComponentResources{CPU: limits.CPU, Memory: limits.Memory, Replicas: &replicas}
+}
+
+func getTemplate(formatted FormattedDeployment) FormattedDeployment {
+	template := formatted
+	template.Deployment.Configuration.Name = ""
+	template.Deployment.Configuration.WorkspaceID = ""
+	template.Deployment.Configuration.ReleaseName = ""
+	template.Deployment.Metadata = nil
+	return template
+}
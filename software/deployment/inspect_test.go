@@ -0,0 +1,109 @@
+package deployment
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/astronomer/astro-cli/houston"
+	mocks "github.com/astronomer/astro-cli/houston/mocks"
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/yaml.v3"
+)
+
+var inspectDeployment = &houston.Deployment{
+	ID:             "ckbv818oa00r107606ywhoqtw",
+	Label:          "prod",
+	Description:    "production",
+	ReleaseName:    "burning-terrestrial-5940",
+	Version:        "0.33.0",
+	AirflowVersion: "2.5.1",
+	RuntimeVersion: "7.2.0",
+	Workspace:      houston.Workspace{ID: "ck05r3bor07h40d02y2hw4n4v"},
+	DeploymentInfo: houston.DeploymentInfo{Current: "deploy-1"},
+	Config: houston.AirflowConfig{
+		Executor:  houston.CeleryExecutorType,
+		Scheduler: houston.ComponentConfig{Replicas: 2, Resources: houston.ComponentResources{Limits: houston.ResourceQuantities{CPU: "500m", Memory: "1920Mi"}}},
+		Workers:   houston.ComponentConfig{Replicas: 3, Resources: houston.ComponentResources{Limits: houston.ResourceQuantities{CPU: "1", Memory: "3840Mi"}}},
+	},
+	DagDeployment: houston.DagDeployment{Type: houston.GitSyncDeploymentType, RepositoryURL: "https://github.com/neel-astro/private-airflow-dags-test", BranchName: "main", SyncInterval: 60},
+	Urls:          []houston.DeploymentURL{{Type: "airflow", URL: "https://deployments.local.astronomer.io/burning-terrestrial-5940/airflow"}},
+	CreatedAt:     time.Date(2023, 6, 5, 0, 0, 0, 0, time.UTC),
+	UpdatedAt:     time.Date(2023, 6, 6, 0, 0, 0, 0, time.UTC),
+}
+
+var inspectUsers = []houston.DeploymentUser{
+	{Username: "admin@astronomer.io", RoleBindings: []houston.RoleBinding{{Role: houston.DeploymentAdminRole, Deployment: houston.Deployment{ID: "ckbv818oa00r107606ywhoqtw"}}}},
+	{Username: "other@astronomer.io", RoleBindings: []houston.RoleBinding{{Role: houston.DeploymentViewerRole, Deployment: houston.Deployment{ID: "other-deployment"}}}},
+}
+
+var inspectTeams = []houston.Team{
+	{ID: "team-id", Name: "data", RoleBindings: []houston.RoleBinding{{Role: houston.DeploymentEditorRole, Deployment: houston.Deployment{ID: "ckbv818oa00r107606ywhoqtw"}}}},
+}
+
+func mockInspect(api *mocks.ClientInterface) {
+	api.On("GetDeployment", inspectDeployment.ID).Return(inspectDeployment, nil).Once()
+	api.On("ListDeploymentUsers", houston.ListDeploymentUsersRequest{DeploymentID: inspectDeployment.ID}).Return(inspectUsers, nil).Once()
+	api.On("ListDeploymentTeamsAndRoles", inspectDeployment.ID).Return(inspectTeams, nil).Once()
+}
+
+func TestInspect(t *testing.T) {
+	t.Run("yaml", func(t *testing.T) {
+		api := new(mocks.ClientInterface)
+		mockInspect(api)
+
+		out := new(bytes.Buffer)
+		err := Inspect(inspectDeployment.ID, "", false, api, out)
+		assert.NoError(t, err)
+
+		var formatted FormattedDeployment
+		assert.NoError(t, yaml.Unmarshal(out.Bytes(), &formatted))
+		spec := formatted.Deployment
+		assert.Equal(t, DeploymentConfiguration{
+			Name:           "prod",
+			Description:    "production",
+			WorkspaceID:    "ck05r3bor07h40d02y2hw4n4v",
+			ReleaseName:    "burning-terrestrial-5940",
+			Executor:       houston.CeleryExecutorType,
+			RuntimeVersion: "7.2.0",
+		}, spec.Configuration)
+		assert.Equal(t, "500m", spec.Resources.Scheduler.CPU)
+		assert.Equal(t, 2, *spec.Resources.Scheduler.Replicas)
+		assert.Equal(t, "3840Mi", spec.Resources.Workers.Memory)
+		assert.Nil(t, spec.Resources.Triggerer)
+		assert.Equal(t, &DagDeploymentConfig{Type: houston.GitSyncDeploymentType, RepositoryURL: "https://github.com/neel-astro/private-airflow-dags-test", BranchName: "main", SyncInterval: 60}, spec.DagDeployment)
+		assert.Equal(t, []DeploymentRole{{Email: "admin@astronomer.io", Role: houston.DeploymentAdminRole}}, spec.Users)
+		assert.Equal(t, []DeploymentRole{{ID: "team-id", Name: "data", Role: houston.DeploymentEditorRole}}, spec.Teams)
+		assert.Equal(t, "deploy-1", spec.Metadata.CurrentTag)
+		assert.Equal(t, "https://deployments.local.astronomer.io/burning-terrestrial-5940/airflow", spec.Metadata.AirflowURL)
+		api.AssertExpectations(t)
+	})
+
+	t.Run("json template", func(t *testing.T) {
+		api := new(mocks.ClientInterface)
+		mockInspect(api)
+
+		out := new(bytes.Buffer)
+		err := Inspect(inspectDeployment.ID, jsonFormat, true, api, out)
+		assert.NoError(t, err)
+
+		var formatted FormattedDeployment
+		assert.NoError(t, json.Unmarshal(out.Bytes(), &formatted))
+		assert.Empty(t, formatted.Deployment.Configuration.Name)
+		assert.Empty(t, formatted.Deployment.Configuration.ReleaseName)
+		assert.Empty(t, formatted.Deployment.Configuration.WorkspaceID)
+		assert.Nil(t, formatted.Deployment.Metadata)
+		assert.Equal(t, houston.CeleryExecutorType, formatted.Deployment.Configuration.Executor)
+		api.AssertExpectations(t)
+	})
+
+	t.Run("get deployment error", func(t *testing.T) {
+		api := new(mocks.ClientInterface)
+		api.On("GetDeployment", inspectDeployment.ID).Return(nil, errMock).Once()
+
+		err := Inspect(inspectDeployment.ID, "", false, api, new(bytes.Buffer))
+		assert.ErrorIs(t, err, errMock)
+		api.AssertExpectations(t)
+	})
+}
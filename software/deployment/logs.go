@@ -1,52 +1,239 @@
 package deployment
 
 import (
+	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
+	"os"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
 	"time"
 
 	"github.com/astronomer/astro-cli/config"
+	"github.com/astronomer/astro-cli/pkg/fileutil"
 
 	"github.com/astronomer/astro-cli/houston"
 )
 
-var subscribe = houston.Subscribe
+var (
+	subscribe = houston.SubscribeDeploymentLogs
 
-func Log(deploymentID, component, search string, since time.Duration, client houston.ClientInterface, out io.Writer) error {
-	// Calculate timestamp as now - since e.g:
-	// (2019-04-02 17:51:03.780819 +0000 UTC - 2 mins) = 2019-04-02 17:49:03.780819 +0000 UTC
-	timestamp := time.Now().UTC().Add(-since)
-	request := houston.ListDeploymentLogsRequest{
-		DeploymentID: deploymentID,
-		Component:    component,
-		Search:       search,
-		Timestamp:    timestamp,
-	}
+	errNoLogComponents = errors.New("no component to get logs from")
+	errInvalidLogRegex = errors.New("invalid --regex filter")
+)
 
-	logs, err := houston.Call(client.ListDeploymentLogs)(request)
+// LogOptions are the filters of the logs to get, and how and where to print them
+type LogOptions struct {
+	Components []string
+	Search     string
+	Since      time.Duration
+	// Until drops the logs after this time, the zero time keeps every log
+	Until time.Time
+	// Regex keeps only the logs matching at least one of the expressions
+	Regex []string
+	// JSON prints every log as a JSON object on its own line
+	JSON bool
+	// ToFile writes the logs to this file instead of the output, the file is rotated once it reaches MaxFileSize bytes
+	// and at most MaxFiles rotated files are kept
+	ToFile      string
+	MaxFileSize int64
+	MaxFiles    int
+}
+
+// Log prints the logs of the deployment components. Logs of several components are merged in timestamp order.
+func Log(deploymentID string, opts LogOptions, client houston.ClientInterface, out io.Writer) error {
+	if len(opts.Components) == 0 {
+		return errNoLogComponents
+	}
+	printer, err := newLogPrinter(opts, out)
 	if err != nil {
 		return err
 	}
+	defer printer.close()
+
+	// Calculate timestamp as now - since e.g:
+	// (2019-04-02 17:51:03.780819 +0000 UTC - 2 mins) = 2019-04-02 17:49:03.780819 +0000 UTC
+	timestamp := time.Now().UTC().Add(-opts.Since)
+	var logs []houston.DeploymentLog
+	for _, component := range opts.Components {
+		request := houston.ListDeploymentLogsRequest{
+			DeploymentID: deploymentID,
+			Component:    component,
+			Search:       opts.Search,
+			Timestamp:    timestamp,
+		}
+
+		componentLogs, err := houston.Call(client.ListDeploymentLogs)(request)
+		if err != nil {
+			return err
+		}
+		for i := range componentLogs {
+			if componentLogs[i].Component == "" {
+				componentLogs[i].Component = component
+			}
+		}
+		logs = append(logs, componentLogs...)
+	}
+	if len(opts.Components) > 1 {
+		sortLogs(logs)
+	}
 
-	for _, log := range logs {
-		fmt.Fprintln(out, log.Log)
+	for i := range logs {
+		if err := printer.print(logs[i]); err != nil {
+			return err
+		}
 	}
+	printer.done()
 	return nil
 }
 
-func SubscribeDeploymentLog(deploymentID, component, search string, since time.Duration) error {
-	// Calculate timestamp as now - since e.g:
-	// (2019-04-02 17:51:03.780819 +0000 UTC - 2 mins) = 2019-04-02 17:49:03.780819 +0000 UTC
-	timestamp := time.Now().UTC().Add(-since)
-	request, _ := houston.BuildDeploymentLogsSubscribeRequest(deploymentID, component, search, timestamp)
+// SubscribeDeploymentLog prints the logs of the deployment components as they arrive, until interrupted. Every
+// component has its own subscription which reconnects when its connection drops.
+func SubscribeDeploymentLog(deploymentID string, opts LogOptions, out io.Writer) error {
+	if len(opts.Components) == 0 {
+		return errNoLogComponents
+	}
 	cl, err := config.GetCurrentContext()
 	if err != nil {
 		return err
 	}
-
-	err = subscribe(cl.Token, cl.GetSoftwareWebsocketURL(), request)
+	printer, err := newLogPrinter(opts, out)
 	if err != nil {
 		return err
 	}
+	defer printer.close()
+
+	// Calculate timestamp as now - since e.g:
+	// (2019-04-02 17:51:03.780819 +0000 UTC - 2 mins) = 2019-04-02 17:49:03.780819 +0000 UTC
+	timestamp := time.Now().UTC().Add(-opts.Since)
+	errs := make(chan error, len(opts.Components))
+	for _, component := range opts.Components {
+		request := houston.ListDeploymentLogsRequest{
+			DeploymentID: deploymentID,
+			Component:    component,
+			Search:       opts.Search,
+			Timestamp:    timestamp,
+		}
+		go func() {
+			errs <- subscribe(cl.Token, cl.GetSoftwareWebsocketURL(), request, printer.print, os.Stderr)
+		}()
+	}
+
+	var subscribeErr error
+	for range opts.Components {
+		if err := <-errs; err != nil {
+			if len(opts.Components) > 1 {
+				fmt.Fprintf(os.Stderr, "Stopped following logs: %s\n", err.Error())
+			}
+			if subscribeErr == nil {
+				subscribeErr = err
+			}
+		}
+	}
+	if subscribeErr != nil {
+		return subscribeErr
+	}
+	printer.done()
 	return nil
 }
+
+// sortLogs orders logs by timestamp, logs without a valid timestamp keep their position relative to each other
+func sortLogs(logs []houston.DeploymentLog) {
+	timestamps := make(map[string]time.Time, len(logs))
+	for i := range logs {
+		timestamps[logs[i].CreatedAt], _ = time.Parse(time.RFC3339Nano, logs[i].CreatedAt)
+	}
+	sort.SliceStable(logs, func(i, j int) bool {
+		return timestamps[logs[i].CreatedAt].Before(timestamps[logs[j].CreatedAt])
+	})
+}
+
+// logPrinter filters logs and prints them, it is safe for concurrent use
+type logPrinter struct {
+	mu         sync.Mutex
+	opts       LogOptions
+	regex      []*regexp.Regexp
+	out        io.Writer
+	file       *fileutil.RotatingFile
+	multi      bool
+	numPrinted int
+	status     io.Writer
+}
+
+func newLogPrinter(opts LogOptions, out io.Writer) (*logPrinter, error) {
+	p := &logPrinter{opts: opts, out: out, multi: len(opts.Components) > 1, status: out}
+	for _, expr := range opts.Regex {
+		re, err := regexp.Compile(expr)
+		if err != nil {
+			return nil, fmt.Errorf("%w %s: %s", errInvalidLogRegex, expr, err.Error())
+		}
+		p.regex = append(p.regex, re)
+	}
+	if opts.ToFile != "" {
+		file, err := fileutil.NewRotatingFile(opts.ToFile, opts.MaxFileSize, opts.MaxFiles)
+		if err != nil {
+			return nil, fmt.Errorf("unable to create file %s: %w", opts.ToFile, err)
+		}
+		p.file = file
+		p.out = file
+	}
+	return p, nil
+}
+
+func (p *logPrinter) match(deploymentLog houston.DeploymentLog) bool {
+	if !p.opts.Until.IsZero() {
+		if ts, err := time.Parse(time.RFC3339Nano, deploymentLog.CreatedAt); err == nil && ts.After(p.opts.Until) {
+			return false
+		}
+	}
+	if len(p.regex) == 0 {
+		return true
+	}
+	for _, re := range p.regex {
+		if re.MatchString(deploymentLog.Log) {
+			return true
+		}
+	}
+	return false
+}
+
+func (p *logPrinter) print(deploymentLog houston.DeploymentLog) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if !p.match(deploymentLog) {
+		return nil
+	}
+	deploymentLog.Log = strings.TrimRight(deploymentLog.Log, "\n")
+	p.numPrinted++
+	if p.opts.JSON {
+		line, err := json.Marshal(deploymentLog)
+		if err != nil {
+			return err
+		}
+		_, err = fmt.Fprintln(p.out, string(line))
+		return err
+	}
+	if p.multi {
+		_, err := fmt.Fprintf(p.out, "[%s] %s\n", deploymentLog.Component, deploymentLog.Log)
+		return err
+	}
+	_, err := fmt.Fprintln(p.out, deploymentLog.Log)
+	return err
+}
+
+// done reports where the logs were written to when they went to a file
+func (p *logPrinter) done() {
+	if p.file != nil {
+		fmt.Fprintf(p.status, "Wrote %d log lines to %s\n", p.numPrinted, p.opts.ToFile)
+	}
+}
+
+func (p *logPrinter) close() {
+	if p.file != nil {
+		p.file.Close()
+	}
+}
@@ -2,7 +2,12 @@ package deployment
 
 import (
 	"bytes"
+	"encoding/json"
+	"io"
+	"os"
+	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/astronomer/astro-cli/houston"
 	mocks "github.com/astronomer/astro-cli/houston/mocks"
@@ -19,7 +24,7 @@ func TestLog(t *testing.T) {
 		api.On("ListDeploymentLogs", mock.AnythingOfType("houston.ListDeploymentLogsRequest")).Return([]houston.DeploymentLog{{ID: "test-id", Log: "test log"}}, nil)
 		out := new(bytes.Buffer)
 
-		err := Log("test-id", "test-component", "test", 0, api, out)
+		err := Log("test-id", LogOptions{Components: []string{"test-component"}, Search: "test"}, api, out)
 		assert.NoError(t, err)
 		assert.Contains(t, out.String(), "test log")
 	})
@@ -29,29 +34,113 @@ func TestLog(t *testing.T) {
 		api.On("ListDeploymentLogs", mock.AnythingOfType("houston.ListDeploymentLogsRequest")).Return([]houston.DeploymentLog{}, errMock)
 		out := new(bytes.Buffer)
 
-		err := Log("test-id", "test-component", "test", 0, api, out)
+		err := Log("test-id", LogOptions{Components: []string{"test-component"}, Search: "test"}, api, out)
 		assert.ErrorIs(t, err, errMock)
 	})
+
+	t.Run("no components", func(t *testing.T) {
+		err := Log("test-id", LogOptions{}, new(mocks.ClientInterface), io.Discard)
+		assert.ErrorIs(t, err, errNoLogComponents)
+	})
+
+	t.Run("merges components in timestamp order", func(t *testing.T) {
+		api := new(mocks.ClientInterface)
+		api.On("ListDeploymentLogs", mock.MatchedBy(func(r houston.ListDeploymentLogsRequest) bool { return r.Component == "scheduler" })).
+			Return([]houston.DeploymentLog{{ID: "1", CreatedAt: "2023-06-05T10:00:00Z", Log: "scheduler first"}, {ID: "3", CreatedAt: "2023-06-05T10:00:02Z", Log: "scheduler second"}}, nil).Once()
+		api.On("ListDeploymentLogs", mock.MatchedBy(func(r houston.ListDeploymentLogsRequest) bool { return r.Component == "worker" })).
+			Return([]houston.DeploymentLog{{ID: "2", CreatedAt: "2023-06-05T10:00:01Z", Log: "worker first"}}, nil).Once()
+		out := new(bytes.Buffer)
+
+		err := Log("test-id", LogOptions{Components: []string{"scheduler", "worker"}}, api, out)
+		assert.NoError(t, err)
+		assert.Equal(t, "[scheduler] scheduler first\n[worker] worker first\n[scheduler] scheduler second\n", out.String())
+		api.AssertExpectations(t)
+	})
+
+	t.Run("until and regex filters", func(t *testing.T) {
+		api := new(mocks.ClientInterface)
+		api.On("ListDeploymentLogs", mock.AnythingOfType("houston.ListDeploymentLogsRequest")).Return([]houston.DeploymentLog{
+			{ID: "1", CreatedAt: "2023-06-05T10:00:00Z", Log: "ERROR first"},
+			{ID: "2", CreatedAt: "2023-06-05T10:00:01Z", Log: "INFO second"},
+			{ID: "3", CreatedAt: "2023-06-05T10:00:02Z", Log: "WARNING third"},
+			{ID: "4", CreatedAt: "2023-06-05T10:00:03Z", Log: "ERROR fourth"},
+		}, nil).Once()
+		out := new(bytes.Buffer)
+
+		opts := LogOptions{
+			Components: []string{"scheduler"},
+			Until:      time.Date(2023, 6, 5, 10, 0, 2, 0, time.UTC),
+			Regex:      []string{"^ERROR", "WARN"},
+		}
+		err := Log("test-id", opts, api, out)
+		assert.NoError(t, err)
+		assert.Equal(t, "ERROR first\nWARNING third\n", out.String())
+	})
+
+	t.Run("invalid regex", func(t *testing.T) {
+		err := Log("test-id", LogOptions{Components: []string{"scheduler"}, Regex: []string{"("}}, new(mocks.ClientInterface), io.Discard)
+		assert.ErrorIs(t, err, errInvalidLogRegex)
+	})
+
+	t.Run("json output", func(t *testing.T) {
+		api := new(mocks.ClientInterface)
+		api.On("ListDeploymentLogs", mock.AnythingOfType("houston.ListDeploymentLogsRequest")).Return([]houston.DeploymentLog{{ID: "1", CreatedAt: "2023-06-05T10:00:00Z", Log: "first\n"}}, nil).Once()
+		out := new(bytes.Buffer)
+
+		err := Log("test-id", LogOptions{Components: []string{"scheduler"}, JSON: true}, api, out)
+		assert.NoError(t, err)
+		var log houston.DeploymentLog
+		assert.NoError(t, json.Unmarshal(out.Bytes(), &log))
+		assert.Equal(t, houston.DeploymentLog{ID: "1", Component: "scheduler", CreatedAt: "2023-06-05T10:00:00Z", Log: "first"}, log)
+	})
+
+	t.Run("to file", func(t *testing.T) {
+		api := new(mocks.ClientInterface)
+		api.On("ListDeploymentLogs", mock.AnythingOfType("houston.ListDeploymentLogsRequest")).Return([]houston.DeploymentLog{{ID: "1", Log: "first"}, {ID: "2", Log: "second"}}, nil).Once()
+		out := new(bytes.Buffer)
+		file := filepath.Join(t.TempDir(), "scheduler.log")
+
+		err := Log("test-id", LogOptions{Components: []string{"scheduler"}, ToFile: file}, api, out)
+		assert.NoError(t, err)
+		assert.Contains(t, out.String(), "Wrote 2 log lines to "+file)
+		content, err := os.ReadFile(file)
+		assert.NoError(t, err)
+		assert.Equal(t, "first\nsecond\n", string(content))
+	})
 }
 
 func TestSubscribeDeploymentLog(t *testing.T) {
 	testUtil.InitTestConfig(testUtil.SoftwarePlatform)
 
 	t.Run("success", func(t *testing.T) {
-		subscribe = func(jwtToken, url, queryMessage string) error {
-			return nil
+		subscribe = func(jwtToken, url string, request houston.ListDeploymentLogsRequest, handler func(houston.DeploymentLog) error, status io.Writer) error {
+			return handler(houston.DeploymentLog{ID: "1", Component: request.Component, Log: "test log"})
 		}
+		out := new(bytes.Buffer)
+
+		err := SubscribeDeploymentLog("test-id", LogOptions{Components: []string{"test-component"}, Search: "test"}, out)
+		assert.NoError(t, err)
+		assert.Equal(t, "test log\n", out.String())
+	})
+
+	t.Run("multiple components", func(t *testing.T) {
+		subscribe = func(jwtToken, url string, request houston.ListDeploymentLogsRequest, handler func(houston.DeploymentLog) error, status io.Writer) error {
+			return handler(houston.DeploymentLog{ID: request.Component, Component: request.Component, Log: "test log"})
+		}
+		out := new(bytes.Buffer)
 
-		err := SubscribeDeploymentLog("test-id", "test-component", "test", 0)
+		err := SubscribeDeploymentLog("test-id", LogOptions{Components: []string{"scheduler", "worker"}}, out)
 		assert.NoError(t, err)
+		assert.Contains(t, out.String(), "[scheduler] test log\n")
+		assert.Contains(t, out.String(), "[worker] test log\n")
 	})
 
 	t.Run("houston failure", func(t *testing.T) {
-		subscribe = func(jwtToken, url, queryMessage string) error {
+		subscribe = func(jwtToken, url string, request houston.ListDeploymentLogsRequest, handler func(houston.DeploymentLog) error, status io.Writer) error {
 			return errMock
 		}
 
-		err := SubscribeDeploymentLog("test-id", "test-component", "test", 0)
+		err := SubscribeDeploymentLog("test-id", LogOptions{Components: []string{"test-component"}, Search: "test"}, io.Discard)
 		assert.ErrorIs(t, err, errMock)
 	})
 }
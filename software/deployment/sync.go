@@ -0,0 +1,266 @@
+package deployment
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/astronomer/astro-cli/context"
+	"github.com/astronomer/astro-cli/houston"
+	"github.com/astronomer/astro-cli/pkg/input"
+	"github.com/ghodss/yaml"
+	"github.com/golang-jwt/jwt/v4"
+)
+
+const (
+	addAction    = "add"
+	updateAction = "update"
+	removeAction = "remove"
+)
+
+var (
+	pastTenses = map[string]string{addAction: "Added", updateAction: "Updated", removeAction: "Removed"}
+
+	errNoIdPGroups = errors.New("declares no groups, a deployment can't be synced with an empty export")
+
+	// currentUserID is used to monkey patch the ID of the logged in user in unit tests
+	currentUserID = loggedInUserID
+)
+
+// deploymentRoleRanks orders the deployment roles, a user or team in several groups gets the highest of their roles
+var deploymentRoleRanks = map[string]int{
+	houston.DeploymentViewerRole: 1,
+	houston.DeploymentEditorRole: 2,
+	houston.DeploymentAdminRole:  3,
+}
+
+// IdPGroups is the format of the identity provider group export used by SyncRoles
+type IdPGroups struct {
+	Groups []IdPGroup `yaml:"groups" json:"groups"`
+}
+
+// IdPGroup maps the members of an identity provider group, and optionally a team, to a deployment role
+type IdPGroup struct {
+	Name    string   `yaml:"name" json:"name"`
+	Role    string   `yaml:"role" json:"role"`
+	TeamID  string   `yaml:"team_id,omitempty" json:"team_id,omitempty"`
+	Members []string `yaml:"members,omitempty" json:"members,omitempty"`
+}
+
+type roleChange struct {
+	action string
+	kind   string
+	name   string
+	role   string
+}
+
+// roleBinding is the role of a user or team, name is the username or email used in the mutations
+type roleBinding struct {
+	name string
+	role string
+}
+
+// idpRoles are the users and teams of the groups of an identity provider export, keyed by lowercased email and team ID
+type idpRoles struct {
+	users map[string]roleBinding
+	teams map[string]roleBinding
+	// syncUsers is true when at least one group has a members list, even an empty one
+	syncUsers bool
+}
+
+// SyncRoles adds, updates and removes the users and teams of a deployment so that their roles match the groups of
+// inputFile. Users are synced when at least one group has a members list, an empty list removing its members, and
+// teams when at least one group has a team, other bindings are left as they are. The logged in user is never removed.
+// The changes are only printed with dryRun, and removals need a confirmation unless yes is set.
+func SyncRoles(deploymentID, inputFile string, dryRun, yes bool, client houston.ClientInterface, out io.Writer) error {
+	wanted, err := readIdPGroups(inputFile)
+	if err != nil {
+		return err
+	}
+
+	var changes []roleChange
+	if wanted.syncUsers {
+		deploymentUsers, err := houston.Call(client.ListDeploymentUsers)(houston.ListDeploymentUsersRequest{DeploymentID: deploymentID})
+		if err != nil {
+			return err
+		}
+		current := map[string]roleBinding{}
+		selfID := currentUserID()
+		for i := range deploymentUsers {
+			key := strings.ToLower(deploymentUsers[i].Username)
+			role := getDeploymentLevelRole(deploymentUsers[i].RoleBindings, deploymentID)
+			if _, ok := wanted.users[key]; !ok && selfID != "" && deploymentUsers[i].ID == selfID && role != houston.NoneRole {
+				fmt.Fprintf(out, "You (%s) are not in %s, your own access is left unchanged\n", deploymentUsers[i].Username, inputFile)
+				continue
+			}
+			current[key] = roleBinding{name: deploymentUsers[i].Username, role: role}
+		}
+		changes = append(changes, diffRoles("user", current, wanted.users)...)
+	}
+	if len(wanted.teams) > 0 {
+		deploymentTeams, err := houston.Call(client.ListDeploymentTeamsAndRoles)(deploymentID)
+		if err != nil {
+			return err
+		}
+		current := map[string]roleBinding{}
+		for i := range deploymentTeams {
+			current[deploymentTeams[i].ID] = roleBinding{name: deploymentTeams[i].ID, role: getDeploymentLevelRole(deploymentTeams[i].RoleBindings, deploymentID)}
+		}
+		changes = append(changes, diffRoles("team", current, wanted.teams)...)
+	}
+
+	if len(changes) == 0 {
+		fmt.Fprintf(out, "Users and teams of deployment %s already match %s\n", deploymentID, inputFile)
+		return nil
+	}
+	if !dryRun && !yes && !confirmRemovals(changes, out) {
+		fmt.Fprintln(out, "Canceling the sync, nothing was changed")
+		return nil
+	}
+	for _, c := range changes {
+		if dryRun {
+			fmt.Fprintf(out, "Would %s\n", c.describe(c.action))
+			continue
+		}
+		if err := applyRoleChange(deploymentID, c, client); err != nil {
+			return fmt.Errorf("failed to %s: %w", c.describe(c.action), err)
+		}
+		fmt.Fprintln(out, c.describe(pastTenses[c.action]))
+	}
+	return nil
+}
+
+// confirmRemovals prints the removals among changes and asks for a confirmation, it returns true when there are none
+func confirmRemovals(changes []roleChange, out io.Writer) bool {
+	var removals []string
+	for _, c := range changes {
+		if c.action == removeAction {
+			removals = append(removals, c.describe("Remove"))
+		}
+	}
+	if len(removals) == 0 {
+		return true
+	}
+	fmt.Fprintln(out, "The sync removes the access of:")
+	for _, r := range removals {
+		fmt.Fprintf(out, "  %s\n", r)
+	}
+	ok, _ := input.Confirm(fmt.Sprintf("Are you sure you want to remove %d users and teams from the deployment?", len(removals)))
+	return ok
+}
+
+// loggedInUserID returns the ID of the user of the current context token, or an empty string when it is not a user token
+func loggedInUserID() string {
+	ctx, err := context.GetCurrentContext()
+	if err != nil || ctx.Token == "" {
+		return ""
+	}
+	claims := jwt.MapClaims{}
+	if _, _, err := jwt.NewParser().ParseUnverified(strings.TrimPrefix(ctx.Token, "Bearer "), claims); err != nil {
+		return ""
+	}
+	id, _ := claims["id"].(string)
+	return id
+}
+
+// describe returns a description of the change starting with verb
+func (c roleChange) describe(verb string) string {
+	switch c.action {
+	case addAction:
+		return fmt.Sprintf("%s %s %s as a %s", verb, c.kind, c.name, c.role)
+	case updateAction:
+		return fmt.Sprintf("%s %s %s to a %s", verb, c.kind, c.name, c.role)
+	default:
+		return fmt.Sprintf("%s %s %s", verb, c.kind, c.name)
+	}
+}
+
+// readIdPGroups returns the deployment role of every user and team of the groups of inputFile
+func readIdPGroups(inputFile string) (*idpRoles, error) {
+	dataBytes, err := os.ReadFile(inputFile)
+	if err != nil {
+		return nil, err
+	}
+	if len(dataBytes) == 0 {
+		return nil, fmt.Errorf("%s %w", inputFile, errEmptyFile)
+	}
+
+	var groups IdPGroups
+	if err := yaml.Unmarshal(dataBytes, &groups); err != nil {
+		return nil, err
+	}
+	if len(groups.Groups) == 0 {
+		return nil, fmt.Errorf("%s %w", inputFile, errNoIdPGroups)
+	}
+
+	roles := &idpRoles{users: map[string]roleBinding{}, teams: map[string]roleBinding{}}
+	for _, group := range groups.Groups {
+		if group.Role == houston.NoneRole || !IsValidDeploymentLevelRole(group.Role) {
+			return nil, fmt.Errorf("group %s: role %s %w, use one of %s, %s or %s", group.Name, group.Role, errInvalidValue, houston.DeploymentAdminRole, houston.DeploymentEditorRole, houston.DeploymentViewerRole)
+		}
+		if group.Members != nil {
+			roles.syncUsers = true
+		}
+		for _, member := range group.Members {
+			email := strings.TrimSpace(member)
+			if email == "" {
+				continue
+			}
+			key := strings.ToLower(email)
+			if current, ok := roles.users[key]; ok {
+				email = current.name
+			}
+			if deploymentRoleRanks[group.Role] > deploymentRoleRanks[roles.users[key].role] {
+				roles.users[key] = roleBinding{name: email, role: group.Role}
+			}
+		}
+		if group.TeamID != "" && deploymentRoleRanks[group.Role] > deploymentRoleRanks[roles.teams[group.TeamID].role] {
+			roles.teams[group.TeamID] = roleBinding{name: group.TeamID, role: group.Role}
+		}
+	}
+	return roles, nil
+}
+
+// diffRoles returns the changes needed for the current roles to match the wanted ones, sorted by name. Users are
+// compared by lowercased email, and the changes use the username of the deployment, or the email of the file for
+// the users who are added.
+func diffRoles(kind string, current, wanted map[string]roleBinding) []roleChange {
+	var changes []roleChange
+	for key, w := range wanted {
+		switch c, ok := current[key]; {
+		case !ok || c.role == houston.NoneRole:
+			changes = append(changes, roleChange{action: addAction, kind: kind, name: w.name, role: w.role})
+		case c.role != w.role:
+			changes = append(changes, roleChange{action: updateAction, kind: kind, name: c.name, role: w.role})
+		}
+	}
+	for key, c := range current {
+		if _, ok := wanted[key]; !ok && c.role != houston.NoneRole {
+			changes = append(changes, roleChange{action: removeAction, kind: kind, name: c.name})
+		}
+	}
+	sort.Slice(changes, func(i, j int) bool { return strings.ToLower(changes[i].name) < strings.ToLower(changes[j].name) })
+	return changes
+}
+
+func applyRoleChange(deploymentID string, c roleChange, client houston.ClientInterface) error {
+	var err error
+	switch {
+	case c.kind == "user" && c.action == addAction:
+		_, err = houston.Call(client.AddDeploymentUser)(houston.UpdateDeploymentUserRequest{Email: c.name, Role: c.role, DeploymentID: deploymentID})
+	case c.kind == "user" && c.action == updateAction:
+		_, err = houston.Call(client.UpdateDeploymentUser)(houston.UpdateDeploymentUserRequest{Email: c.name, Role: c.role, DeploymentID: deploymentID})
+	case c.kind == "user":
+		_, err = houston.Call(client.DeleteDeploymentUser)(houston.DeleteDeploymentUserRequest{Email: c.name, DeploymentID: deploymentID})
+	case c.action == addAction:
+		_, err = houston.Call(client.AddDeploymentTeam)(houston.AddDeploymentTeamRequest{TeamID: c.name, Role: c.role, DeploymentID: deploymentID})
+	case c.action == updateAction:
+		_, err = houston.Call(client.UpdateDeploymentTeamRole)(houston.UpdateDeploymentTeamRequest{TeamID: c.name, Role: c.role, DeploymentID: deploymentID})
+	default:
+		_, err = houston.Call(client.RemoveDeploymentTeam)(houston.RemoveDeploymentTeamRequest{TeamID: c.name, DeploymentID: deploymentID})
+	}
+	return err
+}
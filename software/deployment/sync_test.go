@@ -0,0 +1,168 @@
+package deployment
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/astronomer/astro-cli/houston"
+	mocks "github.com/astronomer/astro-cli/houston/mocks"
+	testUtil "github.com/astronomer/astro-cli/pkg/testing"
+	"github.com/stretchr/testify/assert"
+)
+
+const groupsFileContent = `groups:
+  - name: data-admins
+    role: DEPLOYMENT_ADMIN
+    members:
+      - admin@astronomer.io
+  - name: data-engineers
+    role: DEPLOYMENT_EDITOR
+    team_id: team-id
+    members:
+      - Admin@astronomer.io
+      - editor@astronomer.io
+      - new@astronomer.io
+`
+
+func TestSyncRoles(t *testing.T) {
+	deploymentUsers := []houston.DeploymentUser{
+		{Username: "admin@astronomer.io", RoleBindings: []houston.RoleBinding{{Role: houston.DeploymentAdminRole, Deployment: houston.Deployment{ID: "deployment-id"}}}},
+		{Username: "editor@astronomer.io", RoleBindings: []houston.RoleBinding{{Role: houston.DeploymentViewerRole, Deployment: houston.Deployment{ID: "deployment-id"}}}},
+		{Username: "old@astronomer.io", RoleBindings: []houston.RoleBinding{{Role: houston.DeploymentViewerRole, Deployment: houston.Deployment{ID: "deployment-id"}}}},
+	}
+	deploymentTeams := []houston.Team{
+		{ID: "team-id", RoleBindings: []houston.RoleBinding{{Role: houston.DeploymentEditorRole, Deployment: houston.Deployment{ID: "deployment-id"}}}},
+		{ID: "old-team-id", RoleBindings: []houston.RoleBinding{{Role: houston.DeploymentAdminRole, Deployment: houston.Deployment{ID: "deployment-id"}}}},
+	}
+
+	t.Run("success", func(t *testing.T) {
+		api := new(mocks.ClientInterface)
+		api.On("ListDeploymentUsers", houston.ListDeploymentUsersRequest{DeploymentID: "deployment-id"}).Return(deploymentUsers, nil)
+		api.On("ListDeploymentTeamsAndRoles", "deployment-id").Return(deploymentTeams, nil)
+		api.On("UpdateDeploymentUser", houston.UpdateDeploymentUserRequest{Email: "editor@astronomer.io", Role: houston.DeploymentEditorRole, DeploymentID: "deployment-id"}).Return(&houston.RoleBinding{}, nil)
+		api.On("AddDeploymentUser", houston.UpdateDeploymentUserRequest{Email: "new@astronomer.io", Role: houston.DeploymentEditorRole, DeploymentID: "deployment-id"}).Return(&houston.RoleBinding{}, nil)
+		api.On("DeleteDeploymentUser", houston.DeleteDeploymentUserRequest{Email: "old@astronomer.io", DeploymentID: "deployment-id"}).Return(&houston.RoleBinding{}, nil)
+		api.On("RemoveDeploymentTeam", houston.RemoveDeploymentTeamRequest{TeamID: "old-team-id", DeploymentID: "deployment-id"}).Return(&houston.RoleBinding{}, nil)
+
+		buf := new(bytes.Buffer)
+		err := SyncRoles("deployment-id", writeDeploymentFile(t, groupsFileContent), false, true, api, buf)
+		assert.NoError(t, err)
+		assert.Equal(t, `Updated user editor@astronomer.io to a DEPLOYMENT_EDITOR
+Added user new@astronomer.io as a DEPLOYMENT_EDITOR
+Removed user old@astronomer.io
+Removed team old-team-id
+`, buf.String())
+		api.AssertExpectations(t)
+	})
+
+	t.Run("dry run", func(t *testing.T) {
+		api := new(mocks.ClientInterface)
+		api.On("ListDeploymentUsers", houston.ListDeploymentUsersRequest{DeploymentID: "deployment-id"}).Return(deploymentUsers, nil)
+		api.On("ListDeploymentTeamsAndRoles", "deployment-id").Return(deploymentTeams, nil)
+
+		buf := new(bytes.Buffer)
+		err := SyncRoles("deployment-id", writeDeploymentFile(t, groupsFileContent), true, false, api, buf)
+		assert.NoError(t, err)
+		assert.Contains(t, buf.String(), "Would add user new@astronomer.io as a DEPLOYMENT_EDITOR")
+		assert.Contains(t, buf.String(), "Would remove team old-team-id")
+		api.AssertExpectations(t)
+	})
+
+	t.Run("already in sync", func(t *testing.T) {
+		api := new(mocks.ClientInterface)
+		api.On("ListDeploymentUsers", houston.ListDeploymentUsersRequest{DeploymentID: "deployment-id"}).Return(deploymentUsers[:1], nil)
+
+		path := writeDeploymentFile(t, "groups:\n  - name: admins\n    role: DEPLOYMENT_ADMIN\n    members: [admin@astronomer.io]\n")
+		buf := new(bytes.Buffer)
+		err := SyncRoles("deployment-id", path, false, false, api, buf)
+		assert.NoError(t, err)
+		assert.Contains(t, buf.String(), "already match")
+		api.AssertExpectations(t)
+	})
+
+	t.Run("invalid role", func(t *testing.T) {
+		path := writeDeploymentFile(t, "groups:\n  - name: admins\n    role: WORKSPACE_ADMIN\n    members: [admin@astronomer.io]\n")
+		err := SyncRoles("deployment-id", path, false, false, nil, new(bytes.Buffer))
+		assert.ErrorIs(t, err, errInvalidValue)
+		assert.Contains(t, err.Error(), "group admins")
+	})
+
+	t.Run("empty file", func(t *testing.T) {
+		err := SyncRoles("deployment-id", writeDeploymentFile(t, ""), false, false, nil, new(bytes.Buffer))
+		assert.ErrorIs(t, err, errEmptyFile)
+	})
+
+	t.Run("keeps the username of the deployment", func(t *testing.T) {
+		api := new(mocks.ClientInterface)
+		api.On("ListDeploymentUsers", houston.ListDeploymentUsersRequest{DeploymentID: "deployment-id"}).Return([]houston.DeploymentUser{
+			{Username: "Admin@Astronomer.io", RoleBindings: []houston.RoleBinding{{Role: houston.DeploymentViewerRole, Deployment: houston.Deployment{ID: "deployment-id"}}}},
+			{Username: "Old@Astronomer.io", RoleBindings: []houston.RoleBinding{{Role: houston.DeploymentViewerRole, Deployment: houston.Deployment{ID: "deployment-id"}}}},
+		}, nil)
+		api.On("UpdateDeploymentUser", houston.UpdateDeploymentUserRequest{Email: "Admin@Astronomer.io", Role: houston.DeploymentAdminRole, DeploymentID: "deployment-id"}).Return(&houston.RoleBinding{}, nil)
+		api.On("DeleteDeploymentUser", houston.DeleteDeploymentUserRequest{Email: "Old@Astronomer.io", DeploymentID: "deployment-id"}).Return(&houston.RoleBinding{}, nil)
+
+		path := writeDeploymentFile(t, "groups:\n  - name: admins\n    role: DEPLOYMENT_ADMIN\n    members: [admin@astronomer.io]\n")
+		err := SyncRoles("deployment-id", path, false, true, api, new(bytes.Buffer))
+		assert.NoError(t, err)
+		api.AssertExpectations(t)
+	})
+
+	t.Run("never removes the current user", func(t *testing.T) {
+		currentUserID = func() string { return "current-user-id" }
+		defer func() { currentUserID = loggedInUserID }()
+
+		api := new(mocks.ClientInterface)
+		api.On("ListDeploymentUsers", houston.ListDeploymentUsersRequest{DeploymentID: "deployment-id"}).Return([]houston.DeploymentUser{
+			{ID: "current-user-id", Username: "me@astronomer.io", RoleBindings: []houston.RoleBinding{{Role: houston.DeploymentAdminRole, Deployment: houston.Deployment{ID: "deployment-id"}}}},
+		}, nil)
+
+		path := writeDeploymentFile(t, "groups:\n  - name: admins\n    role: DEPLOYMENT_ADMIN\n    members: []\n")
+		buf := new(bytes.Buffer)
+		err := SyncRoles("deployment-id", path, false, true, api, buf)
+		assert.NoError(t, err)
+		assert.Contains(t, buf.String(), "You (me@astronomer.io) are not in")
+		api.AssertExpectations(t)
+	})
+
+	t.Run("empty members list removes the users", func(t *testing.T) {
+		api := new(mocks.ClientInterface)
+		api.On("ListDeploymentUsers", houston.ListDeploymentUsersRequest{DeploymentID: "deployment-id"}).Return(deploymentUsers[2:], nil)
+		api.On("DeleteDeploymentUser", houston.DeleteDeploymentUserRequest{Email: "old@astronomer.io", DeploymentID: "deployment-id"}).Return(&houston.RoleBinding{}, nil)
+
+		path := writeDeploymentFile(t, "groups:\n  - name: admins\n    role: DEPLOYMENT_ADMIN\n    members: []\n")
+		err := SyncRoles("deployment-id", path, false, true, api, new(bytes.Buffer))
+		assert.NoError(t, err)
+		api.AssertExpectations(t)
+	})
+
+	t.Run("removals declined", func(t *testing.T) {
+		defer testUtil.MockUserInput(t, "n")()
+		api := new(mocks.ClientInterface)
+		api.On("ListDeploymentUsers", houston.ListDeploymentUsersRequest{DeploymentID: "deployment-id"}).Return(deploymentUsers, nil)
+		api.On("ListDeploymentTeamsAndRoles", "deployment-id").Return(deploymentTeams, nil)
+
+		buf := new(bytes.Buffer)
+		err := SyncRoles("deployment-id", writeDeploymentFile(t, groupsFileContent), false, false, api, buf)
+		assert.NoError(t, err)
+		assert.Contains(t, buf.String(), "Remove user old@astronomer.io")
+		assert.Contains(t, buf.String(), "Canceling the sync, nothing was changed")
+		api.AssertExpectations(t)
+	})
+
+	t.Run("no groups", func(t *testing.T) {
+		err := SyncRoles("deployment-id", writeDeploymentFile(t, "groups: []\n"), false, false, nil, new(bytes.Buffer))
+		assert.ErrorIs(t, err, errNoIdPGroups)
+	})
+
+	t.Run("api error", func(t *testing.T) {
+		api := new(mocks.ClientInterface)
+		api.On("ListDeploymentUsers", houston.ListDeploymentUsersRequest{DeploymentID: "deployment-id"}).Return(deploymentUsers, nil)
+		api.On("ListDeploymentTeamsAndRoles", "deployment-id").Return(deploymentTeams, nil)
+		api.On("UpdateDeploymentUser", houston.UpdateDeploymentUserRequest{Email: "editor@astronomer.io", Role: houston.DeploymentEditorRole, DeploymentID: "deployment-id"}).Return(nil, errMock)
+
+		err := SyncRoles("deployment-id", writeDeploymentFile(t, groupsFileContent), false, true, api, new(bytes.Buffer))
+		assert.ErrorIs(t, err, errMock)
+		assert.Contains(t, err.Error(), "failed to update user editor@astronomer.io")
+		api.AssertExpectations(t)
+	})
+}
@@ -0,0 +1,231 @@
+package deployment
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/astronomer/astro-cli/houston"
+)
+
+// Kinds of upgrade done by UpgradeBatch
+const (
+	AirflowUpgradeKind = "airflow"
+	RuntimeUpgradeKind = "runtime"
+	RuntimeMigrateKind = "migrate"
+
+	certifiedImageRepository = "quay.io/astronomer/ap-airflow"
+	runtimeImageRepository   = "quay.io/astronomer/astro-runtime"
+)
+
+var (
+	errNoUpgradeInProgress = errors.New("the deployment has no upgrade in progress")
+	errUpgradeTimeout      = errors.New("timed out waiting for the upgrade")
+	errNoDeploymentsMatch  = errors.New("no deployments in the workspace match the label filter")
+	errBatchDesiredVersion = errors.New("a desired version is required to upgrade several deployments")
+	errBatchUpgradeFailed  = errors.New("failed to upgrade deployment")
+	errInvalidLabelFilter  = errors.New("invalid label filter")
+	errUnknownUpgradeKind  = errors.New("unknown upgrade kind")
+	errNoUpgradeDeploy     = errors.New("no deploy function to deploy the upgraded image")
+	errNoCertifiedImage    = errors.New("the platform has no Astronomer Certified image for Airflow")
+
+	// monkey patched to write tests
+	sleep = time.Sleep
+)
+
+// UpgradeWaitOptions sets how WaitForUpgrade deploys the upgraded image and waits for the deployment to run it
+type UpgradeWaitOptions struct {
+	// Deploy rebuilds the project on baseImage and deploys it to the deployment
+	Deploy   func(deploymentID, baseImage string) error
+	Timeout  time.Duration
+	Interval time.Duration
+}
+
+// WaitForUpgrade deploys the project on the image of the version the deployment is being upgraded to, then polls the
+// deployment until it runs that version and every component is healthy, or the timeout hits
+func WaitForUpgrade(deploymentID string, opts UpgradeWaitOptions, client houston.ClientInterface, out io.Writer) error {
+	if opts.Deploy == nil {
+		return errNoUpgradeDeploy
+	}
+	d, err := houston.Call(client.GetDeployment)(deploymentID)
+	if err != nil {
+		return err
+	}
+
+	baseImage, upgraded, err := upgradeTarget(d, client)
+	if err != nil {
+		return err
+	}
+	if err := opts.Deploy(deploymentID, baseImage); err != nil {
+		return err
+	}
+
+	fmt.Fprintf(out, "\nWaiting up to %s for deployment %s to run %s and be healthy...\n", opts.Timeout, d.Label, baseImage)
+	deadline := time.Now().Add(opts.Timeout)
+	for {
+		d, err = houston.Call(client.GetDeployment)(deploymentID)
+		if err != nil {
+			return err
+		}
+		status, err := houston.Call(client.GetDeploymentStatus)(deploymentID)
+		if err != nil {
+			return err
+		}
+
+		if upgraded(d) && isHealthy(status) {
+			fmt.Fprintf(out, "Deployment %s is running %s and all of its components are healthy\n", d.Label, baseImage)
+			return nil
+		}
+		if !time.Now().Before(deadline) {
+			return fmt.Errorf("%w of deployment %s after %s, %s", errUpgradeTimeout, d.Label, opts.Timeout, describeStatus(status))
+		}
+		fmt.Fprintf(out, "Deployment %s: %s\n", d.Label, describeStatus(status))
+		sleep(opts.Interval)
+	}
+}
+
+// upgradeTarget returns the base image of the upgrade in progress, and a check of whether a deployment runs it
+func upgradeTarget(d *houston.Deployment, client houston.ClientInterface) (string, func(*houston.Deployment) bool, error) {
+	switch {
+	case d.DesiredRuntimeVersion != "" && d.DesiredRuntimeVersion != d.RuntimeVersion:
+		desired := d.DesiredRuntimeVersion
+		return runtimeImageRepository + ":" + desired, func(d *houston.Deployment) bool { return d.RuntimeVersion == desired }, nil
+	case d.RuntimeVersion == "" && d.DesiredAirflowVersion != "" && d.DesiredAirflowVersion != d.AirflowVersion:
+		desired := d.DesiredAirflowVersion
+		tag, err := certifiedImageTag(desired, client)
+		if err != nil {
+			return "", nil, err
+		}
+		return certifiedImageRepository + ":" + tag, func(d *houston.Deployment) bool { return d.AirflowVersion == desired }, nil
+	}
+	return "", nil, fmt.Errorf("%w: %s", errNoUpgradeInProgress, d.Label)
+}
+
+// certifiedImageTag returns the latest onbuild tag of the Astronomer Certified images the platform offers for an
+// Airflow version, or its latest tag when none of them is an onbuild image
+func certifiedImageTag(airflowVersion string, client houston.ClientInterface) (string, error) {
+	deploymentConfig, err := houston.Call(client.GetDeploymentConfig)(nil)
+	if err != nil {
+		return "", err
+	}
+	var tag string
+	for _, image := range deploymentConfig.AirflowImages {
+		if image.Version != airflowVersion {
+			continue
+		}
+		if tag == "" || !strings.HasSuffix(tag, "-onbuild") || strings.HasSuffix(image.Tag, "-onbuild") {
+			tag = image.Tag
+		}
+	}
+	if tag == "" {
+		return "", fmt.Errorf("%w %s", errNoCertifiedImage, airflowVersion)
+	}
+	return tag, nil
+}
+
+func isHealthy(status *houston.DeploymentStatus) bool {
+	if status == nil || status.Status != houston.HealthyStatus {
+		return false
+	}
+	for _, component := range status.Components {
+		if component.Status != houston.HealthyStatus {
+			return false
+		}
+	}
+	return true
+}
+
+func describeStatus(status *houston.DeploymentStatus) string {
+	if status == nil {
+		return "status unknown"
+	}
+	var unhealthy []string
+	for _, component := range status.Components {
+		if component.Status != houston.HealthyStatus {
+			unhealthy = append(unhealthy, fmt.Sprintf("%s is %s", component.Name, strings.ToLower(component.Status)))
+		}
+	}
+	if len(unhealthy) == 0 {
+		return "status " + strings.ToLower(status.Status)
+	}
+	return "status " + strings.ToLower(status.Status) + ", " + strings.Join(unhealthy, ", ")
+}
+
+// BatchUpgrade is the upgrade UpgradeBatch runs on every deployment
+type BatchUpgrade struct {
+	// Kind is one of AirflowUpgradeKind, RuntimeUpgradeKind or RuntimeMigrateKind
+	Kind string
+	// DesiredVersion is the Airflow or Runtime version to upgrade to, it is not used by migrations
+	DesiredVersion string
+}
+
+// UpgradeBatch upgrades the deployments of the workspace whose label matches labelFilter, a glob pattern like prod-*,
+// one at a time. It stops at the first failure. Deployments which already run the desired version are skipped, so
+// the batch can be run again once the failure is fixed.
+func UpgradeBatch(ws, labelFilter string, upgrade BatchUpgrade, client houston.ClientInterface, out io.Writer) error {
+	if upgrade.Kind != RuntimeMigrateKind && upgrade.DesiredVersion == "" {
+		return errBatchDesiredVersion
+	}
+	if _, err := path.Match(labelFilter, ""); err != nil {
+		return fmt.Errorf("%w %s: %s", errInvalidLabelFilter, labelFilter, err.Error())
+	}
+
+	deployments, err := GetDeployments(ws, client)
+	if err != nil {
+		return err
+	}
+	var matched []houston.Deployment
+	for i := range deployments {
+		if ok, _ := path.Match(labelFilter, deployments[i].Label); ok {
+			matched = append(matched, deployments[i])
+		}
+	}
+	if len(matched) == 0 {
+		return fmt.Errorf("%w %s", errNoDeploymentsMatch, labelFilter)
+	}
+
+	fmt.Fprintf(out, "Upgrading %d deployments matching %s, one at a time\n", len(matched), labelFilter)
+	for i := range matched {
+		d := matched[i]
+		fmt.Fprintf(out, "\n[%d/%d] %s (%s)\n", i+1, len(matched), d.Label, d.ID)
+		if upgradeDone(&d, upgrade) {
+			fmt.Fprintf(out, "Deployment %s is already upgraded, skipping\n", d.Label)
+			continue
+		}
+		if err := upgradeDeployment(d.ID, upgrade, client, out); err != nil {
+			return fmt.Errorf("%w %s (%s), %d of %d deployments upgraded: %s", errBatchUpgradeFailed, d.Label, d.ID, i, len(matched), err.Error())
+		}
+	}
+	fmt.Fprintf(out, "\nSuccessfully upgraded %d deployments\n", len(matched))
+	return nil
+}
+
+func upgradeDone(d *houston.Deployment, upgrade BatchUpgrade) bool {
+	switch upgrade.Kind {
+	case AirflowUpgradeKind:
+		return d.AirflowVersion == upgrade.DesiredVersion
+	case RuntimeUpgradeKind:
+		return d.RuntimeVersion == upgrade.DesiredVersion
+	case RuntimeMigrateKind:
+		return d.RuntimeVersion != ""
+	}
+	return false
+}
+
+func upgradeDeployment(deploymentID string, upgrade BatchUpgrade, client houston.ClientInterface, out io.Writer) error {
+	var err error
+	switch upgrade.Kind {
+	case AirflowUpgradeKind:
+		err = AirflowUpgrade(deploymentID, upgrade.DesiredVersion, client, out)
+	case RuntimeUpgradeKind:
+		err = RuntimeUpgrade(deploymentID, upgrade.DesiredVersion, client, out)
+	case RuntimeMigrateKind:
+		err = RuntimeMigrate(deploymentID, client, out)
+	default:
+		err = fmt.Errorf("%w %s", errUnknownUpgradeKind, upgrade.Kind)
+	}
+	return err
+}
@@ -0,0 +1,210 @@
+package deployment
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/astronomer/astro-cli/houston"
+	mocks "github.com/astronomer/astro-cli/houston/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+var (
+	healthyStatus = &houston.DeploymentStatus{
+		Status:     houston.HealthyStatus,
+		Components: []houston.ComponentStatus{{Name: "scheduler", Status: houston.HealthyStatus}, {Name: "webserver", Status: houston.HealthyStatus}},
+	}
+	unhealthyStatus = &houston.DeploymentStatus{
+		Status:     "UNHEALTHY",
+		Components: []houston.ComponentStatus{{Name: "scheduler", Status: "DEPLOYING"}, {Name: "webserver", Status: houston.HealthyStatus}},
+	}
+)
+
+func TestWaitForUpgrade(t *testing.T) {
+	defer func() { sleep = time.Sleep }()
+	sleep = func(time.Duration) {}
+
+	upgrading := &houston.Deployment{ID: "dep-id", Label: "prod-1", RuntimeVersion: "6.0.0", DesiredRuntimeVersion: "7.0.0"}
+	upgraded := &houston.Deployment{ID: "dep-id", Label: "prod-1", RuntimeVersion: "7.0.0", DesiredRuntimeVersion: "7.0.0"}
+
+	t.Run("deploys the upgraded image and waits for it to be healthy", func(t *testing.T) {
+		api := new(mocks.ClientInterface)
+		api.On("GetDeployment", "dep-id").Return(upgrading, nil).Twice()
+		api.On("GetDeployment", "dep-id").Return(upgraded, nil)
+		api.On("GetDeploymentStatus", "dep-id").Return(unhealthyStatus, nil).Once()
+		api.On("GetDeploymentStatus", "dep-id").Return(healthyStatus, nil)
+
+		var deployedImage string
+		opts := UpgradeWaitOptions{
+			Deploy: func(deploymentID, baseImage string) error {
+				deployedImage = baseImage
+				return nil
+			},
+			Timeout: time.Minute,
+		}
+		buf := new(bytes.Buffer)
+		err := WaitForUpgrade("dep-id", opts, api, buf)
+		assert.NoError(t, err)
+		assert.Equal(t, "quay.io/astronomer/astro-runtime:7.0.0", deployedImage)
+		assert.Contains(t, buf.String(), "Deployment prod-1: status unhealthy, scheduler is deploying")
+		assert.Contains(t, buf.String(), "Deployment prod-1 is running quay.io/astronomer/astro-runtime:7.0.0 and all of its components are healthy")
+		api.AssertExpectations(t)
+	})
+
+	t.Run("airflow upgrade", func(t *testing.T) {
+		api := new(mocks.ClientInterface)
+		api.On("GetDeployment", "dep-id").Return(&houston.Deployment{ID: "dep-id", AirflowVersion: "2.2.4", DesiredAirflowVersion: "2.4.1"}, nil).Once()
+		api.On("GetDeployment", "dep-id").Return(&houston.Deployment{ID: "dep-id", AirflowVersion: "2.4.1", DesiredAirflowVersion: "2.4.1"}, nil)
+		api.On("GetDeploymentStatus", "dep-id").Return(healthyStatus, nil)
+		api.On("GetDeploymentConfig", nil).Return(&houston.DeploymentConfig{AirflowImages: []houston.AirflowImage{
+			{Version: "2.2.4", Tag: "2.2.4-onbuild"},
+			{Version: "2.4.1", Tag: "2.4.1-1-onbuild"},
+			{Version: "2.4.1", Tag: "2.4.1-2"},
+			{Version: "2.4.1", Tag: "2.4.1-2-onbuild"},
+		}}, nil)
+
+		var deployedImage string
+		opts := UpgradeWaitOptions{
+			Deploy: func(deploymentID, baseImage string) error {
+				deployedImage = baseImage
+				return nil
+			},
+			Timeout: time.Minute,
+		}
+		err := WaitForUpgrade("dep-id", opts, api, new(bytes.Buffer))
+		assert.NoError(t, err)
+		assert.Equal(t, "quay.io/astronomer/ap-airflow:2.4.1-2-onbuild", deployedImage)
+	})
+
+	t.Run("airflow version without image", func(t *testing.T) {
+		api := new(mocks.ClientInterface)
+		api.On("GetDeployment", "dep-id").Return(&houston.Deployment{ID: "dep-id", AirflowVersion: "2.2.4", DesiredAirflowVersion: "2.4.1"}, nil)
+		api.On("GetDeploymentConfig", nil).Return(&houston.DeploymentConfig{AirflowImages: []houston.AirflowImage{{Version: "2.2.4", Tag: "2.2.4-onbuild"}}}, nil)
+
+		opts := UpgradeWaitOptions{Deploy: func(string, string) error {
+			t.Error("nothing should be deployed")
+			return nil
+		}}
+		err := WaitForUpgrade("dep-id", opts, api, new(bytes.Buffer))
+		assert.ErrorIs(t, err, errNoCertifiedImage)
+		api.AssertExpectations(t)
+	})
+
+	t.Run("timeout", func(t *testing.T) {
+		api := new(mocks.ClientInterface)
+		api.On("GetDeployment", "dep-id").Return(upgrading, nil).Once()
+		api.On("GetDeployment", "dep-id").Return(upgraded, nil)
+		api.On("GetDeploymentStatus", "dep-id").Return(unhealthyStatus, nil)
+
+		opts := UpgradeWaitOptions{Deploy: func(string, string) error { return nil }}
+		err := WaitForUpgrade("dep-id", opts, api, new(bytes.Buffer))
+		assert.ErrorIs(t, err, errUpgradeTimeout)
+		assert.Contains(t, err.Error(), "scheduler is deploying")
+	})
+
+	t.Run("no upgrade in progress", func(t *testing.T) {
+		api := new(mocks.ClientInterface)
+		api.On("GetDeployment", "dep-id").Return(upgraded, nil)
+
+		opts := UpgradeWaitOptions{Deploy: func(string, string) error {
+			t.Error("nothing should be deployed")
+			return nil
+		}}
+		err := WaitForUpgrade("dep-id", opts, api, new(bytes.Buffer))
+		assert.ErrorIs(t, err, errNoUpgradeInProgress)
+	})
+
+	t.Run("deploy error", func(t *testing.T) {
+		api := new(mocks.ClientInterface)
+		api.On("GetDeployment", "dep-id").Return(upgrading, nil)
+
+		opts := UpgradeWaitOptions{Deploy: func(string, string) error { return errMock }}
+		err := WaitForUpgrade("dep-id", opts, api, new(bytes.Buffer))
+		assert.ErrorIs(t, err, errMock)
+	})
+
+	t.Run("status error", func(t *testing.T) {
+		api := new(mocks.ClientInterface)
+		api.On("GetDeployment", "dep-id").Return(upgrading, nil)
+		api.On("GetDeploymentStatus", "dep-id").Return(nil, errMock)
+
+		opts := UpgradeWaitOptions{Deploy: func(string, string) error { return nil }, Timeout: time.Minute}
+		err := WaitForUpgrade("dep-id", opts, api, new(bytes.Buffer))
+		assert.ErrorIs(t, err, errMock)
+	})
+
+	t.Run("no deploy function", func(t *testing.T) {
+		err := WaitForUpgrade("dep-id", UpgradeWaitOptions{}, new(mocks.ClientInterface), new(bytes.Buffer))
+		assert.ErrorIs(t, err, errNoUpgradeDeploy)
+	})
+}
+
+func TestUpgradeBatch(t *testing.T) {
+	deployments := []houston.Deployment{
+		{ID: "dep-1", Label: "prod-1", RuntimeVersion: "6.0.0"},
+		{ID: "dep-2", Label: "staging-1", RuntimeVersion: "6.0.0"},
+		{ID: "dep-3", Label: "prod-2", RuntimeVersion: "7.0.0"},
+		{ID: "dep-4", Label: "prod-3", RuntimeVersion: "6.0.0"},
+	}
+	upgrade := BatchUpgrade{Kind: RuntimeUpgradeKind, DesiredVersion: "7.0.0"}
+
+	t.Run("upgrades the matching deployments one at a time", func(t *testing.T) {
+		api := new(mocks.ClientInterface)
+		api.On("ListDeployments", houston.ListDeploymentsRequest{WorkspaceID: "ws-id"}).Return(deployments, nil)
+		for _, d := range []houston.Deployment{deployments[0], deployments[3]} {
+			d := d
+			api.On("GetDeployment", d.ID).Return(&d, nil)
+			upgraded := d
+			upgraded.DesiredRuntimeVersion = "7.0.0"
+			api.On("UpdateDeploymentRuntime", map[string]interface{}{"deploymentUuid": d.ID, "desiredRuntimeVersion": "7.0.0"}).Return(&upgraded, nil).Once()
+		}
+
+		buf := new(bytes.Buffer)
+		err := UpgradeBatch("ws-id", "prod-*", upgrade, api, buf)
+		assert.NoError(t, err)
+		assert.Contains(t, buf.String(), "Upgrading 3 deployments matching prod-*")
+		assert.Contains(t, buf.String(), "Deployment prod-2 is already upgraded, skipping")
+		assert.Contains(t, buf.String(), "Successfully upgraded 3 deployments")
+		assert.NotContains(t, buf.String(), "staging-1")
+		api.AssertExpectations(t)
+	})
+
+	t.Run("stops at the first failure", func(t *testing.T) {
+		api := new(mocks.ClientInterface)
+		api.On("ListDeployments", mock.Anything).Return(deployments, nil)
+		api.On("GetDeployment", "dep-1").Return(nil, errMock).Once()
+
+		err := UpgradeBatch("ws-id", "prod-*", upgrade, api, new(bytes.Buffer))
+		assert.ErrorIs(t, err, errBatchUpgradeFailed)
+		assert.Contains(t, err.Error(), "prod-1 (dep-1), 0 of 3 deployments upgraded")
+		api.AssertNotCalled(t, "GetDeployment", "dep-4")
+	})
+
+	t.Run("no match", func(t *testing.T) {
+		api := new(mocks.ClientInterface)
+		api.On("ListDeployments", mock.Anything).Return(deployments, nil)
+
+		err := UpgradeBatch("ws-id", "dev-*", upgrade, api, new(bytes.Buffer))
+		assert.ErrorIs(t, err, errNoDeploymentsMatch)
+	})
+
+	t.Run("invalid filter", func(t *testing.T) {
+		err := UpgradeBatch("ws-id", "prod-[", upgrade, new(mocks.ClientInterface), new(bytes.Buffer))
+		assert.ErrorIs(t, err, errInvalidLabelFilter)
+	})
+
+	t.Run("desired version required", func(t *testing.T) {
+		err := UpgradeBatch("ws-id", "prod-*", BatchUpgrade{Kind: AirflowUpgradeKind}, new(mocks.ClientInterface), new(bytes.Buffer))
+		assert.ErrorIs(t, err, errBatchDesiredVersion)
+	})
+
+	t.Run("list error", func(t *testing.T) {
+		api := new(mocks.ClientInterface)
+		api.On("ListDeployments", mock.Anything).Return(nil, errMock)
+
+		err := UpgradeBatch("ws-id", "prod-*", upgrade, api, new(bytes.Buffer))
+		assert.ErrorIs(t, err, errMock)
+	})
+}
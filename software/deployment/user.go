@@ -6,6 +6,7 @@ import (
 
 	"github.com/astronomer/astro-cli/houston"
 	"github.com/astronomer/astro-cli/pkg/printutil"
+	"github.com/astronomer/astro-cli/software/utils"
 )
 
 const (
@@ -60,6 +61,41 @@ func UserList(deploymentID, email, userID, fullName string, client houston.Clien
 	return nil
 }
 
+// PaginatedUserList prints the page of users with deployment access after the cursor of opts, or every user after it
+// with opts.All. Users are filtered by email and by deployment role when they are set.
+func PaginatedUserList(deploymentID, email, role string, opts utils.ListOptions, client houston.ClientInterface, out io.Writer) error {
+	fetch := func(cursor string, take int) ([]houston.DeploymentUser, error) {
+		request := houston.PaginatedDeploymentUsersRequest{DeploymentID: deploymentID, Email: email, Role: role, CursorID: cursor, Take: take}
+		return houston.Call(client.PaginatedListDeploymentUsers)(request)
+	}
+	deploymentUsers, next, err := utils.FetchPages(opts, fetch, func(u *houston.DeploymentUser) string { return u.ID })
+	if err != nil {
+		return err
+	}
+
+	if len(deploymentUsers) < 1 {
+		_, err = out.Write([]byte(houstonInvalidDeploymentUsersMsg))
+		return err
+	}
+
+	usersTab := printutil.Table{
+		Padding:        []int{44, 50},
+		DynamicPadding: true,
+		Header:         []string{"USER ID", "NAME", "EMAIL", "ROLE"},
+	}
+	for _, d := range deploymentUsers {
+		userRole := getDeploymentLevelRole(d.RoleBindings, deploymentID)
+		if userRole != houston.NoneRole {
+			usersTab.AddRow([]string{d.ID, d.FullName, d.Username, userRole}, false)
+		}
+	}
+	if err := usersTab.Print(out); err != nil {
+		return err
+	}
+	utils.PrintNextCursor(next, out)
+	return nil
+}
+
 // Add a user to a deployment with specified role
 func Add(deploymentID, email, role string, client houston.ClientInterface, out io.Writer) error { //nolint:dupl
 	addUserRequest := houston.UpdateDeploymentUserRequest{
@@ -8,8 +8,10 @@ import (
 
 	"github.com/astronomer/astro-cli/houston"
 	testUtil "github.com/astronomer/astro-cli/pkg/testing"
+	"github.com/astronomer/astro-cli/software/utils"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 )
 
 func TestUserList(t *testing.T) {
@@ -113,6 +115,68 @@ func TestUserList(t *testing.T) {
 	})
 }
 
+func TestPaginatedUserList(t *testing.T) {
+	testUtil.InitTestConfig(testUtil.SoftwarePlatform)
+	deploymentID := "ckgqw2k2600081qc90nbage4h"
+	mockUsers := []houston.DeploymentUser{
+		{
+			ID:           "user-1",
+			FullName:     "Some Person",
+			Username:     "somebody@astronomer.io",
+			RoleBindings: []houston.RoleBinding{{Role: houston.DeploymentAdminRole, Deployment: houston.Deployment{ID: deploymentID}}},
+		},
+		{
+			ID:           "user-2",
+			FullName:     "Other Person",
+			Username:     "other@astronomer.io",
+			RoleBindings: []houston.RoleBinding{{Role: houston.DeploymentAdminRole, Deployment: houston.Deployment{ID: deploymentID}}},
+		},
+	}
+
+	t.Run("page of users filtered by role", func(t *testing.T) {
+		api := new(mocks.ClientInterface)
+		request := houston.PaginatedDeploymentUsersRequest{DeploymentID: deploymentID, Role: houston.DeploymentAdminRole, Take: 2}
+		api.On("PaginatedListDeploymentUsers", request).Return(mockUsers, nil)
+
+		buf := new(bytes.Buffer)
+		err := PaginatedUserList(deploymentID, "", houston.DeploymentAdminRole, utils.ListOptions{PageSize: 2}, api, buf)
+		assert.NoError(t, err)
+		assert.Contains(t, buf.String(), "other@astronomer.io")
+		assert.Contains(t, buf.String(), "--cursor=user-2")
+		api.AssertExpectations(t)
+	})
+
+	t.Run("every user after a cursor", func(t *testing.T) {
+		api := new(mocks.ClientInterface)
+		request := houston.PaginatedDeploymentUsersRequest{DeploymentID: deploymentID, Email: "other@astronomer.io", CursorID: "user-1", Take: utils.DefaultPageSize}
+		api.On("PaginatedListDeploymentUsers", request).Return(mockUsers[1:], nil)
+
+		buf := new(bytes.Buffer)
+		err := PaginatedUserList(deploymentID, "other@astronomer.io", "", utils.ListOptions{Cursor: "user-1", All: true}, api, buf)
+		assert.NoError(t, err)
+		assert.Contains(t, buf.String(), "other@astronomer.io")
+		assert.NotContains(t, buf.String(), "--cursor")
+	})
+
+	t.Run("no users", func(t *testing.T) {
+		api := new(mocks.ClientInterface)
+		api.On("PaginatedListDeploymentUsers", mock.Anything).Return([]houston.DeploymentUser{}, nil)
+
+		buf := new(bytes.Buffer)
+		err := PaginatedUserList(deploymentID, "", "", utils.ListOptions{All: true}, api, buf)
+		assert.NoError(t, err)
+		assert.Equal(t, houstonInvalidDeploymentUsersMsg, buf.String())
+	})
+
+	t.Run("error", func(t *testing.T) {
+		api := new(mocks.ClientInterface)
+		api.On("PaginatedListDeploymentUsers", mock.Anything).Return(nil, errMock)
+
+		err := PaginatedUserList(deploymentID, "", "", utils.ListOptions{All: true}, api, new(bytes.Buffer))
+		assert.ErrorIs(t, err, errMock)
+	})
+}
+
 func TestAdd(t *testing.T) {
 	testUtil.InitTestConfig(testUtil.SoftwarePlatform)
 
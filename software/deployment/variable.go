@@ -0,0 +1,270 @@
+package deployment
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/astronomer/astro-cli/houston"
+	"github.com/astronomer/astro-cli/pkg/printutil"
+)
+
+const secretMask = "****"
+
+var (
+	errVariableDeploymentNotFound = errors.New("deployment not found in the workspace")
+	errVarCreateUpdate            = errors.New("there was an error while creating or updating one or more of the environment variables, check the logs above for more information")
+	errVarDelete                  = errors.New("one or more of the environment variables to delete were not found")
+)
+
+func newVariableTable() *printutil.Table {
+	return &printutil.Table{
+		Padding:        []int{5, 30, 30, 10},
+		DynamicPadding: true,
+		Header:         []string{"#", "KEY", "VALUE", "SECRET"},
+	}
+}
+
+// VariableList prints the environment variables of a deployment, or only the one with key when it is set. Secret
+// values are masked.
+func VariableList(ws, deploymentID, key string, client houston.ClientInterface, out io.Writer) error {
+	d, err := getVariableDeployment(ws, deploymentID, client)
+	if err != nil {
+		return err
+	}
+
+	variables, err := houston.Call(client.ListDeploymentVariables)(houston.ListDeploymentVariablesRequest{DeploymentID: d.ID, ReleaseName: d.ReleaseName})
+	if err != nil {
+		return err
+	}
+
+	if key != "" {
+		filtered := []houston.EnvironmentVariable{}
+		for i := range variables {
+			if variables[i].Key == key {
+				filtered = append(filtered, variables[i])
+			}
+		}
+		variables = filtered
+	}
+
+	if len(variables) == 0 {
+		fmt.Fprintln(out, "\nNo variables found")
+		return nil
+	}
+	return printVariables(variables, out)
+}
+
+// VariableModify creates the variables of variableList, given as key=value, and of envFile when useEnvFile is set.
+// With updateVars, existing variables are updated, otherwise they are skipped. Variables can be made secret but a
+// secret variable can't be made public again.
+func VariableModify(ws, deploymentID, envFile string, variableList []string, useEnvFile, makeSecret, updateVars bool, client houston.ClientInterface, out io.Writer) error {
+	d, err := getVariableDeployment(ws, deploymentID, client)
+	if err != nil {
+		return err
+	}
+
+	variables, err := houston.Call(client.ListDeploymentVariables)(houston.ListDeploymentVariablesRequest{DeploymentID: d.ID, ReleaseName: d.ReleaseName})
+	if err != nil {
+		return err
+	}
+
+	var newVariables []houston.EnvironmentVariable
+	invalid := false
+	for _, pair := range variableList {
+		key, value, found := strings.Cut(pair, "=")
+		if !found || key == "" || value == "" {
+			fmt.Fprintf(out, "Input %s is not a valid key value pair, should be of the form key=value\n", pair)
+			invalid = true
+			continue
+		}
+		newVariables = append(newVariables, houston.EnvironmentVariable{Key: key, Value: value, IsSecret: makeSecret})
+	}
+	if useEnvFile {
+		fileVariables, ok, err := readEnvFile(envFile, makeSecret, out)
+		if err != nil {
+			return err
+		}
+		invalid = invalid || !ok
+		newVariables = append(newVariables, fileVariables...)
+	}
+
+	// existing secrets are sent back without a value, houston keeps their stored value
+	for i := range variables {
+		if variables[i].IsSecret {
+			variables[i].Value = ""
+		}
+	}
+	for _, newVariable := range newVariables {
+		index := variableIndex(variables, newVariable.Key)
+		switch {
+		case index == -1:
+			fmt.Fprintf(out, "adding variable %s\n", newVariable.Key)
+			variables = append(variables, newVariable)
+		case updateVars:
+			fmt.Fprintf(out, "updating variable %s\n", newVariable.Key)
+			variables[index].Value = newVariable.Value
+			variables[index].IsSecret = variables[index].IsSecret || newVariable.IsSecret
+		default:
+			fmt.Fprintf(out, "key %s already exists, skipping creation. Use the update command to update existing variables\n", newVariable.Key)
+			invalid = true
+		}
+	}
+
+	updated, err := houston.Call(client.UpdateDeploymentVariables)(houston.UpdateDeploymentVariablesRequest{DeploymentID: d.ID, ReleaseName: d.ReleaseName, EnvironmentVariables: variables})
+	if err != nil {
+		return err
+	}
+
+	if len(updated) == 0 {
+		fmt.Fprintln(out, "\nNo variables for this Deployment")
+	} else {
+		fmt.Fprintln(out, "\nUpdated list of your Deployment's variables:")
+		if err := printVariables(updated, out); err != nil {
+			return err
+		}
+	}
+	if invalid {
+		return errVarCreateUpdate
+	}
+	return nil
+}
+
+// VariableDelete deletes the variables with keys from a deployment
+func VariableDelete(ws, deploymentID string, keys []string, client houston.ClientInterface, out io.Writer) error {
+	d, err := getVariableDeployment(ws, deploymentID, client)
+	if err != nil {
+		return err
+	}
+
+	variables, err := houston.Call(client.ListDeploymentVariables)(houston.ListDeploymentVariablesRequest{DeploymentID: d.ID, ReleaseName: d.ReleaseName})
+	if err != nil {
+		return err
+	}
+
+	notFound := false
+	for _, key := range keys {
+		index := variableIndex(variables, key)
+		if index == -1 {
+			fmt.Fprintf(out, "variable %s not found, skipping deletion\n", key)
+			notFound = true
+			continue
+		}
+		fmt.Fprintf(out, "deleting variable %s\n", key)
+		variables = append(variables[:index], variables[index+1:]...)
+	}
+	for i := range variables {
+		if variables[i].IsSecret {
+			variables[i].Value = ""
+		}
+	}
+
+	updated, err := houston.Call(client.UpdateDeploymentVariables)(houston.UpdateDeploymentVariablesRequest{DeploymentID: d.ID, ReleaseName: d.ReleaseName, EnvironmentVariables: variables})
+	if err != nil {
+		return err
+	}
+
+	if len(updated) == 0 {
+		fmt.Fprintln(out, "\nNo variables for this Deployment")
+	} else {
+		fmt.Fprintln(out, "\nUpdated list of your Deployment's variables:")
+		if err := printVariables(updated, out); err != nil {
+			return err
+		}
+	}
+	if notFound {
+		return errVarDelete
+	}
+	return nil
+}
+
+// getVariableDeployment returns the deployment with deploymentID from the workspace, the user selects one when
+// deploymentID is empty. The release name of the deployment is needed to manage its variables.
+func getVariableDeployment(ws, deploymentID string, client houston.ClientInterface) (houston.Deployment, error) {
+	deployments, err := GetDeployments(ws, client)
+	if err != nil {
+		return houston.Deployment{}, err
+	}
+
+	if deploymentID == "" {
+		d, err := SelectDeployment(deployments, "Select which Deployment you want to manage variables for")
+		if err != nil {
+			return houston.Deployment{}, err
+		}
+		if d.ID == "" {
+			return houston.Deployment{}, fmt.Errorf("%w: %s", errVariableDeploymentNotFound, ws)
+		}
+		return d, nil
+	}
+
+	for i := range deployments {
+		if deployments[i].ID == deploymentID {
+			return deployments[i], nil
+		}
+	}
+	return houston.Deployment{}, fmt.Errorf("%w: %s", errVariableDeploymentNotFound, deploymentID)
+}
+
+// readEnvFile reads the variables of an environment file. It returns false if some lines are not valid variables,
+// those are skipped.
+func readEnvFile(envFile string, makeSecret bool, out io.Writer) ([]houston.EnvironmentVariable, bool, error) {
+	file, err := os.Open(envFile)
+	if err != nil {
+		return nil, false, fmt.Errorf("unable to read file %s: %w", envFile, err)
+	}
+	defer file.Close()
+
+	var variables []houston.EnvironmentVariable
+	valid := true
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		key, value, found := strings.Cut(line, "=")
+		key = strings.TrimSpace(key)
+		value = strings.TrimSpace(value)
+		value = strings.Trim(value, `"`)
+		value = strings.Trim(value, `'`)
+		switch {
+		case !found:
+			fmt.Fprintf(out, "%s is an improperly formatted variable, no variable created\n", line)
+			valid = false
+		case key == "" || value == "":
+			fmt.Fprintf(out, "empty key or value in %s, no variable created\n", line)
+			valid = false
+		case variableIndex(variables, key) != -1:
+			fmt.Fprintf(out, "key %s already exists within the file specified, skipping creation\n", key)
+			valid = false
+		default:
+			variables = append(variables, houston.EnvironmentVariable{Key: key, Value: value, IsSecret: makeSecret})
+		}
+	}
+	return variables, valid, scanner.Err()
+}
+
+func variableIndex(variables []houston.EnvironmentVariable, key string) int {
+	for i := range variables {
+		if variables[i].Key == key {
+			return i
+		}
+	}
+	return -1
+}
+
+func printVariables(variables []houston.EnvironmentVariable, out io.Writer) error {
+	tab := newVariableTable()
+	for i := range variables {
+		value := variables[i].Value
+		if variables[i].IsSecret {
+			value = secretMask
+		}
+		tab.AddRow([]string{strconv.Itoa(i + 1), variables[i].Key, value, strconv.FormatBool(variables[i].IsSecret)}, false)
+	}
+	return tab.Print(out)
+}
@@ -0,0 +1,228 @@
+package deployment
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/astronomer/astro-cli/houston"
+	mocks "github.com/astronomer/astro-cli/houston/mocks"
+	testUtil "github.com/astronomer/astro-cli/pkg/testing"
+	"github.com/stretchr/testify/assert"
+)
+
+var (
+	variableDeployments = []houston.Deployment{
+		{ID: "ckbv818oa00r107606ywhoqtw", Label: "prod", ReleaseName: "burning-terrestrial-5940"},
+	}
+	variableListRequest = houston.ListDeploymentVariablesRequest{DeploymentID: "ckbv818oa00r107606ywhoqtw", ReleaseName: "burning-terrestrial-5940"}
+)
+
+func mockVariables() []houston.EnvironmentVariable {
+	return []houston.EnvironmentVariable{
+		{Key: "FOO", Value: "bar"},
+		{Key: "TOKEN", IsSecret: true},
+	}
+}
+
+func TestVariableList(t *testing.T) {
+	testUtil.InitTestConfig(testUtil.SoftwarePlatform)
+
+	t.Run("success", func(t *testing.T) {
+		api := new(mocks.ClientInterface)
+		api.On("ListDeployments", houston.ListDeploymentsRequest{WorkspaceID: "ws-id"}).Return(variableDeployments, nil).Once()
+		api.On("ListDeploymentVariables", variableListRequest).Return(mockVariables(), nil).Once()
+
+		buf := new(bytes.Buffer)
+		err := VariableList("ws-id", "ckbv818oa00r107606ywhoqtw", "", api, buf)
+		assert.NoError(t, err)
+		assert.Contains(t, buf.String(), "FOO")
+		assert.Contains(t, buf.String(), "bar")
+		assert.Contains(t, buf.String(), "TOKEN")
+		assert.Contains(t, buf.String(), secretMask)
+		api.AssertExpectations(t)
+	})
+
+	t.Run("filter by key", func(t *testing.T) {
+		api := new(mocks.ClientInterface)
+		api.On("ListDeployments", houston.ListDeploymentsRequest{WorkspaceID: "ws-id"}).Return(variableDeployments, nil).Once()
+		api.On("ListDeploymentVariables", variableListRequest).Return(mockVariables(), nil).Once()
+
+		buf := new(bytes.Buffer)
+		err := VariableList("ws-id", "ckbv818oa00r107606ywhoqtw", "TOKEN", api, buf)
+		assert.NoError(t, err)
+		assert.NotContains(t, buf.String(), "FOO")
+		assert.Contains(t, buf.String(), "TOKEN")
+		api.AssertExpectations(t)
+	})
+
+	t.Run("deployment not found", func(t *testing.T) {
+		api := new(mocks.ClientInterface)
+		api.On("ListDeployments", houston.ListDeploymentsRequest{WorkspaceID: "ws-id"}).Return(variableDeployments, nil).Once()
+
+		buf := new(bytes.Buffer)
+		err := VariableList("ws-id", "unknown-id", "", api, buf)
+		assert.ErrorIs(t, err, errVariableDeploymentNotFound)
+		api.AssertExpectations(t)
+	})
+
+	t.Run("list error", func(t *testing.T) {
+		api := new(mocks.ClientInterface)
+		api.On("ListDeployments", houston.ListDeploymentsRequest{WorkspaceID: "ws-id"}).Return(variableDeployments, nil).Once()
+		api.On("ListDeploymentVariables", variableListRequest).Return(nil, errMock).Once()
+
+		buf := new(bytes.Buffer)
+		err := VariableList("ws-id", "ckbv818oa00r107606ywhoqtw", "", api, buf)
+		assert.ErrorIs(t, err, errMock)
+		api.AssertExpectations(t)
+	})
+}
+
+func TestVariableModify(t *testing.T) {
+	testUtil.InitTestConfig(testUtil.SoftwarePlatform)
+
+	t.Run("create new variables", func(t *testing.T) {
+		expected := houston.UpdateDeploymentVariablesRequest{
+			DeploymentID: "ckbv818oa00r107606ywhoqtw",
+			ReleaseName:  "burning-terrestrial-5940",
+			EnvironmentVariables: []houston.EnvironmentVariable{
+				{Key: "FOO", Value: "bar"},
+				{Key: "TOKEN", IsSecret: true},
+				{Key: "NEW", Value: "value", IsSecret: true},
+			},
+		}
+		api := new(mocks.ClientInterface)
+		api.On("ListDeployments", houston.ListDeploymentsRequest{WorkspaceID: "ws-id"}).Return(variableDeployments, nil).Once()
+		api.On("ListDeploymentVariables", variableListRequest).Return(mockVariables(), nil).Once()
+		api.On("UpdateDeploymentVariables", expected).Return(expected.EnvironmentVariables, nil).Once()
+
+		buf := new(bytes.Buffer)
+		err := VariableModify("ws-id", "ckbv818oa00r107606ywhoqtw", "", []string{"NEW=value"}, false, true, false, api, buf)
+		assert.NoError(t, err)
+		assert.Contains(t, buf.String(), "adding variable NEW")
+		assert.NotContains(t, buf.String(), "value ")
+		api.AssertExpectations(t)
+	})
+
+	t.Run("create skips existing variables", func(t *testing.T) {
+		expected := houston.UpdateDeploymentVariablesRequest{
+			DeploymentID: "ckbv818oa00r107606ywhoqtw",
+			ReleaseName:  "burning-terrestrial-5940",
+			EnvironmentVariables: []houston.EnvironmentVariable{
+				{Key: "FOO", Value: "bar"},
+				{Key: "TOKEN", IsSecret: true},
+			},
+		}
+		api := new(mocks.ClientInterface)
+		api.On("ListDeployments", houston.ListDeploymentsRequest{WorkspaceID: "ws-id"}).Return(variableDeployments, nil).Once()
+		api.On("ListDeploymentVariables", variableListRequest).Return(mockVariables(), nil).Once()
+		api.On("UpdateDeploymentVariables", expected).Return(expected.EnvironmentVariables, nil).Once()
+
+		buf := new(bytes.Buffer)
+		err := VariableModify("ws-id", "ckbv818oa00r107606ywhoqtw", "", []string{"FOO=baz", "invalid"}, false, false, false, api, buf)
+		assert.ErrorIs(t, err, errVarCreateUpdate)
+		assert.Contains(t, buf.String(), "key FOO already exists")
+		assert.Contains(t, buf.String(), "Input invalid is not a valid key value pair")
+		api.AssertExpectations(t)
+	})
+
+	t.Run("update existing variables", func(t *testing.T) {
+		expected := houston.UpdateDeploymentVariablesRequest{
+			DeploymentID: "ckbv818oa00r107606ywhoqtw",
+			ReleaseName:  "burning-terrestrial-5940",
+			EnvironmentVariables: []houston.EnvironmentVariable{
+				{Key: "FOO", Value: "baz"},
+				{Key: "TOKEN", Value: "new-token", IsSecret: true},
+			},
+		}
+		api := new(mocks.ClientInterface)
+		api.On("ListDeployments", houston.ListDeploymentsRequest{WorkspaceID: "ws-id"}).Return(variableDeployments, nil).Once()
+		api.On("ListDeploymentVariables", variableListRequest).Return(mockVariables(), nil).Once()
+		api.On("UpdateDeploymentVariables", expected).Return(expected.EnvironmentVariables, nil).Once()
+
+		buf := new(bytes.Buffer)
+		err := VariableModify("ws-id", "ckbv818oa00r107606ywhoqtw", "", []string{"FOO=baz", "TOKEN=new-token"}, false, false, true, api, buf)
+		assert.NoError(t, err)
+		assert.Contains(t, buf.String(), "updating variable FOO")
+		assert.NotContains(t, buf.String(), "new-token")
+		api.AssertExpectations(t)
+	})
+
+	t.Run("load from env file", func(t *testing.T) {
+		envFile := filepath.Join(t.TempDir(), ".env")
+		err := os.WriteFile(envFile, []byte("# comment\nFILE_VAR=\"from-file\"\nBROKEN\n"), os.ModePerm)
+		assert.NoError(t, err)
+
+		expected := houston.UpdateDeploymentVariablesRequest{
+			DeploymentID: "ckbv818oa00r107606ywhoqtw",
+			ReleaseName:  "burning-terrestrial-5940",
+			EnvironmentVariables: []houston.EnvironmentVariable{
+				{Key: "FOO", Value: "bar"},
+				{Key: "TOKEN", IsSecret: true},
+				{Key: "FILE_VAR", Value: "from-file"},
+			},
+		}
+		api := new(mocks.ClientInterface)
+		api.On("ListDeployments", houston.ListDeploymentsRequest{WorkspaceID: "ws-id"}).Return(variableDeployments, nil).Once()
+		api.On("ListDeploymentVariables", variableListRequest).Return(mockVariables(), nil).Once()
+		api.On("UpdateDeploymentVariables", expected).Return(expected.EnvironmentVariables, nil).Once()
+
+		buf := new(bytes.Buffer)
+		err = VariableModify("ws-id", "ckbv818oa00r107606ywhoqtw", envFile, nil, true, false, false, api, buf)
+		assert.ErrorIs(t, err, errVarCreateUpdate)
+		assert.Contains(t, buf.String(), "BROKEN is an improperly formatted variable")
+		api.AssertExpectations(t)
+	})
+
+	t.Run("missing env file", func(t *testing.T) {
+		api := new(mocks.ClientInterface)
+		api.On("ListDeployments", houston.ListDeploymentsRequest{WorkspaceID: "ws-id"}).Return(variableDeployments, nil).Once()
+		api.On("ListDeploymentVariables", variableListRequest).Return(mockVariables(), nil).Once()
+
+		buf := new(bytes.Buffer)
+		err := VariableModify("ws-id", "ckbv818oa00r107606ywhoqtw", filepath.Join(t.TempDir(), ".env"), nil, true, false, false, api, buf)
+		assert.ErrorContains(t, err, "unable to read file")
+		api.AssertExpectations(t)
+	})
+}
+
+func TestVariableDelete(t *testing.T) {
+	testUtil.InitTestConfig(testUtil.SoftwarePlatform)
+
+	t.Run("success", func(t *testing.T) {
+		expected := houston.UpdateDeploymentVariablesRequest{
+			DeploymentID:         "ckbv818oa00r107606ywhoqtw",
+			ReleaseName:          "burning-terrestrial-5940",
+			EnvironmentVariables: []houston.EnvironmentVariable{{Key: "TOKEN", IsSecret: true}},
+		}
+		api := new(mocks.ClientInterface)
+		api.On("ListDeployments", houston.ListDeploymentsRequest{WorkspaceID: "ws-id"}).Return(variableDeployments, nil).Once()
+		api.On("ListDeploymentVariables", variableListRequest).Return(mockVariables(), nil).Once()
+		api.On("UpdateDeploymentVariables", expected).Return(expected.EnvironmentVariables, nil).Once()
+
+		buf := new(bytes.Buffer)
+		err := VariableDelete("ws-id", "ckbv818oa00r107606ywhoqtw", []string{"FOO"}, api, buf)
+		assert.NoError(t, err)
+		assert.Contains(t, buf.String(), "deleting variable FOO")
+		api.AssertExpectations(t)
+	})
+
+	t.Run("variable not found", func(t *testing.T) {
+		expected := houston.UpdateDeploymentVariablesRequest{
+			DeploymentID:         "ckbv818oa00r107606ywhoqtw",
+			ReleaseName:          "burning-terrestrial-5940",
+			EnvironmentVariables: mockVariables(),
+		}
+		api := new(mocks.ClientInterface)
+		api.On("ListDeployments", houston.ListDeploymentsRequest{WorkspaceID: "ws-id"}).Return(variableDeployments, nil).Once()
+		api.On("ListDeploymentVariables", variableListRequest).Return(mockVariables(), nil).Once()
+		api.On("UpdateDeploymentVariables", expected).Return(expected.EnvironmentVariables, nil).Once()
+
+		buf := new(bytes.Buffer)
+		err := VariableDelete("ws-id", "ckbv818oa00r107606ywhoqtw", []string{"MISSING"}, api, buf)
+		assert.ErrorIs(t, err, errVarDelete)
+		assert.Contains(t, buf.String(), "variable MISSING not found")
+		api.AssertExpectations(t)
+	})
+}
@@ -0,0 +1,129 @@
+package platform
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/astronomer/astro-cli/houston"
+	"github.com/astronomer/astro-cli/pkg/printutil"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	StatusAvailable   = "available"
+	StatusUnavailable = "unavailable"
+	StatusDegraded    = "degraded"
+)
+
+// Command is the support of a CLI command on the connected platform
+type Command struct {
+	Name    string `yaml:"name" json:"name"`
+	Status  string `yaml:"status" json:"status"`
+	Details string `yaml:"details,omitempty" json:"details,omitempty"`
+}
+
+// platformInfo is the document printed by Info in json or yaml
+type platformInfo struct {
+	Version    string          `yaml:"version" json:"version"`
+	BaseDomain string          `yaml:"base_domain" json:"base_domain"`
+	Flags      map[string]bool `yaml:"flags" json:"flags"`
+	Commands   []Command       `yaml:"commands" json:"commands"`
+}
+
+// Info prints the platform version, its feature flags and the support of the version or feature restricted CLI
+// commands, as tables or as a single json or yaml document depending on outputFormat
+func Info(version string, appConfig *houston.AppConfig, commands []Command, outputFormat string, out io.Writer) error {
+	var flags houston.FeatureFlags
+	if appConfig != nil {
+		flags = appConfig.Flags
+	}
+	if outputFormat == printutil.JSONFormat || outputFormat == printutil.YAMLFormat {
+		return printDocument(version, appConfig, featureFlags(flags), commands, outputFormat, out)
+	}
+
+	if version == "" {
+		version = "unknown"
+	}
+	fmt.Fprintf(out, "Astronomer Software Version: %s\n", version)
+	if appConfig != nil && appConfig.BaseDomain != "" {
+		fmt.Fprintf(out, "Base Domain: %s\n", appConfig.BaseDomain)
+	}
+	fmt.Fprintln(out)
+
+	flagsTable := printutil.Table{
+		Padding:        []int{30, 10},
+		DynamicPadding: true,
+		Header:         []string{"FEATURE FLAG", "ENABLED"},
+	}
+	for _, flag := range featureFlags(flags) {
+		flagsTable.AddRow([]string{flag.name, strconv.FormatBool(flag.enabled)}, false)
+	}
+	if err := flagsTable.Print(out); err != nil {
+		return err
+	}
+	fmt.Fprintln(out)
+
+	commandsTable := printutil.Table{
+		Padding:        []int{40, 15, 50},
+		DynamicPadding: true,
+		Header:         []string{"COMMAND", "STATUS", "DETAILS"},
+	}
+	for _, c := range commands {
+		commandsTable.AddRow([]string{c.Name, c.Status, c.Details}, false)
+	}
+	if err := commandsTable.Print(out); err != nil {
+		return err
+	}
+	fmt.Fprintln(out, "\nAll other commands are available")
+	return nil
+}
+
+func printDocument(version string, appConfig *houston.AppConfig, flags []featureFlag, commands []Command, outputFormat string, out io.Writer) error {
+	info := platformInfo{Version: version, Flags: make(map[string]bool, len(flags)), Commands: commands}
+	if appConfig != nil {
+		info.BaseDomain = appConfig.BaseDomain
+	}
+	for _, flag := range flags {
+		info.Flags[flag.name] = flag.enabled
+	}
+	if info.Commands == nil {
+		info.Commands = []Command{}
+	}
+
+	var document []byte
+	var err error
+	if outputFormat == printutil.JSONFormat {
+		document, err = json.MarshalIndent(info, "", "    ")
+	} else {
+		document, err = yaml.Marshal(info)
+	}
+	if err != nil {
+		return err
+	}
+	fmt.Fprintln(out, strings.TrimSuffix(string(document), "\n"))
+	return nil
+}
+
+type featureFlag struct {
+	name    string
+	enabled bool
+}
+
+// featureFlags lists the feature flags under the name Houston reports them with
+func featureFlags(flags houston.FeatureFlags) []featureFlag {
+	v := reflect.ValueOf(flags)
+	t := v.Type()
+	list := make([]featureFlag, 0, t.NumField())
+	for i := 0; i < t.NumField(); i++ {
+		name, _, _ := strings.Cut(t.Field(i).Tag.Get("json"), ",")
+		if name == "" {
+			name = t.Field(i).Name
+		}
+		list = append(list, featureFlag{name: name, enabled: v.Field(i).Bool()})
+	}
+	return list
+}
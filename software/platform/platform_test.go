@@ -0,0 +1,80 @@
+package platform
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/astronomer/astro-cli/houston"
+	"github.com/astronomer/astro-cli/pkg/printutil"
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/yaml.v3"
+)
+
+func TestInfo(t *testing.T) {
+	t.Run("prints version, flags and commands", func(t *testing.T) {
+		buf := new(bytes.Buffer)
+		appConfig := &houston.AppConfig{BaseDomain: "local.astronomer.io", Flags: houston.FeatureFlags{GitSyncEnabled: true}}
+		commands := []Command{
+			{Name: "astro deploy", Status: StatusDegraded, Details: "no DAG-only deploys"},
+			{Name: "astro team", Status: StatusAvailable},
+		}
+		err := Info("0.30.0", appConfig, commands, printutil.TableFormat, buf)
+		assert.NoError(t, err)
+		assert.Contains(t, buf.String(), "Astronomer Software Version: 0.30.0")
+		assert.Regexp(t, `gitSyncDagDeployment\s+true`, buf.String())
+		assert.Regexp(t, `nfsMountDagDeployment\s+false`, buf.String())
+		assert.Regexp(t, `astro deploy\s+degraded\s+no DAG-only deploys`, buf.String())
+		assert.Contains(t, buf.String(), "All other commands are available")
+	})
+
+	t.Run("unknown version without app config", func(t *testing.T) {
+		buf := new(bytes.Buffer)
+		err := Info("", nil, nil, printutil.TableFormat, buf)
+		assert.NoError(t, err)
+		assert.Contains(t, buf.String(), "Astronomer Software Version: unknown")
+		assert.NotContains(t, buf.String(), "Base Domain")
+	})
+
+	t.Run("json document", func(t *testing.T) {
+		buf := new(bytes.Buffer)
+		appConfig := &houston.AppConfig{BaseDomain: "local.astronomer.io", Flags: houston.FeatureFlags{GitSyncEnabled: true}}
+		commands := []Command{
+			{Name: "astro deploy", Status: StatusDegraded, Details: "no DAG-only deploys"},
+			{Name: "astro team", Status: StatusAvailable},
+		}
+		err := Info("0.30.0", appConfig, commands, printutil.JSONFormat, buf)
+		assert.NoError(t, err)
+
+		var info map[string]interface{}
+		assert.NoError(t, json.Unmarshal(buf.Bytes(), &info))
+		assert.Equal(t, "0.30.0", info["version"])
+		assert.Equal(t, "local.astronomer.io", info["base_domain"])
+		assert.Equal(t, true, info["flags"].(map[string]interface{})["gitSyncDagDeployment"])
+		assert.Equal(t, false, info["flags"].(map[string]interface{})["nfsMountDagDeployment"])
+		assert.Equal(t, []interface{}{
+			map[string]interface{}{"name": "astro deploy", "status": "degraded", "details": "no DAG-only deploys"},
+			map[string]interface{}{"name": "astro team", "status": "available"},
+		}, info["commands"])
+	})
+
+	t.Run("yaml document without app config", func(t *testing.T) {
+		buf := new(bytes.Buffer)
+		err := Info("0.30.0", nil, nil, printutil.YAMLFormat, buf)
+		assert.NoError(t, err)
+
+		var info platformInfo
+		assert.NoError(t, yaml.Unmarshal(buf.Bytes(), &info))
+		assert.Equal(t, "0.30.0", info.Version)
+		assert.Empty(t, info.BaseDomain)
+		assert.Len(t, info.Flags, 9)
+		assert.Contains(t, buf.String(), "commands: []")
+	})
+}
+
+func TestFeatureFlags(t *testing.T) {
+	flags := featureFlags(houston.FeatureFlags{DagOnlyDeployment: true})
+	assert.Len(t, flags, 9)
+	assert.Equal(t, featureFlag{name: "nfsMountDagDeployment", enabled: false}, flags[0])
+	assert.Equal(t, featureFlag{name: "dagOnlyDeployment", enabled: true}, flags[8])
+}
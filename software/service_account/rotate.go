@@ -0,0 +1,171 @@
+package serviceaccount
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/astronomer/astro-cli/houston"
+)
+
+const keyFilePermissions = 0o600
+
+var (
+	errServiceAccountNotFound = errors.New("no service account found with this ID")
+	errNoServiceAccountRole   = errors.New("unable to find the role of the service account, set the role of the new service account with --role")
+
+	serviceAccountRotatedMsg = "\n Service account successfully rotated."
+
+	// now is used to print when the grace period ends and is replaced in tests
+	now = time.Now
+)
+
+// RotateOptions are the settings used to rotate the API key of a service account
+type RotateOptions struct {
+	// Role of the new service account, the role of the old one is kept when empty
+	Role string
+	// KeyFile is the file the new API key is written to instead of being printed
+	KeyFile string
+	// DeleteOld deletes the old service account right away, or when GracePeriod is set prints the command deleting it
+	// and when to run it
+	DeleteOld   bool
+	GracePeriod time.Duration
+}
+
+// RotateWorkspaceServiceAccount replaces the workspace service account serviceAccountID with a new one that has the
+// same label, category and role, so that its API key can be rotated
+func RotateWorkspaceServiceAccount(workspaceID, serviceAccountID string, opts RotateOptions, client houston.ClientInterface, out io.Writer) error {
+	sas, err := houston.Call(client.ListWorkspaceServiceAccounts)(workspaceID)
+	if err != nil {
+		return err
+	}
+	old, role, err := findRotatedServiceAccount(sas, serviceAccountID, opts.Role, out)
+	if err != nil {
+		return err
+	}
+
+	newSA, err := houston.Call(client.CreateWorkspaceServiceAccount)(&houston.CreateServiceAccountRequest{
+		WorkspaceID: workspaceID,
+		Label:       old.Label,
+		Category:    old.Category,
+		Role:        role,
+	})
+	if err != nil {
+		return err
+	}
+	if err := printRotatedKey(newSA.Label, newSA.Category, newSA.ID, newSA.APIKey, opts.KeyFile, out); err != nil {
+		return err
+	}
+
+	if !opts.DeleteOld || opts.GracePeriod > 0 {
+		deleteCmd := fmt.Sprintf("astro workspace service-account delete %s --workspace-id=%s", old.ID, workspaceID)
+		printOldServiceAccountDeletion(old.ID, deleteCmd, opts.GracePeriod, out)
+		return nil
+	}
+	return DeleteUsingWorkspaceUUID(old.ID, workspaceID, client, out)
+}
+
+// RotateDeploymentServiceAccount replaces the deployment service account serviceAccountID with a new one that has the
+// same label, category and role, so that its API key can be rotated
+func RotateDeploymentServiceAccount(deploymentID, serviceAccountID string, opts RotateOptions, client houston.ClientInterface, out io.Writer) error {
+	sas, err := houston.Call(client.ListDeploymentServiceAccounts)(deploymentID)
+	if err != nil {
+		return err
+	}
+	old, role, err := findRotatedServiceAccount(sas, serviceAccountID, opts.Role, out)
+	if err != nil {
+		return err
+	}
+
+	newSA, err := houston.Call(client.CreateDeploymentServiceAccount)(&houston.CreateServiceAccountRequest{
+		DeploymentID: deploymentID,
+		Label:        old.Label,
+		Category:     old.Category,
+		Role:         role,
+	})
+	if err != nil {
+		return err
+	}
+	if err := printRotatedKey(newSA.Label, newSA.Category, newSA.ID, newSA.APIKey, opts.KeyFile, out); err != nil {
+		return err
+	}
+
+	if !opts.DeleteOld || opts.GracePeriod > 0 {
+		deleteCmd := fmt.Sprintf("astro deployment service-account delete %s --deployment-id=%s", old.ID, deploymentID)
+		printOldServiceAccountDeletion(old.ID, deleteCmd, opts.GracePeriod, out)
+		return nil
+	}
+	return DeleteUsingDeploymentUUID(old.ID, deploymentID, client, out)
+}
+
+// findRotatedServiceAccount returns the service account with the given ID along with the role its replacement gets,
+// and prints when it was created and last used
+func findRotatedServiceAccount(sas []houston.ServiceAccount, id, role string, out io.Writer) (*houston.ServiceAccount, string, error) {
+	var old *houston.ServiceAccount
+	for i := range sas {
+		if sas[i].ID == id {
+			old = &sas[i]
+			break
+		}
+	}
+	if old == nil {
+		return nil, "", fmt.Errorf("%w: %s", errServiceAccountNotFound, id)
+	}
+
+	if role == "" && old.RoleBinding != nil {
+		role = old.RoleBinding.Role
+	}
+	if role == "" {
+		return nil, "", errNoServiceAccountRole
+	}
+
+	fmt.Fprintf(out, "Rotating service account %s (%s), created at %s and last used %s\n", old.Label, old.ID, old.CreatedAt, lastUsed(old))
+	return old, role, nil
+}
+
+// printRotatedKey prints the new service account, or writes its API key to keyFile when it is set
+func printRotatedKey(label, category, id, apiKey, keyFile string, out io.Writer) error {
+	if keyFile != "" {
+		if err := writeKeyFile(keyFile, apiKey); err != nil {
+			// the new service account already exists, so its API key must not be lost
+			fmt.Fprintf(out, "Created service account %s (%s) but its API key could not be written to %s\nCopy and paste this API key for your records, you will not be shown it again:\n%s\n", label, id, keyFile, apiKey)
+			return fmt.Errorf("failed to write the new API key to %s: %w", keyFile, err)
+		}
+		fmt.Fprintf(out, "Created service account %s (%s), its API key was written to %s\n", label, id, keyFile)
+		return nil
+	}
+
+	tab := newTableOut()
+	tab.AddRow([]string{label, category, id, apiKey}, false)
+	tab.SuccessMsg = serviceAccountRotatedMsg
+	return tab.Print(out)
+}
+
+// writeKeyFile writes apiKey to keyFile, which is only readable by the current user even when it already existed
+func writeKeyFile(keyFile, apiKey string) error {
+	f, err := os.OpenFile(keyFile, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, keyFilePermissions)
+	if err != nil {
+		return err
+	}
+	// the permissions passed to OpenFile only apply to new files
+	if err := f.Chmod(keyFilePermissions); err != nil {
+		f.Close()
+		return err
+	}
+	if _, err := f.WriteString(apiKey); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// printOldServiceAccountDeletion tells how to delete the old service account, and when to once the grace period is over
+func printOldServiceAccountDeletion(id, deleteCmd string, gracePeriod time.Duration, out io.Writer) {
+	if gracePeriod <= 0 {
+		fmt.Fprintf(out, "\nThe old service account %s is still active, delete it with: %s\n", id, deleteCmd)
+		return
+	}
+	fmt.Fprintf(out, "\nThe old service account %s is still active during the grace period, delete it after %s with: %s\n", id, now().Add(gracePeriod).Format(time.RFC3339), deleteCmd)
+}
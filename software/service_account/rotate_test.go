@@ -0,0 +1,155 @@
+package serviceaccount
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/astronomer/astro-cli/houston"
+	mocks "github.com/astronomer/astro-cli/houston/mocks"
+	testUtil "github.com/astronomer/astro-cli/pkg/testing"
+	"github.com/stretchr/testify/assert"
+)
+
+var rotatedSA = houston.ServiceAccount{
+	ID:          "old-sa-id",
+	APIKey:      "old-api-key",
+	Label:       "ci",
+	Category:    "default",
+	CreatedAt:   "2022-01-01T00:00:00.000Z",
+	LastUsedAt:  "2022-03-01T00:00:00.000Z",
+	RoleBinding: &houston.RoleBinding{Role: houston.WorkspaceEditorRole},
+}
+
+func TestRotateWorkspaceServiceAccount(t *testing.T) {
+	testUtil.InitTestConfig(testUtil.SoftwarePlatform)
+	expectedRequest := &houston.CreateServiceAccountRequest{WorkspaceID: "ws-id", Label: "ci", Category: "default", Role: houston.WorkspaceEditorRole}
+	newSA := &houston.WorkspaceServiceAccount{ID: "new-sa-id", APIKey: "new-api-key", Label: "ci", Category: "default"}
+
+	t.Run("keep old service account", func(t *testing.T) {
+		api := new(mocks.ClientInterface)
+		api.On("ListWorkspaceServiceAccounts", "ws-id").Return([]houston.ServiceAccount{rotatedSA}, nil)
+		api.On("CreateWorkspaceServiceAccount", expectedRequest).Return(newSA, nil)
+
+		buf := new(bytes.Buffer)
+		err := RotateWorkspaceServiceAccount("ws-id", "old-sa-id", RotateOptions{}, api, buf)
+		assert.NoError(t, err)
+		assert.Contains(t, buf.String(), "created at 2022-01-01T00:00:00.000Z and last used 2022-03-01T00:00:00.000Z")
+		assert.Contains(t, buf.String(), "new-api-key")
+		assert.Contains(t, buf.String(), "Service account successfully rotated.")
+		assert.Contains(t, buf.String(), "astro workspace service-account delete old-sa-id --workspace-id=ws-id")
+		api.AssertExpectations(t)
+	})
+
+	t.Run("print when to delete old service account after grace period", func(t *testing.T) {
+		now = func() time.Time { return time.Date(2022, 4, 1, 12, 0, 0, 0, time.UTC) }
+		defer func() { now = time.Now }()
+
+		api := new(mocks.ClientInterface)
+		api.On("ListWorkspaceServiceAccounts", "ws-id").Return([]houston.ServiceAccount{rotatedSA}, nil)
+		api.On("CreateWorkspaceServiceAccount", expectedRequest).Return(newSA, nil)
+
+		buf := new(bytes.Buffer)
+		err := RotateWorkspaceServiceAccount("ws-id", "old-sa-id", RotateOptions{DeleteOld: true, GracePeriod: time.Hour}, api, buf)
+		assert.NoError(t, err)
+		assert.Contains(t, buf.String(), "delete it after 2022-04-01T13:00:00Z with: astro workspace service-account delete old-sa-id --workspace-id=ws-id")
+		api.AssertExpectations(t)
+	})
+
+	t.Run("delete old service account", func(t *testing.T) {
+		api := new(mocks.ClientInterface)
+		api.On("ListWorkspaceServiceAccounts", "ws-id").Return([]houston.ServiceAccount{rotatedSA}, nil)
+		api.On("CreateWorkspaceServiceAccount", expectedRequest).Return(newSA, nil)
+		api.On("DeleteWorkspaceServiceAccount", houston.DeleteServiceAccountRequest{WorkspaceID: "ws-id", ServiceAccountID: "old-sa-id"}).Return(&houston.ServiceAccount{ID: "old-sa-id", Label: "ci"}, nil)
+
+		buf := new(bytes.Buffer)
+		err := RotateWorkspaceServiceAccount("ws-id", "old-sa-id", RotateOptions{DeleteOld: true}, api, buf)
+		assert.NoError(t, err)
+		assert.Contains(t, buf.String(), "Service Account ci (old-sa-id) successfully deleted")
+		api.AssertExpectations(t)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		api := new(mocks.ClientInterface)
+		api.On("ListWorkspaceServiceAccounts", "ws-id").Return([]houston.ServiceAccount{rotatedSA}, nil)
+
+		err := RotateWorkspaceServiceAccount("ws-id", "unknown", RotateOptions{}, api, new(bytes.Buffer))
+		assert.ErrorIs(t, err, errServiceAccountNotFound)
+		api.AssertExpectations(t)
+	})
+
+	t.Run("error", func(t *testing.T) {
+		api := new(mocks.ClientInterface)
+		api.On("ListWorkspaceServiceAccounts", "ws-id").Return([]houston.ServiceAccount{rotatedSA}, nil)
+		api.On("CreateWorkspaceServiceAccount", expectedRequest).Return(nil, errMock)
+
+		err := RotateWorkspaceServiceAccount("ws-id", "old-sa-id", RotateOptions{DeleteOld: true}, api, new(bytes.Buffer))
+		assert.EqualError(t, err, errMock.Error())
+		api.AssertExpectations(t)
+	})
+}
+
+func TestRotateDeploymentServiceAccount(t *testing.T) {
+	testUtil.InitTestConfig(testUtil.SoftwarePlatform)
+	oldSA := houston.ServiceAccount{ID: "old-sa-id", Label: "ci", Category: "default"}
+	expectedRequest := &houston.CreateServiceAccountRequest{DeploymentID: "deployment-id", Label: "ci", Category: "default", Role: houston.DeploymentAdminRole}
+	newSA := &houston.DeploymentServiceAccount{ID: "new-sa-id", APIKey: "new-api-key", Label: "ci", Category: "default"}
+
+	t.Run("write key file", func(t *testing.T) {
+		api := new(mocks.ClientInterface)
+		api.On("ListDeploymentServiceAccounts", "deployment-id").Return([]houston.ServiceAccount{oldSA}, nil)
+		api.On("CreateDeploymentServiceAccount", expectedRequest).Return(newSA, nil)
+		api.On("DeleteDeploymentServiceAccount", houston.DeleteServiceAccountRequest{DeploymentID: "deployment-id", ServiceAccountID: "old-sa-id"}).Return(&houston.ServiceAccount{ID: "old-sa-id", Label: "ci"}, nil)
+
+		// an existing key file readable by others is restricted to the current user
+		keyFile := filepath.Join(t.TempDir(), "key")
+		assert.NoError(t, os.WriteFile(keyFile, []byte("old-api-key-with-a-longer-value"), 0o644)) //nolint:gosec
+		buf := new(bytes.Buffer)
+		err := RotateDeploymentServiceAccount("deployment-id", "old-sa-id", RotateOptions{Role: houston.DeploymentAdminRole, KeyFile: keyFile, DeleteOld: true}, api, buf)
+		assert.NoError(t, err)
+		assert.Contains(t, buf.String(), "last used never")
+		assert.NotContains(t, buf.String(), "new-api-key")
+
+		key, err := os.ReadFile(keyFile)
+		assert.NoError(t, err)
+		assert.Equal(t, "new-api-key", string(key))
+		info, err := os.Stat(keyFile)
+		assert.NoError(t, err)
+		assert.Equal(t, os.FileMode(keyFilePermissions), info.Mode().Perm())
+		api.AssertExpectations(t)
+	})
+
+	t.Run("print key when the key file can not be written", func(t *testing.T) {
+		api := new(mocks.ClientInterface)
+		api.On("ListDeploymentServiceAccounts", "deployment-id").Return([]houston.ServiceAccount{oldSA}, nil)
+		api.On("CreateDeploymentServiceAccount", expectedRequest).Return(newSA, nil)
+
+		keyFile := filepath.Join(t.TempDir(), "missing", "key")
+		buf := new(bytes.Buffer)
+		err := RotateDeploymentServiceAccount("deployment-id", "old-sa-id", RotateOptions{Role: houston.DeploymentAdminRole, KeyFile: keyFile, DeleteOld: true}, api, buf)
+		assert.ErrorContains(t, err, "failed to write the new API key to "+keyFile)
+		assert.Contains(t, buf.String(), "Created service account ci (new-sa-id) but its API key could not be written")
+		assert.Contains(t, buf.String(), "you will not be shown it again:\nnew-api-key")
+		api.AssertExpectations(t)
+	})
+
+	t.Run("unknown role", func(t *testing.T) {
+		api := new(mocks.ClientInterface)
+		api.On("ListDeploymentServiceAccounts", "deployment-id").Return([]houston.ServiceAccount{oldSA}, nil)
+
+		err := RotateDeploymentServiceAccount("deployment-id", "old-sa-id", RotateOptions{}, api, new(bytes.Buffer))
+		assert.ErrorIs(t, err, errNoServiceAccountRole)
+		api.AssertExpectations(t)
+	})
+
+	t.Run("error", func(t *testing.T) {
+		api := new(mocks.ClientInterface)
+		api.On("ListDeploymentServiceAccounts", "deployment-id").Return(nil, errMock)
+
+		err := RotateDeploymentServiceAccount("deployment-id", "old-sa-id", RotateOptions{}, api, new(bytes.Buffer))
+		assert.EqualError(t, err, errMock.Error())
+		api.AssertExpectations(t)
+	})
+}
@@ -6,6 +6,7 @@ import (
 
 	"github.com/astronomer/astro-cli/houston"
 	"github.com/astronomer/astro-cli/pkg/printutil"
+	"github.com/astronomer/astro-cli/software/utils"
 )
 
 var serviceAccountSuccessMsg = "\n Service account successfully created."
@@ -18,6 +19,26 @@ func newTableOut() *printutil.Table {
 	}
 }
 
+func newListTableOut() *printutil.Table {
+	return &printutil.Table{
+		Padding:        []int{40, 40, 50, 50, 30},
+		DynamicPadding: true,
+		Header:         []string{"NAME", "CATEGORY", "ID", "APIKEY", "LAST USED"},
+	}
+}
+
+func listRow(sa *houston.ServiceAccount) []string {
+	return []string{sa.Label, sa.Category, sa.ID, sa.APIKey, lastUsed(sa)}
+}
+
+// lastUsed returns when the API key of sa was last used, or never if it has not been used yet
+func lastUsed(sa *houston.ServiceAccount) string {
+	if sa.LastUsedAt == "" {
+		return "never"
+	}
+	return sa.LastUsedAt
+}
+
 func CreateUsingDeploymentUUID(deploymentUUID, label, category, role string, client houston.ClientInterface, out io.Writer) error { //nolint:dupl
 	createServiceAccountRequest := &houston.CreateServiceAccountRequest{
 		DeploymentID: deploymentUUID,
@@ -87,9 +108,9 @@ func GetDeploymentServiceAccounts(id string, client houston.ClientInterface, out
 		return err
 	}
 
-	tab := newTableOut()
-	for _, sa := range sas {
-		tab.AddRow([]string{sa.Label, sa.Category, sa.ID, sa.APIKey}, false)
+	tab := newListTableOut()
+	for i := range sas {
+		tab.AddRow(listRow(&sas[i]), false)
 	}
 
 	return tab.Print(out)
@@ -102,10 +123,45 @@ func GetWorkspaceServiceAccounts(id string, client houston.ClientInterface, out
 		return err
 	}
 
-	tab := newTableOut()
-	for _, sa := range sas {
-		tab.AddRow([]string{sa.Label, sa.Category, sa.ID, sa.APIKey}, false)
+	tab := newListTableOut()
+	for i := range sas {
+		tab.AddRow(listRow(&sas[i]), false)
 	}
 
 	return tab.Print(out)
 }
+
+// PaginatedDeploymentServiceAccounts prints the page of deployment service accounts after the cursor of opts, or every
+// service account after it with opts.All. Only the service accounts with label are listed when it is set.
+func PaginatedDeploymentServiceAccounts(id, label string, opts utils.ListOptions, client houston.ClientInterface, out io.Writer) error {
+	fetch := func(cursor string, take int) ([]houston.ServiceAccount, error) {
+		return houston.Call(client.PaginatedListDeploymentServiceAccounts)(houston.PaginatedServiceAccountsRequest{DeploymentID: id, Label: label, CursorID: cursor, Take: take})
+	}
+	return printServiceAccountPages(opts, fetch, out)
+}
+
+// PaginatedWorkspaceServiceAccounts prints the page of workspace service accounts after the cursor of opts, or every
+// service account after it with opts.All. Only the service accounts with label are listed when it is set.
+func PaginatedWorkspaceServiceAccounts(id, label string, opts utils.ListOptions, client houston.ClientInterface, out io.Writer) error {
+	fetch := func(cursor string, take int) ([]houston.ServiceAccount, error) {
+		return houston.Call(client.PaginatedListWorkspaceServiceAccounts)(houston.PaginatedServiceAccountsRequest{WorkspaceID: id, Label: label, CursorID: cursor, Take: take})
+	}
+	return printServiceAccountPages(opts, fetch, out)
+}
+
+func printServiceAccountPages(opts utils.ListOptions, fetch func(cursor string, take int) ([]houston.ServiceAccount, error), out io.Writer) error {
+	sas, next, err := utils.FetchPages(opts, fetch, func(sa *houston.ServiceAccount) string { return sa.ID })
+	if err != nil {
+		return err
+	}
+
+	tab := newListTableOut()
+	for i := range sas {
+		tab.AddRow(listRow(&sas[i]), false)
+	}
+	if err := tab.Print(out); err != nil {
+		return err
+	}
+	utils.PrintNextCursor(next, out)
+	return nil
+}
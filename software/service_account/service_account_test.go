@@ -9,7 +9,9 @@ import (
 
 	"github.com/astronomer/astro-cli/houston"
 	mocks "github.com/astronomer/astro-cli/houston/mocks"
+	"github.com/astronomer/astro-cli/software/utils"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 )
 
 var errMock = errors.New("api error")
@@ -250,3 +252,57 @@ func TestGetWorkspaceServiceAccount(t *testing.T) {
 		api.AssertExpectations(t)
 	})
 }
+
+func TestPaginatedDeploymentServiceAccounts(t *testing.T) {
+	testUtil.InitTestConfig(testUtil.SoftwarePlatform)
+	page := []houston.ServiceAccount{
+		{ID: "sa-1", Label: "ci", Category: "default", APIKey: "key-1"},
+		{ID: "sa-2", Label: "ci", Category: "default", APIKey: "key-2"},
+	}
+
+	t.Run("first page", func(t *testing.T) {
+		api := new(mocks.ClientInterface)
+		api.On("PaginatedListDeploymentServiceAccounts", houston.PaginatedServiceAccountsRequest{DeploymentID: "dep-id", Label: "ci", Take: 2}).Return(page, nil)
+
+		buf := new(bytes.Buffer)
+		err := PaginatedDeploymentServiceAccounts("dep-id", "ci", utils.ListOptions{PageSize: 2}, api, buf)
+		assert.NoError(t, err)
+		assert.Contains(t, buf.String(), "key-2")
+		assert.Contains(t, buf.String(), "--cursor=sa-2")
+		api.AssertExpectations(t)
+	})
+
+	t.Run("all pages", func(t *testing.T) {
+		api := new(mocks.ClientInterface)
+		api.On("PaginatedListDeploymentServiceAccounts", houston.PaginatedServiceAccountsRequest{DeploymentID: "dep-id", Take: 2}).Return(page, nil)
+		api.On("PaginatedListDeploymentServiceAccounts", houston.PaginatedServiceAccountsRequest{DeploymentID: "dep-id", CursorID: "sa-2", Take: 2}).Return([]houston.ServiceAccount{{ID: "sa-3", APIKey: "key-3"}}, nil)
+
+		buf := new(bytes.Buffer)
+		err := PaginatedDeploymentServiceAccounts("dep-id", "", utils.ListOptions{PageSize: 2, All: true}, api, buf)
+		assert.NoError(t, err)
+		assert.Contains(t, buf.String(), "key-3")
+		assert.NotContains(t, buf.String(), "--cursor")
+		api.AssertExpectations(t)
+	})
+
+	t.Run("error", func(t *testing.T) {
+		api := new(mocks.ClientInterface)
+		api.On("PaginatedListDeploymentServiceAccounts", mock.Anything).Return(nil, errMock)
+
+		err := PaginatedDeploymentServiceAccounts("dep-id", "", utils.ListOptions{}, api, new(bytes.Buffer))
+		assert.ErrorIs(t, err, errMock)
+	})
+}
+
+func TestPaginatedWorkspaceServiceAccounts(t *testing.T) {
+	testUtil.InitTestConfig(testUtil.SoftwarePlatform)
+
+	api := new(mocks.ClientInterface)
+	api.On("PaginatedListWorkspaceServiceAccounts", houston.PaginatedServiceAccountsRequest{WorkspaceID: "ws-id", CursorID: "sa-1", Take: utils.DefaultPageSize}).Return([]houston.ServiceAccount{{ID: "sa-2", APIKey: "key-2"}}, nil)
+
+	buf := new(bytes.Buffer)
+	err := PaginatedWorkspaceServiceAccounts("ws-id", "", utils.ListOptions{Cursor: "sa-1"}, api, buf)
+	assert.NoError(t, err)
+	assert.Contains(t, buf.String(), "key-2")
+	api.AssertExpectations(t)
+}
@@ -1,17 +1,25 @@
 package utils
 
 import (
+	"errors"
 	"fmt"
+	"io"
 
 	"github.com/astronomer/astro-cli/pkg/input"
+	"github.com/astronomer/astro-cli/pkg/printutil"
 )
 
 const (
+	// DefaultPageSize is the number of results fetched at once by the list commands when --page-size is not set
+	DefaultPageSize = 100
+
 	defaultPaginationOptions      = "f. first p. previous n. next q. quit\n> "
 	paginationWithoutNextOptions  = "f. first p. previous q. quit\n> "
 	paginationWithNextQuitOptions = "n. next q. quit\n> "
 )
 
+var ErrInvalidPageSize = errors.New("--page-size must be a positive number")
+
 type PaginationOptions struct {
 	CursorID   string
 	PageSize   int
@@ -56,3 +64,58 @@ func Abs(n int) int {
 	}
 	return n
 }
+
+// ListOptions are the flags of the list commands to get one page of results without prompting, or every result
+type ListOptions struct {
+	PageSize int
+	Cursor   string
+	All      bool
+}
+
+// Paginated returns whether any of the pagination flags is set
+func (o ListOptions) Paginated() bool {
+	return o.PageSize != 0 || o.Cursor != "" || o.All
+}
+
+// Validate checks the page size, 0 means DefaultPageSize
+func (o ListOptions) Validate() error {
+	if o.PageSize < 0 {
+		return ErrInvalidPageSize
+	}
+	return nil
+}
+
+// FetchPages returns the page of results after opts.Cursor or, with opts.All, every result after it. fetch returns at
+// most take results after cursor, and id the cursor of a result. The returned cursor is the one of the next page, it
+// is empty when there are no more results.
+func FetchPages[T any](opts ListOptions, fetch func(cursor string, take int) ([]T, error), id func(*T) string) ([]T, string, error) {
+	take := opts.PageSize
+	if take <= 0 {
+		take = DefaultPageSize
+	}
+	cursor := opts.Cursor
+	var results []T
+	for {
+		page, err := fetch(cursor, take)
+		if err != nil {
+			return nil, "", err
+		}
+		results = append(results, page...)
+		// houston does not send back the total number of records, a short page is the last one
+		if len(page) < take {
+			return results, "", nil
+		}
+		cursor = id(&page[len(page)-1])
+		if !opts.All {
+			return results, cursor, nil
+		}
+	}
+}
+
+// PrintNextCursor tells how to get the next page of results, when there is one. The hint goes to stderr in structured
+// output, so that it does not break the parsing of the results.
+func PrintNextCursor(cursor string, out io.Writer) {
+	if cursor != "" {
+		fmt.Fprintf(printutil.MessageOut(out), "\nMore results are available, run the same command with --cursor=%s to get the next page\n", cursor)
+	}
+}
@@ -0,0 +1,100 @@
+package utils
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var errMock = errors.New("api error")
+
+type result struct{ ID string }
+
+// pager returns results in pages after the cursor, like houston does
+func pager(results []result, calls *[]string) func(cursor string, take int) ([]result, error) {
+	return func(cursor string, take int) ([]result, error) {
+		*calls = append(*calls, cursor)
+		start := 0
+		for i := range results {
+			if results[i].ID == cursor {
+				start = i + 1
+			}
+		}
+		end := start + take
+		if end > len(results) {
+			end = len(results)
+		}
+		return results[start:end], nil
+	}
+}
+
+func TestFetchPages(t *testing.T) {
+	results := []result{{"a"}, {"b"}, {"c"}, {"d"}, {"e"}}
+	id := func(r *result) string { return r.ID }
+
+	t.Run("first page", func(t *testing.T) {
+		var calls []string
+		page, next, err := FetchPages(ListOptions{PageSize: 2}, pager(results, &calls), id)
+		assert.NoError(t, err)
+		assert.Equal(t, []result{{"a"}, {"b"}}, page)
+		assert.Equal(t, "b", next)
+		assert.Equal(t, []string{""}, calls)
+	})
+
+	t.Run("page after cursor", func(t *testing.T) {
+		var calls []string
+		page, next, err := FetchPages(ListOptions{PageSize: 2, Cursor: "d"}, pager(results, &calls), id)
+		assert.NoError(t, err)
+		assert.Equal(t, []result{{"e"}}, page)
+		assert.Equal(t, "", next)
+	})
+
+	t.Run("all pages", func(t *testing.T) {
+		var calls []string
+		page, next, err := FetchPages(ListOptions{PageSize: 2, All: true}, pager(results, &calls), id)
+		assert.NoError(t, err)
+		assert.Equal(t, results, page)
+		assert.Equal(t, "", next)
+		assert.Equal(t, []string{"", "b", "d"}, calls)
+	})
+
+	t.Run("default page size", func(t *testing.T) {
+		var takes []int
+		fetch := func(cursor string, take int) ([]result, error) {
+			takes = append(takes, take)
+			return nil, nil
+		}
+		_, _, err := FetchPages(ListOptions{All: true}, fetch, id)
+		assert.NoError(t, err)
+		assert.Equal(t, []int{DefaultPageSize}, takes)
+	})
+
+	t.Run("error", func(t *testing.T) {
+		fetch := func(cursor string, take int) ([]result, error) {
+			return nil, errMock
+		}
+		_, _, err := FetchPages(ListOptions{All: true}, fetch, id)
+		assert.ErrorIs(t, err, errMock)
+	})
+}
+
+func TestListOptions(t *testing.T) {
+	assert.False(t, ListOptions{}.Paginated())
+	assert.True(t, ListOptions{PageSize: 10}.Paginated())
+	assert.True(t, ListOptions{Cursor: "a"}.Paginated())
+	assert.True(t, ListOptions{All: true}.Paginated())
+
+	assert.NoError(t, ListOptions{}.Validate())
+	assert.ErrorIs(t, ListOptions{PageSize: -1}.Validate(), ErrInvalidPageSize)
+}
+
+func TestPrintNextCursor(t *testing.T) {
+	buf := new(bytes.Buffer)
+	PrintNextCursor("", buf)
+	assert.Empty(t, buf.String())
+
+	PrintNextCursor("next-id", buf)
+	assert.Contains(t, buf.String(), "--cursor=next-id")
+}
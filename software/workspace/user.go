@@ -129,6 +129,36 @@ func PaginatedListRoles(workspaceID, cursorID string, take, pageNumber int, clie
 	return PaginatedListRoles(workspaceID, selectedOption.CursorID, selectedOption.PageSize, selectedOption.PageNumber, client, out)
 }
 
+// FilteredListRoles prints the page of workspace users after the cursor of opts, or every user after it with
+// opts.All, without prompting. Users are filtered by email and by workspace role when they are set.
+func FilteredListRoles(workspaceID, email, role string, opts utils.ListOptions, client houston.ClientInterface, out io.Writer) error {
+	fetch := func(cursor string, take int) ([]houston.WorkspaceUserRoleBindings, error) {
+		request := houston.PaginatedWorkspaceUsersRequest{WorkspaceID: workspaceID, Email: email, Role: role, CursorID: cursor, Take: take}
+		return houston.Call(client.PaginatedListWorkspaceUsers)(request)
+	}
+	users, next, err := utils.FetchPages(opts, fetch, func(u *houston.WorkspaceUserRoleBindings) string { return u.ID })
+	if err != nil {
+		return err
+	}
+
+	tab := printutil.Table{
+		Padding:        []int{44, 50},
+		DynamicPadding: true,
+		Header:         []string{"USERNAME", "ID", "ROLE"},
+	}
+	for i := range users {
+		userRole := getWorkspaceLevelRole(users[i].RoleBindings, workspaceID)
+		if userRole != houston.NoneRole {
+			tab.AddRow([]string{users[i].Username, users[i].ID, userRole}, false)
+		}
+	}
+	if err := tab.Print(out); err != nil {
+		return err
+	}
+	utils.PrintNextCursor(next, out)
+	return nil
+}
+
 // Update workspace user role
 func UpdateRole(workspaceID, email, role string, client houston.ClientInterface, out io.Writer) error {
 	// get user you are updating to show role from before change
@@ -10,7 +10,9 @@ import (
 
 	"github.com/astronomer/astro-cli/houston"
 	mocks "github.com/astronomer/astro-cli/houston/mocks"
+	"github.com/astronomer/astro-cli/software/utils"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 )
 
 var (
@@ -367,6 +369,52 @@ func TestPaginatedListRolesError(t *testing.T) {
 	api.AssertExpectations(t)
 }
 
+func TestFilteredListRoles(t *testing.T) {
+	testUtil.InitTestConfig(testUtil.SoftwarePlatform)
+	wsID := "ckoixo6o501496qemiwsja1tl"
+	mockUsers := []houston.WorkspaceUserRoleBindings{
+		{
+			ID:           "user-1",
+			Username:     "admin@astronomer.io",
+			RoleBindings: []houston.RoleBinding{{Role: houston.WorkspaceAdminRole, Workspace: houston.Workspace{ID: wsID}}},
+		},
+	}
+
+	t.Run("every user with a role", func(t *testing.T) {
+		api := new(mocks.ClientInterface)
+		request := houston.PaginatedWorkspaceUsersRequest{WorkspaceID: wsID, Take: 1, Role: houston.WorkspaceAdminRole}
+		api.On("PaginatedListWorkspaceUsers", request).Return(mockUsers, nil).Once()
+		request.CursorID = "user-1"
+		api.On("PaginatedListWorkspaceUsers", request).Return([]houston.WorkspaceUserRoleBindings{}, nil).Once()
+
+		buf := new(bytes.Buffer)
+		err := FilteredListRoles(wsID, "", houston.WorkspaceAdminRole, utils.ListOptions{PageSize: 1, All: true}, api, buf)
+		assert.NoError(t, err)
+		assert.Contains(t, buf.String(), "admin@astronomer.io")
+		assert.NotContains(t, buf.String(), "--cursor")
+		api.AssertExpectations(t)
+	})
+
+	t.Run("one page by email", func(t *testing.T) {
+		api := new(mocks.ClientInterface)
+		request := houston.PaginatedWorkspaceUsersRequest{WorkspaceID: wsID, Take: 1, Email: "admin@astronomer.io"}
+		api.On("PaginatedListWorkspaceUsers", request).Return(mockUsers, nil)
+
+		buf := new(bytes.Buffer)
+		err := FilteredListRoles(wsID, "admin@astronomer.io", "", utils.ListOptions{PageSize: 1}, api, buf)
+		assert.NoError(t, err)
+		assert.Contains(t, buf.String(), "--cursor=user-1")
+	})
+
+	t.Run("error", func(t *testing.T) {
+		api := new(mocks.ClientInterface)
+		api.On("PaginatedListWorkspaceUsers", mock.Anything).Return(nil, errMock)
+
+		err := FilteredListRoles(wsID, "", "", utils.ListOptions{All: true}, api, new(bytes.Buffer))
+		assert.ErrorIs(t, err, errMock)
+	})
+}
+
 func TestShowListRolesPaginatedOption(t *testing.T) {
 	wsID := "ck1qg6whg001r08691y117hub"
 	paginationPageSize := 100